@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
+// source: accesskey.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	AccessKeyService_Create_FullMethodName = "/begonia.org.accesskey.AccessKeyService/Create"
+	AccessKeyService_List_FullMethodName   = "/begonia.org.accesskey.AccessKeyService/List"
+	AccessKeyService_Update_FullMethodName = "/begonia.org.accesskey.AccessKeyService/Update"
+	AccessKeyService_Rotate_FullMethodName = "/begonia.org.accesskey.AccessKeyService/Rotate"
+	AccessKeyService_Delete_FullMethodName = "/begonia.org.accesskey.AccessKeyService/Delete"
+)
+
+// AccessKeyServiceClient is the client API for AccessKeyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AccessKeyServiceClient interface {
+	Create(ctx context.Context, in *CreateAccessKeyRequest, opts ...grpc.CallOption) (*AccessKey, error)
+	List(ctx context.Context, in *ListAccessKeysRequest, opts ...grpc.CallOption) (*ListAccessKeysResponse, error)
+	Update(ctx context.Context, in *UpdateAccessKeyRequest, opts ...grpc.CallOption) (*AccessKey, error)
+	Rotate(ctx context.Context, in *RotateAccessKeyRequest, opts ...grpc.CallOption) (*AccessKey, error)
+	Delete(ctx context.Context, in *DeleteAccessKeyRequest, opts ...grpc.CallOption) (*DeleteAccessKeyResponse, error)
+}
+
+type accessKeyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccessKeyServiceClient(cc grpc.ClientConnInterface) AccessKeyServiceClient {
+	return &accessKeyServiceClient{cc}
+}
+
+func (c *accessKeyServiceClient) Create(ctx context.Context, in *CreateAccessKeyRequest, opts ...grpc.CallOption) (*AccessKey, error) {
+	out := new(AccessKey)
+	err := c.cc.Invoke(ctx, AccessKeyService_Create_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessKeyServiceClient) List(ctx context.Context, in *ListAccessKeysRequest, opts ...grpc.CallOption) (*ListAccessKeysResponse, error) {
+	out := new(ListAccessKeysResponse)
+	err := c.cc.Invoke(ctx, AccessKeyService_List_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessKeyServiceClient) Update(ctx context.Context, in *UpdateAccessKeyRequest, opts ...grpc.CallOption) (*AccessKey, error) {
+	out := new(AccessKey)
+	err := c.cc.Invoke(ctx, AccessKeyService_Update_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessKeyServiceClient) Rotate(ctx context.Context, in *RotateAccessKeyRequest, opts ...grpc.CallOption) (*AccessKey, error) {
+	out := new(AccessKey)
+	err := c.cc.Invoke(ctx, AccessKeyService_Rotate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessKeyServiceClient) Delete(ctx context.Context, in *DeleteAccessKeyRequest, opts ...grpc.CallOption) (*DeleteAccessKeyResponse, error) {
+	out := new(DeleteAccessKeyResponse)
+	err := c.cc.Invoke(ctx, AccessKeyService_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AccessKeyServiceServer is the server API for AccessKeyService service.
+// All implementations must embed UnimplementedAccessKeyServiceServer
+// for forward compatibility
+type AccessKeyServiceServer interface {
+	Create(context.Context, *CreateAccessKeyRequest) (*AccessKey, error)
+	List(context.Context, *ListAccessKeysRequest) (*ListAccessKeysResponse, error)
+	Update(context.Context, *UpdateAccessKeyRequest) (*AccessKey, error)
+	Rotate(context.Context, *RotateAccessKeyRequest) (*AccessKey, error)
+	Delete(context.Context, *DeleteAccessKeyRequest) (*DeleteAccessKeyResponse, error)
+	mustEmbedUnimplementedAccessKeyServiceServer()
+}
+
+// UnimplementedAccessKeyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAccessKeyServiceServer struct {
+}
+
+func (UnimplementedAccessKeyServiceServer) Create(context.Context, *CreateAccessKeyRequest) (*AccessKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedAccessKeyServiceServer) List(context.Context, *ListAccessKeysRequest) (*ListAccessKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedAccessKeyServiceServer) Update(context.Context, *UpdateAccessKeyRequest) (*AccessKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedAccessKeyServiceServer) Rotate(context.Context, *RotateAccessKeyRequest) (*AccessKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rotate not implemented")
+}
+func (UnimplementedAccessKeyServiceServer) Delete(context.Context, *DeleteAccessKeyRequest) (*DeleteAccessKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedAccessKeyServiceServer) mustEmbedUnimplementedAccessKeyServiceServer() {}
+
+// UnsafeAccessKeyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AccessKeyServiceServer will
+// result in compilation errors.
+type UnsafeAccessKeyServiceServer interface {
+	mustEmbedUnimplementedAccessKeyServiceServer()
+}
+
+func RegisterAccessKeyServiceServer(s grpc.ServiceRegistrar, srv AccessKeyServiceServer) {
+	s.RegisterService(&AccessKeyService_ServiceDesc, srv)
+}
+
+func _AccessKeyService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccessKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessKeyServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessKeyService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessKeyServiceServer).Create(ctx, req.(*CreateAccessKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessKeyService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessKeyServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessKeyService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessKeyServiceServer).List(ctx, req.(*ListAccessKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessKeyService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAccessKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessKeyServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessKeyService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessKeyServiceServer).Update(ctx, req.(*UpdateAccessKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessKeyService_Rotate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateAccessKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessKeyServiceServer).Rotate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessKeyService_Rotate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessKeyServiceServer).Rotate(ctx, req.(*RotateAccessKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessKeyService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccessKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessKeyServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessKeyService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessKeyServiceServer).Delete(ctx, req.(*DeleteAccessKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AccessKeyService_ServiceDesc is the grpc.ServiceDesc for AccessKeyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AccessKeyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "begonia.org.accesskey.AccessKeyService",
+	HandlerType: (*AccessKeyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _AccessKeyService_Create_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _AccessKeyService_List_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _AccessKeyService_Update_Handler,
+		},
+		{
+			MethodName: "Rotate",
+			Handler:    _AccessKeyService_Rotate_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _AccessKeyService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "accesskey.proto",
+}
@@ -0,0 +1,1348 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
+// source: admin.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	EndpointAdminService_GetPolicy_FullMethodName            = "/begonia.org.admin.EndpointAdminService/GetPolicy"
+	EndpointAdminService_PutPolicy_FullMethodName            = "/begonia.org.admin.EndpointAdminService/PutPolicy"
+	EndpointAdminService_GetTransform_FullMethodName         = "/begonia.org.admin.EndpointAdminService/GetTransform"
+	EndpointAdminService_PutTransform_FullMethodName         = "/begonia.org.admin.EndpointAdminService/PutTransform"
+	EndpointAdminService_GetCache_FullMethodName             = "/begonia.org.admin.EndpointAdminService/GetCache"
+	EndpointAdminService_PutCache_FullMethodName             = "/begonia.org.admin.EndpointAdminService/PutCache"
+	EndpointAdminService_PurgeCache_FullMethodName           = "/begonia.org.admin.EndpointAdminService/PurgeCache"
+	EndpointAdminService_ListReleases_FullMethodName         = "/begonia.org.admin.EndpointAdminService/ListReleases"
+	EndpointAdminService_PutRelease_FullMethodName           = "/begonia.org.admin.EndpointAdminService/PutRelease"
+	EndpointAdminService_Rollback_FullMethodName             = "/begonia.org.admin.EndpointAdminService/Rollback"
+	EndpointAdminService_Promote_FullMethodName              = "/begonia.org.admin.EndpointAdminService/Promote"
+	EndpointAdminService_PutTraffic_FullMethodName           = "/begonia.org.admin.EndpointAdminService/PutTraffic"
+	EndpointAdminService_GetMirror_FullMethodName            = "/begonia.org.admin.EndpointAdminService/GetMirror"
+	EndpointAdminService_PutMirror_FullMethodName            = "/begonia.org.admin.EndpointAdminService/PutMirror"
+	EndpointAdminService_DeleteMirror_FullMethodName         = "/begonia.org.admin.EndpointAdminService/DeleteMirror"
+	EndpointAdminService_GetCapture_FullMethodName           = "/begonia.org.admin.EndpointAdminService/GetCapture"
+	EndpointAdminService_PutCapture_FullMethodName           = "/begonia.org.admin.EndpointAdminService/PutCapture"
+	EndpointAdminService_DeleteCapture_FullMethodName        = "/begonia.org.admin.EndpointAdminService/DeleteCapture"
+	EndpointAdminService_ListCaptures_FullMethodName         = "/begonia.org.admin.EndpointAdminService/ListCaptures"
+	EndpointAdminService_ClearCaptures_FullMethodName        = "/begonia.org.admin.EndpointAdminService/ClearCaptures"
+	EndpointAdminService_GetDiscovery_FullMethodName         = "/begonia.org.admin.EndpointAdminService/GetDiscovery"
+	EndpointAdminService_PutDiscovery_FullMethodName         = "/begonia.org.admin.EndpointAdminService/PutDiscovery"
+	EndpointAdminService_DeleteDiscovery_FullMethodName      = "/begonia.org.admin.EndpointAdminService/DeleteDiscovery"
+	EndpointAdminService_GetTLS_FullMethodName               = "/begonia.org.admin.EndpointAdminService/GetTLS"
+	EndpointAdminService_PutTLS_FullMethodName               = "/begonia.org.admin.EndpointAdminService/PutTLS"
+	EndpointAdminService_DeleteTLS_FullMethodName            = "/begonia.org.admin.EndpointAdminService/DeleteTLS"
+	EndpointAdminService_ListCircuitBreakers_FullMethodName  = "/begonia.org.admin.EndpointAdminService/ListCircuitBreakers"
+	EndpointAdminService_ResetCircuitBreakers_FullMethodName = "/begonia.org.admin.EndpointAdminService/ResetCircuitBreakers"
+)
+
+// EndpointAdminServiceClient is the client API for EndpointAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EndpointAdminServiceClient interface {
+	GetPolicy(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error)
+	PutPolicy(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	GetTransform(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error)
+	PutTransform(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	GetCache(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error)
+	PutCache(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	PurgeCache(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	ListReleases(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*ListReleasesResponse, error)
+	PutRelease(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	Rollback(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	Promote(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	PutTraffic(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	GetMirror(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error)
+	PutMirror(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	DeleteMirror(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	GetCapture(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error)
+	PutCapture(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	DeleteCapture(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	ListCaptures(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*ListCapturesResponse, error)
+	ClearCaptures(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*ClearCapturesResponse, error)
+	GetDiscovery(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error)
+	PutDiscovery(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	DeleteDiscovery(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	// GetTLS 私钥脱敏后返回
+	GetTLS(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error)
+	PutTLS(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	DeleteTLS(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error)
+	ListCircuitBreakers(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*ListCircuitBreakersResponse, error)
+	ResetCircuitBreakers(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*ResetCircuitBreakersResponse, error)
+}
+
+type endpointAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEndpointAdminServiceClient(cc grpc.ClientConnInterface) EndpointAdminServiceClient {
+	return &endpointAdminServiceClient{cc}
+}
+
+func (c *endpointAdminServiceClient) GetPolicy(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error) {
+	out := new(EndpointConfig)
+	err := c.cc.Invoke(ctx, EndpointAdminService_GetPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) PutPolicy(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_PutPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) GetTransform(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error) {
+	out := new(EndpointConfig)
+	err := c.cc.Invoke(ctx, EndpointAdminService_GetTransform_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) PutTransform(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_PutTransform_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) GetCache(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error) {
+	out := new(EndpointConfig)
+	err := c.cc.Invoke(ctx, EndpointAdminService_GetCache_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) PutCache(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_PutCache_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) PurgeCache(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_PurgeCache_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) ListReleases(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*ListReleasesResponse, error) {
+	out := new(ListReleasesResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_ListReleases_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) PutRelease(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_PutRelease_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) Rollback(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_Rollback_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) Promote(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_Promote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) PutTraffic(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_PutTraffic_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) GetMirror(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error) {
+	out := new(EndpointConfig)
+	err := c.cc.Invoke(ctx, EndpointAdminService_GetMirror_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) PutMirror(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_PutMirror_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) DeleteMirror(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_DeleteMirror_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) GetCapture(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error) {
+	out := new(EndpointConfig)
+	err := c.cc.Invoke(ctx, EndpointAdminService_GetCapture_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) PutCapture(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_PutCapture_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) DeleteCapture(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_DeleteCapture_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) ListCaptures(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*ListCapturesResponse, error) {
+	out := new(ListCapturesResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_ListCaptures_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) ClearCaptures(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*ClearCapturesResponse, error) {
+	out := new(ClearCapturesResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_ClearCaptures_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) GetDiscovery(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error) {
+	out := new(EndpointConfig)
+	err := c.cc.Invoke(ctx, EndpointAdminService_GetDiscovery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) PutDiscovery(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_PutDiscovery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) DeleteDiscovery(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_DeleteDiscovery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) GetTLS(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*EndpointConfig, error) {
+	out := new(EndpointConfig)
+	err := c.cc.Invoke(ctx, EndpointAdminService_GetTLS_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) PutTLS(ctx context.Context, in *PutEndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_PutTLS_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) DeleteTLS(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*UpdateEndpointConfigResponse, error) {
+	out := new(UpdateEndpointConfigResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_DeleteTLS_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) ListCircuitBreakers(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*ListCircuitBreakersResponse, error) {
+	out := new(ListCircuitBreakersResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_ListCircuitBreakers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *endpointAdminServiceClient) ResetCircuitBreakers(ctx context.Context, in *EndpointConfigRequest, opts ...grpc.CallOption) (*ResetCircuitBreakersResponse, error) {
+	out := new(ResetCircuitBreakersResponse)
+	err := c.cc.Invoke(ctx, EndpointAdminService_ResetCircuitBreakers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EndpointAdminServiceServer is the server API for EndpointAdminService service.
+// All implementations must embed UnimplementedEndpointAdminServiceServer
+// for forward compatibility
+type EndpointAdminServiceServer interface {
+	GetPolicy(context.Context, *EndpointConfigRequest) (*EndpointConfig, error)
+	PutPolicy(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	GetTransform(context.Context, *EndpointConfigRequest) (*EndpointConfig, error)
+	PutTransform(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	GetCache(context.Context, *EndpointConfigRequest) (*EndpointConfig, error)
+	PutCache(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	PurgeCache(context.Context, *EndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	ListReleases(context.Context, *EndpointConfigRequest) (*ListReleasesResponse, error)
+	PutRelease(context.Context, *ReleaseRequest) (*UpdateEndpointConfigResponse, error)
+	Rollback(context.Context, *ReleaseRequest) (*UpdateEndpointConfigResponse, error)
+	Promote(context.Context, *ReleaseRequest) (*UpdateEndpointConfigResponse, error)
+	PutTraffic(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	GetMirror(context.Context, *EndpointConfigRequest) (*EndpointConfig, error)
+	PutMirror(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	DeleteMirror(context.Context, *EndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	GetCapture(context.Context, *EndpointConfigRequest) (*EndpointConfig, error)
+	PutCapture(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	DeleteCapture(context.Context, *EndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	ListCaptures(context.Context, *EndpointConfigRequest) (*ListCapturesResponse, error)
+	ClearCaptures(context.Context, *EndpointConfigRequest) (*ClearCapturesResponse, error)
+	GetDiscovery(context.Context, *EndpointConfigRequest) (*EndpointConfig, error)
+	PutDiscovery(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	DeleteDiscovery(context.Context, *EndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	// GetTLS 私钥脱敏后返回
+	GetTLS(context.Context, *EndpointConfigRequest) (*EndpointConfig, error)
+	PutTLS(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	DeleteTLS(context.Context, *EndpointConfigRequest) (*UpdateEndpointConfigResponse, error)
+	ListCircuitBreakers(context.Context, *EndpointConfigRequest) (*ListCircuitBreakersResponse, error)
+	ResetCircuitBreakers(context.Context, *EndpointConfigRequest) (*ResetCircuitBreakersResponse, error)
+	mustEmbedUnimplementedEndpointAdminServiceServer()
+}
+
+// UnimplementedEndpointAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedEndpointAdminServiceServer struct {
+}
+
+func (UnimplementedEndpointAdminServiceServer) GetPolicy(context.Context, *EndpointConfigRequest) (*EndpointConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPolicy not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) PutPolicy(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutPolicy not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) GetTransform(context.Context, *EndpointConfigRequest) (*EndpointConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransform not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) PutTransform(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutTransform not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) GetCache(context.Context, *EndpointConfigRequest) (*EndpointConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCache not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) PutCache(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutCache not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) PurgeCache(context.Context, *EndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeCache not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) ListReleases(context.Context, *EndpointConfigRequest) (*ListReleasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReleases not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) PutRelease(context.Context, *ReleaseRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutRelease not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) Rollback(context.Context, *ReleaseRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rollback not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) Promote(context.Context, *ReleaseRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Promote not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) PutTraffic(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutTraffic not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) GetMirror(context.Context, *EndpointConfigRequest) (*EndpointConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMirror not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) PutMirror(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutMirror not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) DeleteMirror(context.Context, *EndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMirror not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) GetCapture(context.Context, *EndpointConfigRequest) (*EndpointConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapture not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) PutCapture(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutCapture not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) DeleteCapture(context.Context, *EndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCapture not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) ListCaptures(context.Context, *EndpointConfigRequest) (*ListCapturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCaptures not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) ClearCaptures(context.Context, *EndpointConfigRequest) (*ClearCapturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearCaptures not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) GetDiscovery(context.Context, *EndpointConfigRequest) (*EndpointConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDiscovery not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) PutDiscovery(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutDiscovery not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) DeleteDiscovery(context.Context, *EndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDiscovery not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) GetTLS(context.Context, *EndpointConfigRequest) (*EndpointConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTLS not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) PutTLS(context.Context, *PutEndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutTLS not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) DeleteTLS(context.Context, *EndpointConfigRequest) (*UpdateEndpointConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTLS not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) ListCircuitBreakers(context.Context, *EndpointConfigRequest) (*ListCircuitBreakersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCircuitBreakers not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) ResetCircuitBreakers(context.Context, *EndpointConfigRequest) (*ResetCircuitBreakersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetCircuitBreakers not implemented")
+}
+func (UnimplementedEndpointAdminServiceServer) mustEmbedUnimplementedEndpointAdminServiceServer() {}
+
+// UnsafeEndpointAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EndpointAdminServiceServer will
+// result in compilation errors.
+type UnsafeEndpointAdminServiceServer interface {
+	mustEmbedUnimplementedEndpointAdminServiceServer()
+}
+
+func RegisterEndpointAdminServiceServer(s grpc.ServiceRegistrar, srv EndpointAdminServiceServer) {
+	s.RegisterService(&EndpointAdminService_ServiceDesc, srv)
+}
+
+func _EndpointAdminService_GetPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).GetPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_GetPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).GetPolicy(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_PutPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutEndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).PutPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_PutPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).PutPolicy(ctx, req.(*PutEndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_GetTransform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).GetTransform(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_GetTransform_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).GetTransform(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_PutTransform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutEndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).PutTransform(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_PutTransform_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).PutTransform(ctx, req.(*PutEndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_GetCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).GetCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_GetCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).GetCache(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_PutCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutEndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).PutCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_PutCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).PutCache(ctx, req.(*PutEndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_PurgeCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).PurgeCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_PurgeCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).PurgeCache(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_ListReleases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).ListReleases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_ListReleases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).ListReleases(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_PutRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).PutRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_PutRelease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).PutRelease(ctx, req.(*ReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).Rollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_Rollback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).Rollback(ctx, req.(*ReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_Promote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).Promote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_Promote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).Promote(ctx, req.(*ReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_PutTraffic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutEndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).PutTraffic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_PutTraffic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).PutTraffic(ctx, req.(*PutEndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_GetMirror_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).GetMirror(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_GetMirror_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).GetMirror(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_PutMirror_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutEndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).PutMirror(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_PutMirror_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).PutMirror(ctx, req.(*PutEndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_DeleteMirror_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).DeleteMirror(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_DeleteMirror_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).DeleteMirror(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_GetCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).GetCapture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_GetCapture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).GetCapture(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_PutCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutEndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).PutCapture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_PutCapture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).PutCapture(ctx, req.(*PutEndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_DeleteCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).DeleteCapture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_DeleteCapture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).DeleteCapture(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_ListCaptures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).ListCaptures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_ListCaptures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).ListCaptures(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_ClearCaptures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).ClearCaptures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_ClearCaptures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).ClearCaptures(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_GetDiscovery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).GetDiscovery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_GetDiscovery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).GetDiscovery(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_PutDiscovery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutEndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).PutDiscovery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_PutDiscovery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).PutDiscovery(ctx, req.(*PutEndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_DeleteDiscovery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).DeleteDiscovery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_DeleteDiscovery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).DeleteDiscovery(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_GetTLS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).GetTLS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_GetTLS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).GetTLS(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_PutTLS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutEndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).PutTLS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_PutTLS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).PutTLS(ctx, req.(*PutEndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_DeleteTLS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).DeleteTLS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_DeleteTLS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).DeleteTLS(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_ListCircuitBreakers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).ListCircuitBreakers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_ListCircuitBreakers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).ListCircuitBreakers(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EndpointAdminService_ResetCircuitBreakers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndpointConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EndpointAdminServiceServer).ResetCircuitBreakers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EndpointAdminService_ResetCircuitBreakers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EndpointAdminServiceServer).ResetCircuitBreakers(ctx, req.(*EndpointConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EndpointAdminService_ServiceDesc is the grpc.ServiceDesc for EndpointAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EndpointAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "begonia.org.admin.EndpointAdminService",
+	HandlerType: (*EndpointAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPolicy",
+			Handler:    _EndpointAdminService_GetPolicy_Handler,
+		},
+		{
+			MethodName: "PutPolicy",
+			Handler:    _EndpointAdminService_PutPolicy_Handler,
+		},
+		{
+			MethodName: "GetTransform",
+			Handler:    _EndpointAdminService_GetTransform_Handler,
+		},
+		{
+			MethodName: "PutTransform",
+			Handler:    _EndpointAdminService_PutTransform_Handler,
+		},
+		{
+			MethodName: "GetCache",
+			Handler:    _EndpointAdminService_GetCache_Handler,
+		},
+		{
+			MethodName: "PutCache",
+			Handler:    _EndpointAdminService_PutCache_Handler,
+		},
+		{
+			MethodName: "PurgeCache",
+			Handler:    _EndpointAdminService_PurgeCache_Handler,
+		},
+		{
+			MethodName: "ListReleases",
+			Handler:    _EndpointAdminService_ListReleases_Handler,
+		},
+		{
+			MethodName: "PutRelease",
+			Handler:    _EndpointAdminService_PutRelease_Handler,
+		},
+		{
+			MethodName: "Rollback",
+			Handler:    _EndpointAdminService_Rollback_Handler,
+		},
+		{
+			MethodName: "Promote",
+			Handler:    _EndpointAdminService_Promote_Handler,
+		},
+		{
+			MethodName: "PutTraffic",
+			Handler:    _EndpointAdminService_PutTraffic_Handler,
+		},
+		{
+			MethodName: "GetMirror",
+			Handler:    _EndpointAdminService_GetMirror_Handler,
+		},
+		{
+			MethodName: "PutMirror",
+			Handler:    _EndpointAdminService_PutMirror_Handler,
+		},
+		{
+			MethodName: "DeleteMirror",
+			Handler:    _EndpointAdminService_DeleteMirror_Handler,
+		},
+		{
+			MethodName: "GetCapture",
+			Handler:    _EndpointAdminService_GetCapture_Handler,
+		},
+		{
+			MethodName: "PutCapture",
+			Handler:    _EndpointAdminService_PutCapture_Handler,
+		},
+		{
+			MethodName: "DeleteCapture",
+			Handler:    _EndpointAdminService_DeleteCapture_Handler,
+		},
+		{
+			MethodName: "ListCaptures",
+			Handler:    _EndpointAdminService_ListCaptures_Handler,
+		},
+		{
+			MethodName: "ClearCaptures",
+			Handler:    _EndpointAdminService_ClearCaptures_Handler,
+		},
+		{
+			MethodName: "GetDiscovery",
+			Handler:    _EndpointAdminService_GetDiscovery_Handler,
+		},
+		{
+			MethodName: "PutDiscovery",
+			Handler:    _EndpointAdminService_PutDiscovery_Handler,
+		},
+		{
+			MethodName: "DeleteDiscovery",
+			Handler:    _EndpointAdminService_DeleteDiscovery_Handler,
+		},
+		{
+			MethodName: "GetTLS",
+			Handler:    _EndpointAdminService_GetTLS_Handler,
+		},
+		{
+			MethodName: "PutTLS",
+			Handler:    _EndpointAdminService_PutTLS_Handler,
+		},
+		{
+			MethodName: "DeleteTLS",
+			Handler:    _EndpointAdminService_DeleteTLS_Handler,
+		},
+		{
+			MethodName: "ListCircuitBreakers",
+			Handler:    _EndpointAdminService_ListCircuitBreakers_Handler,
+		},
+		{
+			MethodName: "ResetCircuitBreakers",
+			Handler:    _EndpointAdminService_ResetCircuitBreakers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
+
+const (
+	AppAdminService_GetCapture_FullMethodName    = "/begonia.org.admin.AppAdminService/GetCapture"
+	AppAdminService_PutCapture_FullMethodName    = "/begonia.org.admin.AppAdminService/PutCapture"
+	AppAdminService_DeleteCapture_FullMethodName = "/begonia.org.admin.AppAdminService/DeleteCapture"
+	AppAdminService_ListCaptures_FullMethodName  = "/begonia.org.admin.AppAdminService/ListCaptures"
+	AppAdminService_ClearCaptures_FullMethodName = "/begonia.org.admin.AppAdminService/ClearCaptures"
+)
+
+// AppAdminServiceClient is the client API for AppAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AppAdminServiceClient interface {
+	GetCapture(ctx context.Context, in *AppCaptureRequest, opts ...grpc.CallOption) (*AppCaptureResponse, error)
+	PutCapture(ctx context.Context, in *PutAppCaptureRequest, opts ...grpc.CallOption) (*AppCaptureResponse, error)
+	DeleteCapture(ctx context.Context, in *AppCaptureRequest, opts ...grpc.CallOption) (*DeleteAppCaptureResponse, error)
+	ListCaptures(ctx context.Context, in *AppCaptureRequest, opts ...grpc.CallOption) (*ListCapturesResponse, error)
+	ClearCaptures(ctx context.Context, in *AppCaptureRequest, opts ...grpc.CallOption) (*ClearCapturesResponse, error)
+}
+
+type appAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAppAdminServiceClient(cc grpc.ClientConnInterface) AppAdminServiceClient {
+	return &appAdminServiceClient{cc}
+}
+
+func (c *appAdminServiceClient) GetCapture(ctx context.Context, in *AppCaptureRequest, opts ...grpc.CallOption) (*AppCaptureResponse, error) {
+	out := new(AppCaptureResponse)
+	err := c.cc.Invoke(ctx, AppAdminService_GetCapture_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appAdminServiceClient) PutCapture(ctx context.Context, in *PutAppCaptureRequest, opts ...grpc.CallOption) (*AppCaptureResponse, error) {
+	out := new(AppCaptureResponse)
+	err := c.cc.Invoke(ctx, AppAdminService_PutCapture_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appAdminServiceClient) DeleteCapture(ctx context.Context, in *AppCaptureRequest, opts ...grpc.CallOption) (*DeleteAppCaptureResponse, error) {
+	out := new(DeleteAppCaptureResponse)
+	err := c.cc.Invoke(ctx, AppAdminService_DeleteCapture_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appAdminServiceClient) ListCaptures(ctx context.Context, in *AppCaptureRequest, opts ...grpc.CallOption) (*ListCapturesResponse, error) {
+	out := new(ListCapturesResponse)
+	err := c.cc.Invoke(ctx, AppAdminService_ListCaptures_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appAdminServiceClient) ClearCaptures(ctx context.Context, in *AppCaptureRequest, opts ...grpc.CallOption) (*ClearCapturesResponse, error) {
+	out := new(ClearCapturesResponse)
+	err := c.cc.Invoke(ctx, AppAdminService_ClearCaptures_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AppAdminServiceServer is the server API for AppAdminService service.
+// All implementations must embed UnimplementedAppAdminServiceServer
+// for forward compatibility
+type AppAdminServiceServer interface {
+	GetCapture(context.Context, *AppCaptureRequest) (*AppCaptureResponse, error)
+	PutCapture(context.Context, *PutAppCaptureRequest) (*AppCaptureResponse, error)
+	DeleteCapture(context.Context, *AppCaptureRequest) (*DeleteAppCaptureResponse, error)
+	ListCaptures(context.Context, *AppCaptureRequest) (*ListCapturesResponse, error)
+	ClearCaptures(context.Context, *AppCaptureRequest) (*ClearCapturesResponse, error)
+	mustEmbedUnimplementedAppAdminServiceServer()
+}
+
+// UnimplementedAppAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAppAdminServiceServer struct {
+}
+
+func (UnimplementedAppAdminServiceServer) GetCapture(context.Context, *AppCaptureRequest) (*AppCaptureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapture not implemented")
+}
+func (UnimplementedAppAdminServiceServer) PutCapture(context.Context, *PutAppCaptureRequest) (*AppCaptureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutCapture not implemented")
+}
+func (UnimplementedAppAdminServiceServer) DeleteCapture(context.Context, *AppCaptureRequest) (*DeleteAppCaptureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCapture not implemented")
+}
+func (UnimplementedAppAdminServiceServer) ListCaptures(context.Context, *AppCaptureRequest) (*ListCapturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCaptures not implemented")
+}
+func (UnimplementedAppAdminServiceServer) ClearCaptures(context.Context, *AppCaptureRequest) (*ClearCapturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearCaptures not implemented")
+}
+func (UnimplementedAppAdminServiceServer) mustEmbedUnimplementedAppAdminServiceServer() {}
+
+// UnsafeAppAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AppAdminServiceServer will
+// result in compilation errors.
+type UnsafeAppAdminServiceServer interface {
+	mustEmbedUnimplementedAppAdminServiceServer()
+}
+
+func RegisterAppAdminServiceServer(s grpc.ServiceRegistrar, srv AppAdminServiceServer) {
+	s.RegisterService(&AppAdminService_ServiceDesc, srv)
+}
+
+func _AppAdminService_GetCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppCaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppAdminServiceServer).GetCapture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppAdminService_GetCapture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppAdminServiceServer).GetCapture(ctx, req.(*AppCaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppAdminService_PutCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutAppCaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppAdminServiceServer).PutCapture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppAdminService_PutCapture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppAdminServiceServer).PutCapture(ctx, req.(*PutAppCaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppAdminService_DeleteCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppCaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppAdminServiceServer).DeleteCapture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppAdminService_DeleteCapture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppAdminServiceServer).DeleteCapture(ctx, req.(*AppCaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppAdminService_ListCaptures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppCaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppAdminServiceServer).ListCaptures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppAdminService_ListCaptures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppAdminServiceServer).ListCaptures(ctx, req.(*AppCaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppAdminService_ClearCaptures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppCaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppAdminServiceServer).ClearCaptures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppAdminService_ClearCaptures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppAdminServiceServer).ClearCaptures(ctx, req.(*AppCaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AppAdminService_ServiceDesc is the grpc.ServiceDesc for AppAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AppAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "begonia.org.admin.AppAdminService",
+	HandlerType: (*AppAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCapture",
+			Handler:    _AppAdminService_GetCapture_Handler,
+		},
+		{
+			MethodName: "PutCapture",
+			Handler:    _AppAdminService_PutCapture_Handler,
+		},
+		{
+			MethodName: "DeleteCapture",
+			Handler:    _AppAdminService_DeleteCapture_Handler,
+		},
+		{
+			MethodName: "ListCaptures",
+			Handler:    _AppAdminService_ListCaptures_Handler,
+		},
+		{
+			MethodName: "ClearCaptures",
+			Handler:    _AppAdminService_ClearCaptures_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
@@ -0,0 +1,146 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
+// source: mfa_login.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	MFALoginService_BeginWebAuthn_FullMethodName = "/begonia.org.mfa.MFALoginService/BeginWebAuthn"
+	MFALoginService_Verify_FullMethodName        = "/begonia.org.mfa.MFALoginService/Verify"
+)
+
+// MFALoginServiceClient is the client API for MFALoginService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MFALoginServiceClient interface {
+	BeginWebAuthn(ctx context.Context, in *BeginMFAWebAuthnRequest, opts ...grpc.CallOption) (*WebAuthnOptions, error)
+	Verify(ctx context.Context, in *VerifyMFARequest, opts ...grpc.CallOption) (*MFALoginResponse, error)
+}
+
+type mFALoginServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMFALoginServiceClient(cc grpc.ClientConnInterface) MFALoginServiceClient {
+	return &mFALoginServiceClient{cc}
+}
+
+func (c *mFALoginServiceClient) BeginWebAuthn(ctx context.Context, in *BeginMFAWebAuthnRequest, opts ...grpc.CallOption) (*WebAuthnOptions, error) {
+	out := new(WebAuthnOptions)
+	err := c.cc.Invoke(ctx, MFALoginService_BeginWebAuthn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mFALoginServiceClient) Verify(ctx context.Context, in *VerifyMFARequest, opts ...grpc.CallOption) (*MFALoginResponse, error) {
+	out := new(MFALoginResponse)
+	err := c.cc.Invoke(ctx, MFALoginService_Verify_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MFALoginServiceServer is the server API for MFALoginService service.
+// All implementations must embed UnimplementedMFALoginServiceServer
+// for forward compatibility
+type MFALoginServiceServer interface {
+	BeginWebAuthn(context.Context, *BeginMFAWebAuthnRequest) (*WebAuthnOptions, error)
+	Verify(context.Context, *VerifyMFARequest) (*MFALoginResponse, error)
+	mustEmbedUnimplementedMFALoginServiceServer()
+}
+
+// UnimplementedMFALoginServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMFALoginServiceServer struct {
+}
+
+func (UnimplementedMFALoginServiceServer) BeginWebAuthn(context.Context, *BeginMFAWebAuthnRequest) (*WebAuthnOptions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginWebAuthn not implemented")
+}
+func (UnimplementedMFALoginServiceServer) Verify(context.Context, *VerifyMFARequest) (*MFALoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Verify not implemented")
+}
+func (UnimplementedMFALoginServiceServer) mustEmbedUnimplementedMFALoginServiceServer() {}
+
+// UnsafeMFALoginServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MFALoginServiceServer will
+// result in compilation errors.
+type UnsafeMFALoginServiceServer interface {
+	mustEmbedUnimplementedMFALoginServiceServer()
+}
+
+func RegisterMFALoginServiceServer(s grpc.ServiceRegistrar, srv MFALoginServiceServer) {
+	s.RegisterService(&MFALoginService_ServiceDesc, srv)
+}
+
+func _MFALoginService_BeginWebAuthn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginMFAWebAuthnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MFALoginServiceServer).BeginWebAuthn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MFALoginService_BeginWebAuthn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MFALoginServiceServer).BeginWebAuthn(ctx, req.(*BeginMFAWebAuthnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MFALoginService_Verify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMFARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MFALoginServiceServer).Verify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MFALoginService_Verify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MFALoginServiceServer).Verify(ctx, req.(*VerifyMFARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MFALoginService_ServiceDesc is the grpc.ServiceDesc for MFALoginService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MFALoginService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "begonia.org.mfa.MFALoginService",
+	HandlerType: (*MFALoginServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BeginWebAuthn",
+			Handler:    _MFALoginService_BeginWebAuthn_Handler,
+		},
+		{
+			MethodName: "Verify",
+			Handler:    _MFALoginService_Verify_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mfa_login.proto",
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/begonia-org/begonia"
 	"github.com/begonia-org/begonia/config"
@@ -49,11 +53,39 @@ func NewGatewayCmd() *cobra.Command {
 			worker := internal.New(config, gateway.Log, endpoint)
 			hd, _ := os.UserHomeDir()
 			_ = os.WriteFile(hd+"/.begonia/gateway.json", []byte(fmt.Sprintf(`{"addr":"http://%s"}`, endpoint)), 0666)
-			worker.Start()
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+			defer stop()
+			started := make(chan error, 1)
+			go func() {
+				started <- worker.Start()
+			}()
+			code := 0
+			select {
+			case <-ctx.Done():
+			case err := <-started:
+				if err != nil {
+					log.Printf("start gateway error: %v", err)
+					code = 1
+				}
+			}
+			stop()
+			// 等待进行中的请求完成，超时后强制关闭
+			timeout, _ := cmd.Flags().GetDuration("shutdown-timeout")
+			log.Printf("shutting down gateway,timeout %s", timeout)
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			if err := worker.Shutdown(shutdownCtx); err != nil {
+				log.Printf("shutdown gateway error: %v", err)
+			}
+			if code != 0 {
+				cancel()
+				os.Exit(code)
+			}
 
 		},
 	}
 	cmd.Flags().StringP("endpoint", "", "127.0.0.1:12138", "Endpoint Of Your Service")
+	cmd.Flags().Duration("shutdown-timeout", 30*time.Second, "Max Time To Wait For In-flight Requests On Shutdown")
 	// cmd.Flags().StringP("name", "", "begonia", "Name Of Your Gateway Server")
 
 	return cmd
@@ -133,6 +165,7 @@ func main() {
 	rootCmd.AddCommand(NewBegoniaInfoCmd())
 	rootCmd.AddCommand(addCommonCommand(NewInitCmd()))
 	rootCmd.AddCommand(NewEndpointCmd())
+	rootCmd.AddCommand(NewReplayCmd())
 	if err := cmd.Execute(); err != nil {
 		log.Fatalf("failed to start master: %v", err)
 	}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func NewReplayCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "replay",
+		Short: "Replay Captured Requests Against An Endpoint And Diff The Responses",
+
+		Run: func(cmd *cobra.Command, args []string) {
+			file, _ := cmd.Flags().GetString("file")
+			endpoint, _ := cmd.Flags().GetString("endpoint")
+			desc, _ := cmd.Flags().GetString("desc")
+			ids, _ := cmd.Flags().GetUintSlice("id")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			upstream, err := replayTLS(cmd)
+			if err != nil {
+				log.Fatalf("read tls flags error: %v", err)
+			}
+
+			if ReplayCaptures(file, endpoint, desc, ids, timeout, upstream) > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().StringP("file", "f", "", "Captured Requests, Json Lines Of The Capture File Or Response Of The Admin API")
+	cmd.Flags().StringP("endpoint", "p", "", "Endpoint To Replay Against (example:127.0.0.1:1949)")
+	cmd.Flags().StringP("desc", "d", "", "Descriptions Set Of The Service (example:./example/example.pb)")
+	cmd.Flags().UintSlice("id", []uint{}, "Only Replay These Captures")
+	cmd.Flags().Duration("timeout", 10*time.Second, "Timeout Of Each Replayed Request")
+	cmd.Flags().Bool("tls", false, "Connect To The Endpoint With TLS")
+	cmd.Flags().String("ca", "", "CA File To Verify The Endpoint Certificate, System CA Is Used If Empty")
+	cmd.Flags().String("cert", "", "Client Certificate File For Mutual TLS")
+	cmd.Flags().String("key", "", "Client Private Key File For Mutual TLS")
+	cmd.Flags().String("server-name", "", "Server Name To Verify The Endpoint Certificate")
+	cmd.Flags().Bool("insecure-skip-verify", false, "Skip Verifying The Endpoint Certificate")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.MarkFlagRequired("endpoint")
+	_ = cmd.MarkFlagRequired("desc")
+	return cmd
+}
+
+// replayTLS 根据命令行参数生成连接端点的tls配置，未开启tls时返回nil使用明文连接
+func replayTLS(cmd *cobra.Command) (*gateway.UpstreamTLS, error) {
+	enabled, _ := cmd.Flags().GetBool("tls")
+	if !enabled {
+		return nil, nil
+	}
+	upstream := &gateway.UpstreamTLS{}
+	upstream.ServerName, _ = cmd.Flags().GetString("server-name")
+	upstream.InsecureSkipVerify, _ = cmd.Flags().GetBool("insecure-skip-verify")
+	for flag, value := range map[string]*string{"ca": &upstream.CA, "cert": &upstream.Cert, "key": &upstream.Key} {
+		path, _ := cmd.Flags().GetString(flag)
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		*value = string(data)
+	}
+	return upstream, nil
+}
+
+// ReplayCaptures 重放录制的请求并与录制的响应比较，返回存在差异的调用数
+func ReplayCaptures(file string, endpoint string, desc string, ids []uint, timeout time.Duration, upstream *gateway.UpstreamTLS) int {
+	entries, err := readCaptures(file)
+	if err != nil {
+		log.Fatalf("read captures error: %v", err)
+	}
+	files, err := readDescriptorSet(desc)
+	if err != nil {
+		log.Fatalf("read descriptor set error: %v", err)
+	}
+	creds, err := upstream.Credentials()
+	if err != nil {
+		log.Fatalf("load tls credentials error: %v", err)
+	}
+	conn, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		log.Fatalf("dial %s error: %v", endpoint, err)
+	}
+	defer conn.Close()
+	selected := make(map[uint64]bool)
+	for _, id := range ids {
+		selected[uint64(id)] = true
+	}
+	replayed, changed := 0, 0
+	for _, entry := range entries {
+		if len(selected) > 0 && !selected[entry.ID] {
+			continue
+		}
+		replayed++
+		diffs, elapsed, err := replayCapture(conn, files, entry, timeout)
+		if err != nil {
+			changed++
+			fmt.Printf("#%d %s: replay error: %v\n", entry.ID, entry.Method, err)
+			continue
+		}
+		if len(diffs) == 0 {
+			fmt.Printf("#%d %s: identical (%.2fms -> %.2fms)\n", entry.ID, entry.Method, entry.DurationMs, float64(elapsed.Microseconds())/1000)
+			continue
+		}
+		changed++
+		fmt.Printf("#%d %s: %d differences (%.2fms -> %.2fms)\n", entry.ID, entry.Method, len(diffs), entry.DurationMs, float64(elapsed.Microseconds())/1000)
+		for _, diff := range diffs {
+			fmt.Printf("  %s\n", diff)
+		}
+		if entry.Truncated {
+			fmt.Printf("  messages of the capture were truncated\n")
+		}
+	}
+	fmt.Printf("replayed %d captures, %d with differences\n", replayed, changed)
+	return changed
+}
+
+// readCaptures 读取录制文件，支持json lines、json数组和管理接口ListCaptures的响应
+func readCaptures(path string) ([]*gateway.CaptureEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*gateway.CaptureEntry, 0)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &entries)
+		return entries, err
+	}
+	rsp := struct {
+		Data *struct {
+			Entries []*gateway.CaptureEntry `json:"entries"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(data, &rsp); err == nil && rsp.Data != nil {
+		return append(entries, rsp.Data.Entries...), nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		entry := &gateway.CaptureEntry{}
+		if err := json.Unmarshal(line, entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+func readDescriptorSet(path string) (*protoregistry.Files, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, fds); err != nil {
+		return nil, err
+	}
+	return protodesc.NewFiles(fds)
+}
+
+// replayMetadata 去掉传输相关和脱敏后的请求头
+func replayMetadata(values map[string][]string) metadata.MD {
+	md := metadata.MD{}
+	for key, value := range values {
+		if strings.HasPrefix(key, ":") || strings.HasPrefix(key, "grpc-") || key == "content-type" || key == "user-agent" {
+			continue
+		}
+		if len(value) == 1 && value[0] == gateway.TransformRedacted {
+			continue
+		}
+		md.Append(key, value...)
+	}
+	return md
+}
+
+// decodeMessage 录制时解码失败的消息保存为base64编码的原始消息
+func decodeMessage(md protoreflect.MessageDescriptor, value json.RawMessage) (proto.Message, error) {
+	var raw string
+	if err := json.Unmarshal(value, &raw); err == nil {
+		b, err := base64.StdEncoding.DecodeString(raw)
+		if err != nil {
+			return nil, err
+		}
+		msg := &emptypb.Empty{}
+		msg.ProtoReflect().SetUnknown(b)
+		return msg, nil
+	}
+	msg := dynamicpb.NewMessage(md)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(value, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func replayCapture(conn *grpc.ClientConn, files *protoregistry.Files, entry *gateway.CaptureEntry, timeout time.Duration) ([]string, time.Duration, error) {
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(entry.Method, "/"), "/", ".")))
+	if err != nil {
+		return nil, 0, err
+	}
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, 0, fmt.Errorf("%s is not a method", entry.Method)
+	}
+	ctx, cancel := context.WithTimeout(metadata.NewOutgoingContext(context.Background(), replayMetadata(entry.Metadata)), timeout)
+	defer cancel()
+	start := time.Now()
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, entry.Method)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, value := range entry.Requests {
+		in, err := decodeMessage(method.Input(), value)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := stream.SendMsg(in); err != nil {
+			break
+		}
+	}
+	_ = stream.CloseSend()
+	responses := make([]json.RawMessage, 0)
+	for {
+		out := dynamicpb.NewMessage(method.Output())
+		if err = stream.RecvMsg(out); err != nil {
+			break
+		}
+		gateway.MaskMessage(out, entry.MaskFields)
+		b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(out)
+		if err != nil {
+			return nil, 0, err
+		}
+		responses = append(responses, b)
+	}
+	elapsed := time.Since(start)
+	if err == io.EOF {
+		err = nil
+	}
+	st := status.Convert(err)
+	diffs := make([]string, 0)
+	if st.Code().String() != entry.Code {
+		diffs = append(diffs, fmt.Sprintf("code: %s -> %s", entry.Code, st.Code().String()))
+	}
+	if st.Message() != entry.Message {
+		diffs = append(diffs, fmt.Sprintf("message: %q -> %q", entry.Message, st.Message()))
+	}
+	if len(responses) != len(entry.Responses) {
+		diffs = append(diffs, fmt.Sprintf("responses: %d -> %d messages", len(entry.Responses), len(responses)))
+	}
+	for i := 0; i < len(responses) && i < len(entry.Responses); i++ {
+		var captured, replayed interface{}
+		_ = json.Unmarshal(entry.Responses[i], &captured)
+		_ = json.Unmarshal(responses[i], &replayed)
+		diffs = diffJSON(fmt.Sprintf("responses[%d]", i), captured, replayed, diffs)
+	}
+	return diffs, elapsed, nil
+}
+
+// diffJSON 比较两个json值，返回字段路径上的差异
+func diffJSON(path string, captured interface{}, replayed interface{}, diffs []string) []string {
+	switch a := captured.(type) {
+	case map[string]interface{}:
+		b, ok := replayed.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(a)+len(b))
+		for key := range a {
+			keys = append(keys, key)
+		}
+		for key := range b {
+			if _, ok := a[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			diffs = diffJSON(path+"."+key, a[key], b[key], diffs)
+		}
+		return diffs
+	case []interface{}:
+		b, ok := replayed.([]interface{})
+		if !ok || len(a) != len(b) {
+			break
+		}
+		for i := range a {
+			diffs = diffJSON(fmt.Sprintf("%s[%d]", path, i), a[i], b[i], diffs)
+		}
+		return diffs
+	}
+	if reflect.DeepEqual(captured, replayed) {
+		return diffs
+	}
+	before, _ := json.Marshal(captured)
+	after, _ := json.Marshal(replayed)
+	return append(diffs, fmt.Sprintf("%s: %s -> %s", path, before, after))
+}
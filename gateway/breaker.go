@@ -0,0 +1,236 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+func (s CircuitState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+type CircuitBreakerOptions struct {
+	// 统计窗口，窗口结束后清空计数
+	Window time.Duration
+	// 窗口内的最小请求数，低于该值时不触发熔断
+	MinRequests int
+	// 错误率阈值
+	ErrorRateThreshold float64
+	// 慢调用的耗时阈值，为0时不统计慢调用
+	SlowCallThreshold time.Duration
+	// 慢调用比例阈值
+	SlowCallRateThreshold float64
+	// 熔断打开后经过该时间进入半开状态
+	OpenTimeout time.Duration
+	// 半开状态下允许通过的探测请求数，全部成功后关闭熔断
+	HalfOpenRequests int
+}
+
+func DefaultCircuitBreakerOptions() *CircuitBreakerOptions {
+	return &CircuitBreakerOptions{
+		Window:                10 * time.Second,
+		MinRequests:           20,
+		ErrorRateThreshold:    0.5,
+		SlowCallThreshold:     5 * time.Second,
+		SlowCallRateThreshold: 0.8,
+		OpenTimeout:           30 * time.Second,
+		HalfOpenRequests:      3,
+	}
+}
+
+// CircuitBreakerState 端点熔断器的状态
+type CircuitBreakerState struct {
+	Addr      string       `json:"addr"`
+	State     CircuitState `json:"state"`
+	Requests  int64        `json:"requests"`
+	Failures  int64        `json:"failures"`
+	SlowCalls int64        `json:"slow_calls"`
+	OpenedAt  time.Time    `json:"opened_at,omitempty"`
+	LastError string       `json:"last_error,omitempty"`
+}
+
+type circuitBreaker struct {
+	CircuitBreakerState
+	windowStart time.Time
+	// 半开状态下已放行和已成功的探测请求数
+	probes    int
+	successes int
+}
+
+// CircuitBreakers 按端点地址维护熔断器，
+// 熔断打开时负载均衡器跳过该端点，所有端点都熔断时快速失败
+type CircuitBreakers struct {
+	opts     *CircuitBreakerOptions
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+func NewCircuitBreakers(opts *CircuitBreakerOptions) *CircuitBreakers {
+	if opts == nil {
+		opts = DefaultCircuitBreakerOptions()
+	}
+	return &CircuitBreakers{
+		opts:     opts,
+		breakers: make(map[string]*circuitBreaker),
+	}
+}
+
+func (c *CircuitBreakers) get(addr string, now time.Time) *circuitBreaker {
+	cb, ok := c.breakers[addr]
+	if !ok {
+		cb = &circuitBreaker{CircuitBreakerState: CircuitBreakerState{Addr: addr}, windowStart: now}
+		c.breakers[addr] = cb
+	}
+	return cb
+}
+
+// Allow 端点是否允许请求通过，半开状态下会占用一个探测名额
+func (c *CircuitBreakers) Allow(addr string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	cb := c.get(addr, now)
+	switch cb.State {
+	case CircuitOpen:
+		if now.Sub(cb.OpenedAt) < c.opts.OpenTimeout {
+			return false
+		}
+		cb.State = CircuitHalfOpen
+		cb.probes = 0
+		cb.successes = 0
+		Log.Infof(context.Background(), "circuit breaker of %s is half open", addr)
+		fallthrough
+	case CircuitHalfOpen:
+		if cb.probes >= c.opts.HalfOpenRequests {
+			return false
+		}
+		cb.probes++
+	}
+	return true
+}
+
+// Report 上报请求结果和耗时
+func (c *CircuitBreakers) Report(addr string, err error, latency time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	cb := c.get(addr, now)
+	canceled := status.Code(err) == codes.Canceled
+	failed := isUpstreamFailure(err)
+	slow := c.opts.SlowCallThreshold > 0 && latency >= c.opts.SlowCallThreshold
+	if failed {
+		cb.LastError = err.Error()
+	}
+	switch cb.State {
+	case CircuitHalfOpen:
+		if canceled {
+			// 释放探测名额
+			cb.probes--
+			return
+		}
+		if failed || slow {
+			c.open(cb, now)
+			return
+		}
+		cb.successes++
+		if cb.successes >= c.opts.HalfOpenRequests {
+			c.close(cb, now)
+		}
+		return
+	case CircuitOpen:
+		return
+	}
+	if canceled {
+		return
+	}
+	if now.Sub(cb.windowStart) >= c.opts.Window {
+		c.resetWindow(cb, now)
+	}
+	cb.Requests++
+	if failed {
+		cb.Failures++
+	}
+	if slow {
+		cb.SlowCalls++
+	}
+	if cb.Requests < int64(c.opts.MinRequests) {
+		return
+	}
+	errorRate := float64(cb.Failures) / float64(cb.Requests)
+	slowRate := float64(cb.SlowCalls) / float64(cb.Requests)
+	if (c.opts.ErrorRateThreshold > 0 && errorRate >= c.opts.ErrorRateThreshold) ||
+		(c.opts.SlowCallRateThreshold > 0 && slowRate >= c.opts.SlowCallRateThreshold) {
+		c.open(cb, now)
+	}
+}
+
+func (c *CircuitBreakers) open(cb *circuitBreaker, now time.Time) {
+	cb.State = CircuitOpen
+	cb.OpenedAt = now
+	Log.Warnf(context.Background(), "circuit breaker of %s is open,requests:%d,failures:%d,slow calls:%d,last error:%s", cb.Addr, cb.Requests, cb.Failures, cb.SlowCalls, cb.LastError)
+	c.resetWindow(cb, now)
+}
+
+func (c *CircuitBreakers) close(cb *circuitBreaker, now time.Time) {
+	cb.State = CircuitClosed
+	cb.OpenedAt = time.Time{}
+	cb.LastError = ""
+	c.resetWindow(cb, now)
+	Log.Infof(context.Background(), "circuit breaker of %s is closed", cb.Addr)
+}
+
+func (c *CircuitBreakers) resetWindow(cb *circuitBreaker, now time.Time) {
+	cb.windowStart = now
+	cb.Requests = 0
+	cb.Failures = 0
+	cb.SlowCalls = 0
+	cb.probes = 0
+	cb.successes = 0
+}
+
+// State 获取端点的熔断器状态
+func (c *CircuitBreakers) State(addr string) *CircuitBreakerState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cb, ok := c.breakers[addr]
+	if !ok {
+		return &CircuitBreakerState{Addr: addr}
+	}
+	state := cb.CircuitBreakerState
+	return &state
+}
+
+// Reset 手动关闭端点的熔断器
+func (c *CircuitBreakers) Reset(addr string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cb, ok := c.breakers[addr]; ok {
+		c.close(cb, time.Now())
+	}
+}
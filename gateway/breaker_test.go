@@ -0,0 +1,82 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	opts := &CircuitBreakerOptions{
+		Window:                time.Minute,
+		MinRequests:           4,
+		ErrorRateThreshold:    0.5,
+		SlowCallThreshold:     100 * time.Millisecond,
+		SlowCallRateThreshold: 0.8,
+		OpenTimeout:           100 * time.Millisecond,
+		HalfOpenRequests:      2,
+	}
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	c.Convey("test circuit breaker open by error rate", t, func() {
+		breakers := NewCircuitBreakers(opts)
+		addr := "127.0.0.1:2001"
+		breakers.Report(addr, nil, time.Millisecond)
+		breakers.Report(addr, status.Error(codes.NotFound, "not found"), time.Millisecond)
+		breakers.Report(addr, unavailable, time.Millisecond)
+		c.So(breakers.State(addr).State, c.ShouldEqual, CircuitClosed)
+		breakers.Report(addr, unavailable, time.Millisecond)
+		c.So(breakers.State(addr).State, c.ShouldEqual, CircuitOpen)
+		c.So(breakers.Allow(addr), c.ShouldBeFalse)
+
+		// 半开状态只放行有限的探测请求，全部成功后关闭
+		time.Sleep(opts.OpenTimeout)
+		c.So(breakers.Allow(addr), c.ShouldBeTrue)
+		c.So(breakers.State(addr).State, c.ShouldEqual, CircuitHalfOpen)
+		c.So(breakers.Allow(addr), c.ShouldBeTrue)
+		c.So(breakers.Allow(addr), c.ShouldBeFalse)
+		breakers.Report(addr, nil, time.Millisecond)
+		breakers.Report(addr, nil, time.Millisecond)
+		c.So(breakers.State(addr).State, c.ShouldEqual, CircuitClosed)
+		c.So(breakers.Allow(addr), c.ShouldBeTrue)
+	})
+	c.Convey("test circuit breaker open by slow calls", t, func() {
+		breakers := NewCircuitBreakers(opts)
+		addr := "127.0.0.1:2002"
+		for i := 0; i < 4; i++ {
+			breakers.Report(addr, nil, 200*time.Millisecond)
+		}
+		c.So(breakers.State(addr).State, c.ShouldEqual, CircuitOpen)
+
+		// 半开状态下探测失败重新打开
+		time.Sleep(opts.OpenTimeout)
+		c.So(breakers.Allow(addr), c.ShouldBeTrue)
+		breakers.Report(addr, unavailable, time.Millisecond)
+		c.So(breakers.State(addr).State, c.ShouldEqual, CircuitOpen)
+		breakers.Reset(addr)
+		c.So(breakers.State(addr).State, c.ShouldEqual, CircuitClosed)
+	})
+	c.Convey("test select skip open circuit", t, func() {
+		eps := []loadbalance.Endpoint{NewGrpcEndpoint("127.0.0.1:2003", nil), NewGrpcEndpoint("127.0.0.1:2004", nil)}
+		rr, _ := loadbalance.New(loadbalance.RRBalanceType, eps)
+		lb := NewGrpcLoadBalancer()
+		lb.lb["/HELLOWORLD.GREETER/SAYHELLO"] = rr
+		lb.SetCircuitBreakers(opts)
+		for i := 0; i < 4; i++ {
+			lb.ReportResult("127.0.0.1:2003", unavailable, time.Millisecond)
+		}
+		for i := 0; i < 4; i++ {
+			endpoint, err := lb.Select("/helloworld.Greeter/SayHello")
+			c.So(err, c.ShouldBeNil)
+			c.So(endpoint.Addr(), c.ShouldEqual, "127.0.0.1:2004")
+		}
+		for i := 0; i < 4; i++ {
+			lb.ReportResult("127.0.0.1:2004", unavailable, time.Millisecond)
+		}
+		_, err := lb.Select("/helloworld.Greeter/SayHello")
+		c.So(err, c.ShouldEqual, ErrCircuitOpen)
+	})
+}
@@ -0,0 +1,207 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	cache "github.com/begonia-org/begonia/api/cache/v1"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+)
+
+const responseCachePrefix = "response_cache"
+
+// ResponseCacheStore 响应缓存的存储，例如本地和redis的多级缓存
+type ResponseCacheStore interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, exp time.Duration) error
+}
+
+// CachePolicy 方法的响应缓存策略，只对GET方法的一元调用生效
+type CachePolicy struct {
+	// 缓存时间(秒)
+	TTL int64 `json:"ttl"`
+	// 参与缓存key计算的请求头
+	VaryHeaders []string `json:"vary_headers,omitempty"`
+	// 是否按调用者的身份区分缓存
+	VaryIdentity bool `json:"vary_identity,omitempty"`
+}
+
+// EndpointCache 端点的响应缓存策略，键的匹配方式与EndpointPolicy相同，
+// 值为null时关闭方法在描述文件中配置的缓存
+type EndpointCache map[string]*CachePolicy
+
+func (c EndpointCache) Validate() error {
+	for key, policy := range c {
+		if policy != nil && policy.TTL <= 0 {
+			return fmt.Errorf("%s:ttl must be positive", key)
+		}
+	}
+	return nil
+}
+
+func (c EndpointCache) lookup(service, method string) (*CachePolicy, bool) {
+	if policy, ok := c[fmt.Sprintf("%s/%s", service, method)]; ok {
+		return policy, true
+	}
+	if policy, ok := c[service]; ok {
+		return policy, true
+	}
+	policy, ok := c["*"]
+	return policy, ok
+}
+
+func (p *CachePolicy) ttl() time.Duration {
+	return time.Duration(p.TTL) * time.Second
+}
+
+// MethodCache 方法的缓存策略，version随端点配置变化，端点更新或清除缓存后旧的缓存不再命中
+type MethodCache struct {
+	*CachePolicy
+	method  string
+	version string
+}
+
+// Key 缓存的key，由方法、端点版本、请求内容(包含路径参数和查询参数)、指定的请求头和身份计算得到
+func (m *MethodCache) Key(req []byte, headers map[string]string, identity string) string {
+	h := sha256.New()
+	h.Write(req)
+	for _, name := range m.VaryHeaders {
+		fmt.Fprintf(h, "\n%s:%s", strings.ToLower(name), headers[strings.ToLower(name)])
+	}
+	if m.VaryIdentity {
+		fmt.Fprintf(h, "\nidentity:%s", identity)
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", responseCachePrefix, m.method, m.version, hex.EncodeToString(h.Sum(nil)))
+}
+
+func (m *MethodCache) TTL() time.Duration {
+	return m.ttl()
+}
+
+// CacheControl 响应的Cache-Control头，按身份区分的缓存不允许共享缓存保存
+func (m *MethodCache) CacheControl() string {
+	if m.VaryIdentity {
+		return fmt.Sprintf("private, max-age=%d", m.CachePolicy.TTL)
+	}
+	return fmt.Sprintf("max-age=%d", m.CachePolicy.TTL)
+}
+
+// CacheRegistry 按方法保存响应缓存策略，端点更新时由EndpointWatcher重新注册
+type CacheRegistry struct {
+	mu       sync.RWMutex
+	policies map[string]*MethodCache
+}
+
+var responseCaches = &CacheRegistry{policies: make(map[string]*MethodCache)}
+
+// ResponseCaches 全局的响应缓存策略，缓存插件在网关启动前创建，因此不挂载在GatewayServer上
+func ResponseCaches() *CacheRegistry {
+	return responseCaches
+}
+
+// Register 注册端点中GET方法的缓存策略，端点配置优先于方法的cache选项
+func (r *CacheRegistry) Register(pd ProtobufDescription, policies EndpointCache, version string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, file := range pd.GetFileDescriptorSet().GetFile() {
+		for _, service := range file.GetService() {
+			serviceName := fmt.Sprintf("%s.%s", file.GetPackage(), service.GetName())
+			for _, method := range service.GetMethod() {
+				key := strings.ToUpper(fmt.Sprintf("/%s/%s", serviceName, method.GetName()))
+				delete(r.policies, key)
+				if method.GetClientStreaming() || method.GetServerStreaming() {
+					continue
+				}
+				rule, _ := proto.GetExtension(method.GetOptions(), annotations.E_Http).(*annotations.HttpRule)
+				if rule.GetGet() == "" {
+					continue
+				}
+				policy, ok := policies.lookup(serviceName, method.GetName())
+				if !ok {
+					if option, _ := proto.GetExtension(method.GetOptions(), cache.E_Cache).(*cache.CacheOption); option.GetTtl() > 0 {
+						policy = &CachePolicy{TTL: option.GetTtl(), VaryHeaders: option.GetVaryHeaders(), VaryIdentity: option.GetVaryIdentity()}
+					}
+				}
+				if policy == nil {
+					continue
+				}
+				r.policies[key] = &MethodCache{CachePolicy: policy, method: key, version: version}
+			}
+		}
+	}
+}
+
+func (r *CacheRegistry) Delete(pd ProtobufDescription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	walkMethods(pd, func(key string, _ string, _ string) {
+		delete(r.policies, key)
+	})
+}
+
+// Get 获取方法的缓存策略，没有开启缓存时返回nil
+func (r *CacheRegistry) Get(fullMethod string) *MethodCache {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !strings.HasPrefix(fullMethod, "/") {
+		fullMethod = "/" + fullMethod
+	}
+	return r.policies[strings.ToUpper(fullMethod)]
+}
+
+// cacheResponseWriter 缓存GET请求的响应内容，用于计算ETag和处理If-None-Match
+type cacheResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *cacheResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *cacheResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// flush 成功的响应设置ETag和Cache-Control，客户端的ETag与响应一致时返回304
+func (w *cacheResponseWriter) flush(req *http.Request, policy *MethodCache) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.status != http.StatusOK {
+		w.ResponseWriter.WriteHeader(w.status)
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+		return
+	}
+	sum := sha256.Sum256(w.body.Bytes())
+	etag := fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:16]))
+	header := w.ResponseWriter.Header()
+	header.Set("ETag", etag)
+	header.Set("Cache-Control", policy.CacheControl())
+	if etagMatch(req.Header.Get("If-None-Match"), etag) {
+		header.Del("Content-Length")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
+
+func etagMatch(ifNoneMatch string, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	c "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResponseCache(t *testing.T) {
+	c.Convey("test response cache", t, func() {
+		pd := newTransformTestDescription()
+		defer ResponseCaches().Delete(pd)
+
+		c.So(EndpointCache{"*": {TTL: 0}}.Validate(), c.ShouldNotBeNil)
+		c.So(EndpointCache{"*": {TTL: 10}, "helloworld.Greeter/SayHelloError": nil}.Validate(), c.ShouldBeNil)
+
+		ResponseCaches().Register(pd, EndpointCache{
+			"helloworld.Greeter":               {TTL: 10, VaryHeaders: []string{"Accept-Language"}},
+			"helloworld.Greeter/SayHelloError": nil,
+		}, "v1")
+		// 只缓存GET方法的一元调用
+		c.So(ResponseCaches().Get("/helloworld.Greeter/SayHello"), c.ShouldBeNil)
+		c.So(ResponseCaches().Get("/helloworld.Greeter/SayHelloServerSideEvent"), c.ShouldBeNil)
+		c.So(ResponseCaches().Get("/helloworld.Greeter/SayHelloError"), c.ShouldBeNil)
+		policy := ResponseCaches().Get("helloworld.Greeter/SayHelloGet")
+		c.So(policy, c.ShouldNotBeNil)
+		c.So(policy.TTL(), c.ShouldEqual, 10*time.Second)
+		c.So(policy.CacheControl(), c.ShouldEqual, "max-age=10")
+
+		key := policy.Key([]byte("req"), map[string]string{"accept-language": "zh"}, "user:1")
+		c.So(key, c.ShouldStartWith, "response_cache:/HELLOWORLD.GREETER/SAYHELLOGET:v1:")
+		c.So(policy.Key([]byte("req"), map[string]string{"accept-language": "zh"}, "user:2"), c.ShouldEqual, key)
+		c.So(policy.Key([]byte("req"), map[string]string{"accept-language": "en"}, "user:1"), c.ShouldNotEqual, key)
+		c.So(policy.Key([]byte("other"), map[string]string{"accept-language": "zh"}, "user:1"), c.ShouldNotEqual, key)
+
+		// 端点更新后版本变化，之前的缓存不再命中
+		ResponseCaches().Register(pd, EndpointCache{"*": {TTL: 5, VaryIdentity: true}}, "v2")
+		updated := ResponseCaches().Get("/helloworld.Greeter/SayHelloGet")
+		c.So(updated.Key([]byte("req"), nil, "user:1"), c.ShouldNotEqual, updated.Key([]byte("req"), nil, "user:2"))
+		c.So(updated.Key([]byte("req"), nil, "user:1"), c.ShouldContainSubstring, ":v2:")
+		c.So(updated.CacheControl(), c.ShouldEqual, "private, max-age=5")
+		c.So(ResponseCaches().Get("/helloworld.Greeter/SayHelloError"), c.ShouldNotBeNil)
+
+		ResponseCaches().Register(pd, nil, "v3")
+		c.So(ResponseCaches().Get("/helloworld.Greeter/SayHelloGet"), c.ShouldBeNil)
+	})
+}
+
+func TestCacheResponseWriter(t *testing.T) {
+	c.Convey("test cache response writer", t, func() {
+		policy := &MethodCache{CachePolicy: &CachePolicy{TTL: 60}}
+		write := func(ifNoneMatch string, status int) *httptest.ResponseRecorder {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/example/test", nil)
+			if ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", ifNoneMatch)
+			}
+			w := &cacheResponseWriter{ResponseWriter: rec}
+			if status != 0 {
+				w.WriteHeader(status)
+			}
+			_, _ = w.Write([]byte(`{"message":"hello"}`))
+			w.flush(req, policy)
+			return rec
+		}
+		rec := write("", 0)
+		c.So(rec.Code, c.ShouldEqual, http.StatusOK)
+		c.So(rec.Body.String(), c.ShouldEqual, `{"message":"hello"}`)
+		c.So(rec.Header().Get("Cache-Control"), c.ShouldEqual, "max-age=60")
+		etag := rec.Header().Get("ETag")
+		c.So(etag, c.ShouldNotBeEmpty)
+
+		rec = write(etag, 0)
+		c.So(rec.Code, c.ShouldEqual, http.StatusNotModified)
+		c.So(rec.Body.Len(), c.ShouldEqual, 0)
+		c.So(write(`"other", W/`+etag, 0).Code, c.ShouldEqual, http.StatusNotModified)
+		c.So(write(`"other"`, 0).Code, c.ShouldEqual, http.StatusOK)
+
+		// 失败的响应不设置ETag
+		rec = write(etag, http.StatusNotFound)
+		c.So(rec.Code, c.ShouldEqual, http.StatusNotFound)
+		c.So(rec.Header().Get("ETag"), c.ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,651 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	defaultCaptureEntries  = 100
+	maxCaptureEntries      = 10000
+	defaultCaptureMessages = 10
+	defaultAppCaptureTTL   = time.Hour
+)
+
+// 名称包含这些词的字段总是脱敏
+var sensitiveFieldWords = []string{"password", "passwd", "secret", "token", "credential", "private_key", "api_key"}
+
+// 总是脱敏的请求头和响应头，刷新令牌和会话ID可以直接用于续期登录态
+var sensitiveHeaders = []string{"authorization", "cookie", "set-cookie", "x-token", "x-api-key", "x-refresh-token", XSessionID}
+
+// CapturePolicy 代理调用的录制策略，录制解码后的请求响应、请求头、状态码和耗时，用于调试和回放
+type CapturePolicy struct {
+	// 采样率(0,1]，默认全部录制
+	SampleRate float64 `json:"sample_rate,omitempty"`
+	// 只录制这些应用或用户的调用，均为空时录制所有调用
+	Apps  []string `json:"apps,omitempty"`
+	Users []string `json:"users,omitempty"`
+	// 内存中保存的最近录制条数，默认100
+	MaxEntries int `json:"max_entries,omitempty"`
+	// 以json lines格式追加写入的文件，为录制目录下的相对路径，为空时只保存在内存中
+	File string `json:"file,omitempty"`
+	// 脱敏的字段路径，与转换规则的redact相同
+	MaskFields []string `json:"mask_fields,omitempty"`
+	// 脱敏的请求头
+	MaskHeaders []string `json:"mask_headers,omitempty"`
+	// 流式调用每个方向最多录制的消息数，默认10
+	MaxMessages int `json:"max_messages,omitempty"`
+}
+
+func (p *CapturePolicy) Validate() error {
+	if p.SampleRate < 0 || p.SampleRate > 1 {
+		return fmt.Errorf("sample_rate must be in (0,1]")
+	}
+	if p.MaxEntries < 0 || p.MaxEntries > maxCaptureEntries {
+		return fmt.Errorf("max_entries must be in [0,%d]", maxCaptureEntries)
+	}
+	if p.MaxMessages < 0 {
+		return fmt.Errorf("max_messages must not be negative")
+	}
+	if p.File != "" {
+		if _, err := captures.path(p.File); err != nil {
+			return err
+		}
+	}
+	for _, field := range p.MaskFields {
+		if field == "" {
+			return fmt.Errorf("mask field must not be empty")
+		}
+	}
+	return nil
+}
+
+func (p *CapturePolicy) sample() bool {
+	return p.SampleRate == 0 || rand.Float64() < p.SampleRate
+}
+
+func (p *CapturePolicy) match(app string, user string) bool {
+	if len(p.Apps) == 0 && len(p.Users) == 0 {
+		return true
+	}
+	return containsValue(p.Apps, app) || containsValue(p.Users, user)
+}
+
+func (p *CapturePolicy) maxEntries() int {
+	if p.MaxEntries > 0 {
+		return p.MaxEntries
+	}
+	return defaultCaptureEntries
+}
+
+func (p *CapturePolicy) maxMessages() int {
+	if p.MaxMessages > 0 {
+		return p.MaxMessages
+	}
+	return defaultCaptureMessages
+}
+
+// AppCapture 按应用录制所有端点的调用，只在当前网关实例生效，到期后自动关闭
+type AppCapture struct {
+	CapturePolicy
+	// 录制时长(秒)，默认1小时
+	TTLSeconds int64     `json:"ttl_seconds,omitempty"`
+	ExpireAt   time.Time `json:"expire_at"`
+}
+
+func (a *AppCapture) Validate() error {
+	if a.TTLSeconds < 0 {
+		return fmt.Errorf("ttl_seconds must not be negative")
+	}
+	return a.CapturePolicy.Validate()
+}
+
+func (a *AppCapture) ttl() time.Duration {
+	if a.TTLSeconds > 0 {
+		return time.Duration(a.TTLSeconds) * time.Second
+	}
+	return defaultAppCaptureTTL
+}
+
+// CaptureEntry 一次录制的调用，请求和响应为脱敏后的protojson
+type CaptureEntry struct {
+	ID         uint64              `json:"id"`
+	Source     string              `json:"source"`
+	Method     string              `json:"method"`
+	Endpoint   string              `json:"endpoint,omitempty"`
+	Release    string              `json:"release,omitempty"`
+	App        string              `json:"app,omitempty"`
+	User       string              `json:"user,omitempty"`
+	Metadata   map[string][]string `json:"metadata,omitempty"`
+	Requests   []json.RawMessage   `json:"requests"`
+	Responses  []json.RawMessage   `json:"responses"`
+	Code       string              `json:"code"`
+	Message    string              `json:"message,omitempty"`
+	StartAt    time.Time           `json:"start_at"`
+	DurationMs float64             `json:"duration_ms"`
+	// 录制时脱敏的字段，回放时对响应做相同的脱敏后再比较
+	MaskFields []string `json:"mask_fields,omitempty"`
+	// 流式调用的消息数超过了录制上限
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// EndpointCaptureSource 端点录制的来源名称
+func EndpointCaptureSource(key string) string {
+	return "endpoint:" + key
+}
+
+// AppCaptureSource 应用录制的来源名称
+func AppCaptureSource(app string) string {
+	return "app:" + app
+}
+
+// captureSink 一个录制来源的环形缓冲区，关闭录制后保留已录制的内容
+type captureSink struct {
+	source   string
+	mu       sync.Mutex
+	policy   *CapturePolicy
+	expireAt time.Time
+	entries  []*CaptureEntry
+	next     int
+	full     bool
+}
+
+func (s *captureSink) active(app string, user string) *CapturePolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.policy == nil || (!s.expireAt.IsZero() && time.Now().After(s.expireAt)) {
+		return nil
+	}
+	if !s.policy.match(app, user) || !s.policy.sample() {
+		return nil
+	}
+	return s.policy
+}
+
+// setPolicy 更新录制策略，缓冲区大小变化时保留最近的记录
+func (s *captureSink) setPolicy(policy *CapturePolicy, expireAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policy = policy
+	s.expireAt = expireAt
+	if policy == nil || len(s.entries) == policy.maxEntries() {
+		return
+	}
+	entries := s.list()
+	size := policy.maxEntries()
+	if len(entries) > size {
+		entries = entries[len(entries)-size:]
+	}
+	s.entries = make([]*CaptureEntry, size)
+	copy(s.entries, entries)
+	s.next = len(entries) % size
+	s.full = len(entries) == size
+}
+
+// list 按录制顺序返回，调用方持有锁
+func (s *captureSink) list() []*CaptureEntry {
+	entries := make([]*CaptureEntry, 0, len(s.entries))
+	if s.full {
+		entries = append(entries, s.entries[s.next:]...)
+	}
+	return append(entries, s.entries[:s.next]...)
+}
+
+func (s *captureSink) add(entry *CaptureEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.entries) == 0 {
+		return
+	}
+	s.entries[s.next] = entry
+	s.next = (s.next + 1) % len(s.entries)
+	if s.next == 0 {
+		s.full = true
+	}
+	if s.policy == nil || s.policy.File == "" {
+		return
+	}
+	if err := appendCaptureFile(s.policy.File, entry); err != nil {
+		Log.Errorf(context.Background(), "write capture of %s to %s error: %s", entry.Method, s.policy.File, err.Error())
+	}
+}
+
+func appendCaptureFile(name string, entry *CaptureEntry) error {
+	path, err := captures.path(name)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(b, '\n'))
+	return err
+}
+
+// methodCapture 方法的输入输出类型，用于解码录制的消息
+type methodCapture struct {
+	source string
+	in     protoreflect.MessageDescriptor
+	out    protoreflect.MessageDescriptor
+}
+
+func (m *methodCapture) getSource() string {
+	if m == nil {
+		return ""
+	}
+	return m.source
+}
+
+// CaptureRegistry 按端点和应用保存录制策略和录制的调用
+type CaptureRegistry struct {
+	mu      sync.RWMutex
+	methods map[string]*methodCapture
+	sinks   map[string]*captureSink
+	seq     atomic.Uint64
+	// 录制文件所在的目录，为空时不允许写入文件
+	dir atomic.Value
+}
+
+var captures = &CaptureRegistry{methods: make(map[string]*methodCapture), sinks: make(map[string]*captureSink)}
+
+// Captures 全局的录制配置和记录，管理接口和grpc代理共用
+func Captures() *CaptureRegistry {
+	return captures
+}
+
+// SetDir 设置录制文件所在的目录，录制策略只能写入该目录下的文件
+func (r *CaptureRegistry) SetDir(dir string) {
+	r.dir.Store(dir)
+}
+
+// path 录制文件在录制目录下的路径，不允许绝对路径和跳出录制目录的路径
+func (r *CaptureRegistry) path(name string) (string, error) {
+	dir, _ := r.dir.Load().(string)
+	if dir == "" {
+		return "", fmt.Errorf("capture dir is not configured")
+	}
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("file must be a relative path in the capture dir")
+	}
+	path := filepath.Join(dir, name)
+	if rel, err := filepath.Rel(dir, path); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("file must be in the capture dir")
+	}
+	return path, nil
+}
+
+func (r *CaptureRegistry) sink(source string) *captureSink {
+	sink, ok := r.sinks[source]
+	if !ok {
+		sink = &captureSink{source: source}
+		r.sinks[source] = sink
+	}
+	return sink
+}
+
+// Register 注册端点所有方法的录制策略，policy为nil时关闭端点的录制，
+// 应用的录制需要端点已注册以解码消息
+func (r *CaptureRegistry) Register(pd ProtobufDescription, key string, policy *CapturePolicy) error {
+	files, err := protodesc.NewFiles(pd.GetFileDescriptorSet())
+	if err != nil {
+		return fmt.Errorf("new capture files error:%w", err)
+	}
+	methods := make(map[string]*methodCapture)
+	var rangeErr error
+	walkMethods(pd, func(k string, service string, method string) {
+		desc, err := files.FindDescriptorByName(protoreflect.FullName(fmt.Sprintf("%s.%s", service, method)))
+		if err != nil {
+			rangeErr = fmt.Errorf("find method %s/%s error:%w", service, method, err)
+			return
+		}
+		if md, ok := desc.(protoreflect.MethodDescriptor); ok {
+			methods[k] = &methodCapture{source: EndpointCaptureSource(key), in: md.Input(), out: md.Output()}
+		}
+	})
+	if rangeErr != nil {
+		return rangeErr
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	walkMethods(pd, func(k string, _ string, _ string) {
+		delete(r.methods, k)
+		if method, ok := methods[k]; ok {
+			r.methods[k] = method
+		}
+	})
+	if sink, ok := r.sinks[EndpointCaptureSource(key)]; ok || policy != nil {
+		if !ok {
+			sink = r.sink(EndpointCaptureSource(key))
+		}
+		sink.setPolicy(policy, time.Time{})
+	}
+	return nil
+}
+
+func (r *CaptureRegistry) Delete(pd ProtobufDescription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	walkMethods(pd, func(key string, _ string, _ string) {
+		delete(r.methods, key)
+	})
+}
+
+// SetApp 开启或关闭应用的录制，capture为nil时关闭
+func (r *CaptureRegistry) SetApp(app string, capture *AppCapture) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sink, ok := r.sinks[AppCaptureSource(app)]
+	if !ok && capture == nil {
+		return
+	}
+	if !ok {
+		sink = r.sink(AppCaptureSource(app))
+	}
+	if capture == nil {
+		sink.setPolicy(nil, time.Time{})
+		return
+	}
+	capture.ExpireAt = time.Now().Add(capture.ttl())
+	sink.setPolicy(&capture.CapturePolicy, capture.ExpireAt)
+}
+
+// App 应用当前的录制配置，未开启或已过期时返回nil
+func (r *CaptureRegistry) App(app string) *AppCapture {
+	r.mu.RLock()
+	sink, ok := r.sinks[AppCaptureSource(app)]
+	r.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if sink.policy == nil || time.Now().After(sink.expireAt) {
+		return nil
+	}
+	return &AppCapture{CapturePolicy: *sink.policy, ExpireAt: sink.expireAt}
+}
+
+// Entries 来源最近录制的调用，按录制顺序返回
+func (r *CaptureRegistry) Entries(source string) []*CaptureEntry {
+	r.mu.RLock()
+	sink, ok := r.sinks[source]
+	r.mu.RUnlock()
+	if !ok {
+		return []*CaptureEntry{}
+	}
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	return sink.list()
+}
+
+// Clear 清空来源录制的调用，不影响录制策略
+func (r *CaptureRegistry) Clear(source string) {
+	r.mu.RLock()
+	sink, ok := r.sinks[source]
+	r.mu.RUnlock()
+	if !ok {
+		return
+	}
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	sink.entries = make([]*CaptureEntry, len(sink.entries))
+	sink.next = 0
+	sink.full = false
+}
+
+// start 请求需要录制时返回录制器，端点和应用的录制都命中时同时写入两者
+func (r *CaptureRegistry) start(ctx context.Context, fullMethod string) *captureRecorder {
+	if !strings.HasPrefix(fullMethod, "/") {
+		fullMethod = "/" + fullMethod
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	app, user := firstValue(md, XIdentity), firstValue(md, XUID)
+	r.mu.RLock()
+	method, ok := r.methods[strings.ToUpper(fullMethod)]
+	candidates := make([]*captureSink, 0, 2)
+	if sink, exists := r.sinks[method.getSource()]; ok && exists {
+		candidates = append(candidates, sink)
+	}
+	if sink, exists := r.sinks[AppCaptureSource(app)]; ok && exists && app != "" {
+		candidates = append(candidates, sink)
+	}
+	r.mu.RUnlock()
+	if len(candidates) == 0 {
+		return nil
+	}
+	recorder := &captureRecorder{method: method, fullMethod: fullMethod, app: app, user: user, md: md, start: time.Now()}
+	for _, sink := range candidates {
+		policy := sink.active(app, user)
+		if policy == nil {
+			continue
+		}
+		recorder.sinks = append(recorder.sinks, sink)
+		recorder.maskFields = append(recorder.maskFields, policy.MaskFields...)
+		recorder.maskHeaders = append(recorder.maskHeaders, policy.MaskHeaders...)
+		if policy.maxMessages() > recorder.maxMessages {
+			recorder.maxMessages = policy.maxMessages()
+		}
+	}
+	if len(recorder.sinks) == 0 {
+		return nil
+	}
+	return recorder
+}
+
+// captureRecorder 录制一次代理调用，消息在调用结束后解码
+type captureRecorder struct {
+	method      *methodCapture
+	fullMethod  string
+	app         string
+	user        string
+	md          metadata.MD
+	start       time.Time
+	sinks       []*captureSink
+	maskFields  []string
+	maskHeaders []string
+	maxMessages int
+
+	mu        sync.Mutex
+	endpoint  string
+	requests  [][]byte
+	responses [][]byte
+	truncated bool
+}
+
+func (c *captureRecorder) record(messages *[][]byte, m interface{}) {
+	msg, ok := m.(*emptypb.Empty)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(*messages) >= c.maxMessages {
+		c.truncated = true
+		return
+	}
+	*messages = append(*messages, append([]byte{}, msg.ProtoReflect().GetUnknown()...))
+}
+
+func (c *captureRecorder) setEndpoint(addr string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.endpoint = addr
+}
+
+// finish 解码、脱敏后写入所有命中的来源
+func (c *captureRecorder) finish(ctx context.Context, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st := status.Convert(err)
+	entry := &CaptureEntry{
+		ID:         captures.seq.Add(1),
+		Method:     c.fullMethod,
+		Endpoint:   c.endpoint,
+		Release:    releaseFromContext(ctx),
+		App:        c.app,
+		User:       c.user,
+		Metadata:   MaskHeaders(c.md, c.maskHeaders),
+		Requests:   c.decode(c.method.in, c.requests),
+		Responses:  c.decode(c.method.out, c.responses),
+		Code:       st.Code().String(),
+		Message:    st.Message(),
+		StartAt:    c.start,
+		DurationMs: float64(time.Since(c.start).Microseconds()) / 1000,
+		MaskFields: c.maskFields,
+		Truncated:  c.truncated,
+	}
+	for _, sink := range c.sinks {
+		item := *entry
+		item.Source = sink.source
+		sink.add(&item)
+	}
+}
+
+// decode 解码失败时保存base64编码的原始消息
+func (c *captureRecorder) decode(md protoreflect.MessageDescriptor, messages [][]byte) []json.RawMessage {
+	values := make([]json.RawMessage, 0, len(messages))
+	for _, raw := range messages {
+		msg := dynamicpb.NewMessage(md)
+		var b []byte
+		err := proto.Unmarshal(raw, msg)
+		if err == nil {
+			MaskMessage(msg, c.maskFields)
+			b, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+		}
+		if err != nil {
+			b, _ = json.Marshal(base64.StdEncoding.EncodeToString(raw))
+		}
+		values = append(values, b)
+	}
+	return values
+}
+
+// MaskMessage 脱敏消息中的字段，fields为字段路径，名称包含password、secret、token等的字段总是脱敏
+func MaskMessage(m protoreflect.Message, fields []string) {
+	for _, field := range fields {
+		redact(m, strings.Split(field, "."))
+	}
+	maskSensitive(m)
+}
+
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range sensitiveFieldWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func maskSensitive(m protoreflect.Message) {
+	sensitive := make([]string, 0)
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if isSensitive(string(fd.Name())) {
+			sensitive = append(sensitive, string(fd.Name()))
+			return true
+		}
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					maskSensitive(value.Message())
+					return true
+				})
+			}
+		case fd.Message() == nil:
+		case fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				maskSensitive(v.List().Get(i).Message())
+			}
+		default:
+			maskSensitive(v.Message())
+		}
+		return true
+	})
+	for _, name := range sensitive {
+		redact(m, []string{name})
+	}
+}
+
+// MaskHeaders 复制请求头并脱敏，authorization、cookie等请求头总是脱敏
+func MaskHeaders(md metadata.MD, headers []string) map[string][]string {
+	masked := make(map[string][]string, len(md))
+	for key, values := range md {
+		if containsValue(sensitiveHeaders, strings.ToLower(key)) || containsFold(headers, key) {
+			values = []string{TransformRedacted}
+		}
+		masked[key] = append([]string{}, values...)
+	}
+	return masked
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// captureStream 录制代理调用收发的消息
+type captureStream struct {
+	grpc.ServerStream
+	recorder *captureRecorder
+}
+
+func (s *captureStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.recorder.record(&s.recorder.requests, m)
+	return nil
+}
+
+func (s *captureStream) SendMsg(m interface{}) error {
+	s.recorder.record(&s.recorder.responses, m)
+	return s.ServerStream.SendMsg(m)
+}
+
+type captureKey struct{}
+
+func withCapture(ctx context.Context, recorder *captureRecorder) context.Context {
+	return context.WithValue(ctx, captureKey{}, recorder)
+}
+
+// setCaptureEndpoint 记录请求被转发到的端点，重试时为最后一次的端点
+func setCaptureEndpoint(ctx context.Context, endpoint loadbalance.Endpoint) {
+	if recorder, ok := ctx.Value(captureKey{}).(*captureRecorder); ok {
+		recorder.setEndpoint(endpoint.Addr())
+	}
+}
@@ -0,0 +1,170 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// newCaptureTestProxy 启动返回"hello "+name的上游服务和grpc代理
+func newCaptureTestProxy(pd ProtobufDescription) (*grpc.ClientConn, string, func()) {
+	in := pd.GetMessageTypeByFullName("helloworld.HelloRequest")
+	out := pd.GetMessageTypeByFullName("helloworld.HelloReply")
+	upstream := grpc.NewServer(grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		raw := &emptypb.Empty{}
+		if err := stream.RecvMsg(raw); err != nil {
+			return err
+		}
+		req := dynamicpb.NewMessage(in)
+		if err := proto.Unmarshal(raw.ProtoReflect().GetUnknown(), req); err != nil {
+			return err
+		}
+		rsp := dynamicpb.NewMessage(out)
+		rsp.Set(out.Fields().ByName("message"), protoreflect.ValueOfString("hello "+dynamicField(req, "name").String()))
+		return stream.SendMsg(rsp)
+	}))
+	upstreamAddr := serveGrpc(upstream)
+	lb := NewGrpcLoadBalancer()
+	rr, _ := loadbalance.New(loadbalance.RRBalanceType, []loadbalance.Endpoint{NewGrpcEndpoint(upstreamAddr, NewGrpcConnPool(upstreamAddr))})
+	lb.Register(rr, pd)
+	proxy := NewGrpcServer(&GrpcServerOptions{}, lb)
+	cc, err := grpc.Dial(serveGrpc(proxy), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	return cc, upstreamAddr, func() {
+		cc.Close()
+		proxy.Stop()
+		upstream.Stop()
+	}
+}
+
+func TestProxyCapture(t *testing.T) {
+	c.Convey("test capture proxied calls", t, func() {
+		pd := newTransformTestDescription()
+		defer Captures().Delete(pd)
+		cc, upstreamAddr, stop := newCaptureTestProxy(pd)
+		defer stop()
+		in := pd.GetMessageTypeByFullName("helloworld.HelloRequest")
+		out := pd.GetMessageTypeByFullName("helloworld.HelloReply")
+		call := func(name string, kv ...string) {
+			req := dynamicpb.NewMessage(in)
+			req.Set(in.Fields().ByName("name"), protoreflect.ValueOfString(name))
+			req.Set(in.Fields().ByName("msg"), protoreflect.ValueOfString("sensitive"))
+			ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(kv...))
+			c.So(cc.Invoke(ctx, testRetryMethod, req, dynamicpb.NewMessage(out)), c.ShouldBeNil)
+		}
+
+		c.So((&CapturePolicy{SampleRate: 2}).Validate(), c.ShouldNotBeNil)
+		// 未配置录制目录时不允许写入文件
+		c.So((&CapturePolicy{File: "captures.jsonl"}).Validate(), c.ShouldNotBeNil)
+		dir := t.TempDir()
+		Captures().SetDir(dir)
+		defer Captures().SetDir("")
+		c.So((&CapturePolicy{File: "captures.jsonl"}).Validate(), c.ShouldBeNil)
+		c.So((&CapturePolicy{File: filepath.Join(dir, "captures.jsonl")}).Validate(), c.ShouldNotBeNil)
+		c.So((&CapturePolicy{File: "../captures.jsonl"}).Validate(), c.ShouldNotBeNil)
+		c.So((&CapturePolicy{File: "."}).Validate(), c.ShouldNotBeNil)
+		c.So((&CapturePolicy{MaxEntries: maxCaptureEntries + 1}).Validate(), c.ShouldNotBeNil)
+		c.So((&AppCapture{TTLSeconds: -1}).Validate(), c.ShouldNotBeNil)
+
+		source := EndpointCaptureSource("capture-test")
+		file := filepath.Join(dir, "captures.jsonl")
+		c.So(Captures().Register(pd, "capture-test", &CapturePolicy{MaxEntries: 2, MaskFields: []string{"msg"}, File: "captures.jsonl"}), c.ShouldBeNil)
+		for _, name := range []string{"a", "b", "c"} {
+			call(name, "authorization", "Bearer token", XUID, "user-1")
+		}
+		// 环形缓冲区只保留最近的记录
+		entries := Captures().Entries(source)
+		c.So(len(entries), c.ShouldEqual, 2)
+		c.So(entries[0].ID, c.ShouldBeLessThan, entries[1].ID)
+		entry := entries[1]
+		c.So(entry.Source, c.ShouldEqual, source)
+		c.So(entry.Method, c.ShouldEqual, testRetryMethod)
+		c.So(entry.Endpoint, c.ShouldEqual, upstreamAddr)
+		c.So(entry.Code, c.ShouldEqual, "OK")
+		c.So(entry.User, c.ShouldEqual, "user-1")
+		c.So(entry.Metadata["authorization"], c.ShouldResemble, []string{TransformRedacted})
+		c.So(len(entry.Requests), c.ShouldEqual, 1)
+		req := map[string]string{}
+		c.So(json.Unmarshal(entry.Requests[0], &req), c.ShouldBeNil)
+		c.So(req["name"], c.ShouldEqual, "c")
+		c.So(req["msg"], c.ShouldEqual, TransformRedacted)
+		rsp := map[string]string{}
+		c.So(json.Unmarshal(entry.Responses[0], &rsp), c.ShouldBeNil)
+		c.So(rsp["message"], c.ShouldEqual, "hello c")
+
+		data, err := os.ReadFile(file)
+		c.So(err, c.ShouldBeNil)
+		c.So(strings.Count(string(data), "\n"), c.ShouldEqual, 3)
+		c.So(string(data), c.ShouldNotContainSubstring, "sensitive")
+
+		// 只录制指定用户的调用
+		c.So(Captures().Register(pd, "capture-test", &CapturePolicy{Users: []string{"user-2"}}), c.ShouldBeNil)
+		call("d", XUID, "user-1")
+		c.So(len(Captures().Entries(source)), c.ShouldEqual, 2)
+		call("e", XUID, "user-2")
+		// 缓冲区扩大时保留已录制的调用
+		entries = Captures().Entries(source)
+		c.So(len(entries), c.ShouldEqual, 3)
+		c.So(entries[1].ID, c.ShouldEqual, entry.ID)
+		c.So(entries[2].User, c.ShouldEqual, "user-2")
+
+		// 关闭端点录制后保留已录制的调用，按应用录制所有端点的调用
+		c.So(Captures().Register(pd, "capture-test", nil), c.ShouldBeNil)
+		Captures().SetApp("capture-app", &AppCapture{})
+		c.So(Captures().App("capture-app"), c.ShouldNotBeNil)
+		call("f", XIdentity, "capture-app")
+		call("g", XIdentity, "other-app")
+		c.So(len(Captures().Entries(source)), c.ShouldEqual, 3)
+		appEntries := Captures().Entries(AppCaptureSource("capture-app"))
+		c.So(len(appEntries), c.ShouldEqual, 1)
+		c.So(appEntries[0].App, c.ShouldEqual, "capture-app")
+
+		Captures().SetApp("capture-app", nil)
+		c.So(Captures().App("capture-app"), c.ShouldBeNil)
+		Captures().Clear(source)
+		c.So(len(Captures().Entries(source)), c.ShouldEqual, 0)
+	})
+}
+
+func TestMaskMessage(t *testing.T) {
+	c.Convey("test mask message", t, func() {
+		c.So(isSensitive("user_password"), c.ShouldBeTrue)
+		c.So(isSensitive("AccessToken"), c.ShouldBeTrue)
+		c.So(isSensitive("name"), c.ShouldBeFalse)
+
+		pd := newTransformTestDescription()
+		out := pd.GetMessageTypeByFullName("helloworld.HelloReply")
+		rsp := dynamicpb.NewMessage(out)
+		rsp.Set(out.Fields().ByName("message"), protoreflect.ValueOfString("message"))
+		rsp.Set(out.Fields().ByName("name"), protoreflect.ValueOfString("name"))
+		MaskMessage(rsp, []string{"name", "not_exists"})
+		c.So(dynamicField(rsp, "message").String(), c.ShouldEqual, "message")
+		c.So(dynamicField(rsp, "name").String(), c.ShouldEqual, TransformRedacted)
+
+		headers := MaskHeaders(metadata.Pairs("Cookie", "a=b", "x-secret-header", "v", "x-uid", "1"), []string{"X-Secret-Header"})
+		c.So(headers["cookie"], c.ShouldResemble, []string{TransformRedacted})
+		c.So(headers["x-secret-header"], c.ShouldResemble, []string{TransformRedacted})
+		c.So(headers["x-uid"], c.ShouldResemble, []string{"1"})
+
+		headers = MaskHeaders(metadata.Pairs("X-Refresh-Token", "refresh", "x-session-id", "sid", "authorization", "Bearer token", "x-api-key", "key"), nil)
+		c.So(headers["x-refresh-token"], c.ShouldResemble, []string{TransformRedacted})
+		c.So(headers["x-session-id"], c.ShouldResemble, []string{TransformRedacted})
+		c.So(headers["authorization"], c.ShouldResemble, []string{TransformRedacted})
+		c.So(headers["x-api-key"], c.ShouldResemble, []string{TransformRedacted})
+	})
+}
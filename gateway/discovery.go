@@ -0,0 +1,224 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/begonia-org/go-sdk/api/endpoint/v1"
+)
+
+// 端点成员的发现来源
+const (
+	DiscoveryEtcd = "etcd"
+	DiscoveryDNS  = "dns"
+	DiscoveryFile = "file"
+)
+
+const (
+	defaultDNSDiscoveryInterval  = 30 * time.Second
+	defaultFileDiscoveryInterval = 5 * time.Second
+)
+
+// DiscoveryConfig 端点成员的动态发现配置，发现的成员替换端点配置中的地址列表
+type DiscoveryConfig struct {
+	Type string `json:"type"`
+	// etcd：后端使用租约注册的键前缀，值为{"addr":"","weight":0}或者地址
+	Prefix string `json:"prefix,omitempty"`
+	// dns：以"_"开头时查询SRV记录，否则查询A/AAAA记录并使用port
+	Name string `json:"name,omitempty"`
+	Port int    `json:"port,omitempty"`
+	// file：json数组或者每行一个地址，可以在地址后以空格分隔权重，需要为绝对路径
+	Path string `json:"path,omitempty"`
+	// dns重新解析和file检查修改的间隔(秒)，默认分别为30秒和5秒
+	IntervalSeconds int `json:"interval_seconds,omitempty"`
+}
+
+func (d *DiscoveryConfig) Validate() error {
+	if d.IntervalSeconds < 0 {
+		return fmt.Errorf("interval_seconds must not be negative")
+	}
+	switch d.Type {
+	case DiscoveryEtcd:
+		if d.Prefix == "" {
+			return fmt.Errorf("prefix is required")
+		}
+	case DiscoveryDNS:
+		if d.Name == "" {
+			return fmt.Errorf("name is required")
+		}
+		if !strings.HasPrefix(d.Name, "_") && (d.Port <= 0 || d.Port > 65535) {
+			return fmt.Errorf("port is required for A records")
+		}
+	case DiscoveryFile:
+		if !filepath.IsAbs(d.Path) {
+			return fmt.Errorf("path must be an absolute path")
+		}
+	default:
+		return fmt.Errorf("unknown discovery type %s", d.Type)
+	}
+	return nil
+}
+
+func (d *DiscoveryConfig) interval() time.Duration {
+	if d.IntervalSeconds > 0 {
+		return time.Duration(d.IntervalSeconds) * time.Second
+	}
+	if d.Type == DiscoveryFile {
+		return defaultFileDiscoveryInterval
+	}
+	return defaultDNSDiscoveryInterval
+}
+
+// Resolver 持续发现端点的成员，每次查询后调用update，查询失败时members为nil，ctx取消后返回
+type Resolver interface {
+	Resolve(ctx context.Context, update func(members []*api.EndpointMeta, err error)) error
+}
+
+type ResolverFunc func(ctx context.Context, update func(members []*api.EndpointMeta, err error)) error
+
+func (f ResolverFunc) Resolve(ctx context.Context, update func(members []*api.EndpointMeta, err error)) error {
+	return f(ctx, update)
+}
+
+// NewResolver 创建dns或file来源的成员发现，etcd来源由数据层实现
+func NewResolver(cfg *DiscoveryConfig) (Resolver, error) {
+	switch cfg.Type {
+	case DiscoveryDNS:
+		return &pollResolver{interval: cfg.interval(), lookup: func(ctx context.Context) ([]*api.EndpointMeta, error) {
+			return lookupDNS(ctx, net.DefaultResolver, cfg.Name, cfg.Port)
+		}}, nil
+	case DiscoveryFile:
+		return &pollResolver{interval: cfg.interval(), lookup: func(ctx context.Context) ([]*api.EndpointMeta, error) {
+			return readMembersFile(cfg.Path)
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported resolver type %s", cfg.Type)
+	}
+}
+
+// pollResolver 按固定间隔查询成员
+type pollResolver struct {
+	interval time.Duration
+	lookup   func(ctx context.Context) ([]*api.EndpointMeta, error)
+}
+
+func (p *pollResolver) Resolve(ctx context.Context, update func(members []*api.EndpointMeta, err error)) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		update(p.lookup(ctx))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func lookupDNS(ctx context.Context, resolver *net.Resolver, name string, port int) ([]*api.EndpointMeta, error) {
+	members := make([]*api.EndpointMeta, 0)
+	if strings.HasPrefix(name, "_") {
+		_, srvs, err := resolver.LookupSRV(ctx, "", "", name)
+		if err != nil {
+			return nil, err
+		}
+		for _, srv := range srvs {
+			members = append(members, &api.EndpointMeta{Addr: net.JoinHostPort(strings.TrimSuffix(srv.Target, "."), strconv.Itoa(int(srv.Port))), Weight: int32(srv.Weight)})
+		}
+		return members, nil
+	}
+	addrs, err := resolver.LookupHost(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		members = append(members, &api.EndpointMeta{Addr: net.JoinHostPort(addr, strconv.Itoa(port))})
+	}
+	return members, nil
+}
+
+func readMembersFile(path string) ([]*api.EndpointMeta, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*api.EndpointMeta, 0)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &members); err != nil {
+			return nil, fmt.Errorf("unmarshal %s error:%w", path, err)
+		}
+		return members, nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		member, err := ParseEndpointMeta([]byte(line))
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, scanner.Err()
+}
+
+// ParseEndpointMeta 解析注册的成员，支持json格式的EndpointMeta或者"地址 权重"
+func ParseEndpointMeta(value []byte) (*api.EndpointMeta, error) {
+	value = bytes.TrimSpace(value)
+	member := &api.EndpointMeta{}
+	if len(value) > 0 && value[0] == '{' {
+		if err := json.Unmarshal(value, member); err != nil {
+			return nil, err
+		}
+	} else {
+		fields := strings.Fields(string(value))
+		if len(fields) > 0 {
+			member.Addr = fields[0]
+		}
+		if len(fields) > 1 {
+			weight, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid weight of %s", member.Addr)
+			}
+			member.Weight = int32(weight)
+		}
+	}
+	if _, _, err := net.SplitHostPort(member.Addr); err != nil {
+		return nil, fmt.Errorf("invalid member address %q:%w", member.Addr, err)
+	}
+	return member, nil
+}
+
+// SameMembers 比较两组成员的地址和权重，与顺序无关
+func SameMembers(a []*api.EndpointMeta, b []*api.EndpointMeta) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	keys := func(members []*api.EndpointMeta) []string {
+		values := make([]string, 0, len(members))
+		for _, member := range members {
+			values = append(values, fmt.Sprintf("%s/%d", member.Addr, member.Weight))
+		}
+		sort.Strings(values)
+		return values
+	}
+	x, y := keys(a), keys(b)
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	api "github.com/begonia-org/go-sdk/api/endpoint/v1"
+	c "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDiscoveryConfig(t *testing.T) {
+	c.Convey("test discovery config", t, func() {
+		c.So((&DiscoveryConfig{Type: DiscoveryEtcd, Prefix: "/backends/helloworld"}).Validate(), c.ShouldBeNil)
+		c.So((&DiscoveryConfig{Type: DiscoveryEtcd}).Validate(), c.ShouldNotBeNil)
+		c.So((&DiscoveryConfig{Type: DiscoveryDNS, Name: "helloworld.local", Port: 1949}).Validate(), c.ShouldBeNil)
+		c.So((&DiscoveryConfig{Type: DiscoveryDNS, Name: "helloworld.local"}).Validate(), c.ShouldNotBeNil)
+		c.So((&DiscoveryConfig{Type: DiscoveryDNS, Name: "_grpc._tcp.helloworld.local"}).Validate(), c.ShouldBeNil)
+		c.So((&DiscoveryConfig{Type: DiscoveryFile, Path: "backends.txt"}).Validate(), c.ShouldNotBeNil)
+		c.So((&DiscoveryConfig{Type: DiscoveryFile, Path: "/etc/backends.txt", IntervalSeconds: -1}).Validate(), c.ShouldNotBeNil)
+		c.So((&DiscoveryConfig{Type: "consul"}).Validate(), c.ShouldNotBeNil)
+
+		c.So((&DiscoveryConfig{Type: DiscoveryFile}).interval(), c.ShouldEqual, defaultFileDiscoveryInterval)
+		c.So((&DiscoveryConfig{Type: DiscoveryDNS}).interval(), c.ShouldEqual, defaultDNSDiscoveryInterval)
+		c.So((&DiscoveryConfig{Type: DiscoveryDNS, IntervalSeconds: 3}).interval(), c.ShouldEqual, 3*time.Second)
+
+		_, err := NewResolver(&DiscoveryConfig{Type: DiscoveryEtcd, Prefix: "/backends"})
+		c.So(err, c.ShouldNotBeNil)
+	})
+}
+
+func TestParseEndpointMeta(t *testing.T) {
+	c.Convey("test parse endpoint meta", t, func() {
+		member, err := ParseEndpointMeta([]byte(`{"addr":"127.0.0.1:1949","weight":2}`))
+		c.So(err, c.ShouldBeNil)
+		c.So(member.Addr, c.ShouldEqual, "127.0.0.1:1949")
+		c.So(member.Weight, c.ShouldEqual, 2)
+
+		member, err = ParseEndpointMeta([]byte(" 127.0.0.1:1950 3\n"))
+		c.So(err, c.ShouldBeNil)
+		c.So(member.Addr, c.ShouldEqual, "127.0.0.1:1950")
+		c.So(member.Weight, c.ShouldEqual, 3)
+
+		_, err = ParseEndpointMeta([]byte("127.0.0.1"))
+		c.So(err, c.ShouldNotBeNil)
+		_, err = ParseEndpointMeta([]byte("127.0.0.1:1950 heavy"))
+		c.So(err, c.ShouldNotBeNil)
+		_, err = ParseEndpointMeta([]byte(`{"addr":`))
+		c.So(err, c.ShouldNotBeNil)
+
+		a := []*api.EndpointMeta{{Addr: "127.0.0.1:1949", Weight: 1}, {Addr: "127.0.0.1:1950"}}
+		b := []*api.EndpointMeta{{Addr: "127.0.0.1:1950"}, {Addr: "127.0.0.1:1949", Weight: 1}}
+		c.So(SameMembers(a, b), c.ShouldBeTrue)
+		c.So(SameMembers(a, b[:1]), c.ShouldBeFalse)
+		c.So(SameMembers(a, []*api.EndpointMeta{{Addr: "127.0.0.1:1950"}, {Addr: "127.0.0.1:1949", Weight: 2}}), c.ShouldBeFalse)
+	})
+}
+
+func TestDiscoveryResolver(t *testing.T) {
+	c.Convey("test file and dns resolver", t, func() {
+		path := filepath.Join(t.TempDir(), "backends")
+		c.So(os.WriteFile(path, []byte("# helloworld\n127.0.0.1:1949 2\n\n127.0.0.1:1950\n"), 0644), c.ShouldBeNil)
+		members, err := readMembersFile(path)
+		c.So(err, c.ShouldBeNil)
+		c.So(SameMembers(members, []*api.EndpointMeta{{Addr: "127.0.0.1:1949", Weight: 2}, {Addr: "127.0.0.1:1950"}}), c.ShouldBeTrue)
+
+		c.So(os.WriteFile(path, []byte(`[{"addr":"127.0.0.1:1951"}]`), 0644), c.ShouldBeNil)
+		members, err = readMembersFile(path)
+		c.So(err, c.ShouldBeNil)
+		c.So(len(members), c.ShouldEqual, 1)
+		c.So(members[0].Addr, c.ShouldEqual, "127.0.0.1:1951")
+
+		c.So(os.WriteFile(path, []byte("127.0.0.1\n"), 0644), c.ShouldBeNil)
+		_, err = readMembersFile(path)
+		c.So(err, c.ShouldNotBeNil)
+		_, err = readMembersFile(filepath.Join(t.TempDir(), "not_exists"))
+		c.So(err, c.ShouldNotBeNil)
+
+		// 文件修改后在下一次检查时发现新成员
+		c.So(os.WriteFile(path, []byte("127.0.0.1:1949\n"), 0644), c.ShouldBeNil)
+		resolver, err := NewResolver(&DiscoveryConfig{Type: DiscoveryFile, Path: path, IntervalSeconds: 1})
+		c.So(err, c.ShouldBeNil)
+		ctx, cancel := context.WithCancel(context.Background())
+		updates := make(chan []*api.EndpointMeta, 4)
+		done := make(chan error, 1)
+		go func() {
+			done <- resolver.Resolve(ctx, func(members []*api.EndpointMeta, err error) {
+				if err == nil {
+					updates <- members
+				}
+			})
+		}()
+		first := <-updates
+		c.So(first[0].Addr, c.ShouldEqual, "127.0.0.1:1949")
+		c.So(os.WriteFile(path, []byte("127.0.0.1:1950\n"), 0644), c.ShouldBeNil)
+		var second []*api.EndpointMeta
+		select {
+		case second = <-updates:
+		case <-time.After(3 * time.Second):
+		}
+		c.So(len(second), c.ShouldEqual, 1)
+		c.So(second[0].Addr, c.ShouldEqual, "127.0.0.1:1950")
+		cancel()
+		c.So(<-done, c.ShouldEqual, context.Canceled)
+
+		members, err = lookupDNS(context.Background(), net.DefaultResolver, "localhost", 1949)
+		c.So(err, c.ShouldBeNil)
+		c.So(len(members), c.ShouldBeGreaterThan, 0)
+		host, port, _ := net.SplitHostPort(members[0].Addr)
+		c.So(net.ParseIP(host).IsLoopback(), c.ShouldBeTrue)
+		c.So(port, c.ShouldEqual, "1949")
+	})
+}
@@ -16,6 +16,7 @@ type HttpForwardGrpcEndpoint interface {
 	ServerSideStream(req GrpcRequest) (ServerSideStream, error)
 	ClientSideStream(req GrpcRequest) (ClientSideStream, error)
 	Stream(req GrpcRequest) (StreamClient, error)
+	Close() error
 }
 
 type httpForwardGrpcEndpointImpl struct {
@@ -30,6 +31,11 @@ func NewEndpoint(pool loadbalance.Pool) HttpForwardGrpcEndpoint {
 	}
 }
 
+// Close 关闭到网关grpc代理的连接池
+func (e *httpForwardGrpcEndpointImpl) Close() error {
+	return e.pool.Close()
+}
+
 // request is the request message with method, path, body and query params.
 // 发起普通的请求请求
 func (e *httpForwardGrpcEndpointImpl) Request(req GrpcRequest) (proto.Message, runtime.ServerMetadata, error) {
@@ -50,6 +56,16 @@ func (e *httpForwardGrpcEndpointImpl) Request(req GrpcRequest) (proto.Message, r
 	out := req.GetOut()
 	in := req.GetIn()
 	ctx := req.GetContext()
+	// 请求没有设置deadline时使用策略中的默认超时时间，重试和对冲由grpc代理执行
+	if gw := Get(); gw != nil {
+		if policy := gw.proxyLB.Policy(req.GetFullMethodName()); policy != nil && policy.TimeoutMs > 0 {
+			if _, ok := ctx.Deadline(); !ok {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, policy.timeout())
+				defer cancel()
+			}
+		}
+	}
 
 	err = conn.Invoke(ctx, req.GetFullMethodName(), in, out, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
 	return out, metadata, err
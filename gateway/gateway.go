@@ -2,16 +2,18 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"strings"
 	"sync"
-	"time"
+	"sync/atomic"
 
 	loadbalance "github.com/begonia-org/go-loadbalancer"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
@@ -23,6 +25,16 @@ type GrpcServerOptions struct {
 	PoolOptions     []loadbalance.PoolOptionsBuildOption
 	HttpMiddlewares []runtime.ServeMuxOption
 	HttpHandlers    []func(http.Handler) http.Handler
+	// 端点健康检查配置，为空时不开启
+	HealthCheck *HealthCheckOptions
+	// 端点熔断配置，为空时不开启
+	CircuitBreaker *CircuitBreakerOptions
+	// 链路追踪的导出配置，为空时不导出
+	Tracing *TracingOptions
+	// 网关监听的tls配置，为空时使用h2c明文
+	TLS *TLSOptions
+	// grpc server接收消息的最大字节数，为0时使用grpc的默认值
+	MaxRecvMsgSize int
 }
 type GatewayConfig struct {
 	GatewayAddr   string
@@ -37,6 +49,15 @@ type GatewayServer struct {
 	proxyAddr   string
 	opts        *GrpcServerOptions
 	mux         *sync.Mutex
+	openapi     *OpenAPIGenerator
+	reflection  *ReflectionRegistry
+	tracer      *sdktrace.TracerProvider
+	httpServer  *http.Server
+	certs       *CertReloader
+	// 网关已开始监听且未处于关闭中
+	ready atomic.Bool
+	// 为空或关闭后网关才就绪
+	loaded <-chan struct{}
 }
 
 func NewGrpcServer(opts *GrpcServerOptions, lb *GrpcLoadBalancer) *grpc.Server {
@@ -44,6 +65,9 @@ func NewGrpcServer(opts *GrpcServerOptions, lb *GrpcLoadBalancer) *grpc.Server {
 	proxy := NewGrpcProxy(lb, opts.Middlewares...)
 
 	opts.Options = append(opts.Options, grpc.UnknownServiceHandler(proxy.Handler))
+	if opts.MaxRecvMsgSize > 0 {
+		opts.Options = append(opts.Options, grpc.MaxRecvMsgSize(opts.MaxRecvMsgSize))
+	}
 	return grpc.NewServer(opts.Options...)
 }
 
@@ -57,7 +81,16 @@ func NewHttpServer(addr string, poolOpt ...loadbalance.PoolOptionsBuildOption) (
 }
 func NewGateway(cfg *GatewayConfig, opts *GrpcServerOptions) *GatewayServer {
 	lb := NewGrpcLoadBalancer()
+	if opts.HealthCheck != nil {
+		lb.SetHealthChecker(opts.HealthCheck)
+	}
+	if opts.CircuitBreaker != nil {
+		lb.SetCircuitBreakers(opts.CircuitBreaker)
+	}
 	grpcServer := NewGrpcServer(opts, lb)
+	poolStats.setLoadBalancer(lb)
+	reflection := NewReflectionRegistry(grpcServer)
+	RegisterReflection(grpcServer, reflection)
 	_, port, _ := net.SplitHostPort(cfg.GrpcProxyAddr)
 	proxy := fmt.Sprintf("127.0.0.1:%s", port)
 
@@ -77,6 +110,22 @@ func NewGateway(cfg *GatewayConfig, opts *GrpcServerOptions) *GatewayServer {
 		proxyAddr:   cfg.GrpcProxyAddr,
 		opts:        opts,
 		mux:         &sync.Mutex{},
+		openapi:     NewOpenAPIGenerator(nil),
+		reflection:  reflection,
+	}
+	if opts.Tracing != nil {
+		tracer, err := NewTracerProvider(context.Background(), opts.Tracing)
+		if err != nil {
+			panic(err)
+		}
+		gatewayS.tracer = tracer
+	}
+	if opts.TLS != nil {
+		certs, err := NewCertReloader(opts.TLS)
+		if err != nil {
+			panic(err)
+		}
+		gatewayS.certs = certs
 	}
 	// })
 	return gatewayS
@@ -85,9 +134,17 @@ func NewGateway(cfg *GatewayConfig, opts *GrpcServerOptions) *GatewayServer {
 func (g *GatewayServer) RegisterService(ctx context.Context, pd ProtobufDescription, lb loadbalance.LoadBalance) error {
 	g.mux.Lock()
 	defer g.mux.Unlock()
+	// 先注册反射，失败时不留下没有反射的代理路由
+	if err := g.reflection.Register(pd); err != nil {
+		return err
+	}
 	g.proxyLB.Register(lb, pd)
-
-	return g.httpGateway.RegisterHandlerClient(ctx, pd, g.gatewayMux)
+	if err := g.httpGateway.RegisterHandlerClient(ctx, pd, g.gatewayMux); err != nil {
+		g.proxyLB.Delete(pd)
+		g.reflection.Delete(pd)
+		return err
+	}
+	return nil
 }
 func (g *GatewayServer) RegisterLocalService(ctx context.Context, pd ProtobufDescription, sd *grpc.ServiceDesc, ss any) error {
 	info := g.grpcServer.GetServiceInfo()
@@ -95,12 +152,20 @@ func (g *GatewayServer) RegisterLocalService(ctx context.Context, pd ProtobufDes
 		return fmt.Errorf("service %s already exists", sd.ServiceName)
 	}
 	g.grpcServer.RegisterService(sd, ss)
+	if err := g.reflection.RegisterLocal(pd); err != nil {
+		return err
+	}
+	// 本地服务只使用方法的cache选项
+	ResponseCaches().Register(pd, nil, "")
 	return g.httpGateway.RegisterHandlerClient(ctx, pd, g.gatewayMux)
 }
 func (g *GatewayServer) DeleteLocalService(pd ProtobufDescription) {
 	g.mux.Lock()
 	defer g.mux.Unlock()
 	g.proxyLB.Delete(pd)
+	g.reflection.Delete(pd)
+	Transforms().Delete(pd)
+	ResponseCaches().Delete(pd)
 	_ = g.DeleteHandlerClient(context.Background(), pd)
 }
 func (g *GatewayServer) GetLoadbalanceName() loadbalance.BalanceType {
@@ -109,9 +174,15 @@ func (g *GatewayServer) GetLoadbalanceName() loadbalance.BalanceType {
 func (g *GatewayServer) RegisterHandlerClient(ctx context.Context, pd ProtobufDescription) error {
 	return g.httpGateway.RegisterHandlerClient(ctx, pd, g.gatewayMux)
 }
-func (g *GatewayServer) Start() {
+
+// Start 开始监听并阻塞到网关关闭，监听或者服务失败时返回错误
+func (g *GatewayServer) Start() error {
+	grpcWeb := NewGrpcWebHandler(g.grpcServer, g.opts.MaxRecvMsgSize)
 	handler := h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.ProtoMajor == 2 && strings.Contains(r.Header.Get("Content-Type"), "application/grpc") {
+		if IsGrpcWebRequest(r) {
+			// grpc-web,grpc-web-text,connect协议转换为grpc后经过相同的插件链
+			grpcWeb.ServeHTTP(w, r)
+		} else if r.ProtoMajor == 2 && strings.Contains(r.Header.Get("Content-Type"), "application/grpc") {
 			g.grpcServer.ServeHTTP(w, r)
 		} else {
 			g.gatewayMux.ServeHTTP(w, r)
@@ -119,6 +190,7 @@ func (g *GatewayServer) Start() {
 		}
 	}), &http2.Server{})
 
+	handler = TrustedHeadersHandler(handler)
 	for _, h := range g.opts.HttpHandlers {
 		handler = h(handler)
 
@@ -127,23 +199,87 @@ func (g *GatewayServer) Start() {
 		Addr:    g.addr,
 		Handler: handler,
 	}
-
+	// 两个端口都完成监听后才开始服务，http网关连接grpc代理时不需要等待
 	lis, err := net.Listen("tcp", g.proxyAddr)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("listen grpc proxy on %s error:%w", g.proxyAddr, err)
+	}
+	httpLis, err := net.Listen("tcp", g.addr)
+	if err != nil {
+		_ = lis.Close()
+		return fmt.Errorf("listen gateway on %s error:%w", g.addr, err)
 	}
+	g.mux.Lock()
+	g.httpServer = s
+	g.mux.Unlock()
+	grpcErr := make(chan error, 1)
 	go func() {
-		err = g.grpcServer.Serve(lis)
-		if err != nil {
-			panic(err)
-
+		// 调用GracefulStop后正常返回
+		if err := g.grpcServer.Serve(lis); err != nil {
+			grpcErr <- fmt.Errorf("serve grpc proxy error:%w", err)
+			_ = s.Close()
 		}
 	}()
-	time.Sleep(1 * time.Second)
+	if health := g.proxyLB.HealthChecker(); health != nil {
+		go health.Start()
+	}
+	g.ready.Store(true)
 	log.Printf("Start on %s\n", g.addr)
-	if err := s.ListenAndServe(); err != nil {
-		panic(err)
-		// return fmt.Errorf("start server error:%w", err)
+	if g.certs != nil {
+		s.TLSConfig = g.certs.TLSConfig()
+		err = s.ServeTLS(httpLis, "", "")
+	} else {
+		err = s.Serve(httpLis)
+	}
+	g.ready.Store(false)
+	select {
+	case err := <-grpcErr:
+		return err
+	default:
+	}
+	// 调用Shutdown后正常返回
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve gateway error:%w", err)
+	}
+	return nil
+}
+
+// EndpointsHealth 获取端点的健康状态
+func (g *GatewayServer) EndpointsHealth(addrs ...string) []*EndpointHealth {
+	health := g.proxyLB.HealthChecker()
+	states := make([]*EndpointHealth, 0, len(addrs))
+	for _, addr := range addrs {
+		if health == nil {
+			states = append(states, &EndpointHealth{Addr: addr, Healthy: true})
+			continue
+		}
+		states = append(states, health.Health(addr))
+	}
+	return states
+}
+
+// CircuitBreakerStates 获取端点的熔断器状态
+func (g *GatewayServer) CircuitBreakerStates(addrs ...string) []*CircuitBreakerState {
+	breakers := g.proxyLB.CircuitBreakers()
+	states := make([]*CircuitBreakerState, 0, len(addrs))
+	for _, addr := range addrs {
+		if breakers == nil {
+			states = append(states, &CircuitBreakerState{Addr: addr})
+			continue
+		}
+		states = append(states, breakers.State(addr))
+	}
+	return states
+}
+
+// ResetCircuitBreakers 手动关闭端点的熔断器
+func (g *GatewayServer) ResetCircuitBreakers(addrs ...string) {
+	breakers := g.proxyLB.CircuitBreakers()
+	if breakers == nil {
+		return
+	}
+	for _, addr := range addrs {
+		breakers.Reset(addr)
 	}
 }
 func (g *GatewayServer) GetOptions() *GrpcServerOptions {
@@ -153,6 +289,10 @@ func (g *GatewayServer) DeleteLoadBalance(pd ProtobufDescription) {
 	g.mux.Lock()
 	defer g.mux.Unlock()
 	g.proxyLB.Delete(pd)
+	g.reflection.Delete(pd)
+	Transforms().Delete(pd)
+	ResponseCaches().Delete(pd)
+	Captures().Delete(pd)
 	// g.httpGateway.DeleteEndpoint(ctx, pd, mux)
 }
 
@@ -161,6 +301,56 @@ func (g *GatewayServer) DeleteHandlerClient(ctx context.Context, pd ProtobufDesc
 	return g.httpGateway.DeleteEndpoint(ctx, pd, g.gatewayMux)
 }
 
+// HandlePath 在网关的http路由上注册自定义的处理函数
+func (g *GatewayServer) HandlePath(meth string, pathPattern string, h runtime.HandlerFunc) error {
+	return g.gatewayMux.HandlePath(meth, pathPattern, h)
+}
+
+// RegisterPolicy 注册端点的调用策略，在RegisterService之后调用
+func (g *GatewayServer) RegisterPolicy(pd ProtobufDescription, policy EndpointPolicy) {
+	g.proxyLB.RegisterPolicy(pd, policy)
+}
+
+// RegisterReleases 注册端点的非稳定版本和分流规则，在RegisterService之后调用
+func (g *GatewayServer) RegisterReleases(pd ProtobufDescription, split *TrafficSplit, lbs map[string]loadbalance.LoadBalance) {
+	g.proxyLB.RegisterReleases(pd, split, lbs)
+}
+
+// RegisterMirror 注册端点的影子端点组，在RegisterService之后调用
+func (g *GatewayServer) RegisterMirror(pd ProtobufDescription, policy *MirrorPolicy, lb loadbalance.LoadBalance) {
+	g.proxyLB.RegisterMirror(pd, policy, lb)
+}
+
+// RegisterTransform 注册端点的请求响应转换规则，在RegisterService之后调用
+func (g *GatewayServer) RegisterTransform(pd ProtobufDescription, transform EndpointTransform) error {
+	return Transforms().Register(pd, transform)
+}
+
+// RegisterCache 注册端点的响应缓存策略，version变化后之前的缓存失效
+func (g *GatewayServer) RegisterCache(pd ProtobufDescription, cache EndpointCache, version string) {
+	ResponseCaches().Register(pd, cache, version)
+}
+
+// RegisterCapture 注册端点的录制策略，policy为nil时关闭端点的录制
+func (g *GatewayServer) RegisterCapture(pd ProtobufDescription, key string, policy *CapturePolicy) error {
+	return Captures().Register(pd, key, policy)
+}
+
+// RegisterOpenAPI 注册或更新端点在OpenAPI文档中的描述
+func (g *GatewayServer) RegisterOpenAPI(srv *OpenAPIService) error {
+	return g.openapi.Register(srv)
+}
+
+// DeleteOpenAPI 从OpenAPI文档中删除端点
+func (g *GatewayServer) DeleteOpenAPI(id string) {
+	g.openapi.Delete(id)
+}
+
+// OpenAPIHandler 输出OpenAPI文档，支持通过id和tag查询参数过滤端点
+func (g *GatewayServer) OpenAPIHandler() runtime.HandlerFunc {
+	return g.openapi.ServeHTTP
+}
+
 func (g *GatewayServer) UpdateLoadbalance(pd ProtobufDescription, lb loadbalance.LoadBalance) {
 	g.proxyLB.Register(lb, pd)
 }
@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/spark-lence/tiga"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
@@ -30,8 +32,13 @@ type EndpointServer struct {
 
 // NewGrpcConnPool 创建一个grpc连接池
 func NewGrpcConnPool(addr string, poolOpt ...loadbalance.PoolOptionsBuildOption) loadbalance.Pool {
+	return NewGrpcConnPoolWithCredentials(addr, insecure.NewCredentials(), poolOpt...)
+}
+
+// NewGrpcConnPoolWithCredentials 创建一个使用指定传输凭证的grpc连接池
+func NewGrpcConnPoolWithCredentials(addr string, creds credentials.TransportCredentials, poolOpt ...loadbalance.PoolOptionsBuildOption) loadbalance.Pool {
 	opts := loadbalance.NewPoolOptions(func(ctx context.Context) (loadbalance.Connection, error) {
-		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds))
 		if err != nil {
 			return nil, err
 		}
@@ -57,6 +64,9 @@ func (g *grpcEndpointImpl) AfterTransform(ctx context.Context, cn loadbalance.Co
 	g.pool.Release(ctx, cn)
 }
 func (g *grpcEndpointImpl) Stats() loadbalance.Stats {
+	if g.pool == nil {
+		return nil
+	}
 	return g.pool.Stats()
 }
 func (g *grpcEndpointImpl) Addr() string {
@@ -71,14 +81,22 @@ func (g *grpcEndpointImpl) Close() error {
 }
 
 type GrpcLoadBalancer struct {
-	lb   map[string]loadbalance.LoadBalance
-	mu   sync.Mutex
-	name loadbalance.BalanceType
+	lb       map[string]loadbalance.LoadBalance
+	mu       sync.Mutex
+	name     loadbalance.BalanceType
+	health   *HealthChecker
+	breakers *CircuitBreakers
+	policies *policyRegistry
+	releases *releaseRegistry
+	mirrors  *mirrorRegistry
 }
 
 func NewGrpcLoadBalancer() *GrpcLoadBalancer {
 	return &GrpcLoadBalancer{
-		lb: make(map[string]loadbalance.LoadBalance),
+		lb:       make(map[string]loadbalance.LoadBalance),
+		policies: newPolicyRegistry(),
+		releases: newReleaseRegistry(),
+		mirrors:  newMirrorRegistry(),
 	}
 }
 
@@ -116,18 +134,184 @@ func (g *GrpcLoadBalancer) Delete(pd ProtobufDescription) {
 			}
 		}
 	}
+	g.policies.delete(pd)
+	g.releases.delete(pd)
+	g.mirrors.delete(pd)
+}
+
+// RegisterPolicy 注册端点的调用策略
+func (g *GrpcLoadBalancer) RegisterPolicy(pd ProtobufDescription, policy EndpointPolicy) {
+	g.policies.register(pd, policy)
+}
+
+// Policy 获取方法的调用策略
+func (g *GrpcLoadBalancer) Policy(fullMethod string) *CallPolicy {
+	if policy := g.policies.get(fullMethod); policy != nil {
+		return policy.CallPolicy
+	}
+	return nil
 }
+
+// RegisterReleases 注册端点的非稳定版本及其分流规则，lbs的键为版本
+func (g *GrpcLoadBalancer) RegisterReleases(pd ProtobufDescription, split *TrafficSplit, lbs map[string]loadbalance.LoadBalance) {
+	g.releases.register(pd, split, lbs)
+}
+
+// RegisterMirror 注册端点的影子端点组，policy为nil时关闭流量复制
+func (g *GrpcLoadBalancer) RegisterMirror(pd ProtobufDescription, policy *MirrorPolicy, lb loadbalance.LoadBalance) {
+	g.mirrors.register(pd, policy, lb)
+}
+
+// Release 按分流规则选择请求的版本，返回空字符串表示稳定版本
+func (g *GrpcLoadBalancer) Release(ctx context.Context, method string, clientIP string) string {
+	releases := g.releases.get(method)
+	if releases == nil {
+		return ""
+	}
+	version := releases.split.Route(ctx, clientIP)
+	if _, ok := releases.lbs[version]; !ok {
+		return ""
+	}
+	return version
+}
+
 func (g *GrpcLoadBalancer) Select(method string, args ...interface{}) (loadbalance.Endpoint, error) {
+	return g.SelectRelease("", method, args...)
+}
+
+// SelectRelease 从指定版本的端点中选择，版本为空或不存在时使用稳定版本
+func (g *GrpcLoadBalancer) SelectRelease(version string, method string, args ...interface{}) (loadbalance.Endpoint, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	if lb, ok := g.lb[strings.ToUpper(method)]; ok {
+	lb, ok := g.lb[strings.ToUpper(method)]
+	if releases := g.releases.get(method); ok && version != "" && releases != nil && releases.lbs[version] != nil {
+		lb = releases.lbs[version]
+	}
+	if ok {
 		endpoint, err := lb.Select(args...)
-		return endpoint, err
+		if err != nil {
+			return nil, err
+		}
+		reason := g.available(endpoint.Addr())
+		if reason == nil {
+			return endpoint, nil
+		}
+		// 跳过被剔除或者熔断的端点
+		endpoints := lb.GetEndpoints()
+		for i := 0; i < len(endpoints); i++ {
+			endpoint, err = lb.Select(args...)
+			if err != nil {
+				return nil, err
+			}
+			if g.available(endpoint.Addr()) == nil {
+				return endpoint, nil
+			}
+		}
+		// 一致性哈希等算法在参数相同时总是返回同一个端点
+		for _, endpoint := range endpoints {
+			if g.available(endpoint.Addr()) == nil {
+				return endpoint, nil
+			}
+		}
+		return nil, reason
 
 	}
 	return nil, loadbalance.ErrNoEndpoint
 }
 
+// available 端点是否可被选择，熔断器半开时会占用一个探测名额
+func (g *GrpcLoadBalancer) available(addr string) error {
+	if g.health != nil && !g.health.IsAvailable(addr) {
+		return ErrAllEndpointsEjected
+	}
+	if g.breakers != nil && !g.breakers.Allow(addr) {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// SetHealthChecker 设置健康检查器，探测目标为所有已注册负载均衡器的端点
+func (g *GrpcLoadBalancer) SetHealthChecker(opts *HealthCheckOptions) *HealthChecker {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.health = NewHealthChecker(opts, g.Endpoints)
+	return g.health
+}
+func (g *GrpcLoadBalancer) HealthChecker() *HealthChecker {
+	return g.health
+}
+
+// Endpoints 所有已注册的端点
+func (g *GrpcLoadBalancer) Endpoints() []loadbalance.Endpoint {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	endpoints := make([]loadbalance.Endpoint, 0)
+	visited := make(map[loadbalance.LoadBalance]bool)
+	for _, lb := range g.lb {
+		if visited[lb] {
+			continue
+		}
+		visited[lb] = true
+		endpoints = append(endpoints, lb.GetEndpoints()...)
+	}
+	for _, lb := range g.releases.loadBalances() {
+		if !visited[lb] {
+			visited[lb] = true
+			endpoints = append(endpoints, lb.GetEndpoints()...)
+		}
+	}
+	return endpoints
+}
+
+// Close 关闭所有负载均衡器及其端点的连接池
+func (g *GrpcLoadBalancer) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var errs []error
+	visited := make(map[loadbalance.LoadBalance]bool)
+	for key, lb := range g.lb {
+		delete(g.lb, key)
+		if visited[lb] {
+			continue
+		}
+		visited[lb] = true
+		if err := lb.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, lb := range append(g.releases.loadBalances(), g.mirrors.loadBalances()...) {
+		if visited[lb] {
+			continue
+		}
+		visited[lb] = true
+		if err := lb.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SetCircuitBreakers 开启端点熔断
+func (g *GrpcLoadBalancer) SetCircuitBreakers(opts *CircuitBreakerOptions) *CircuitBreakers {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.breakers = NewCircuitBreakers(opts)
+	return g.breakers
+}
+func (g *GrpcLoadBalancer) CircuitBreakers() *CircuitBreakers {
+	return g.breakers
+}
+
+// ReportResult 上报端点的请求结果和耗时
+func (g *GrpcLoadBalancer) ReportResult(addr string, err error, latency time.Duration) {
+	if g.health != nil {
+		g.health.ReportResult(addr, err)
+	}
+	if g.breakers != nil {
+		g.breakers.Report(addr, err, latency)
+	}
+}
+
 type GrpcProxyMiddleware func(srv interface{}, serverStream grpc.ServerStream) error
 type GrpcProxy struct {
 	lb          *GrpcLoadBalancer
@@ -156,7 +340,7 @@ func (g *GrpcProxy) getXForward(ctx context.Context) []string {
 	return md.Get("X-Forwarded-For")
 }
 
-func (g *GrpcProxy) Handler(srv interface{}, serverStream grpc.ServerStream) error {
+func (g *GrpcProxy) Handler(srv interface{}, serverStream grpc.ServerStream) (err error) {
 
 	// 执行中间件
 	for _, middleware := range g.middlewares {
@@ -180,34 +364,88 @@ func (g *GrpcProxy) Handler(srv interface{}, serverStream grpc.ServerStream) err
 	} else {
 		xForwards = make([]string, 0)
 	}
+	ctx := serverStream.Context()
+	policy := g.lb.policies.get(fullMethodName)
+	// 请求没有设置deadline时使用策略中的默认超时时间
+	if policy != nil && policy.TimeoutMs > 0 {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, policy.timeout())
+			defer cancel()
+		}
+	}
+	// 添加本地ip
+	local, _ := tiga.GetLocalIP()
+	xForwards = append(xForwards, local)
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	md = md.Copy()
+	md.Set("X-Forwarded-For", strings.Join(xForwards, ","))
+	ctx = metadata.NewOutgoingContext(ctx, md)
+	// 按分流规则选择版本，重试和对冲使用同一版本
+	if version := g.lb.Release(serverStream.Context(), fullMethodName, clientIP); version != "" {
+		ctx = withRelease(ctx, version)
+		_ = serverStream.SetHeader(metadata.Pairs(XRelease, version))
+	}
+	// 按采样率将一元调用复制到影子端点
+	mirror := g.lb.mirrors.get(fullMethodName)
+	if mirror != nil && !mirror.sample() {
+		mirror = nil
+	}
+	// 按端点或应用的录制策略录制请求响应
+	if recorder := Captures().start(serverStream.Context(), fullMethodName); recorder != nil {
+		serverStream = &captureStream{ServerStream: serverStream, recorder: recorder}
+		ctx = withCapture(ctx, recorder)
+		defer func() {
+			recorder.finish(ctx, err)
+		}()
+	}
+	// 一元调用按策略进行重试或对冲
+	if policy != nil && policy.unary && g.retryAllowed(serverStream.Context(), policy.CallPolicy) {
+		return g.handleUnary(ctx, serverStream, fullMethodName, clientIP, policy.CallPolicy, mirror)
+	}
+	src := serverStream
+	if mirror != nil {
+		recorder := &mirrorStream{ServerStream: serverStream}
+		src = recorder
+		mirrorStart := time.Now()
+		defer func() {
+			if req := recorder.request(); req != nil {
+				g.mirror(ctx, mirror, fullMethodName, clientIP, req, err, time.Since(mirrorStart))
+			}
+		}()
+	}
 	// 传入ip地址(一致性哈希负载均衡算法)和方法名，选择一个端点
-	endpoint, err := g.lb.Select(fullMethodName, clientIP)
+	endpoint, err := g.lb.SelectRelease(releaseFromContext(ctx), fullMethodName, clientIP)
 	if err != nil {
 		return status.Errorf(codes.Unavailable, "no endpoint available to select,%v", err)
 	}
-	cn, err := endpoint.Get(serverStream.Context())
+	setMetricsEndpoint(ctx, endpoint)
+	setCaptureEndpoint(ctx, endpoint)
+	// 上游调用的client span，链路信息通过metadata传递给上游
+	ctx, span := startClientSpan(ctx, fullMethodName, endpoint.Addr())
+	// 上报请求结果，用于异常端点剔除和熔断
+	start := time.Now()
+	defer func() {
+		g.lb.ReportResult(endpoint.Addr(), err, time.Since(start))
+		EndSpan(span, err)
+	}()
+	cn, err := endpoint.Get(ctx)
 	if err != nil {
 		return status.Errorf(codes.Unavailable, "no endpoint available from endpoint,%v", err)
 	}
 	// 释放链接
-	defer endpoint.AfterTransform(serverStream.Context(), cn.((loadbalance.Connection)))
+	defer endpoint.AfterTransform(ctx, cn.((loadbalance.Connection)))
 
 	conn := cn.(loadbalance.Connection).ConnInstance().(*grpc.ClientConn)
-	clientCtx, clientCancel := context.WithCancel(serverStream.Context())
+	clientCtx, clientCancel := context.WithCancel(ctx)
 	defer clientCancel()
 	proxyDesc := &grpc.StreamDesc{
 		ServerStreams: true,
 		ClientStreams: true,
 	}
-	// 添加本地ip
-	local, _ := tiga.GetLocalIP()
-	xForwards = append(xForwards, local)
-	md, ok := metadata.FromIncomingContext(clientCtx)
-	if !ok {
-		md = metadata.MD{}
-	}
-	md.Set("X-Forwarded-For", strings.Join(xForwards, ","))
-	clientCtx = metadata.NewOutgoingContext(clientCtx, md)
 
 	clientStream, err := grpc.NewClientStream(clientCtx, proxyDesc, conn, fullMethodName)
 	if err != nil {
@@ -215,7 +453,7 @@ func (g *GrpcProxy) Handler(srv interface{}, serverStream grpc.ServerStream) err
 	}
 	// 转发流量
 	// 从客户端到服务端
-	s2cErrChan := g.forwardServerToClient(serverStream, clientStream)
+	s2cErrChan := g.forwardServerToClient(src, clientStream)
 	// 从服务端到客户端
 	c2sErrChan := g.forwardClientToServer(clientStream, serverStream)
 	for i := 0; i < 2; i++ {
@@ -0,0 +1,508 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc/codes"
+)
+
+type webProtocol int
+
+const (
+	protocolUnknown webProtocol = iota
+	protocolGrpcWeb
+	protocolGrpcWebText
+	protocolConnectUnary
+	protocolConnectStream
+	// 使用json编码的grpc-web或connect请求，网关没有对应的消息描述无法转码
+	protocolUnsupportedCodec
+)
+
+const (
+	grpcWebContentType       = "application/grpc-web"
+	grpcWebProtoContentType  = "application/grpc-web+proto"
+	grpcWebTextContentType   = "application/grpc-web-text"
+	grpcWebTextProtoType     = "application/grpc-web-text+proto"
+	connectStreamContentType = "application/connect+proto"
+	connectUnaryContentType  = "application/proto"
+	connectUnaryJSONType     = "application/json"
+	connectProtocolVersion   = "Connect-Protocol-Version"
+	connectTimeoutHeader     = "Connect-Timeout-Ms"
+	grpcTrailerFrameFlag     = byte(0x80)
+	connectEndStreamFlag     = byte(0x02)
+	grpcFrameHeaderLen       = 5
+	// 与grpc server默认的最大接收消息大小一致
+	defaultMaxRecvMsgSize = 1024 * 1024 * 4
+)
+
+var errMessageTooLarge = errors.New("request message larger than max receive message size")
+
+// connectCodes grpc状态码到connect错误码及http状态码的映射
+var connectCodes = map[codes.Code]struct {
+	name   string
+	status int
+}{
+	codes.Canceled:           {"canceled", 499},
+	codes.Unknown:            {"unknown", http.StatusInternalServerError},
+	codes.InvalidArgument:    {"invalid_argument", http.StatusBadRequest},
+	codes.DeadlineExceeded:   {"deadline_exceeded", http.StatusGatewayTimeout},
+	codes.NotFound:           {"not_found", http.StatusNotFound},
+	codes.AlreadyExists:      {"already_exists", http.StatusConflict},
+	codes.PermissionDenied:   {"permission_denied", http.StatusForbidden},
+	codes.ResourceExhausted:  {"resource_exhausted", http.StatusTooManyRequests},
+	codes.FailedPrecondition: {"failed_precondition", http.StatusBadRequest},
+	codes.Aborted:            {"aborted", http.StatusConflict},
+	codes.OutOfRange:         {"out_of_range", http.StatusBadRequest},
+	codes.Unimplemented:      {"unimplemented", http.StatusNotImplemented},
+	codes.Internal:           {"internal", http.StatusInternalServerError},
+	codes.Unavailable:        {"unavailable", http.StatusServiceUnavailable},
+	codes.DataLoss:           {"data_loss", http.StatusInternalServerError},
+	codes.Unauthenticated:    {"unauthenticated", http.StatusUnauthorized},
+}
+
+// grpcTrailerKeys grpc在响应结束时写入的trailer
+var grpcTrailerKeys = []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin"}
+
+// webProtocolOf 根据请求头识别grpc-web或connect协议
+func webProtocolOf(r *http.Request) webProtocol {
+	if r.Method != http.MethodPost {
+		return protocolUnknown
+	}
+	contentType := strings.ToLower(r.Header.Get("Content-Type"))
+	if idx := strings.Index(contentType, ";"); idx >= 0 {
+		contentType = strings.TrimSpace(contentType[:idx])
+	}
+	isConnect := r.Header.Get(connectProtocolVersion) != ""
+	switch {
+	case contentType == grpcWebTextContentType || contentType == grpcWebTextProtoType:
+		return protocolGrpcWebText
+	case contentType == grpcWebContentType || contentType == grpcWebProtoContentType:
+		return protocolGrpcWeb
+	case contentType == connectStreamContentType:
+		return protocolConnectStream
+	case contentType == connectUnaryContentType && isConnect:
+		return protocolConnectUnary
+	case strings.HasPrefix(contentType, grpcWebContentType+"+"), strings.HasPrefix(contentType, grpcWebTextContentType+"+"),
+		strings.HasPrefix(contentType, "application/connect+"), contentType == connectUnaryJSONType && isConnect:
+		// application/grpc-web+json,application/connect+json等其他编码，
+		// 没有connect协议头的application/json仍然交由http网关处理
+		return protocolUnsupportedCodec
+	}
+	return protocolUnknown
+}
+
+// IsGrpcWebRequest 是否为grpc-web或connect协议的请求
+func IsGrpcWebRequest(r *http.Request) bool {
+	return webProtocolOf(r) != protocolUnknown
+}
+
+// GrpcWebHandler 将grpc-web,grpc-web-text以及connect协议的请求转换为grpc请求，
+// 交由grpc server处理，使其经过与原生grpc请求相同的插件链和GrpcProxy.Handler
+type GrpcWebHandler struct {
+	grpc http.Handler
+	// connect unary请求体(解压后)的最大字节数
+	maxRecvMsgSize int
+}
+
+// NewGrpcWebHandler maxRecvMsgSize与grpc server的最大接收消息大小一致，不大于0时使用grpc的默认值
+func NewGrpcWebHandler(grpcHandler http.Handler, maxRecvMsgSize int) *GrpcWebHandler {
+	if maxRecvMsgSize <= 0 {
+		maxRecvMsgSize = defaultMaxRecvMsgSize
+	}
+	return &GrpcWebHandler{grpc: grpcHandler, maxRecvMsgSize: maxRecvMsgSize}
+}
+
+func (h *GrpcWebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	protocol := webProtocolOf(r)
+	if protocol == protocolUnknown || protocol == protocolUnsupportedCodec {
+		w.Header().Set("Accept-Post", strings.Join([]string{grpcWebProtoContentType, grpcWebTextProtoType, connectUnaryContentType, connectStreamContentType}, ", "))
+		http.Error(w, "unsupported content-type", http.StatusUnsupportedMediaType)
+		return
+	}
+	req, err := h.toGrpcRequest(w, r, protocol)
+	if err != nil {
+		code := codes.InvalidArgument
+		if errors.Is(err, errMessageTooLarge) {
+			code = codes.ResourceExhausted
+		}
+		writeConnectOrHttpError(w, protocol, code, err.Error())
+		return
+	}
+	rw := newGrpcWebResponseWriter(w, protocol)
+	h.grpc.ServeHTTP(rw, req)
+	rw.finish()
+}
+
+func (h *GrpcWebHandler) toGrpcRequest(w http.ResponseWriter, r *http.Request, protocol webProtocol) (*http.Request, error) {
+	req := r.Clone(r.Context())
+	req.ProtoMajor = 2
+	req.ProtoMinor = 0
+	req.Proto = "HTTP/2"
+	req.ContentLength = -1
+	req.Header.Del("Content-Length")
+	req.Header.Set("Content-Type", "application/grpc+proto")
+	req.Header.Set("Te", "trailers")
+
+	switch protocol {
+	case protocolGrpcWebText:
+		req.Body = io.NopCloser(base64.NewDecoder(base64.StdEncoding, r.Body))
+	case protocolConnectStream:
+		if encoding := r.Header.Get("Connect-Content-Encoding"); encoding != "" {
+			req.Header.Set("Grpc-Encoding", encoding)
+		}
+		if encoding := r.Header.Get("Connect-Accept-Encoding"); encoding != "" {
+			req.Header.Set("Grpc-Accept-Encoding", encoding)
+		}
+	case protocolConnectUnary:
+		body, err := readConnectUnaryBody(w, r, h.maxRecvMsgSize)
+		if err != nil {
+			return nil, err
+		}
+		frame := make([]byte, grpcFrameHeaderLen, grpcFrameHeaderLen+len(body))
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(body)))
+		req.Body = io.NopCloser(bytes.NewReader(append(frame, body...)))
+		req.Header.Del("Content-Encoding")
+	}
+	if protocol == protocolConnectUnary || protocol == protocolConnectStream {
+		if timeout := r.Header.Get(connectTimeoutHeader); timeout != "" {
+			ms, err := strconv.ParseInt(timeout, 10, 64)
+			if err != nil || ms < 0 {
+				return nil, fmt.Errorf("invalid %s:%s", connectTimeoutHeader, timeout)
+			}
+			req.Header.Set("Grpc-Timeout", fmt.Sprintf("%dm", ms))
+		}
+		req.Header.Del(connectTimeoutHeader)
+		req.Header.Del(connectProtocolVersion)
+		req.Header.Del("Connect-Content-Encoding")
+		req.Header.Del("Connect-Accept-Encoding")
+	}
+	return req, nil
+}
+
+// readConnectUnaryBody 读取完整的请求体，压缩前后的大小都不能超过limit
+func readConnectUnaryBody(w http.ResponseWriter, r *http.Request, limit int) ([]byte, error) {
+	var reader io.Reader = http.MaxBytesReader(w, r.Body, int64(limit))
+	switch encoding := r.Header.Get("Content-Encoding"); encoding {
+	case "", "identity":
+	case "gzip":
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("read gzip body error:%w", tooLargeOr(err))
+		}
+		defer gz.Close()
+		reader = gz
+	default:
+		return nil, fmt.Errorf("unsupported content-encoding:%s", encoding)
+	}
+	body, err := io.ReadAll(io.LimitReader(reader, int64(limit)+1))
+	if err != nil {
+		return nil, fmt.Errorf("read body error:%w", tooLargeOr(err))
+	}
+	if len(body) > limit {
+		return nil, fmt.Errorf("%w:%d", errMessageTooLarge, limit)
+	}
+	return body, nil
+}
+
+func tooLargeOr(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return fmt.Errorf("%w:%d", errMessageTooLarge, maxBytesErr.Limit)
+	}
+	return err
+}
+
+// grpcWebResponseWriter 将grpc server的响应转换为grpc-web或connect协议的响应
+type grpcWebResponseWriter struct {
+	w             http.ResponseWriter
+	protocol      webProtocol
+	header        http.Header
+	headerWritten bool
+	// grpc-web-text 需要以flush为单位进行base64编码
+	// connect unary 需要缓存完整的响应以便根据状态码决定http状态码
+	buf *bytes.Buffer
+}
+
+func newGrpcWebResponseWriter(w http.ResponseWriter, protocol webProtocol) *grpcWebResponseWriter {
+	return &grpcWebResponseWriter{
+		w:        w,
+		protocol: protocol,
+		header:   make(http.Header),
+		buf:      &bytes.Buffer{},
+	}
+}
+
+func (rw *grpcWebResponseWriter) Header() http.Header {
+	return rw.header
+}
+
+func (rw *grpcWebResponseWriter) WriteHeader(code int) {
+	if code != http.StatusOK {
+		// grpc server在协议错误时直接返回http错误，此时透传即可
+		rw.protocol = protocolUnknown
+		copyHeader(rw.w.Header(), rw.header, nil)
+		rw.headerWritten = true
+		rw.w.WriteHeader(code)
+		return
+	}
+	rw.writeHeader()
+}
+
+func (rw *grpcWebResponseWriter) Write(b []byte) (int, error) {
+	rw.writeHeader()
+	switch rw.protocol {
+	case protocolGrpcWebText, protocolConnectUnary:
+		return rw.buf.Write(b)
+	}
+	return rw.w.Write(b)
+}
+
+func (rw *grpcWebResponseWriter) Flush() {
+	if rw.protocol == protocolConnectUnary {
+		return
+	}
+	rw.writeHeader()
+	rw.flushText()
+	if f, ok := rw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (rw *grpcWebResponseWriter) flushText() {
+	if rw.protocol != protocolGrpcWebText || rw.buf.Len() == 0 {
+		return
+	}
+	_, _ = rw.w.Write([]byte(base64.StdEncoding.EncodeToString(rw.buf.Bytes())))
+	rw.buf.Reset()
+}
+
+func (rw *grpcWebResponseWriter) contentType() string {
+	switch rw.protocol {
+	case protocolGrpcWebText:
+		return grpcWebTextContentType + "+proto"
+	case protocolConnectStream:
+		return connectStreamContentType
+	case protocolConnectUnary:
+		return connectUnaryContentType
+	}
+	return grpcWebProtoContentType
+}
+
+func (rw *grpcWebResponseWriter) writeHeader() {
+	if rw.headerWritten || rw.protocol == protocolConnectUnary {
+		return
+	}
+	rw.headerWritten = true
+	dst := rw.w.Header()
+	copyHeader(dst, rw.header, func(k string) bool {
+		return isGrpcTrailerKey(k) || k == "Trailer" || strings.HasPrefix(k, http2.TrailerPrefix)
+	})
+	dst.Set("Content-Type", rw.contentType())
+	dst.Del("Content-Length")
+	exposeHeaders(dst)
+	rw.w.WriteHeader(http.StatusOK)
+}
+
+// trailers grpc server在响应结束后写入的trailer
+func (rw *grpcWebResponseWriter) trailers() http.Header {
+	trailers := make(http.Header)
+	for k, v := range rw.header {
+		if isGrpcTrailerKey(k) {
+			trailers[k] = v
+			continue
+		}
+		if strings.HasPrefix(k, http2.TrailerPrefix) {
+			trailers[textproto.CanonicalMIMEHeaderKey(strings.TrimPrefix(k, http2.TrailerPrefix))] = v
+		}
+	}
+	return trailers
+}
+
+func (rw *grpcWebResponseWriter) grpcStatus() (codes.Code, string) {
+	code, err := strconv.Atoi(rw.header.Get("Grpc-Status"))
+	if err != nil {
+		return codes.Unknown, "missing grpc-status"
+	}
+	return codes.Code(code), decodeGrpcMessage(rw.header.Get("Grpc-Message"))
+}
+
+// finish 在grpc server处理完成后写入trailer
+func (rw *grpcWebResponseWriter) finish() {
+	switch rw.protocol {
+	case protocolUnknown:
+		return
+	case protocolConnectUnary:
+		rw.finishConnectUnary()
+		return
+	}
+	rw.writeHeader()
+	trailers := rw.trailers()
+	var body []byte
+	flag := grpcTrailerFrameFlag
+	if rw.protocol == protocolConnectStream {
+		flag = connectEndStreamFlag
+		body = connectEndStreamMessage(rw, trailers)
+	} else {
+		b := &bytes.Buffer{}
+		for k, vs := range trailers {
+			for _, v := range vs {
+				fmt.Fprintf(b, "%s: %s\r\n", strings.ToLower(k), v)
+			}
+		}
+		body = b.Bytes()
+	}
+	frame := make([]byte, grpcFrameHeaderLen, grpcFrameHeaderLen+len(body))
+	frame[0] = flag
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(body)))
+	frame = append(frame, body...)
+	if rw.protocol == protocolGrpcWebText {
+		rw.buf.Write(frame)
+		rw.flushText()
+	} else {
+		_, _ = rw.w.Write(frame)
+	}
+	if f, ok := rw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+type connectError struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func connectEndStreamMessage(rw *grpcWebResponseWriter, trailers http.Header) []byte {
+	end := struct {
+		Error    *connectError       `json:"error,omitempty"`
+		Metadata map[string][]string `json:"metadata,omitempty"`
+	}{}
+	if code, msg := rw.grpcStatus(); code != codes.OK {
+		end.Error = &connectError{Code: connectCodes[code].name, Message: msg}
+	}
+	for _, k := range grpcTrailerKeys {
+		trailers.Del(k)
+	}
+	if len(trailers) > 0 {
+		end.Metadata = trailers
+	}
+	b, _ := json.Marshal(end)
+	return b
+}
+
+func (rw *grpcWebResponseWriter) finishConnectUnary() {
+	code, msg := rw.grpcStatus()
+	trailers := rw.trailers()
+	for _, k := range grpcTrailerKeys {
+		trailers.Del(k)
+	}
+	dst := rw.w.Header()
+	copyHeader(dst, rw.header, func(k string) bool {
+		return isGrpcTrailerKey(k) || k == "Trailer" || strings.HasPrefix(k, http2.TrailerPrefix) || k == "Content-Type"
+	})
+	for k, vs := range trailers {
+		for _, v := range vs {
+			dst.Add("Trailer-"+k, v)
+		}
+	}
+	if code != codes.OK {
+		writeConnectError(rw.w, code, msg)
+		return
+	}
+	// 去掉grpc的消息帧头，connect unary的响应体为消息本身
+	data := rw.buf.Bytes()
+	if len(data) >= grpcFrameHeaderLen {
+		length := binary.BigEndian.Uint32(data[1:grpcFrameHeaderLen])
+		if int(length) <= len(data)-grpcFrameHeaderLen {
+			data = data[grpcFrameHeaderLen : grpcFrameHeaderLen+int(length)]
+		}
+	}
+	dst.Set("Content-Type", connectUnaryContentType)
+	dst.Set("Content-Length", strconv.Itoa(len(data)))
+	exposeHeaders(dst)
+	rw.w.WriteHeader(http.StatusOK)
+	_, _ = rw.w.Write(data)
+}
+
+func writeConnectError(w http.ResponseWriter, code codes.Code, msg string) {
+	c, ok := connectCodes[code]
+	if !ok {
+		c = connectCodes[codes.Unknown]
+	}
+	b, _ := json.Marshal(&connectError{Code: c.name, Message: msg})
+	w.Header().Set("Content-Type", "application/json")
+	exposeHeaders(w.Header())
+	w.WriteHeader(c.status)
+	_, _ = w.Write(b)
+}
+
+func writeConnectOrHttpError(w http.ResponseWriter, protocol webProtocol, code codes.Code, msg string) {
+	if protocol == protocolConnectUnary {
+		writeConnectError(w, code, msg)
+		return
+	}
+	http.Error(w, msg, http.StatusBadRequest)
+}
+
+func isGrpcTrailerKey(k string) bool {
+	for _, key := range grpcTrailerKeys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func copyHeader(dst, src http.Header, skip func(string) bool) {
+	for k, vs := range src {
+		if skip != nil && skip(k) {
+			continue
+		}
+		for _, v := range vs {
+			dst.Add(k, v)
+		}
+	}
+}
+
+// exposeHeaders 浏览器跨域请求时需要显式暴露响应头才能读取grpc-status等信息
+func exposeHeaders(h http.Header) {
+	keys := make([]string, 0, len(h)+len(grpcTrailerKeys))
+	for k := range h {
+		if strings.HasPrefix(k, "Access-Control-") {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	keys = append(keys, grpcTrailerKeys...)
+	h.Set("Access-Control-Expose-Headers", strings.Join(keys, ", "))
+}
+
+// decodeGrpcMessage grpc-message采用百分号编码
+func decodeGrpcMessage(msg string) string {
+	if !strings.Contains(msg, "%") {
+		return msg
+	}
+	b := &bytes.Buffer{}
+	for i := 0; i < len(msg); i++ {
+		if msg[i] == '%' && i+2 < len(msg) {
+			if v, err := strconv.ParseUint(msg[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(msg[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,183 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newEchoGrpcServer() *grpc.Server {
+	return grpc.NewServer(grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		if strings.HasSuffix(method, "NotFound") {
+			return status.Error(codes.NotFound, "not found")
+		}
+		f := &emptypb.Empty{}
+		if err := stream.RecvMsg(f); err != nil {
+			return err
+		}
+		stream.SetTrailer(metadata.Pairs("x-echo", "begonia"))
+		return stream.SendMsg(f)
+	}))
+}
+
+func grpcFrame(flag byte, msg []byte) []byte {
+	frame := make([]byte, grpcFrameHeaderLen)
+	frame[0] = flag
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	return append(frame, msg...)
+}
+
+func readFrames(data []byte) (map[byte][]byte, error) {
+	frames := make(map[byte][]byte)
+	for len(data) >= grpcFrameHeaderLen {
+		length := int(binary.BigEndian.Uint32(data[1:grpcFrameHeaderLen]))
+		if len(data) < grpcFrameHeaderLen+length {
+			return nil, io.ErrUnexpectedEOF
+		}
+		frames[data[0]] = data[grpcFrameHeaderLen : grpcFrameHeaderLen+length]
+		data = data[grpcFrameHeaderLen+length:]
+	}
+	return frames, nil
+}
+
+func TestGrpcWebHandler(t *testing.T) {
+	handler := NewGrpcWebHandler(newEchoGrpcServer(), 0)
+	msg, _ := proto.Marshal(wrapperspb.String("hello"))
+	c.Convey("test grpc-web request", t, func() {
+		req := httptest.NewRequest(http.MethodPost, "/helloworld.Greeter/SayHello", bytes.NewReader(grpcFrame(0, msg)))
+		req.Header.Set("Content-Type", grpcWebProtoContentType)
+		c.So(IsGrpcWebRequest(req), c.ShouldBeTrue)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		c.So(w.Code, c.ShouldEqual, http.StatusOK)
+		c.So(w.Header().Get("Content-Type"), c.ShouldEqual, grpcWebProtoContentType)
+		frames, err := readFrames(w.Body.Bytes())
+		c.So(err, c.ShouldBeNil)
+		c.So(frames[0], c.ShouldResemble, msg)
+		c.So(string(frames[grpcTrailerFrameFlag]), c.ShouldContainSubstring, "grpc-status: 0")
+		c.So(string(frames[grpcTrailerFrameFlag]), c.ShouldContainSubstring, "x-echo: begonia")
+	})
+	c.Convey("test grpc-web-text request", t, func() {
+		body := base64.StdEncoding.EncodeToString(grpcFrame(0, msg))
+		req := httptest.NewRequest(http.MethodPost, "/helloworld.Greeter/SayHello", strings.NewReader(body))
+		req.Header.Set("Content-Type", grpcWebTextContentType)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		c.So(w.Code, c.ShouldEqual, http.StatusOK)
+		data := make([]byte, 0)
+		// 每次flush独立编码，逐段解码
+		for _, chunk := range strings.SplitAfter(w.Body.String(), "=") {
+			chunk = strings.TrimLeft(chunk, "=")
+			if chunk == "" {
+				continue
+			}
+			for len(chunk)%4 != 0 {
+				chunk += "="
+			}
+			b, err := base64.StdEncoding.DecodeString(chunk)
+			c.So(err, c.ShouldBeNil)
+			data = append(data, b...)
+		}
+		frames, err := readFrames(data)
+		c.So(err, c.ShouldBeNil)
+		c.So(frames[0], c.ShouldResemble, msg)
+		c.So(string(frames[grpcTrailerFrameFlag]), c.ShouldContainSubstring, "grpc-status: 0")
+	})
+	c.Convey("test connect unary request", t, func() {
+		req := httptest.NewRequest(http.MethodPost, "/helloworld.Greeter/SayHello", bytes.NewReader(msg))
+		req.Header.Set("Content-Type", connectUnaryContentType)
+		req.Header.Set(connectProtocolVersion, "1")
+		req.Header.Set(connectTimeoutHeader, "3000")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		c.So(w.Code, c.ShouldEqual, http.StatusOK)
+		c.So(w.Body.Bytes(), c.ShouldResemble, msg)
+		c.So(w.Header().Get("Trailer-X-Echo"), c.ShouldEqual, "begonia")
+
+		req = httptest.NewRequest(http.MethodPost, "/helloworld.Greeter/NotFound", bytes.NewReader(msg))
+		req.Header.Set("Content-Type", connectUnaryContentType)
+		req.Header.Set(connectProtocolVersion, "1")
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		c.So(w.Code, c.ShouldEqual, http.StatusNotFound)
+		e := &connectError{}
+		c.So(json.Unmarshal(w.Body.Bytes(), e), c.ShouldBeNil)
+		c.So(e.Code, c.ShouldEqual, "not_found")
+		c.So(e.Message, c.ShouldEqual, "not found")
+	})
+	c.Convey("test connect stream request", t, func() {
+		req := httptest.NewRequest(http.MethodPost, "/helloworld.Greeter/NotFound", bytes.NewReader(grpcFrame(0, msg)))
+		req.Header.Set("Content-Type", connectStreamContentType)
+		req.Header.Set(connectProtocolVersion, "1")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		c.So(w.Code, c.ShouldEqual, http.StatusOK)
+		frames, err := readFrames(w.Body.Bytes())
+		c.So(err, c.ShouldBeNil)
+		c.So(string(frames[connectEndStreamFlag]), c.ShouldContainSubstring, `"code":"not_found"`)
+	})
+	c.Convey("test not grpc-web request", t, func() {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/hello", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		c.So(IsGrpcWebRequest(req), c.ShouldBeFalse)
+	})
+	c.Convey("test json codec of grpc-web and connect", t, func() {
+		for _, contentType := range []string{"application/grpc-web+json", "application/grpc-web-text+json", "application/connect+json", "application/json; charset=utf-8"} {
+			req := httptest.NewRequest(http.MethodPost, "/helloworld.Greeter/SayHello", strings.NewReader("{}"))
+			req.Header.Set("Content-Type", contentType)
+			req.Header.Set(connectProtocolVersion, "1")
+			c.So(IsGrpcWebRequest(req), c.ShouldBeTrue)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			c.So(w.Code, c.ShouldEqual, http.StatusUnsupportedMediaType)
+			c.So(w.Header().Get("Accept-Post"), c.ShouldContainSubstring, connectUnaryContentType)
+		}
+	})
+	c.Convey("test connect unary request larger than max receive message size", t, func() {
+		limited := NewGrpcWebHandler(newEchoGrpcServer(), 64)
+		large, _ := proto.Marshal(wrapperspb.String(strings.Repeat("a", 256)))
+		gz := &bytes.Buffer{}
+		zw := gzip.NewWriter(gz)
+		_, _ = zw.Write(large)
+		_ = zw.Close()
+		// 压缩后的请求体没有超过限制，解压后超过限制
+		c.So(gz.Len(), c.ShouldBeLessThan, 64)
+		for encoding, body := range map[string][]byte{"identity": large, "gzip": gz.Bytes()} {
+			req := httptest.NewRequest(http.MethodPost, "/helloworld.Greeter/SayHello", bytes.NewReader(body))
+			req.Header.Set("Content-Type", connectUnaryContentType)
+			req.Header.Set("Content-Encoding", encoding)
+			req.Header.Set(connectProtocolVersion, "1")
+			w := httptest.NewRecorder()
+			limited.ServeHTTP(w, req)
+			c.So(w.Code, c.ShouldEqual, http.StatusTooManyRequests)
+			e := &connectError{}
+			c.So(json.Unmarshal(w.Body.Bytes(), e), c.ShouldBeNil)
+			c.So(e.Code, c.ShouldEqual, "resource_exhausted")
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/helloworld.Greeter/SayHello", bytes.NewReader(msg))
+		req.Header.Set("Content-Type", connectUnaryContentType)
+		req.Header.Set(connectProtocolVersion, "1")
+		w := httptest.NewRecorder()
+		limited.ServeHTTP(w, req)
+		c.So(w.Code, c.ShouldEqual, http.StatusOK)
+		c.So(w.Body.Bytes(), c.ShouldResemble, msg)
+	})
+}
@@ -0,0 +1,286 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+var ErrAllEndpointsEjected = errors.New("all endpoints are ejected")
+
+type HealthCheckOptions struct {
+	// 主动探测间隔
+	Interval time.Duration
+	// 单次探测超时时间
+	Timeout time.Duration
+	// 连续失败多少次后剔除
+	UnhealthyThreshold int
+	// 一个探测周期内错误率超过该值后剔除
+	ErrorRateThreshold float64
+	// 计算错误率所需的最小请求数
+	MinRequests int
+	// 剔除的基础冷却时间，多次剔除时按剔除次数递增
+	EjectionTime time.Duration
+	// 最大冷却时间
+	MaxEjectionTime time.Duration
+}
+
+func DefaultHealthCheckOptions() *HealthCheckOptions {
+	return &HealthCheckOptions{
+		Interval:           10 * time.Second,
+		Timeout:            3 * time.Second,
+		UnhealthyThreshold: 3,
+		ErrorRateThreshold: 0.5,
+		MinRequests:        20,
+		EjectionTime:       30 * time.Second,
+		MaxEjectionTime:    300 * time.Second,
+	}
+}
+
+// EndpointHealth 端点的健康状态
+type EndpointHealth struct {
+	Addr                string    `json:"addr"`
+	Healthy             bool      `json:"healthy"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Requests            int64     `json:"requests"`
+	Failures            int64     `json:"failures"`
+	Ejections           int       `json:"ejections"`
+	EjectedUntil        time.Time `json:"ejected_until,omitempty"`
+	LastCheckedAt       time.Time `json:"last_checked_at,omitempty"`
+	LastError           string    `json:"last_error,omitempty"`
+}
+
+type HealthProbe func(ctx context.Context, endpoint loadbalance.Endpoint) error
+
+// HealthChecker 基于grpc.health.v1协议主动探测端点，
+// 同时根据代理请求的结果被动统计错误率，剔除异常端点并在冷却时间后恢复
+type HealthChecker struct {
+	opts    *HealthCheckOptions
+	mu      sync.RWMutex
+	states  map[string]*EndpointHealth
+	targets func() []loadbalance.Endpoint
+	probe   HealthProbe
+	stop    chan struct{}
+	once    sync.Once
+}
+
+func NewHealthChecker(opts *HealthCheckOptions, targets func() []loadbalance.Endpoint) *HealthChecker {
+	if opts == nil {
+		opts = DefaultHealthCheckOptions()
+	}
+	return &HealthChecker{
+		opts:    opts,
+		states:  make(map[string]*EndpointHealth),
+		targets: targets,
+		probe:   GrpcHealthProbe,
+		stop:    make(chan struct{}),
+	}
+}
+
+// GrpcHealthProbe 使用grpc.health.v1协议探测端点，
+// 未实现健康检查服务的端点只要连接可用即视为健康
+func GrpcHealthProbe(ctx context.Context, endpoint loadbalance.Endpoint) error {
+	cn, err := endpoint.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("get connection error:%w", err)
+	}
+	conn := cn.(loadbalance.Connection)
+	defer endpoint.AfterTransform(ctx, conn)
+	cc, ok := conn.ConnInstance().(*grpc.ClientConn)
+	if !ok {
+		return fmt.Errorf("unknown connection type %T", conn.ConnInstance())
+	}
+	rsp, err := healthpb.NewHealthClient(cc).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return err
+	}
+	if rsp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("endpoint status is %s", rsp.GetStatus().String())
+	}
+	return nil
+}
+
+func (h *HealthChecker) SetProbe(probe HealthProbe) {
+	h.probe = probe
+}
+
+func (h *HealthChecker) Start() {
+	ticker := time.NewTicker(h.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			h.Check(context.Background())
+		case <-h.stop:
+			return
+		}
+	}
+}
+
+func (h *HealthChecker) Stop() {
+	h.once.Do(func() {
+		close(h.stop)
+	})
+}
+
+// Check 探测所有端点，并清理已经不存在的端点状态
+func (h *HealthChecker) Check(ctx context.Context) {
+	endpoints := h.targets()
+	alive := make(map[string]bool)
+	wg := &sync.WaitGroup{}
+	for _, ep := range endpoints {
+		if alive[ep.Addr()] {
+			continue
+		}
+		alive[ep.Addr()] = true
+		wg.Add(1)
+		go func(ep loadbalance.Endpoint) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, h.opts.Timeout)
+			defer cancel()
+			h.report(ep.Addr(), h.probe(probeCtx, ep), false)
+		}(ep)
+	}
+	wg.Wait()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	for addr, state := range h.states {
+		if !alive[addr] {
+			delete(h.states, addr)
+			continue
+		}
+		// 恢复后没有失败的探测周期使剔除次数逐步衰减，再次剔除时的冷却时间随之缩短
+		if h.recover(state, now) && state.ConsecutiveFailures == 0 && state.Failures == 0 && state.Ejections > 0 {
+			state.Ejections--
+		}
+		// 错误率按探测周期统计
+		state.Requests = 0
+		state.Failures = 0
+	}
+}
+
+// IsAvailable 端点是否可被选择
+func (h *HealthChecker) IsAvailable(addr string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	state, ok := h.states[addr]
+	if !ok {
+		return true
+	}
+	return !time.Now().Before(state.EjectedUntil)
+}
+
+// ReportResult 上报代理请求的结果，用于被动剔除
+func (h *HealthChecker) ReportResult(addr string, err error) {
+	if status.Code(err) == codes.Canceled {
+		return
+	}
+	if !isUpstreamFailure(err) {
+		err = nil
+	}
+	h.report(addr, err, true)
+}
+
+func (h *HealthChecker) report(addr string, err error, passive bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	state, ok := h.states[addr]
+	if !ok {
+		state = &EndpointHealth{Addr: addr, Healthy: true}
+		h.states[addr] = state
+	}
+	now := time.Now()
+	ejected := !h.recover(state, now)
+	if passive {
+		state.Requests++
+	} else {
+		state.LastCheckedAt = now
+	}
+	if err == nil {
+		state.ConsecutiveFailures = 0
+		if !ejected {
+			state.LastError = ""
+		}
+		return
+	}
+	state.LastError = err.Error()
+	state.ConsecutiveFailures++
+	if passive {
+		state.Failures++
+	}
+	if ejected {
+		return
+	}
+	errorRate := float64(0)
+	if state.Requests > 0 {
+		errorRate = float64(state.Failures) / float64(state.Requests)
+	}
+	if state.ConsecutiveFailures >= h.opts.UnhealthyThreshold ||
+		(state.Requests >= int64(h.opts.MinRequests) && errorRate >= h.opts.ErrorRateThreshold) {
+		h.eject(state, now)
+	}
+}
+
+func (h *HealthChecker) eject(state *EndpointHealth, now time.Time) {
+	state.Ejections++
+	cooldown := h.opts.EjectionTime * time.Duration(state.Ejections)
+	if cooldown > h.opts.MaxEjectionTime {
+		cooldown = h.opts.MaxEjectionTime
+	}
+	state.Healthy = false
+	state.EjectedUntil = now.Add(cooldown)
+	state.ConsecutiveFailures = 0
+	state.Requests = 0
+	state.Failures = 0
+	Log.Warnf(context.Background(), "endpoint %s ejected until %s,last error:%s", state.Addr, state.EjectedUntil.Format(time.RFC3339), state.LastError)
+}
+
+// recover 剔除的冷却时间结束后恢复端点，返回端点是否已不在剔除中
+func (h *HealthChecker) recover(state *EndpointHealth, now time.Time) bool {
+	if now.Before(state.EjectedUntil) {
+		return false
+	}
+	if !state.EjectedUntil.IsZero() {
+		state.Healthy = true
+		state.EjectedUntil = time.Time{}
+		state.ConsecutiveFailures = 0
+	}
+	return true
+}
+
+// Health 获取端点的健康状态，未探测过的端点视为健康
+func (h *HealthChecker) Health(addr string) *EndpointHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	state, ok := h.states[addr]
+	if !ok {
+		return &EndpointHealth{Addr: addr, Healthy: true}
+	}
+	h.recover(state, time.Now())
+	cp := *state
+	return &cp
+}
+
+// isUpstreamFailure 只有网关类错误才计入端点的失败次数，业务错误不影响端点健康状态
+func isUpstreamFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
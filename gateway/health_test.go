@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHealthChecker(t *testing.T) {
+	c.Convey("test health checker eject and recover", t, func() {
+		eps := []loadbalance.Endpoint{
+			NewGrpcEndpoint("127.0.0.1:1001", nil),
+			NewGrpcEndpoint("127.0.0.1:1002", nil),
+		}
+		rr, err := loadbalance.New(loadbalance.RRBalanceType, eps)
+		c.So(err, c.ShouldBeNil)
+		lb := NewGrpcLoadBalancer()
+		lb.lb["/HELLOWORLD.GREETER/SAYHELLO"] = rr
+		opts := DefaultHealthCheckOptions()
+		opts.EjectionTime = 200 * time.Millisecond
+		health := lb.SetHealthChecker(opts)
+		health.SetProbe(func(ctx context.Context, endpoint loadbalance.Endpoint) error {
+			if endpoint.Addr() == "127.0.0.1:1001" {
+				return fmt.Errorf("connection refused")
+			}
+			return nil
+		})
+		for i := 0; i < opts.UnhealthyThreshold; i++ {
+			health.Check(context.Background())
+		}
+		c.So(health.IsAvailable("127.0.0.1:1001"), c.ShouldBeFalse)
+		c.So(health.Health("127.0.0.1:1001").Healthy, c.ShouldBeFalse)
+		c.So(health.Health("127.0.0.1:1002").Healthy, c.ShouldBeTrue)
+		for i := 0; i < 4; i++ {
+			ep, err := lb.Select("/helloworld.Greeter/SayHello")
+			c.So(err, c.ShouldBeNil)
+			c.So(ep.Addr(), c.ShouldEqual, "127.0.0.1:1002")
+		}
+
+		// 冷却时间过后恢复
+		time.Sleep(opts.EjectionTime)
+		c.So(health.IsAvailable("127.0.0.1:1001"), c.ShouldBeTrue)
+		state := health.Health("127.0.0.1:1001")
+		c.So(state.Healthy, c.ShouldBeTrue)
+		c.So(state.EjectedUntil.IsZero(), c.ShouldBeTrue)
+		c.So(state.Ejections, c.ShouldEqual, 1)
+		health.SetProbe(func(ctx context.Context, endpoint loadbalance.Endpoint) error {
+			return nil
+		})
+		health.Check(context.Background())
+		c.So(health.Health("127.0.0.1:1001").Healthy, c.ShouldBeTrue)
+		// 没有失败的探测周期后剔除次数衰减
+		c.So(health.Health("127.0.0.1:1001").Ejections, c.ShouldEqual, 0)
+	})
+	c.Convey("test ejection backoff grows only while endpoint keeps failing", t, func() {
+		opts := DefaultHealthCheckOptions()
+		opts.EjectionTime = 100 * time.Millisecond
+		health := NewHealthChecker(opts, func() []loadbalance.Endpoint {
+			return []loadbalance.Endpoint{NewGrpcEndpoint("127.0.0.1:1006", nil)}
+		})
+		health.SetProbe(func(ctx context.Context, endpoint loadbalance.Endpoint) error {
+			return fmt.Errorf("connection refused")
+		})
+		for i := 0; i < opts.UnhealthyThreshold; i++ {
+			health.Check(context.Background())
+		}
+		c.So(health.Health("127.0.0.1:1006").Ejections, c.ShouldEqual, 1)
+		time.Sleep(opts.EjectionTime)
+		for i := 0; i < opts.UnhealthyThreshold; i++ {
+			health.Check(context.Background())
+		}
+		state := health.Health("127.0.0.1:1006")
+		c.So(state.Healthy, c.ShouldBeFalse)
+		c.So(state.Ejections, c.ShouldEqual, 2)
+		c.So(time.Until(state.EjectedUntil), c.ShouldBeGreaterThan, opts.EjectionTime)
+	})
+	c.Convey("test passive ejection by error rate", t, func() {
+		opts := DefaultHealthCheckOptions()
+		opts.UnhealthyThreshold = 100
+		opts.MinRequests = 10
+		health := NewHealthChecker(opts, func() []loadbalance.Endpoint { return nil })
+		for i := 0; i < 10; i++ {
+			var err error
+			if i%2 == 1 {
+				err = status.Error(codes.Unavailable, "unavailable")
+			}
+			health.ReportResult("127.0.0.1:1003", err)
+		}
+		c.So(health.IsAvailable("127.0.0.1:1003"), c.ShouldBeFalse)
+
+		// 业务错误不计入失败
+		for i := 0; i < 20; i++ {
+			health.ReportResult("127.0.0.1:1004", status.Error(codes.NotFound, "not found"))
+		}
+		c.So(health.IsAvailable("127.0.0.1:1004"), c.ShouldBeTrue)
+	})
+	c.Convey("test all endpoints ejected", t, func() {
+		eps := []loadbalance.Endpoint{NewGrpcEndpoint("127.0.0.1:1005", nil)}
+		rr, _ := loadbalance.New(loadbalance.RRBalanceType, eps)
+		lb := NewGrpcLoadBalancer()
+		lb.lb["/HELLOWORLD.GREETER/SAYHELLO"] = rr
+		health := lb.SetHealthChecker(DefaultHealthCheckOptions())
+		for i := 0; i < 3; i++ {
+			lb.ReportResult("127.0.0.1:1005", status.Error(codes.Unavailable, "unavailable"), time.Millisecond)
+		}
+		_, err := lb.Select("/helloworld.Greeter/SayHello")
+		c.So(err, c.ShouldEqual, ErrAllEndpointsEjected)
+		c.So(health.Health("127.0.0.1:1005").Ejections, c.ShouldEqual, 1)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gorilla/websocket"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -42,6 +43,11 @@ type Template struct {
 type HttpEndpoint interface {
 	RegisterHandlerClient(ctx context.Context, pd ProtobufDescription, mux *runtime.ServeMux) error
 	DeleteEndpoint(ctx context.Context, pd ProtobufDescription, mux *runtime.ServeMux) error
+	// CloseStreams 结束进行中的sse和websocket流，之后不再接受新的流
+	CloseStreams()
+	// WaitStreams 等待流的处理函数全部退出
+	WaitStreams(ctx context.Context) error
+	Close() error
 }
 type HttpEndpointItem struct {
 	Pattern  runtime.Pattern `json:"-"`
@@ -65,8 +71,9 @@ type HttpEndpointItem struct {
 type HttpEndpointImpl struct {
 	// items  []*HttpEndpointItem
 	// pd     ProtobufDescription
-	client HttpForwardGrpcEndpoint
-	mux    *sync.Mutex
+	client  HttpForwardGrpcEndpoint
+	mux     *sync.Mutex
+	streams *streamTracker
 }
 
 func loadHttpEndpointItem(pd ProtobufDescription, descFile string) ([]*HttpEndpointItem, error) {
@@ -139,10 +146,20 @@ func loadGlobalMessages(pd ProtobufDescription) error {
 func NewHttpEndpoint(client HttpForwardGrpcEndpoint) (HttpEndpoint, error) {
 
 	return &HttpEndpointImpl{
-		client: client,
-		mux:    &sync.Mutex{},
+		client:  client,
+		mux:     &sync.Mutex{},
+		streams: newStreamTracker(),
 	}, nil
 }
+func (h *HttpEndpointImpl) CloseStreams() {
+	h.streams.closeAll()
+}
+func (h *HttpEndpointImpl) WaitStreams(ctx context.Context) error {
+	return h.streams.wait(ctx)
+}
+func (h *HttpEndpointImpl) Close() error {
+	return h.client.Close()
+}
 func (h *HttpEndpointImpl) stream(ctx context.Context, item *HttpEndpointItem, marshaler runtime.Marshaler, ws WebsocketForwarder) (StreamClient, runtime.ServerMetadata, error) {
 	var metadata runtime.ServerMetadata
 	grpcReq := NewGrpcRequest(ctx, item.In, item.Out, item.FullMethodName)
@@ -413,6 +430,12 @@ func (h *HttpEndpointImpl) RegisterHandlerClient(ctx context.Context, pd Protobu
 
 			// 普通请求
 			if !item.IsServerStream && !item.IsClientStream {
+				// 开启了响应缓存的GET请求处理ETag
+				if policy := ResponseCaches().Get(item.FullMethodName); policy != nil && req.Method == http.MethodGet {
+					cw := &cacheResponseWriter{ResponseWriter: w}
+					defer cw.flush(req, policy)
+					w = cw
+				}
 				reqInstance, err := h.newRequest(annotatedContext, item, inboundMarshaler, req, pathParams)
 				if err != nil {
 					runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
@@ -428,6 +451,18 @@ func (h *HttpEndpointImpl) RegisterHandlerClient(ctx context.Context, pd Protobu
 				runtime.ForwardResponseMessage(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 			} else if item.IsServerStream && !item.IsClientStream {
 				// 服务端推流,升级为sse服务
+				defer trackStream(StreamTransportSSE)()
+				// 网关关闭时取消上游的流，并以EOF正常结束响应
+				closed := &atomic.Bool{}
+				done, err := h.streams.add(func() {
+					closed.Store(true)
+					cancel()
+				})
+				if err != nil {
+					runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, status.Error(codes.Unavailable, err.Error()))
+					return
+				}
+				defer done()
 				resp, md, err := h.serverStreamRequest(annotatedContext, item, inboundMarshaler, req, pathParams)
 				if err != nil {
 					runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
@@ -436,11 +471,16 @@ func (h *HttpEndpointImpl) RegisterHandlerClient(ctx context.Context, pd Protobu
 				annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
 
 				recv := func() (proto.Message, error) {
-					return resp.Recv()
+					msg, err := resp.Recv()
+					if err != nil && closed.Load() {
+						return nil, io.EOF
+					}
+					return msg, err
 				}
 				runtime.ForwardResponseStream(annotatedContext, mux, outboundMarshaler, w, req, recv, mux.GetForwardResponseOptions()...)
 			} else if !item.IsServerStream && item.IsClientStream {
 				// 客户端推流
+				defer trackStream(StreamTransportClientStream)()
 				resp, md, err := h.clientStreamRequest(annotatedContext, item, inboundMarshaler, req, pathParams)
 				annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
 
@@ -458,6 +498,20 @@ func (h *HttpEndpointImpl) RegisterHandlerClient(ctx context.Context, pd Protobu
 					return
 				}
 				// defer ws.Close()
+				defer trackStream(StreamTransportWebsocket)()
+				// 网关关闭时向客户端发送close帧，并取消上游的流
+				closed := &atomic.Bool{}
+				done, err := h.streams.add(func() {
+					closed.Store(true)
+					_ = ws.Close()
+					cancel()
+				})
+				if err != nil {
+					_ = ws.Close()
+					_ = ws.CloseConn()
+					return
+				}
+				defer done()
 				stream, md, err := h.stream(annotatedContext, item, inboundMarshaler, ws)
 				annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
 
@@ -468,6 +522,9 @@ func (h *HttpEndpointImpl) RegisterHandlerClient(ctx context.Context, pd Protobu
 					return
 				}
 				runtime.ForwardResponseStream(annotatedContext, mux, outboundMarshaler, ws, req, stream.Recv, mux.GetForwardResponseOptions()...)
+				if closed.Load() {
+					_ = ws.CloseConn()
+				}
 			}
 		})
 	}
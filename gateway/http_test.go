@@ -138,10 +138,8 @@ func testRegisterClient(t *testing.T) {
 		time.Sleep(2 * time.Second)
 		go gw.Start()
 		time.Sleep(2 * time.Second)
-		f := func() {
-			gw.Start()
-		}
-		c.So(f, c.ShouldPanic)
+		// 端口已被占用时返回错误
+		c.So(gw.Start(), c.ShouldNotBeNil)
 		time.Sleep(4 * time.Second)
 		_, err = gw.proxyLB.Select("test/.test")
 		c.So(err, c.ShouldNotBeNil)
@@ -1104,11 +1102,13 @@ func testStartErr(t *testing.T) {
 		pd, err := NewDescriptionFromBinary(pb, filepath.Join("tmp", "test-pd"))
 		c.So(err, c.ShouldBeNil)
 		opts, cnf := newTestServer(0, 0)
+		// 非法端口，监听失败
+		cnf.GrpcProxyAddr = "127.0.0.1:-1"
 		localGW := NewGateway(cnf, opts)
 		err = localGW.RegisterHandlerClient(context.Background(), pd)
 		c.So(err, c.ShouldBeNil)
 
-		c.So(localGW.Start, c.ShouldPanic)
+		c.So(localGW.Start(), c.ShouldNotBeNil)
 
 		min := 1949
 		max := 12138
@@ -1125,7 +1125,7 @@ func testStartErr(t *testing.T) {
 		localGW2 := NewGateway(cnf2, opts2)
 		err = localGW2.RegisterHandlerClient(context.Background(), pd)
 		c.So(err, c.ShouldBeNil)
-		c.So(localGW2.Start, c.ShouldPanic)
+		c.So(localGW2.Start(), c.ShouldNotBeNil)
 
 	})
 }
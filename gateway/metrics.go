@@ -0,0 +1,268 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	metricsNamespace = "begonia"
+
+	StreamTransportSSE          = "sse"
+	StreamTransportWebsocket    = "websocket"
+	StreamTransportClientStream = "client_stream"
+
+	// 网关内置服务的端点地址
+	localEndpoint = "local"
+)
+
+// MetricsRegistry 网关的指标注册表
+var MetricsRegistry = prometheus.NewRegistry()
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "requests_total",
+		Help:      "Total number of grpc and http requests handled by the gateway.",
+	}, []string{"method", "route", "code", "endpoint"})
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of requests handled by the gateway.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "code", "endpoint"})
+	inflightStreams = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "inflight_streams",
+		Help:      "Number of in-flight http streams by transport.",
+	}, []string{"transport"})
+	pluginDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "plugin_duration_seconds",
+		Help:      "Time spent in each plugin of the interceptor chain, excluding the downstream handler.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"plugin", "method"})
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "cache_requests_total",
+		Help:      "Layered cache lookups by layer and result.",
+	}, []string{"layer", "result"})
+	etcdWatchEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "etcd_watch_events_total",
+		Help:      "Etcd watch events handled by the gateway.",
+	}, []string{"prefix", "type", "result"})
+	mirrorRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "mirror_requests_total",
+		Help:      "Mirrored requests by status code of the primary and the shadow endpoint.",
+	}, []string{"method", "primary_code", "shadow_code"})
+	mirrorLatencyDiff = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "mirror_latency_diff_seconds",
+		Help:      "Latency of the shadow endpoint minus latency of the primary endpoint.",
+		Buckets:   []float64{-1, -.5, -.25, -.1, -.05, -.01, 0, .01, .05, .1, .25, .5, 1},
+	}, []string{"method"})
+	mirrorDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "mirror_dropped_total",
+		Help:      "Mirrored requests dropped because too many shadow requests are in flight.",
+	}, []string{"method"})
+	discoveryUpdates = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "discovery_updates_total",
+		Help:      "Membership lookups of dynamic endpoint discovery by result.",
+	}, []string{"endpoint", "source", "result"})
+	discoveryMembers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "discovery_members",
+		Help:      "Number of members currently discovered for the endpoint.",
+	}, []string{"endpoint"})
+	poolStats = &poolCollector{
+		active: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "pool", "active_connections"), "Number of connections in use of the endpoint pool.", []string{"endpoint"}, nil),
+		idle:   prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "pool", "idle_connections"), "Number of idle connections of the endpoint pool.", []string{"endpoint"}, nil),
+	}
+)
+
+func init() {
+	MetricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestsTotal,
+		requestDuration,
+		inflightStreams,
+		pluginDuration,
+		cacheRequests,
+		etcdWatchEvents,
+		mirrorRequests,
+		mirrorLatencyDiff,
+		mirrorDropped,
+		discoveryUpdates,
+		discoveryMembers,
+		poolStats,
+	)
+}
+
+// MetricsHandler 输出prometheus格式的指标
+func MetricsHandler() runtime.HandlerFunc {
+	handler := promhttp.HandlerFor(MetricsRegistry, promhttp.HandlerOpts{})
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		handler.ServeHTTP(w, r)
+	}
+}
+
+// poolCollector 采集代理端点连接池的状态
+type poolCollector struct {
+	mu     sync.RWMutex
+	lb     *GrpcLoadBalancer
+	active *prometheus.Desc
+	idle   *prometheus.Desc
+}
+
+func (p *poolCollector) setLoadBalancer(lb *GrpcLoadBalancer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lb = lb
+}
+
+func (p *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.active
+	ch <- p.idle
+}
+
+func (p *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	p.mu.RLock()
+	lb := p.lb
+	p.mu.RUnlock()
+	if lb == nil {
+		return
+	}
+	visited := make(map[string]bool)
+	for _, endpoint := range lb.Endpoints() {
+		if visited[endpoint.Addr()] {
+			continue
+		}
+		visited[endpoint.Addr()] = true
+		stats := endpoint.Stats()
+		if stats == nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(p.active, prometheus.GaugeValue, float64(stats.GetActivateConns()), endpoint.Addr())
+		ch <- prometheus.MustNewConstMetric(p.idle, prometheus.GaugeValue, float64(stats.GetIdleConns()), endpoint.Addr())
+	}
+}
+
+type requestMetricsKey struct{}
+
+// requestMetrics 记录代理请求最终使用的端点地址
+type requestMetrics struct {
+	endpoint atomic.Value
+}
+
+func setMetricsEndpoint(ctx context.Context, endpoint loadbalance.Endpoint) {
+	if m, ok := ctx.Value(requestMetricsKey{}).(*requestMetrics); ok {
+		m.endpoint.Store(endpoint.Addr())
+	}
+}
+
+func (m *requestMetrics) observe(ctx context.Context, method string, err error, elapsed time.Duration) {
+	endpoint := localEndpoint
+	if addr, ok := m.endpoint.Load().(string); ok {
+		endpoint = addr
+	}
+	route := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(XHttpRoute); len(values) > 0 {
+			route = values[0]
+		}
+	}
+	code := status.Code(err).String()
+	requestsTotal.WithLabelValues(method, route, code, endpoint).Inc()
+	requestDuration.WithLabelValues(method, route, code, endpoint).Observe(elapsed.Seconds())
+}
+
+// MetricsUnaryInterceptor 统计请求数和耗时，应位于拦截器链的最外层
+func MetricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	m := &requestMetrics{}
+	start := time.Now()
+	rsp, err := handler(context.WithValue(ctx, requestMetricsKey{}, m), req)
+	m.observe(ctx, info.FullMethod, err, time.Since(start))
+	return rsp, err
+}
+
+// MetricsStreamInterceptor 统计请求数和耗时，代理的请求同样经过该拦截器
+func MetricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	m := &requestMetrics{}
+	start := time.Now()
+	err := handler(srv, WithStreamContext(ss, context.WithValue(ss.Context(), requestMetricsKey{}, m)))
+	m.observe(ss.Context(), info.FullMethod, err, time.Since(start))
+	return err
+}
+
+// trackStream 统计进行中的http流，返回流结束时调用的函数
+func trackStream(transport string) func() {
+	gauge := inflightStreams.WithLabelValues(transport)
+	gauge.Inc()
+	return gauge.Dec
+}
+
+// ObservePlugin 记录插件自身的耗时
+func ObservePlugin(plugin string, method string, elapsed time.Duration) {
+	pluginDuration.WithLabelValues(plugin, method).Observe(elapsed.Seconds())
+}
+
+// ObserveCache 记录缓存命中情况
+func ObserveCache(layer string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequests.WithLabelValues(layer, result).Inc()
+}
+
+// ObserveEtcdWatch 记录etcd监听事件的处理结果
+func ObserveEtcdWatch(prefix string, eventType string, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	etcdWatchEvents.WithLabelValues(prefix, eventType, result).Inc()
+}
+
+// ObserveMirror 记录影子请求与主请求的状态码和耗时差异
+func ObserveMirror(method string, primaryErr error, shadowErr error, primary time.Duration, shadow time.Duration) {
+	mirrorRequests.WithLabelValues(method, status.Code(primaryErr).String(), status.Code(shadowErr).String()).Inc()
+	mirrorLatencyDiff.WithLabelValues(method).Observe((shadow - primary).Seconds())
+}
+
+// ObserveMirrorDropped 记录被丢弃的影子请求
+func ObserveMirrorDropped(method string) {
+	mirrorDropped.WithLabelValues(method).Inc()
+}
+
+// ObserveDiscovery 记录动态发现的查询结果，changed表示成员发生了变化并已生效
+func ObserveDiscovery(endpoint string, source string, members int, changed bool, err error) {
+	result := "unchanged"
+	switch {
+	case err != nil:
+		result = "error"
+	case changed:
+		result = "changed"
+	}
+	discoveryUpdates.WithLabelValues(endpoint, source, result).Inc()
+	if changed {
+		discoveryMembers.WithLabelValues(endpoint).Set(float64(members))
+	}
+}
@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type metricsTestStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *metricsTestStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestMetrics(t *testing.T) {
+	c.Convey("test request metrics", t, func() {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(XHttpRoute, "/api/v1/metrics/{name}"))
+		info := &grpc.StreamServerInfo{FullMethod: "/metrics.Test/Proxy"}
+		// 代理请求记录最终选择的端点
+		err := MetricsStreamInterceptor(nil, &metricsTestStream{ctx: ctx}, info, func(srv interface{}, ss grpc.ServerStream) error {
+			setMetricsEndpoint(ss.Context(), NewGrpcEndpoint("127.0.0.1:9527", nil))
+			return status.Error(codes.Unavailable, "unavailable")
+		})
+		c.So(status.Code(err), c.ShouldEqual, codes.Unavailable)
+		c.So(testutil.ToFloat64(requestsTotal.WithLabelValues("/metrics.Test/Proxy", "/api/v1/metrics/{name}", "Unavailable", "127.0.0.1:9527")), c.ShouldEqual, 1)
+
+		unaryInfo := &grpc.UnaryServerInfo{FullMethod: "/metrics.Test/Local"}
+		_, err = MetricsUnaryInterceptor(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		c.So(err, c.ShouldBeNil)
+		c.So(testutil.ToFloat64(requestsTotal.WithLabelValues("/metrics.Test/Local", "", "OK", localEndpoint)), c.ShouldEqual, 1)
+
+		done := trackStream(StreamTransportSSE)
+		c.So(testutil.ToFloat64(inflightStreams.WithLabelValues(StreamTransportSSE)), c.ShouldEqual, 1)
+		done()
+		c.So(testutil.ToFloat64(inflightStreams.WithLabelValues(StreamTransportSSE)), c.ShouldEqual, 0)
+
+		ObservePlugin("logger", "/metrics.Test/Local", time.Millisecond)
+		ObserveCache("local", true)
+		ObserveCache("local", false)
+		c.So(testutil.ToFloat64(cacheRequests.WithLabelValues("local", "hit")), c.ShouldEqual, 1)
+		ObserveEtcdWatch("/begonia/endpoints", "PUT", nil)
+		c.So(testutil.ToFloat64(etcdWatchEvents.WithLabelValues("/begonia/endpoints", "PUT", "success")), c.ShouldEqual, 1)
+
+		w := httptest.NewRecorder()
+		MetricsHandler()(w, httptest.NewRequest(http.MethodGet, "/metrics", nil), nil)
+		c.So(w.Code, c.ShouldEqual, http.StatusOK)
+		body := w.Body.String()
+		c.So(body, c.ShouldContainSubstring, "begonia_request_duration_seconds_bucket")
+		c.So(body, c.ShouldContainSubstring, "begonia_plugin_duration_seconds")
+		c.So(strings.Contains(body, "go_goroutines"), c.ShouldBeTrue)
+	})
+}
@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -34,9 +36,31 @@ const (
 	XRemoteAddr = "x-http-forwarded-for"
 	XProtocol   = "x-http-protocol"
 	XHttpURI    = "x-http-uri"
+	XHttpRoute  = "x-http-route"
 	XIdentity   = "x-identity"
+	XToken      = "x-token"
+	XSessionID  = "x-session-id"
+	// XClientCertSubject 网关入口校验过的客户端证书subject
+	XClientCertSubject = "x-client-cert-subject"
 )
 
+// TrustedHeaders 只能由网关和鉴权插件设置的身份头，入口处丢弃客户端传入的值
+var TrustedHeaders = []string{XUID, XIdentity, XToken, XSessionID, XClientCertSubject}
+
+// TrustedHeadersHandler 丢弃客户端自行传入的身份头，
+// 开启双向认证时将已校验的客户端证书subject写入x-client-cert-subject，由鉴权插件映射为x-identity
+func TrustedHeadersHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, key := range TrustedHeaders {
+			r.Header.Del(key)
+		}
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+			r.Header.Set(XClientCertSubject, r.TLS.VerifiedChains[0][0].Subject.String())
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
 func preflightHandler(w http.ResponseWriter, _ *http.Request) {
 	// headers := []string{"Content-Type", "Accept", "Authorization", "X-Token", "x-date", "x-access-key"}
 	w.Header().Set("Access-Control-Allow-Headers", "*")
@@ -107,25 +131,17 @@ func IncomingHeadersToMetadata(ctx context.Context, req *http.Request) metadata.
 	md.Set("uri", req.RequestURI)
 	md.Set(XHttpURI, req.RequestURI)
 	md.Set(XHttpMethod, req.Method)
+	if pattern, ok := runtime.HTTPPathPattern(ctx); ok {
+		md.Set(XHttpRoute, pattern)
+		trace.SpanFromContext(ctx).SetName(fmt.Sprintf("%s %s", req.Method, pattern))
+	}
+	// 使用http入口span替换客户端传入的traceparent
+	InjectTraceContext(ctx, md)
 	md.Set("remote_addr", req.RemoteAddr)
 	md.Set(XRemoteAddr, req.RemoteAddr)
 	md.Set("protocol", req.Proto)
 	md.Set(XProtocol, req.Proto)
 	md.Set(gosdk.GetMetadataKey(XRequestID), reqID)
-	xuid := md.Get(XUID)
-	accessKey := md.Get(XAccessKey)
-	author := ""
-	if len(xuid) > 0 {
-		author = xuid[0]
-	}
-	if author == "" && len(accessKey) > 0 {
-		author = accessKey[0]
-	}
-	if author == "" {
-		return md
-	}
-	md.Set(XIdentity, author)
-
 	return md
 }
 
@@ -277,6 +293,14 @@ func HandleErrorWithLogger(logger logger.Logger) runtime.ErrorHandlerFunc {
 			code = runtime.HTTPStatusFromCode(st.Code())
 
 			log.WithField("status", code).Errorf(ctx, msg)
+			// 转发插件设置的响应头，例如限流的Retry-After
+			if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+				for k, v := range md.HeaderMD {
+					if strings.HasPrefix(k, gosdk.MetadataKeyPrefix) {
+						writeHttpHeaders(w, k, v)
+					}
+				}
+			}
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(code)
 			bData, _ := protojson.Marshal(data)
@@ -0,0 +1,190 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// XMirror 发往影子端点的请求带有该请求头，影子服务可据此跳过外部副作用
+const XMirror = "x-begonia-mirror"
+
+const (
+	defaultMirrorTimeout = 5 * time.Second
+	// 每个端点同时进行的影子请求数，超出时丢弃，避免影子端点变慢时堆积
+	maxMirrorInflight = 64
+)
+
+// MirrorPolicy 将一元调用按采样率异步复制到影子端点，影子端点的响应被丢弃
+type MirrorPolicy struct {
+	// 采样率(0,1]
+	SampleRate float64 `json:"sample_rate"`
+	// 影子请求的超时时间(毫秒)，默认5秒
+	TimeoutMs int64 `json:"timeout_ms,omitempty"`
+}
+
+func (p *MirrorPolicy) Validate() error {
+	if p.SampleRate <= 0 || p.SampleRate > 1 {
+		return fmt.Errorf("sample_rate must be in (0,1]")
+	}
+	if p.TimeoutMs < 0 {
+		return fmt.Errorf("timeout_ms must not be negative")
+	}
+	return nil
+}
+
+func (p *MirrorPolicy) timeout() time.Duration {
+	if p.TimeoutMs > 0 {
+		return time.Duration(p.TimeoutMs) * time.Millisecond
+	}
+	return defaultMirrorTimeout
+}
+
+// endpointMirror 端点的影子端点组
+type endpointMirror struct {
+	*MirrorPolicy
+	lb       loadbalance.LoadBalance
+	inflight chan struct{}
+}
+
+func (m *endpointMirror) sample() bool {
+	return rand.Float64() < m.SampleRate
+}
+
+// invoke 向影子端点发起一次调用，响应被丢弃
+func (m *endpointMirror) invoke(ctx context.Context, fullMethodName string, clientIP string, req []byte) error {
+	endpoint, err := m.lb.Select(clientIP)
+	if err != nil {
+		return err
+	}
+	cn, err := endpoint.Get(ctx)
+	if err != nil {
+		return err
+	}
+	defer endpoint.AfterTransform(ctx, cn.(loadbalance.Connection))
+	conn := cn.(loadbalance.Connection).ConnInstance().(*grpc.ClientConn)
+	in := &emptypb.Empty{}
+	in.ProtoReflect().SetUnknown(req)
+	return conn.Invoke(ctx, fullMethodName, in, &emptypb.Empty{})
+}
+
+type mirrorRegistry struct {
+	mu      sync.RWMutex
+	mirrors map[string]*endpointMirror
+}
+
+func newMirrorRegistry() *mirrorRegistry {
+	return &mirrorRegistry{mirrors: make(map[string]*endpointMirror)}
+}
+
+// register 只复制一元调用，policy为nil时关闭复制
+func (r *mirrorRegistry) register(pd ProtobufDescription, policy *MirrorPolicy, lb loadbalance.LoadBalance) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var mirror *endpointMirror
+	if policy != nil && lb != nil {
+		mirror = &endpointMirror{MirrorPolicy: policy, lb: lb, inflight: make(chan struct{}, maxMirrorInflight)}
+	}
+	for _, file := range pd.GetFileDescriptorSet().GetFile() {
+		for _, service := range file.GetService() {
+			for _, method := range service.GetMethod() {
+				key := strings.ToUpper(fmt.Sprintf("/%s.%s/%s", file.GetPackage(), service.GetName(), method.GetName()))
+				delete(r.mirrors, key)
+				if mirror != nil && !method.GetClientStreaming() && !method.GetServerStreaming() {
+					r.mirrors[key] = mirror
+				}
+			}
+		}
+	}
+}
+
+func (r *mirrorRegistry) delete(pd ProtobufDescription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	walkMethods(pd, func(key string, _ string, _ string) {
+		delete(r.mirrors, key)
+	})
+}
+
+func (r *mirrorRegistry) get(fullMethod string) *endpointMirror {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !strings.HasPrefix(fullMethod, "/") {
+		fullMethod = "/" + fullMethod
+	}
+	return r.mirrors[strings.ToUpper(fullMethod)]
+}
+
+func (r *mirrorRegistry) loadBalances() []loadbalance.LoadBalance {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	lbs := make([]loadbalance.LoadBalance, 0)
+	visited := make(map[loadbalance.LoadBalance]bool)
+	for _, mirror := range r.mirrors {
+		if !visited[mirror.lb] {
+			visited[mirror.lb] = true
+			lbs = append(lbs, mirror.lb)
+		}
+	}
+	return lbs
+}
+
+// mirrorStream 记录代理的一元调用的请求内容
+type mirrorStream struct {
+	grpc.ServerStream
+	mu  sync.Mutex
+	req []byte
+}
+
+func (s *mirrorStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(*emptypb.Empty); ok {
+		s.mu.Lock()
+		if s.req == nil {
+			s.req = append([]byte{}, msg.ProtoReflect().GetUnknown()...)
+		}
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *mirrorStream) request() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.req
+}
+
+// mirror 主请求完成后异步复制到影子端点，记录两者状态码和耗时的差异
+func (g *GrpcProxy) mirror(ctx context.Context, mirror *endpointMirror, fullMethodName string, clientIP string, req []byte, primaryErr error, primaryLatency time.Duration) {
+	select {
+	case mirror.inflight <- struct{}{}:
+	default:
+		ObserveMirrorDropped(fullMethodName)
+		return
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(XMirror, "true")
+	go func() {
+		defer func() { <-mirror.inflight }()
+		ctx, cancel := context.WithTimeout(metadata.NewOutgoingContext(context.Background(), md), mirror.timeout())
+		defer cancel()
+		start := time.Now()
+		err := mirror.invoke(ctx, fullMethodName, clientIP, req)
+		ObserveMirror(fullMethodName, primaryErr, err, primaryLatency, time.Since(start))
+		if status.Code(err) != status.Code(primaryErr) {
+			Log.Warnf(ctx, "mirror of %s status mismatch, primary:%s shadow:%s", fullMethodName, status.Code(primaryErr), status.Code(err))
+		}
+	}()
+}
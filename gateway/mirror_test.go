@@ -0,0 +1,125 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type mirrorCall struct {
+	req    []byte
+	mirror string
+}
+
+// newMirrorTestProxy 启动主端点、影子端点和grpc代理，影子端点总是返回错误
+func newMirrorTestProxy(policy *CallPolicy) (*grpc.ClientConn, chan *mirrorCall, func()) {
+	primary := grpc.NewServer(grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		in := &emptypb.Empty{}
+		if err := stream.RecvMsg(in); err != nil {
+			return err
+		}
+		return stream.SendMsg(in)
+	}))
+	calls := make(chan *mirrorCall, 10)
+	shadow := grpc.NewServer(grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		in := &emptypb.Empty{}
+		if err := stream.RecvMsg(in); err != nil {
+			return err
+		}
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		calls <- &mirrorCall{req: in.ProtoReflect().GetUnknown(), mirror: strings.Join(md.Get(XMirror), ",")}
+		return status.Error(codes.Internal, "shadow error")
+	}))
+	primaryAddr := serveGrpc(primary)
+	shadowAddr := serveGrpc(shadow)
+
+	lb := NewGrpcLoadBalancer()
+	rr, _ := loadbalance.New(loadbalance.RRBalanceType, []loadbalance.Endpoint{NewGrpcEndpoint(primaryAddr, NewGrpcConnPool(primaryAddr))})
+	shadowLB, _ := loadbalance.New(loadbalance.RRBalanceType, []loadbalance.Endpoint{NewGrpcEndpoint(shadowAddr, NewGrpcConnPool(shadowAddr))})
+	key := strings.ToUpper(testRetryMethod)
+	lb.lb[key] = rr
+	lb.mirrors.mirrors[key] = &endpointMirror{MirrorPolicy: &MirrorPolicy{SampleRate: 1}, lb: shadowLB, inflight: make(chan struct{}, maxMirrorInflight)}
+	if policy != nil {
+		lb.policies.policies[key] = &methodPolicy{CallPolicy: policy, unary: true}
+	}
+	proxy := NewGrpcServer(&GrpcServerOptions{}, lb)
+	proxyAddr := serveGrpc(proxy)
+	cc, err := grpc.Dial(proxyAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	return cc, calls, func() {
+		cc.Close()
+		proxy.Stop()
+		primary.Stop()
+		shadow.Stop()
+	}
+}
+
+func TestProxyMirror(t *testing.T) {
+	retry := &RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: []string{"UNAVAILABLE"}, InitialBackoffMs: 10, MaxBackoffMs: 10, BackoffMultiplier: 1}
+	for _, policy := range []*CallPolicy{nil, {Retryable: true, Retry: retry}} {
+		c.Convey("test mirror unary request to shadow endpoints", t, func() {
+			cc, calls, stop := newMirrorTestProxy(policy)
+			defer stop()
+			before := testutil.ToFloat64(mirrorRequests.WithLabelValues(testRetryMethod, codes.OK.String(), codes.Internal.String()))
+			out := &wrapperspb.StringValue{}
+			err := cc.Invoke(context.Background(), testRetryMethod, wrapperspb.String("begonia"), out)
+			// 影子端点的错误不影响响应
+			c.So(err, c.ShouldBeNil)
+			c.So(out.Value, c.ShouldEqual, "begonia")
+			select {
+			case call := <-calls:
+				req := &wrapperspb.StringValue{}
+				c.So(proto.Unmarshal(call.req, req), c.ShouldBeNil)
+				c.So(req.Value, c.ShouldEqual, "begonia")
+				c.So(call.mirror, c.ShouldEqual, "true")
+			case <-time.After(3 * time.Second):
+				t.Fatal("mirror request not received")
+			}
+			deadline := time.Now().Add(3 * time.Second)
+			for testutil.ToFloat64(mirrorRequests.WithLabelValues(testRetryMethod, codes.OK.String(), codes.Internal.String())) == before && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			c.So(testutil.ToFloat64(mirrorRequests.WithLabelValues(testRetryMethod, codes.OK.String(), codes.Internal.String())), c.ShouldEqual, before+1)
+		})
+	}
+}
+
+func TestMirrorRegistry(t *testing.T) {
+	c.Convey("test mirror registry", t, func() {
+		c.So((&MirrorPolicy{SampleRate: 0}).Validate(), c.ShouldNotBeNil)
+		c.So((&MirrorPolicy{SampleRate: 1.5}).Validate(), c.ShouldNotBeNil)
+		c.So((&MirrorPolicy{SampleRate: 0.1, TimeoutMs: -1}).Validate(), c.ShouldNotBeNil)
+		c.So((&MirrorPolicy{SampleRate: 0.1}).timeout(), c.ShouldEqual, defaultMirrorTimeout)
+
+		pd := newTransformTestDescription()
+		shadow, _ := loadbalance.New(loadbalance.RRBalanceType, []loadbalance.Endpoint{NewGrpcEndpoint("127.0.0.1:3003", nil)})
+		lb := NewGrpcLoadBalancer()
+		lb.RegisterMirror(pd, &MirrorPolicy{SampleRate: 1}, shadow)
+		// 只复制一元调用
+		c.So(lb.mirrors.get("/helloworld.Greeter/SayHello"), c.ShouldNotBeNil)
+		c.So(lb.mirrors.get("/helloworld.Greeter/SayHelloServerSideEvent"), c.ShouldBeNil)
+		c.So(lb.mirrors.get("/helloworld.Greeter/SayHello").sample(), c.ShouldBeTrue)
+		c.So(len(lb.mirrors.loadBalances()), c.ShouldEqual, 1)
+
+		lb.RegisterMirror(pd, nil, nil)
+		c.So(lb.mirrors.get("/helloworld.Greeter/SayHello"), c.ShouldBeNil)
+		lb.RegisterMirror(pd, &MirrorPolicy{SampleRate: 1}, shadow)
+		lb.Delete(pd)
+		c.So(lb.mirrors.get("/helloworld.Greeter/SayHello"), c.ShouldBeNil)
+	})
+}
@@ -0,0 +1,545 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	openAPIVersion = "3.0.3"
+	// OpenAPILocalServices 网关内置服务在文档中的id
+	OpenAPILocalServices = "begonia"
+)
+
+type OpenAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       *OpenAPIInfo               `json:"info"`
+	Tags       []*OpenAPITag              `json:"tags,omitempty"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components *OpenAPIComponents         `json:"components"`
+}
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type OpenAPITag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIPathItem http方法(小写)到操作的映射
+type OpenAPIPathItem map[string]*OpenAPIOperation
+
+type OpenAPIOperation struct {
+	Tags        []string                    `json:"tags,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	OperationID string                      `json:"operationId"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+	// 网关的传输方式:sse,websocket,client_stream
+	Transport string `json:"x-begonia-transport,omitempty"`
+	// 流式接口的输入输出消息
+	Messages map[string]*OpenAPISchema `json:"x-begonia-messages,omitempty"`
+}
+
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIRequestBody struct {
+	Description string                       `json:"description,omitempty"`
+	Required    bool                         `json:"required,omitempty"`
+	Content     map[string]*OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Headers     map[string]*OpenAPIHeader    `json:"headers,omitempty"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIHeader struct {
+	Description string         `json:"description,omitempty"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas"`
+}
+
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Enum                 []interface{}             `json:"enum,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// OpenAPIService 注册到文档中的服务
+type OpenAPIService struct {
+	// 端点id
+	ID   string
+	Tags []string
+	Pd   ProtobufDescription
+	// 响应是否被网关包装为{code,message,data}格式
+	WrapResponse bool
+	// 只生成指定方法的文档，为空时生成描述文件中的所有方法
+	Methods []string
+	items   []*HttpEndpointItem
+}
+
+// OpenAPIGenerator 根据已注册端点的描述文件和gateway.json生成OpenAPI 3文档，
+// 端点新增、更新、删除时同步更新
+type OpenAPIGenerator struct {
+	mu       sync.RWMutex
+	info     *OpenAPIInfo
+	services map[string]*OpenAPIService
+}
+
+func NewOpenAPIGenerator(info *OpenAPIInfo) *OpenAPIGenerator {
+	if info == nil {
+		info = &OpenAPIInfo{Title: "begonia gateway", Version: "v1"}
+	}
+	return &OpenAPIGenerator{
+		info:     info,
+		services: make(map[string]*OpenAPIService),
+	}
+}
+
+func (o *OpenAPIGenerator) Register(srv *OpenAPIService) error {
+	items, err := loadHttpEndpointItem(srv.Pd, srv.Pd.GetGatewayJsonSchema())
+	if err != nil {
+		return err
+	}
+	if len(srv.Methods) > 0 {
+		methods := make(map[string]bool)
+		for _, method := range srv.Methods {
+			methods[method] = true
+		}
+		filtered := make([]*HttpEndpointItem, 0, len(items))
+		for _, item := range items {
+			if methods[item.FullMethodName] {
+				filtered = append(filtered, item)
+			}
+		}
+		items = filtered
+	}
+	srv.items = items
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.services[srv.ID] = srv
+	return nil
+}
+
+func (o *OpenAPIGenerator) Delete(id string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.services, id)
+}
+
+// Generate 生成文档，ids和tags不为空时只包含匹配的端点
+func (o *OpenAPIGenerator) Generate(ids []string, tags []string) *OpenAPIDocument {
+	o.mu.RLock()
+	services := make([]*OpenAPIService, 0, len(o.services))
+	for _, srv := range o.services {
+		if openAPIMatch(srv, ids, tags) {
+			services = append(services, srv)
+		}
+	}
+	o.mu.RUnlock()
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID < services[j].ID
+	})
+	builder := &openAPIBuilder{
+		doc: &OpenAPIDocument{
+			OpenAPI:    openAPIVersion,
+			Info:       o.info,
+			Paths:      make(map[string]OpenAPIPathItem),
+			Components: &OpenAPIComponents{Schemas: make(map[string]*OpenAPISchema)},
+		},
+		tags: make(map[string]bool),
+	}
+	builder.addErrorSchema()
+	for _, srv := range services {
+		for _, item := range srv.items {
+			builder.addOperation(srv, item)
+		}
+	}
+	sort.Slice(builder.doc.Tags, func(i, j int) bool {
+		return builder.doc.Tags[i].Name < builder.doc.Tags[j].Name
+	})
+	return builder.doc
+}
+
+// ServeHTTP 输出文档，支持通过id和tag查询参数过滤
+func (o *OpenAPIGenerator) ServeHTTP(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	query := r.URL.Query()
+	doc := o.Generate(splitQuery(query["id"]), splitQuery(query["tag"]))
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(doc)
+}
+
+func splitQuery(values []string) []string {
+	ret := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				ret = append(ret, v)
+			}
+		}
+	}
+	return ret
+}
+
+func openAPIMatch(srv *OpenAPIService, ids []string, tags []string) bool {
+	if len(ids) > 0 {
+		matched := false
+		for _, id := range ids {
+			if id == srv.ID {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(tags) > 0 {
+		for _, tag := range tags {
+			for _, t := range srv.Tags {
+				if tag == t {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return true
+}
+
+type openAPIBuilder struct {
+	doc  *OpenAPIDocument
+	tags map[string]bool
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+// openAPIPath 将{name=**}形式的路径参数转换为{name}
+func openAPIPath(uri string) string {
+	if idx := strings.LastIndex(uri, ":"); idx > strings.LastIndex(uri, "}") && idx > strings.LastIndex(uri, "/") {
+		// 保留自定义动词
+		return pathParamPattern.ReplaceAllString(uri[:idx], "{$1}") + uri[idx:]
+	}
+	return pathParamPattern.ReplaceAllString(uri, "{$1}")
+}
+
+func (b *openAPIBuilder) addOperation(srv *OpenAPIService, item *HttpEndpointItem) {
+	if item.In == nil || item.Out == nil {
+		return
+	}
+	fullMethod := strings.TrimPrefix(item.FullMethodName, "/")
+	service, method := fullMethod, fullMethod
+	if idx := strings.LastIndex(fullMethod, "/"); idx > 0 {
+		service, method = fullMethod[:idx], fullMethod[idx+1:]
+	}
+	if !b.tags[service] {
+		b.tags[service] = true
+		b.doc.Tags = append(b.doc.Tags, &OpenAPITag{Name: service, Description: fmt.Sprintf("endpoint %s", srv.ID)})
+	}
+	op := &OpenAPIOperation{
+		Tags:        []string{service},
+		Summary:     method,
+		OperationID: strings.ReplaceAll(fullMethod, "/", "."),
+		Responses:   make(map[string]*OpenAPIResponse),
+	}
+	httpMethod := strings.ToLower(item.HttpMethod)
+	pathParams := make(map[string]bool)
+	for _, name := range pathParamPattern.FindAllStringSubmatch(item.HttpUri, -1) {
+		pathParams[name[1]] = true
+		op.Parameters = append(op.Parameters, &OpenAPIParameter{
+			Name:     name[1],
+			In:       "path",
+			Required: true,
+			Schema:   b.fieldPathSchema(item.In, name[1]),
+		})
+	}
+	in := b.messageSchema(item.In)
+	out := b.messageSchema(item.Out)
+	switch {
+	case item.IsClientStream && item.IsServerStream:
+		// 双向流升级为websocket
+		op.Transport = "websocket"
+		op.Description = "Bidirectional stream over WebSocket, each frame is a JSON encoded message."
+		op.Messages = map[string]*OpenAPISchema{"request": in, "response": out}
+		op.Parameters = append(op.Parameters,
+			&OpenAPIParameter{Name: "Connection", In: "header", Required: true, Schema: &OpenAPISchema{Type: "string", Enum: []interface{}{"Upgrade"}}},
+			&OpenAPIParameter{Name: "Upgrade", In: "header", Required: true, Schema: &OpenAPISchema{Type: "string", Enum: []interface{}{"websocket"}}},
+		)
+		op.Responses["101"] = &OpenAPIResponse{Description: "Switching Protocols"}
+	case item.IsClientStream:
+		op.Transport = "client_stream"
+		op.Description = "Client stream, the request body is a sequence of JSON encoded messages each prefixed with a 4 byte big endian length."
+		op.Messages = map[string]*OpenAPISchema{"request": in}
+		op.RequestBody = &OpenAPIRequestBody{
+			Required: true,
+			Content: map[string]*OpenAPIMediaType{
+				ClientStreamContentType: {Schema: &OpenAPISchema{Type: "string", Format: "binary"}},
+			},
+		}
+		op.Responses["200"] = b.response(srv, item.Out, out)
+	case item.IsServerStream:
+		op.Transport = "sse"
+		op.Description = "Server stream over Server-Sent Events, each event data is a JSON encoded message."
+		op.Messages = map[string]*OpenAPISchema{"response": out}
+		b.addRequest(op, httpMethod, item.In, in, pathParams)
+		op.Responses["200"] = &OpenAPIResponse{
+			Description: "A stream of server-sent events",
+			Content: map[string]*OpenAPIMediaType{
+				"text/event-stream": {Schema: &OpenAPISchema{Type: "string"}},
+			},
+		}
+	default:
+		b.addRequest(op, httpMethod, item.In, in, pathParams)
+		op.Responses["200"] = b.response(srv, item.Out, out)
+	}
+	op.Responses["default"] = &OpenAPIResponse{
+		Description: "Error response",
+		Content: map[string]*OpenAPIMediaType{
+			"application/json": {Schema: &OpenAPISchema{Ref: schemaRef(openAPIErrorSchema)}},
+		},
+	}
+	path := openAPIPath(item.HttpUri)
+	if _, ok := b.doc.Paths[path]; !ok {
+		b.doc.Paths[path] = make(OpenAPIPathItem)
+	}
+	b.doc.Paths[path][httpMethod] = op
+}
+
+// addRequest 没有请求体的方法使用查询参数，其他方法支持json和表单
+func (b *openAPIBuilder) addRequest(op *OpenAPIOperation, httpMethod string, desc protoreflect.MessageDescriptor, schema *OpenAPISchema, pathParams map[string]bool) {
+	switch httpMethod {
+	case "get", "delete", "head", "options":
+		fields := desc.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			if pathParams[string(field.Name())] || pathParams[field.JSONName()] {
+				continue
+			}
+			if field.Kind() == protoreflect.MessageKind && !isScalarWellKnown(field.Message()) {
+				continue
+			}
+			op.Parameters = append(op.Parameters, &OpenAPIParameter{
+				Name:   string(field.Name()),
+				In:     "query",
+				Schema: b.fieldSchema(field),
+			})
+		}
+		return
+	}
+	if isHttpBody(desc) {
+		op.RequestBody = &OpenAPIRequestBody{
+			Content: map[string]*OpenAPIMediaType{"*/*": {Schema: &OpenAPISchema{Type: "string", Format: "binary"}}},
+		}
+		return
+	}
+	op.RequestBody = &OpenAPIRequestBody{
+		Content: map[string]*OpenAPIMediaType{
+			"application/json":                  {Schema: schema},
+			"multipart/form-data":               {Schema: schema},
+			"application/x-www-form-urlencoded": {Schema: schema},
+		},
+	}
+}
+
+func (b *openAPIBuilder) response(srv *OpenAPIService, desc protoreflect.MessageDescriptor, schema *OpenAPISchema) *OpenAPIResponse {
+	if isHttpBody(desc) {
+		return &OpenAPIResponse{
+			Description: "Raw http body",
+			Content:     map[string]*OpenAPIMediaType{"*/*": {Schema: &OpenAPISchema{Type: "string", Format: "binary"}}},
+		}
+	}
+	if srv.WrapResponse {
+		schema = &OpenAPISchema{
+			Type: "object",
+			Properties: map[string]*OpenAPISchema{
+				"code":    {Type: "integer", Format: "int32"},
+				"message": {Type: "string"},
+				"data":    schema,
+			},
+		}
+	}
+	return &OpenAPIResponse{
+		Description: "OK",
+		Content:     map[string]*OpenAPIMediaType{"application/json": {Schema: schema}},
+	}
+}
+
+const openAPIErrorSchema = "begonia.HttpResponse"
+
+func (b *openAPIBuilder) addErrorSchema() {
+	b.doc.Components.Schemas[openAPIErrorSchema] = &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"code":    {Type: "integer", Format: "int32"},
+			"message": {Type: "string"},
+			"data":    {Type: "object"},
+		},
+	}
+}
+
+func schemaRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+func isHttpBody(desc protoreflect.MessageDescriptor) bool {
+	return desc.FullName() == "google.api.HttpBody"
+}
+
+// isScalarWellKnown json中表示为标量的well known类型
+func isScalarWellKnown(desc protoreflect.MessageDescriptor) bool {
+	return wellKnownSchema(desc) != nil && wellKnownSchema(desc).Type != "object" && wellKnownSchema(desc).Type != "array"
+}
+
+func wellKnownSchema(desc protoreflect.MessageDescriptor) *OpenAPISchema {
+	switch desc.FullName() {
+	case "google.protobuf.Timestamp":
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case "google.protobuf.Duration":
+		return &OpenAPISchema{Type: "string", Description: "Duration in seconds with up to nine fractional digits, suffixed with s"}
+	case "google.protobuf.FieldMask":
+		return &OpenAPISchema{Type: "string", Description: "Comma separated field paths"}
+	case "google.protobuf.Struct", "google.protobuf.Empty", "google.protobuf.Any":
+		return &OpenAPISchema{Type: "object"}
+	case "google.protobuf.Value":
+		return &OpenAPISchema{Description: "Any JSON value"}
+	case "google.protobuf.ListValue":
+		return &OpenAPISchema{Type: "array", Items: &OpenAPISchema{}}
+	case "google.protobuf.StringValue":
+		return &OpenAPISchema{Type: "string"}
+	case "google.protobuf.BytesValue":
+		return &OpenAPISchema{Type: "string", Format: "byte"}
+	case "google.protobuf.BoolValue":
+		return &OpenAPISchema{Type: "boolean"}
+	case "google.protobuf.Int32Value", "google.protobuf.UInt32Value":
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+	case "google.protobuf.Int64Value", "google.protobuf.UInt64Value":
+		return &OpenAPISchema{Type: "string", Format: "int64"}
+	case "google.protobuf.FloatValue":
+		return &OpenAPISchema{Type: "number", Format: "float"}
+	case "google.protobuf.DoubleValue":
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	}
+	return nil
+}
+
+// messageSchema 将消息注册到components中并返回引用
+func (b *openAPIBuilder) messageSchema(desc protoreflect.MessageDescriptor) *OpenAPISchema {
+	if schema := wellKnownSchema(desc); schema != nil {
+		return schema
+	}
+	name := string(desc.FullName())
+	if _, ok := b.doc.Components.Schemas[name]; !ok {
+		schema := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+		// 先占位，避免递归引用时死循环
+		b.doc.Components.Schemas[name] = schema
+		fields := desc.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			schema.Properties[field.JSONName()] = b.fieldSchema(field)
+		}
+	}
+	return &OpenAPISchema{Ref: schemaRef(name)}
+}
+
+func (b *openAPIBuilder) fieldSchema(field protoreflect.FieldDescriptor) *OpenAPISchema {
+	if field.IsMap() {
+		return &OpenAPISchema{Type: "object", AdditionalProperties: b.singularSchema(field.MapValue())}
+	}
+	schema := b.singularSchema(field)
+	if field.IsList() {
+		return &OpenAPISchema{Type: "array", Items: schema}
+	}
+	return schema
+}
+
+func (b *openAPIBuilder) singularSchema(field protoreflect.FieldDescriptor) *OpenAPISchema {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return &OpenAPISchema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &OpenAPISchema{Type: "integer", Format: "uint32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// protojson将64位整数编码为字符串
+		return &OpenAPISchema{Type: "string", Format: "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &OpenAPISchema{Type: "string", Format: "uint64"}
+	case protoreflect.FloatKind:
+		return &OpenAPISchema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	case protoreflect.StringKind:
+		return &OpenAPISchema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &OpenAPISchema{Type: "string", Format: "byte"}
+	case protoreflect.EnumKind:
+		// 网关使用枚举的数字值
+		values := field.Enum().Values()
+		enum := make([]interface{}, 0, values.Len())
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			enum = append(enum, int32(values.Get(i).Number()))
+			names = append(names, fmt.Sprintf("%d:%s", values.Get(i).Number(), values.Get(i).Name()))
+		}
+		return &OpenAPISchema{Type: "integer", Format: "int32", Enum: enum, Description: strings.Join(names, ",")}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return b.messageSchema(field.Message())
+	}
+	return &OpenAPISchema{}
+}
+
+// fieldPathSchema 路径参数的类型，支持a.b形式的嵌套字段
+func (b *openAPIBuilder) fieldPathSchema(desc protoreflect.MessageDescriptor, path string) *OpenAPISchema {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		field := desc.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			field = desc.Fields().ByJSONName(name)
+		}
+		if field == nil {
+			break
+		}
+		if i == len(names)-1 && field.Kind() != protoreflect.MessageKind {
+			return b.singularSchema(field)
+		}
+		if field.Kind() != protoreflect.MessageKind {
+			break
+		}
+		desc = field.Message()
+	}
+	return &OpenAPISchema{Type: "string"}
+}
@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	c "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOpenAPIGenerator(t *testing.T) {
+	c.Convey("test openapi generator", t, func() {
+		_, filename, _, _ := runtime.Caller(0)
+		pb, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(filename)), "testdata", "helloworld.pb"))
+		c.So(err, c.ShouldBeNil)
+		pd, err := NewDescriptionFromBinary(pb, filepath.Join("tmp", "test-openapi"))
+		c.So(err, c.ShouldBeNil)
+		defer os.RemoveAll(filepath.Join("tmp", "test-openapi"))
+
+		g := NewOpenAPIGenerator(nil)
+		c.So(g.Register(&OpenAPIService{ID: "hello", Tags: []string{"example"}, Pd: pd}), c.ShouldBeNil)
+		doc := g.Generate(nil, nil)
+		c.So(doc.OpenAPI, c.ShouldEqual, "3.0.3")
+		c.So(doc.Tags[0].Name, c.ShouldEqual, "helloworld.Greeter")
+
+		// 路径参数
+		op := doc.Paths["/api/v1/example/{name}"]["get"]
+		c.So(op, c.ShouldNotBeNil)
+		c.So(op.OperationID, c.ShouldEqual, "helloworld.Greeter.SayHelloGet")
+		c.So(op.Parameters[0].In, c.ShouldEqual, "path")
+		c.So(op.Parameters[0].Name, c.ShouldEqual, "name")
+		c.So(op.Responses["200"].Content["application/json"].Schema.Ref, c.ShouldEqual, "#/components/schemas/helloworld.HelloReply")
+
+		// 请求体支持json和表单
+		op = doc.Paths["/api/v1/example/post"]["post"]
+		c.So(op.RequestBody.Content, c.ShouldContainKey, "application/json")
+		c.So(op.RequestBody.Content, c.ShouldContainKey, "multipart/form-data")
+		c.So(op.RequestBody.Content, c.ShouldContainKey, "application/x-www-form-urlencoded")
+
+		// 流式接口
+		op = doc.Paths["/api/v1/example/server/sse/{name}"]["get"]
+		c.So(op.Transport, c.ShouldEqual, "sse")
+		c.So(op.Responses["200"].Content, c.ShouldContainKey, "text/event-stream")
+		op = doc.Paths["/api/v1/example/server/websocket"]["get"]
+		c.So(op.Transport, c.ShouldEqual, "websocket")
+		c.So(op.Responses, c.ShouldContainKey, "101")
+		c.So(op.Messages["request"].Ref, c.ShouldEqual, "#/components/schemas/helloworld.HelloRequest")
+		op = doc.Paths["/api/v1/example/client/stream"]["post"]
+		c.So(op.Transport, c.ShouldEqual, "client_stream")
+		c.So(op.RequestBody.Content, c.ShouldContainKey, ClientStreamContentType)
+
+		c.So(doc.Components.Schemas, c.ShouldContainKey, "helloworld.HelloRequest")
+		c.So(doc.Components.Schemas["helloworld.HelloRequest"].Properties, c.ShouldContainKey, "name")
+
+		// 内置服务的响应被包装
+		c.So(g.Register(&OpenAPIService{ID: OpenAPILocalServices, Pd: pd, WrapResponse: true, Methods: []string{"/helloworld.Greeter/SayHelloGet"}}), c.ShouldBeNil)
+		doc = g.Generate([]string{OpenAPILocalServices}, nil)
+		c.So(doc.Paths, c.ShouldHaveLength, 1)
+		schema := doc.Paths["/api/v1/example/{name}"]["get"].Responses["200"].Content["application/json"].Schema
+		c.So(schema.Properties["data"].Ref, c.ShouldEqual, "#/components/schemas/helloworld.HelloReply")
+
+		// 按tag过滤
+		c.So(g.Generate(nil, []string{"example"}).Paths, c.ShouldHaveLength, 7)
+		c.So(g.Generate(nil, []string{"unknown"}).Paths, c.ShouldBeEmpty)
+
+		w := httptest.NewRecorder()
+		g.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json?id=hello,begonia", nil), nil)
+		c.So(w.Code, c.ShouldEqual, http.StatusOK)
+		doc = &OpenAPIDocument{}
+		c.So(json.Unmarshal(w.Body.Bytes(), doc), c.ShouldBeNil)
+		c.So(doc.Paths, c.ShouldHaveLength, 7)
+
+		g.Delete("hello")
+		c.So(g.Generate([]string{"hello"}, nil).Paths, c.ShouldBeEmpty)
+		c.So(openAPIPath("/v1/{name=projects/*}:cancel"), c.ShouldEqual, "/v1/{name}:cancel")
+	})
+}
@@ -0,0 +1,199 @@
+package gateway
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// grpc对重试和对冲的最大尝试次数限制为5
+const maxCallAttempts = 5
+
+// CallPolicy 方法调用策略
+type CallPolicy struct {
+	// 默认超时时间(毫秒)，仅在请求没有设置deadline时生效
+	TimeoutMs int64 `json:"timeout_ms,omitempty"`
+	// 非幂等的方法需要显式标记为可重试才会进行重试或对冲
+	Retryable bool           `json:"retryable,omitempty"`
+	Retry     *RetryPolicy   `json:"retry,omitempty"`
+	Hedging   *HedgingPolicy `json:"hedging,omitempty"`
+}
+
+type RetryPolicy struct {
+	MaxAttempts          int      `json:"max_attempts"`
+	RetryableStatusCodes []string `json:"retryable_status_codes"`
+	InitialBackoffMs     int64    `json:"initial_backoff_ms"`
+	MaxBackoffMs         int64    `json:"max_backoff_ms"`
+	BackoffMultiplier    float64  `json:"backoff_multiplier"`
+}
+
+type HedgingPolicy struct {
+	MaxAttempts         int      `json:"max_attempts"`
+	HedgingDelayMs      int64    `json:"hedging_delay_ms"`
+	NonFatalStatusCodes []string `json:"non_fatal_status_codes"`
+}
+
+// EndpointPolicy 端点的调用策略，
+// 键为"*"(所有方法)、"package.Service"(服务)或"package.Service/Method"(方法)，
+// 匹配时方法优先于服务，服务优先于"*"
+type EndpointPolicy map[string]*CallPolicy
+
+func parseStatusCode(name string) (codes.Code, error) {
+	if v, err := strconv.Atoi(name); err == nil {
+		return codes.Code(v), nil
+	}
+	var code codes.Code
+	err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(name))))
+	if err != nil {
+		return codes.Unknown, fmt.Errorf("unknown status code %s", name)
+	}
+	return code, nil
+}
+
+func containsStatusCode(names []string, code codes.Code) bool {
+	for _, name := range names {
+		if c, err := parseStatusCode(name); err == nil && c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (p EndpointPolicy) Validate() error {
+	for key, policy := range p {
+		if policy == nil {
+			continue
+		}
+		if policy.TimeoutMs < 0 {
+			return fmt.Errorf("%s:timeout_ms must not be negative", key)
+		}
+		if policy.Retry != nil && policy.Hedging != nil {
+			return fmt.Errorf("%s:retry and hedging can not be set at the same time", key)
+		}
+		if retry := policy.Retry; retry != nil {
+			if retry.MaxAttempts < 2 || retry.MaxAttempts > maxCallAttempts {
+				return fmt.Errorf("%s:retry.max_attempts must be in [2,%d]", key, maxCallAttempts)
+			}
+			if len(retry.RetryableStatusCodes) == 0 {
+				return fmt.Errorf("%s:retry.retryable_status_codes is required", key)
+			}
+			for _, name := range retry.RetryableStatusCodes {
+				if _, err := parseStatusCode(name); err != nil {
+					return fmt.Errorf("%s:%w", key, err)
+				}
+			}
+			if retry.InitialBackoffMs <= 0 || retry.MaxBackoffMs < retry.InitialBackoffMs || retry.BackoffMultiplier < 1 {
+				return fmt.Errorf("%s:invalid retry backoff", key)
+			}
+		}
+		if hedging := policy.Hedging; hedging != nil {
+			if hedging.MaxAttempts < 2 || hedging.MaxAttempts > maxCallAttempts {
+				return fmt.Errorf("%s:hedging.max_attempts must be in [2,%d]", key, maxCallAttempts)
+			}
+			if hedging.HedgingDelayMs < 0 {
+				return fmt.Errorf("%s:hedging.hedging_delay_ms must not be negative", key)
+			}
+			for _, name := range hedging.NonFatalStatusCodes {
+				if _, err := parseStatusCode(name); err != nil {
+					return fmt.Errorf("%s:%w", key, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// lookup 按方法、服务、全局的顺序匹配策略
+func (p EndpointPolicy) lookup(service, method string) *CallPolicy {
+	if policy, ok := p[fmt.Sprintf("%s/%s", service, method)]; ok {
+		return policy
+	}
+	if policy, ok := p[service]; ok {
+		return policy
+	}
+	return p["*"]
+}
+
+func (p *CallPolicy) timeout() time.Duration {
+	return time.Duration(p.TimeoutMs) * time.Millisecond
+}
+
+func (r *RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := float64(r.InitialBackoffMs)
+	for i := 1; i < attempt; i++ {
+		backoff *= r.BackoffMultiplier
+	}
+	if backoff > float64(r.MaxBackoffMs) {
+		backoff = float64(r.MaxBackoffMs)
+	}
+	return time.Duration(backoff) * time.Millisecond
+}
+
+// isIdempotentHttpMethod 幂等的http请求方法
+func isIdempotentHttpMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+type methodPolicy struct {
+	*CallPolicy
+	// 只有一元调用支持重试和对冲
+	unary bool
+}
+
+type policyRegistry struct {
+	mu       sync.RWMutex
+	policies map[string]*methodPolicy
+}
+
+func newPolicyRegistry() *policyRegistry {
+	return &policyRegistry{policies: make(map[string]*methodPolicy)}
+}
+
+func (r *policyRegistry) register(pd ProtobufDescription, policy EndpointPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.walk(pd, func(key string, service string, method string, unary bool) {
+		delete(r.policies, key)
+		if p := policy.lookup(service, method); p != nil {
+			r.policies[key] = &methodPolicy{CallPolicy: p, unary: unary}
+		}
+	})
+}
+
+func (r *policyRegistry) delete(pd ProtobufDescription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.walk(pd, func(key string, _ string, _ string, _ bool) {
+		delete(r.policies, key)
+	})
+}
+
+func (r *policyRegistry) walk(pd ProtobufDescription, fn func(key string, service string, method string, unary bool)) {
+	fds := pd.GetFileDescriptorSet()
+	for _, file := range fds.GetFile() {
+		for _, service := range file.GetService() {
+			serviceName := fmt.Sprintf("%s.%s", file.GetPackage(), service.GetName())
+			for _, method := range service.GetMethod() {
+				key := strings.ToUpper(fmt.Sprintf("/%s/%s", serviceName, method.GetName()))
+				fn(key, serviceName, method.GetName(), !method.GetClientStreaming() && !method.GetServerStreaming())
+			}
+		}
+	}
+}
+
+func (r *policyRegistry) get(fullMethod string) *methodPolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !strings.HasPrefix(fullMethod, "/") {
+		fullMethod = "/" + fullMethod
+	}
+	return r.policies[strings.ToUpper(fullMethod)]
+}
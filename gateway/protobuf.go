@@ -176,6 +176,31 @@ func NewDescriptionFromBinary(data []byte, outDir string) (ProtobufDescription,
 	desc.gatewayJsonSchema = filepath.Join(outDir, "gateway.json")
 	return desc, nil
 }
+
+// NewDescriptionFromFileDescriptor 使用编译到程序中的描述文件及其依赖创建描述，
+// 用于描述文件不在本地api描述文件中的内置服务
+func NewDescriptionFromFileDescriptor(fd protoreflect.FileDescriptor, outDir string) (ProtobufDescription, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	add(fd)
+	data, err := proto.Marshal(set)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal file descriptor set: %w", err)
+	}
+	return NewDescriptionFromBinary(data, outDir)
+}
 func (p *protobufDescription) GetFileDescriptorSet() *descriptorpb.FileDescriptorSet {
 	return p.fileDescriptorSet
 }
@@ -0,0 +1,262 @@
+package gateway
+
+import (
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	v1reflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	v1alphareflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type reflectionSource struct {
+	files *protoregistry.Files
+	types *protoregistry.Types
+}
+
+// ReflectionRegistry 聚合网关内置服务和所有代理端点的描述文件，
+// 为grpc reflection提供服务列表、描述文件和扩展的查询
+type ReflectionRegistry struct {
+	mu     sync.RWMutex
+	server *grpc.Server
+	// 内置服务的描述文件，只用于查询描述信息，服务列表以grpc server注册的服务为准
+	local map[ProtobufDescription]*reflectionSource
+	// 代理服务的全名到描述文件的映射
+	services map[string]*reflectionSource
+}
+
+func NewReflectionRegistry(server *grpc.Server) *ReflectionRegistry {
+	return &ReflectionRegistry{
+		server:   server,
+		local:    make(map[ProtobufDescription]*reflectionSource),
+		services: make(map[string]*reflectionSource),
+	}
+}
+
+// RegisterReflection 在grpc server上注册v1和v1alpha版本的reflection服务
+func RegisterReflection(server *grpc.Server, registry *ReflectionRegistry) {
+	opts := reflection.ServerOptions{
+		Services:           registry,
+		DescriptorResolver: registry,
+		ExtensionResolver:  registry,
+	}
+	v1reflectiongrpc.RegisterServerReflectionServer(server, reflection.NewServerV1(opts))
+	v1alphareflectiongrpc.RegisterServerReflectionServer(server, reflection.NewServer(opts))
+}
+
+func newReflectionSource(pd ProtobufDescription) (*reflectionSource, error) {
+	files, err := protodesc.NewFiles(pd.GetFileDescriptorSet())
+	if err != nil {
+		return nil, fmt.Errorf("new reflection files error:%w", err)
+	}
+	types := new(protoregistry.Types)
+	var rangeErr error
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		rangeErr = registerExtensions(types, fd.Extensions(), fd.Messages())
+		return rangeErr == nil
+	})
+	if rangeErr != nil {
+		return nil, fmt.Errorf("register reflection extensions error:%w", rangeErr)
+	}
+	return &reflectionSource{files: files, types: types}, nil
+}
+
+func registerExtensions(types *protoregistry.Types, xds protoreflect.ExtensionDescriptors, mds protoreflect.MessageDescriptors) error {
+	for i := 0; i < xds.Len(); i++ {
+		if err := types.RegisterExtension(dynamicpb.NewExtensionType(xds.Get(i))); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		if err := registerExtensions(types, md.Extensions(), md.Messages()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serviceNames 描述文件中定义的所有服务
+func serviceNames(set *descriptorpb.FileDescriptorSet) []string {
+	names := make([]string, 0)
+	for _, file := range set.GetFile() {
+		for _, srv := range file.GetService() {
+			if file.GetPackage() == "" {
+				names = append(names, srv.GetName())
+				continue
+			}
+			names = append(names, fmt.Sprintf("%s.%s", file.GetPackage(), srv.GetName()))
+		}
+	}
+	return names
+}
+
+// Register 注册代理端点的描述文件
+func (r *ReflectionRegistry) Register(pd ProtobufDescription) error {
+	source, err := newReflectionSource(pd)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range serviceNames(pd.GetFileDescriptorSet()) {
+		r.services[name] = source
+	}
+	return nil
+}
+
+// RegisterLocal 注册内置服务的描述文件
+func (r *ReflectionRegistry) RegisterLocal(pd ProtobufDescription) error {
+	r.mu.RLock()
+	_, ok := r.local[pd]
+	r.mu.RUnlock()
+	if ok {
+		return nil
+	}
+	source, err := newReflectionSource(pd)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.local[pd] = source
+	return nil
+}
+
+// Delete 删除代理端点的服务
+func (r *ReflectionRegistry) Delete(pd ProtobufDescription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range serviceNames(pd.GetFileDescriptorSet()) {
+		delete(r.services, name)
+	}
+}
+
+// sources 所有的描述文件，优先使用内置服务的描述文件
+func (r *ReflectionRegistry) sources() []*reflectionSource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sources := make([]*reflectionSource, 0, len(r.local)+len(r.services))
+	seen := make(map[*reflectionSource]bool)
+	for _, source := range r.local {
+		sources = append(sources, source)
+	}
+	for _, source := range r.services {
+		if !seen[source] {
+			seen[source] = true
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// GetServiceInfo 内置服务和代理服务的并集
+func (r *ReflectionRegistry) GetServiceInfo() map[string]grpc.ServiceInfo {
+	info := make(map[string]grpc.ServiceInfo)
+	if r.server != nil {
+		for name, srv := range r.server.GetServiceInfo() {
+			info[name] = srv
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for name, source := range r.services {
+		if _, ok := info[name]; ok {
+			continue
+		}
+		desc, err := source.files.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			continue
+		}
+		sd, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			continue
+		}
+		methods := make([]grpc.MethodInfo, 0, sd.Methods().Len())
+		for i := 0; i < sd.Methods().Len(); i++ {
+			method := sd.Methods().Get(i)
+			methods = append(methods, grpc.MethodInfo{
+				Name:           string(method.Name()),
+				IsClientStream: method.IsStreamingClient(),
+				IsServerStream: method.IsStreamingServer(),
+			})
+		}
+		info[name] = grpc.ServiceInfo{Methods: methods, Metadata: sd.ParentFile().Path()}
+	}
+	return info
+}
+
+func (r *ReflectionRegistry) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	for _, source := range r.sources() {
+		if fd, err := source.files.FindFileByPath(path); err == nil {
+			return fd, nil
+		}
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r *ReflectionRegistry) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	// 服务和方法优先从其所属端点的描述文件中查找
+	r.mu.RLock()
+	source, ok := r.services[string(name)]
+	if !ok {
+		source, ok = r.services[string(name.Parent())]
+	}
+	r.mu.RUnlock()
+	if ok {
+		if desc, err := source.files.FindDescriptorByName(name); err == nil {
+			return desc, nil
+		}
+	}
+	for _, source := range r.sources() {
+		if desc, err := source.files.FindDescriptorByName(name); err == nil {
+			return desc, nil
+		}
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+func (r *ReflectionRegistry) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	for _, source := range r.sources() {
+		if xt, err := source.types.FindExtensionByName(field); err == nil {
+			return xt, nil
+		}
+	}
+	return protoregistry.GlobalTypes.FindExtensionByName(field)
+}
+
+func (r *ReflectionRegistry) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	for _, source := range r.sources() {
+		if xt, err := source.types.FindExtensionByNumber(message, field); err == nil {
+			return xt, nil
+		}
+	}
+	return protoregistry.GlobalTypes.FindExtensionByNumber(message, field)
+}
+
+func (r *ReflectionRegistry) RangeExtensionsByMessage(message protoreflect.FullName, f func(protoreflect.ExtensionType) bool) {
+	seen := make(map[protoreflect.FieldNumber]bool)
+	next := true
+	visit := func(xt protoreflect.ExtensionType) bool {
+		number := xt.TypeDescriptor().Number()
+		if seen[number] {
+			return true
+		}
+		seen[number] = true
+		next = f(xt)
+		return next
+	}
+	for _, source := range r.sources() {
+		source.types.RangeExtensionsByMessage(message, visit)
+		if !next {
+			return
+		}
+	}
+	protoregistry.GlobalTypes.RangeExtensionsByMessage(message, visit)
+}
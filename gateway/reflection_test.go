@@ -0,0 +1,82 @@
+package gateway
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	v1reflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	v1reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	v1alphareflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	v1alphareflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestReflectionRegistry(t *testing.T) {
+	c.Convey("test aggregated reflection", t, func() {
+		_, filename, _, _ := runtime.Caller(0)
+		pb, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(filename)), "testdata", "helloworld.pb"))
+		c.So(err, c.ShouldBeNil)
+		pd, err := NewDescriptionFromBinary(pb, filepath.Join("tmp", "test-reflection"))
+		c.So(err, c.ShouldBeNil)
+		defer os.RemoveAll(filepath.Join("tmp", "test-reflection"))
+
+		srv := grpc.NewServer()
+		registry := NewReflectionRegistry(srv)
+		RegisterReflection(srv, registry)
+		addr := serveGrpc(srv)
+		defer srv.Stop()
+		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		c.So(err, c.ShouldBeNil)
+		defer conn.Close()
+
+		stream, err := v1reflectiongrpc.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+		c.So(err, c.ShouldBeNil)
+		listServices := func() []string {
+			err := stream.Send(&v1reflectionpb.ServerReflectionRequest{MessageRequest: &v1reflectionpb.ServerReflectionRequest_ListServices{}})
+			c.So(err, c.ShouldBeNil)
+			rsp, err := stream.Recv()
+			c.So(err, c.ShouldBeNil)
+			names := make([]string, 0)
+			for _, srv := range rsp.GetListServicesResponse().GetService() {
+				names = append(names, srv.GetName())
+			}
+			return names
+		}
+		c.So(listServices(), c.ShouldNotContain, "helloworld.Greeter")
+		c.So(listServices(), c.ShouldContain, "grpc.reflection.v1.ServerReflection")
+
+		// 注册端点后立即生效
+		c.So(registry.Register(pd), c.ShouldBeNil)
+		c.So(listServices(), c.ShouldContain, "helloworld.Greeter")
+		err = stream.Send(&v1reflectionpb.ServerReflectionRequest{MessageRequest: &v1reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "helloworld.Greeter.SayHello"}})
+		c.So(err, c.ShouldBeNil)
+		rsp, err := stream.Recv()
+		c.So(err, c.ShouldBeNil)
+		files := rsp.GetFileDescriptorResponse().GetFileDescriptorProto()
+		c.So(files, c.ShouldNotBeEmpty)
+		fd := &descriptorpb.FileDescriptorProto{}
+		c.So(proto.Unmarshal(files[0], fd), c.ShouldBeNil)
+		c.So(fd.GetPackage(), c.ShouldEqual, "helloworld")
+
+		// v1alpha
+		alpha, err := v1alphareflectiongrpc.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+		c.So(err, c.ShouldBeNil)
+		err = alpha.Send(&v1alphareflectionpb.ServerReflectionRequest{MessageRequest: &v1alphareflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: fd.GetName()}})
+		c.So(err, c.ShouldBeNil)
+		alphaRsp, err := alpha.Recv()
+		c.So(err, c.ShouldBeNil)
+		c.So(alphaRsp.GetFileDescriptorResponse().GetFileDescriptorProto(), c.ShouldNotBeEmpty)
+
+		// 删除端点后立即生效
+		registry.Delete(pd)
+		c.So(listServices(), c.ShouldNotContain, "helloworld.Greeter")
+		c.So(registry.sources(), c.ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,239 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	"google.golang.org/grpc/metadata"
+)
+
+// XRelease 响应头，请求被路由到的端点版本，稳定版本不设置
+const XRelease = "x-begonia-release"
+
+const (
+	ReleaseHashByUser = "user"
+	ReleaseHashByApp  = "app"
+	ReleaseHashByIP   = "ip"
+)
+
+// ReleaseRule 将匹配的流量路由到指定版本，
+// 请求头、应用和用户任一条件匹配即命中，否则按权重(百分比)分流
+type ReleaseRule struct {
+	Version string            `json:"version"`
+	Weight  int               `json:"weight,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Apps    []string          `json:"apps,omitempty"`
+	Users   []string          `json:"users,omitempty"`
+}
+
+// TrafficSplit 端点在各个版本之间的流量分配，未命中任何规则的请求路由到稳定版本
+type TrafficSplit struct {
+	Rules []*ReleaseRule `json:"rules,omitempty"`
+	// 按权重分流时计算哈希的依据，同一调用者总是路由到同一版本，默认按用户
+	HashBy string `json:"hash_by,omitempty"`
+}
+
+// Validate 校验分流规则，versions为端点已发布的版本
+func (t *TrafficSplit) Validate(versions []string) error {
+	if t == nil {
+		return nil
+	}
+	switch t.HashBy {
+	case "", ReleaseHashByUser, ReleaseHashByApp, ReleaseHashByIP:
+	default:
+		return fmt.Errorf("unknown hash_by %s", t.HashBy)
+	}
+	total := 0
+	for _, rule := range t.Rules {
+		if rule == nil || rule.Version == "" {
+			return fmt.Errorf("version of rule is required")
+		}
+		found := false
+		for _, version := range versions {
+			if version == rule.Version {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("version %s not exists", rule.Version)
+		}
+		if rule.Weight < 0 {
+			return fmt.Errorf("%s:weight must not be negative", rule.Version)
+		}
+		total += rule.Weight
+	}
+	if total > 100 {
+		return fmt.Errorf("sum of weights must not be greater than 100")
+	}
+	return nil
+}
+
+// Without 移除指定版本的分流规则
+func (t *TrafficSplit) Without(version string) *TrafficSplit {
+	if t == nil {
+		return nil
+	}
+	rules := make([]*ReleaseRule, 0, len(t.Rules))
+	for _, rule := range t.Rules {
+		if rule.Version != version {
+			rules = append(rules, rule)
+		}
+	}
+	return &TrafficSplit{Rules: rules, HashBy: t.HashBy}
+}
+
+func (r *ReleaseRule) match(md metadata.MD) bool {
+	for name, value := range r.Headers {
+		values := md.Get(name)
+		if len(values) == 0 || (value != "" && values[0] != value) {
+			return false
+		}
+	}
+	if len(r.Headers) > 0 {
+		return true
+	}
+	if containsValue(r.Apps, firstValue(md, XIdentity)) || containsValue(r.Users, firstValue(md, XUID)) {
+		return true
+	}
+	return false
+}
+
+func containsValue(values []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// bucket 调用者所在的分桶[0,100)
+func (t *TrafficSplit) bucket(md metadata.MD, clientIP string) int {
+	var key string
+	switch t.HashBy {
+	case ReleaseHashByApp:
+		key = firstValue(md, XIdentity)
+	case ReleaseHashByIP:
+		key = clientIP
+	default:
+		key = firstValue(md, XUID)
+		if key == "" {
+			key = firstValue(md, XIdentity)
+		}
+	}
+	if key == "" {
+		key = clientIP
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % 100)
+}
+
+// Route 选择请求的版本，返回空字符串表示稳定版本，
+// 对同一个请求的结果是确定的，http网关和grpc转发的请求都由grpc代理选择版本
+func (t *TrafficSplit) Route(ctx context.Context, clientIP string) string {
+	if t == nil || len(t.Rules) == 0 {
+		return ""
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, rule := range t.Rules {
+		if rule.match(md) {
+			return rule.Version
+		}
+	}
+	bucket := t.bucket(md, clientIP)
+	for _, rule := range t.Rules {
+		if bucket < rule.Weight {
+			return rule.Version
+		}
+		bucket -= rule.Weight
+	}
+	return ""
+}
+
+// endpointReleases 端点的版本及其负载均衡器
+type endpointReleases struct {
+	split *TrafficSplit
+	lbs   map[string]loadbalance.LoadBalance
+}
+
+type releaseRegistry struct {
+	mu       sync.RWMutex
+	releases map[string]*endpointReleases
+}
+
+func newReleaseRegistry() *releaseRegistry {
+	return &releaseRegistry{releases: make(map[string]*endpointReleases)}
+}
+
+func (r *releaseRegistry) register(pd ProtobufDescription, split *TrafficSplit, lbs map[string]loadbalance.LoadBalance) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	releases := &endpointReleases{split: split, lbs: lbs}
+	walkMethods(pd, func(key string, _ string, _ string) {
+		delete(r.releases, key)
+		if len(lbs) > 0 {
+			r.releases[key] = releases
+		}
+	})
+}
+
+func (r *releaseRegistry) delete(pd ProtobufDescription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	walkMethods(pd, func(key string, _ string, _ string) {
+		delete(r.releases, key)
+	})
+}
+
+func (r *releaseRegistry) get(fullMethod string) *endpointReleases {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !strings.HasPrefix(fullMethod, "/") {
+		fullMethod = "/" + fullMethod
+	}
+	return r.releases[strings.ToUpper(fullMethod)]
+}
+
+// loadBalances 所有版本的负载均衡器
+func (r *releaseRegistry) loadBalances() []loadbalance.LoadBalance {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	lbs := make([]loadbalance.LoadBalance, 0)
+	visited := make(map[loadbalance.LoadBalance]bool)
+	for _, releases := range r.releases {
+		for _, lb := range releases.lbs {
+			if !visited[lb] {
+				visited[lb] = true
+				lbs = append(lbs, lb)
+			}
+		}
+	}
+	return lbs
+}
+
+type releaseKey struct{}
+
+func withRelease(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, releaseKey{}, version)
+}
+
+func releaseFromContext(ctx context.Context) string {
+	version, _ := ctx.Value(releaseKey{}).(string)
+	return version
+}
@@ -0,0 +1,89 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTrafficSplit(t *testing.T) {
+	c.Convey("test traffic split", t, func() {
+		versions := []string{"v2", "v3"}
+		c.So((&TrafficSplit{HashBy: "header"}).Validate(versions), c.ShouldNotBeNil)
+		c.So((&TrafficSplit{Rules: []*ReleaseRule{{Version: "v4"}}}).Validate(versions), c.ShouldNotBeNil)
+		c.So((&TrafficSplit{Rules: []*ReleaseRule{{Version: "v2", Weight: 60}, {Version: "v3", Weight: 50}}}).Validate(versions), c.ShouldNotBeNil)
+		c.So((&TrafficSplit{Rules: []*ReleaseRule{{Version: "v2", Weight: -1}}}).Validate(versions), c.ShouldNotBeNil)
+		c.So((*TrafficSplit)(nil).Validate(nil), c.ShouldBeNil)
+
+		split := &TrafficSplit{Rules: []*ReleaseRule{
+			{Version: "v3", Headers: map[string]string{"x-canary": "true"}},
+			{Version: "v3", Apps: []string{"app-1"}, Users: []string{"user-1"}},
+			{Version: "v2", Weight: 30},
+		}}
+		c.So(split.Validate(versions), c.ShouldBeNil)
+		route := func(ip string, kv ...string) string {
+			return split.Route(metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...)), ip)
+		}
+		c.So(route("127.0.0.1", "x-canary", "true"), c.ShouldEqual, "v3")
+		c.So(route("127.0.0.1", XIdentity, "app-1"), c.ShouldEqual, "v3")
+		c.So(route("127.0.0.1", XUID, "user-1"), c.ShouldEqual, "v3")
+
+		// 按权重分流时同一用户总是路由到同一版本
+		canary := 0
+		for i := 0; i < 1000; i++ {
+			user := fmt.Sprintf("user-%d", i+2)
+			version := route("127.0.0.1", XUID, user)
+			c.So(version == "" || version == "v2", c.ShouldBeTrue)
+			c.So(route("127.0.0.2", XUID, user), c.ShouldEqual, version)
+			if version == "v2" {
+				canary++
+			}
+		}
+		c.So(canary, c.ShouldBeBetween, 200, 400)
+
+		without := split.Without("v3")
+		c.So(len(without.Rules), c.ShouldEqual, 1)
+		c.So(without.Route(metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-canary", "true")), "127.0.0.1"), c.ShouldNotEqual, "v3")
+		c.So((*TrafficSplit)(nil).Route(context.Background(), "127.0.0.1"), c.ShouldEqual, "")
+	})
+}
+
+func TestGrpcLoadBalancerRelease(t *testing.T) {
+	c.Convey("test grpc loadbalancer release", t, func() {
+		pd := newTransformTestDescription()
+		stable, _ := loadbalance.New(loadbalance.RRBalanceType, []loadbalance.Endpoint{NewGrpcEndpoint("127.0.0.1:3001", nil)})
+		canary, _ := loadbalance.New(loadbalance.RRBalanceType, []loadbalance.Endpoint{NewGrpcEndpoint("127.0.0.1:3002", nil)})
+		lb := NewGrpcLoadBalancer()
+		lb.Register(stable, pd)
+		lb.RegisterReleases(pd, &TrafficSplit{Rules: []*ReleaseRule{{Version: "v2", Headers: map[string]string{"x-canary": ""}}}}, map[string]loadbalance.LoadBalance{"v2": canary})
+		c.So(len(lb.Endpoints()), c.ShouldEqual, 2)
+
+		method := "/helloworld.Greeter/SayHello"
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-canary", "1"))
+		version := lb.Release(ctx, method, "127.0.0.1")
+		c.So(version, c.ShouldEqual, "v2")
+		c.So(lb.Release(context.Background(), method, "127.0.0.1"), c.ShouldEqual, "")
+		c.So(lb.Release(ctx, "/other.Greeter/SayHello", "127.0.0.1"), c.ShouldEqual, "")
+
+		endpoint, err := lb.SelectRelease(version, method)
+		c.So(err, c.ShouldBeNil)
+		c.So(endpoint.Addr(), c.ShouldEqual, "127.0.0.1:3002")
+		endpoint, err = lb.Select(method)
+		c.So(err, c.ShouldBeNil)
+		c.So(endpoint.Addr(), c.ShouldEqual, "127.0.0.1:3001")
+		// 不存在的版本使用稳定版本
+		endpoint, err = lb.SelectRelease("v3", method)
+		c.So(err, c.ShouldBeNil)
+		c.So(endpoint.Addr(), c.ShouldEqual, "127.0.0.1:3001")
+		c.So(releaseFromContext(withRelease(context.Background(), "v2")), c.ShouldEqual, "v2")
+
+		lb.Delete(pd)
+		c.So(lb.Release(ctx, method, "127.0.0.1"), c.ShouldEqual, "")
+		_, err = lb.SelectRelease("v2", method)
+		c.So(err, c.ShouldEqual, loadbalance.ErrNoEndpoint)
+	})
+}
@@ -0,0 +1,177 @@
+package gateway
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"time"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type unaryResult struct {
+	msg     *emptypb.Empty
+	header  metadata.MD
+	trailer metadata.MD
+	err     error
+}
+
+// retryAllowed 是否允许重试或对冲，
+// 方法显式标记为可重试，或者请求来自http网关且为幂等的请求方法
+func (g *GrpcProxy) retryAllowed(ctx context.Context, policy *CallPolicy) bool {
+	if policy.Retry == nil && policy.Hedging == nil {
+		return false
+	}
+	if policy.Retryable {
+		return true
+	}
+	return isIdempotentHttpMethod(g.httpMethod(ctx))
+}
+
+// httpMethod 获取http网关转发请求时的请求方法，
+// http网关通过本地回环地址访问代理，其他来源的x-http-method不可信
+func (g *GrpcProxy) httpMethod(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return ""
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return ""
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if methods := md.Get(XHttpMethod); len(methods) > 0 {
+		return methods[0]
+	}
+	return ""
+}
+
+func (g *GrpcProxy) handleUnary(ctx context.Context, serverStream grpc.ServerStream, fullMethodName string, clientIP string, policy *CallPolicy, mirror *endpointMirror) error {
+	in := &emptypb.Empty{}
+	if err := serverStream.RecvMsg(in); err != nil {
+		return err
+	}
+	start := time.Now()
+	var rsp *unaryResult
+	if policy.Hedging != nil {
+		rsp = g.hedge(ctx, fullMethodName, clientIP, in, policy.Hedging)
+	} else {
+		rsp = g.retry(ctx, fullMethodName, clientIP, in, policy.Retry)
+	}
+	if mirror != nil {
+		g.mirror(ctx, mirror, fullMethodName, clientIP, in.ProtoReflect().GetUnknown(), rsp.err, time.Since(start))
+	}
+	if rsp.trailer != nil {
+		serverStream.SetTrailer(rsp.trailer)
+	}
+	if rsp.err != nil {
+		// 与没有调用策略时一致，失败时同样返回上游的响应头
+		if rsp.header != nil {
+			_ = serverStream.SetHeader(rsp.header)
+		}
+		return rsp.err
+	}
+	if err := serverStream.SendHeader(rsp.header); err != nil {
+		return err
+	}
+	return serverStream.SendMsg(rsp.msg)
+}
+
+// invoke 选择一个端点发起一次一元调用
+func (g *GrpcProxy) invoke(ctx context.Context, fullMethodName string, clientIP string, in *emptypb.Empty) *unaryResult {
+	endpoint, err := g.lb.SelectRelease(releaseFromContext(ctx), fullMethodName, clientIP)
+	if err != nil {
+		return &unaryResult{err: status.Errorf(codes.Unavailable, "no endpoint available to select,%v", err)}
+	}
+	setMetricsEndpoint(ctx, endpoint)
+	setCaptureEndpoint(ctx, endpoint)
+	ctx, span := startClientSpan(ctx, fullMethodName, endpoint.Addr())
+	rsp := &unaryResult{msg: &emptypb.Empty{}}
+	start := time.Now()
+	defer func() {
+		g.lb.ReportResult(endpoint.Addr(), rsp.err, time.Since(start))
+		EndSpan(span, rsp.err)
+	}()
+	cn, err := endpoint.Get(ctx)
+	if err != nil {
+		rsp.err = status.Errorf(codes.Unavailable, "no endpoint available from endpoint,%v", err)
+		return rsp
+	}
+	defer endpoint.AfterTransform(ctx, cn.(loadbalance.Connection))
+	conn := cn.(loadbalance.Connection).ConnInstance().(*grpc.ClientConn)
+	rsp.err = conn.Invoke(ctx, fullMethodName, in, rsp.msg, grpc.Header(&rsp.header), grpc.Trailer(&rsp.trailer))
+	return rsp
+}
+
+func (g *GrpcProxy) retry(ctx context.Context, fullMethodName string, clientIP string, in *emptypb.Empty, policy *RetryPolicy) *unaryResult {
+	for attempt := 1; ; attempt++ {
+		rsp := g.invoke(ctx, fullMethodName, clientIP, in)
+		if rsp.err == nil || attempt >= policy.MaxAttempts || !containsStatusCode(policy.RetryableStatusCodes, status.Code(rsp.err)) {
+			return rsp
+		}
+		// 随机退避，避免大量请求同时重试
+		backoff := time.Duration(rand.Int63n(int64(policy.backoff(attempt)) + 1))
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return rsp
+		case <-timer.C:
+		}
+		Log.Warnf(ctx, "retry %s attempt %d,last error:%s", fullMethodName, attempt+1, rsp.err.Error())
+	}
+}
+
+// hedge 对冲请求，每隔hedging_delay_ms发起一次新的请求，
+// 返回第一个成功或者出现致命错误的结果，并取消其他请求
+func (g *GrpcProxy) hedge(ctx context.Context, fullMethodName string, clientIP string, in *emptypb.Empty, policy *HedgingPolicy) *unaryResult {
+	hedgeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan *unaryResult, policy.MaxAttempts)
+	sent, received := 0, 0
+	var next <-chan time.Time
+	send := func() {
+		sent++
+		go func() {
+			results <- g.invoke(hedgeCtx, fullMethodName, clientIP, in)
+		}()
+		next = nil
+		if sent < policy.MaxAttempts {
+			next = time.After(time.Duration(policy.HedgingDelayMs) * time.Millisecond)
+		}
+	}
+	send()
+	var last *unaryResult
+	for {
+		select {
+		case <-next:
+			send()
+		case rsp := <-results:
+			received++
+			if rsp.err == nil || !containsStatusCode(policy.NonFatalStatusCodes, status.Code(rsp.err)) {
+				return rsp
+			}
+			last = rsp
+			if sent < policy.MaxAttempts {
+				// 非致命错误立即发起下一次请求
+				send()
+			} else if received == sent {
+				return last
+			}
+		case <-ctx.Done():
+			return &unaryResult{err: status.FromContextError(ctx.Err()).Err()}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package gateway
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const testRetryMethod = "/helloworld.Greeter/SayHello"
+
+func serveGrpc(srv *grpc.Server) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	return lis.Addr().String()
+}
+
+// newRetryTestProxy 启动上游服务和grpc代理，handler返回第n次请求的处理结果
+func newRetryTestProxy(policy *CallPolicy, handler func(n int32) (time.Duration, error)) (*grpc.ClientConn, *int32, func()) {
+	calls := new(int32)
+	upstream := grpc.NewServer(grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		n := atomic.AddInt32(calls, 1)
+		in := &emptypb.Empty{}
+		if err := stream.RecvMsg(in); err != nil {
+			return err
+		}
+		delay, err := handler(n)
+		_ = stream.SetHeader(metadata.Pairs("x-upstream-attempt", strconv.Itoa(int(n))))
+		select {
+		case <-time.After(delay):
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+		if err != nil {
+			return err
+		}
+		return stream.SendMsg(in)
+	}))
+	upstreamAddr := serveGrpc(upstream)
+
+	lb := NewGrpcLoadBalancer()
+	rr, _ := loadbalance.New(loadbalance.RRBalanceType, []loadbalance.Endpoint{NewGrpcEndpoint(upstreamAddr, NewGrpcConnPool(upstreamAddr))})
+	lb.lb[strings.ToUpper(testRetryMethod)] = rr
+	lb.policies.policies[strings.ToUpper(testRetryMethod)] = &methodPolicy{CallPolicy: policy, unary: true}
+	proxy := NewGrpcServer(&GrpcServerOptions{}, lb)
+	proxyAddr := serveGrpc(proxy)
+	cc, err := grpc.Dial(proxyAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	return cc, calls, func() {
+		cc.Close()
+		proxy.Stop()
+		upstream.Stop()
+	}
+}
+
+func TestProxyRetryPolicy(t *testing.T) {
+	retry := &RetryPolicy{
+		MaxAttempts:          3,
+		RetryableStatusCodes: []string{"UNAVAILABLE"},
+		InitialBackoffMs:     10,
+		MaxBackoffMs:         50,
+		BackoffMultiplier:    2,
+	}
+	c.Convey("test retry on retryable method", t, func() {
+		cc, calls, stop := newRetryTestProxy(&CallPolicy{Retryable: true, Retry: retry}, func(n int32) (time.Duration, error) {
+			if n < 3 {
+				return 0, status.Error(codes.Unavailable, "unavailable")
+			}
+			return 0, nil
+		})
+		defer stop()
+		out := &wrapperspb.StringValue{}
+		err := cc.Invoke(context.Background(), testRetryMethod, wrapperspb.String("begonia"), out)
+		c.So(err, c.ShouldBeNil)
+		c.So(out.Value, c.ShouldEqual, "begonia")
+		c.So(atomic.LoadInt32(calls), c.ShouldEqual, 3)
+	})
+	c.Convey("test no retry on non idempotent method", t, func() {
+		cc, calls, stop := newRetryTestProxy(&CallPolicy{Retry: retry}, func(n int32) (time.Duration, error) {
+			return 0, status.Error(codes.Unavailable, "unavailable")
+		})
+		defer stop()
+		err := cc.Invoke(context.Background(), testRetryMethod, wrapperspb.String("begonia"), &wrapperspb.StringValue{})
+		c.So(status.Code(err), c.ShouldEqual, codes.Unavailable)
+		c.So(atomic.LoadInt32(calls), c.ShouldEqual, 1)
+	})
+	c.Convey("test no retry on non retryable code", t, func() {
+		cc, calls, stop := newRetryTestProxy(&CallPolicy{Retryable: true, Retry: retry}, func(n int32) (time.Duration, error) {
+			return 0, status.Error(codes.InvalidArgument, "invalid")
+		})
+		defer stop()
+		header := metadata.MD{}
+		err := cc.Invoke(context.Background(), testRetryMethod, wrapperspb.String("begonia"), &wrapperspb.StringValue{}, grpc.Header(&header))
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+		c.So(atomic.LoadInt32(calls), c.ShouldEqual, 1)
+		c.So(header.Get("x-upstream-attempt"), c.ShouldResemble, []string{"1"})
+	})
+	c.Convey("test hedging", t, func() {
+		hedging := &HedgingPolicy{MaxAttempts: 2, HedgingDelayMs: 50}
+		cc, calls, stop := newRetryTestProxy(&CallPolicy{Retryable: true, Hedging: hedging}, func(n int32) (time.Duration, error) {
+			if n == 1 {
+				return 2 * time.Second, nil
+			}
+			return 0, nil
+		})
+		defer stop()
+		start := time.Now()
+		err := cc.Invoke(context.Background(), testRetryMethod, wrapperspb.String("begonia"), &wrapperspb.StringValue{})
+		c.So(err, c.ShouldBeNil)
+		c.So(time.Since(start), c.ShouldBeLessThan, time.Second)
+		c.So(atomic.LoadInt32(calls), c.ShouldEqual, 2)
+	})
+	c.Convey("test default timeout", t, func() {
+		cc, _, stop := newRetryTestProxy(&CallPolicy{TimeoutMs: 100}, func(n int32) (time.Duration, error) {
+			return 2 * time.Second, nil
+		})
+		defer stop()
+		err := cc.Invoke(context.Background(), testRetryMethod, wrapperspb.String("begonia"), &wrapperspb.StringValue{})
+		c.So(status.Code(err), c.ShouldEqual, codes.DeadlineExceeded)
+	})
+}
+
+func TestEndpointPolicy(t *testing.T) {
+	c.Convey("test endpoint policy lookup and validate", t, func() {
+		policy := EndpointPolicy{
+			"*":                           {TimeoutMs: 1000},
+			"helloworld.Greeter":          {TimeoutMs: 2000},
+			"helloworld.Greeter/SayHello": {TimeoutMs: 3000},
+		}
+		c.So(policy.Validate(), c.ShouldBeNil)
+		c.So(policy.lookup("helloworld.Greeter", "SayHello").TimeoutMs, c.ShouldEqual, 3000)
+		c.So(policy.lookup("helloworld.Greeter", "SayHi").TimeoutMs, c.ShouldEqual, 2000)
+		c.So(policy.lookup("example.Example", "Get").TimeoutMs, c.ShouldEqual, 1000)
+
+		invalid := EndpointPolicy{"*": {Retry: &RetryPolicy{MaxAttempts: 10}}}
+		c.So(invalid.Validate(), c.ShouldNotBeNil)
+		invalid = EndpointPolicy{"*": {Retry: &RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: []string{"NOT_A_CODE"}, InitialBackoffMs: 1, MaxBackoffMs: 1, BackoffMultiplier: 1}}}
+		c.So(invalid.Validate(), c.ShouldNotBeNil)
+
+		retry := &RetryPolicy{InitialBackoffMs: 100, MaxBackoffMs: 300, BackoffMultiplier: 2}
+		c.So(retry.backoff(1), c.ShouldEqual, 100*time.Millisecond)
+		c.So(retry.backoff(2), c.ShouldEqual, 200*time.Millisecond)
+		c.So(retry.backoff(3), c.ShouldEqual, 300*time.Millisecond)
+		c.So(isIdempotentHttpMethod("get"), c.ShouldBeTrue)
+		c.So(isIdempotentHttpMethod("POST"), c.ShouldBeFalse)
+	})
+}
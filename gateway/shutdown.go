@@ -0,0 +1,172 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+var ErrGatewayShuttingDown = errors.New("gateway is shutting down")
+
+// streamTracker 跟踪进行中的sse和websocket流，网关关闭时主动结束这些流
+type streamTracker struct {
+	mu      sync.Mutex
+	closing bool
+	next    uint64
+	streams map[uint64]func()
+	wg      sync.WaitGroup
+}
+
+func newStreamTracker() *streamTracker {
+	return &streamTracker{streams: make(map[uint64]func())}
+}
+
+// add 注册流的关闭函数，返回流结束时调用的函数，网关关闭中时不再接受新的流
+func (t *streamTracker) add(closeFn func()) (func(), error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closing {
+		return nil, ErrGatewayShuttingDown
+	}
+	id := t.next
+	t.next++
+	t.streams[id] = closeFn
+	t.wg.Add(1)
+	once := sync.Once{}
+	return func() {
+		once.Do(func() {
+			t.mu.Lock()
+			delete(t.streams, id)
+			t.mu.Unlock()
+			t.wg.Done()
+		})
+	}, nil
+}
+
+// closeAll 结束所有进行中的流
+func (t *streamTracker) closeAll() {
+	t.mu.Lock()
+	t.closing = true
+	closers := make([]func(), 0, len(t.streams))
+	for _, closeFn := range t.streams {
+		closers = append(closers, closeFn)
+	}
+	t.mu.Unlock()
+	for _, closeFn := range closers {
+		closeFn()
+	}
+}
+
+// wait 等待所有流的处理函数退出
+func (t *streamTracker) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ReadyAfter 网关在loaded关闭后才就绪，例如等待端点从etcd初始加载完成
+func (g *GatewayServer) ReadyAfter(loaded <-chan struct{}) {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	g.loaded = loaded
+}
+
+// Ready 网关是否已经开始监听、完成初始加载并且没有处于关闭中
+func (g *GatewayServer) Ready() bool {
+	if !g.ready.Load() {
+		return false
+	}
+	g.mux.Lock()
+	loaded := g.loaded
+	g.mux.Unlock()
+	if loaded == nil {
+		return true
+	}
+	select {
+	case <-loaded:
+		return true
+	default:
+		return false
+	}
+}
+
+// HealthzHandler 存活探针，进程可以处理请求时返回200
+func (g *GatewayServer) HealthzHandler() runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}
+}
+
+// ReadyzHandler 就绪探针，网关关闭中时返回503，以便负载均衡器摘除流量
+func (g *GatewayServer) ReadyzHandler() runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if !g.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("not ready"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}
+}
+
+// Shutdown 优雅关闭网关：
+// 停止接收新的连接，结束sse和websocket流，等待进行中的grpc请求完成，
+// 最后关闭所有端点的连接池。ctx超时后强制关闭
+func (g *GatewayServer) Shutdown(ctx context.Context) error {
+	g.ready.Store(false)
+	var errs []error
+	// 先结束长连接的流，否则http server会一直等待这些请求结束
+	g.httpGateway.CloseStreams()
+	g.mux.Lock()
+	s := g.httpServer
+	g.mux.Unlock()
+	if s != nil {
+		if err := s.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// websocket连接已被接管，不在http server的跟踪范围内
+	if err := g.httpGateway.WaitStreams(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	done := make(chan struct{})
+	go func() {
+		g.grpcServer.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		g.grpcServer.Stop()
+		<-done
+		errs = append(errs, ctx.Err())
+	}
+	if health := g.proxyLB.HealthChecker(); health != nil {
+		health.Stop()
+	}
+	if err := g.proxyLB.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := g.httpGateway.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if g.tracer != nil {
+		if err := g.tracer.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
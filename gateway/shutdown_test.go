@@ -0,0 +1,117 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/begonia-org/go-sdk/example"
+	c "github.com/smartystreets/goconvey/convey"
+)
+
+func freePort() int {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	defer lis.Close()
+	return lis.Addr().(*net.TCPAddr).Port
+}
+
+func TestStreamTracker(t *testing.T) {
+	c.Convey("test stream tracker", t, func() {
+		tracker := newStreamTracker()
+		closed := make([]int, 0)
+		done1, err := tracker.add(func() { closed = append(closed, 1) })
+		c.So(err, c.ShouldBeNil)
+		done2, err := tracker.add(func() { closed = append(closed, 2) })
+		c.So(err, c.ShouldBeNil)
+		done2()
+		// 重复调用不影响计数
+		done2()
+
+		tracker.closeAll()
+		c.So(closed, c.ShouldResemble, []int{1})
+		_, err = tracker.add(func() {})
+		c.So(err, c.ShouldEqual, ErrGatewayShuttingDown)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		c.So(tracker.wait(ctx), c.ShouldEqual, context.DeadlineExceeded)
+
+		done1()
+		c.So(tracker.wait(context.Background()), c.ShouldBeNil)
+	})
+}
+
+func TestGatewayShutdown(t *testing.T) {
+	c.Convey("test gateway shutdown", t, func() {
+		_, filename, _, _ := runtime.Caller(0)
+		pb, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(filename)), "testdata", "helloworld.pb"))
+		c.So(err, c.ShouldBeNil)
+		pd, err := NewDescriptionFromBinary(pb, filepath.Join("tmp", "test-shutdown"))
+		c.So(err, c.ShouldBeNil)
+
+		gwPort := freePort()
+		opts, cnf := newTestServer(gwPort, 0)
+		cnf.GrpcProxyAddr = fmt.Sprintf("127.0.0.1:%d", freePort())
+		shutdownGW := NewGateway(cnf, opts)
+		exampleServer := example.NewExampleServer()
+		err = shutdownGW.RegisterLocalService(context.Background(), pd, exampleServer.Desc(), exampleServer)
+		c.So(err, c.ShouldBeNil)
+		c.So(shutdownGW.HandlePath(http.MethodGet, "/readyz", shutdownGW.ReadyzHandler()), c.ShouldBeNil)
+		c.So(shutdownGW.HandlePath(http.MethodGet, "/healthz", shutdownGW.HealthzHandler()), c.ShouldBeNil)
+		c.So(shutdownGW.Ready(), c.ShouldBeFalse)
+		loaded := make(chan struct{})
+		shutdownGW.ReadyAfter(loaded)
+
+		stopped := make(chan error, 1)
+		go func() {
+			stopped <- shutdownGW.Start()
+		}()
+		time.Sleep(2 * time.Second)
+		// 初始加载完成前不就绪
+		c.So(shutdownGW.Ready(), c.ShouldBeFalse)
+		resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/readyz", gwPort))
+		c.So(err, c.ShouldBeNil)
+		resp.Body.Close()
+		c.So(resp.StatusCode, c.ShouldEqual, http.StatusServiceUnavailable)
+
+		close(loaded)
+		c.So(shutdownGW.Ready(), c.ShouldBeTrue)
+		resp, err = http.Get(fmt.Sprintf("http://127.0.0.1:%d/readyz", gwPort))
+		c.So(err, c.ShouldBeNil)
+		resp.Body.Close()
+		c.So(resp.StatusCode, c.ShouldEqual, http.StatusOK)
+		resp, err = http.Get(fmt.Sprintf("http://127.0.0.1:%d/api/v1/example/world?msg=hello", gwPort))
+		c.So(err, c.ShouldBeNil)
+		resp.Body.Close()
+		c.So(resp.StatusCode, c.ShouldEqual, http.StatusOK)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		c.So(shutdownGW.Shutdown(ctx), c.ShouldBeNil)
+		c.So(shutdownGW.Ready(), c.ShouldBeFalse)
+		select {
+		case err := <-stopped:
+			// 正常关闭时不返回错误
+			c.So(err, c.ShouldBeNil)
+		case <-time.After(3 * time.Second):
+			t.Fatal("gateway start did not return after shutdown")
+		}
+
+		_, err = http.Get(fmt.Sprintf("http://127.0.0.1:%d/healthz", gwPort))
+		c.So(err, c.ShouldNotBeNil)
+
+		w := httptest.NewRecorder()
+		shutdownGW.ReadyzHandler()(w, httptest.NewRequest(http.MethodGet, "/readyz", nil), nil)
+		c.So(w.Code, c.ShouldEqual, http.StatusServiceUnavailable)
+	})
+}
@@ -0,0 +1,251 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// RedactedSecret 接口返回时私钥的脱敏值
+const RedactedSecret = "******"
+
+type TLSOptions struct {
+	CertFile string
+	KeyFile  string
+	// 校验客户端证书的CA文件
+	ClientCAFile string
+	ClientAuth   tls.ClientAuthType
+	// 证书文件的检查间隔，文件修改后自动重新加载，为0时不检查
+	ReloadInterval time.Duration
+}
+
+// ParseClientAuth 解析客户端证书的校验方式:none,request,require,verify_if_given,require_and_verify
+func ParseClientAuth(mode string) (tls.ClientAuthType, error) {
+	switch mode {
+	case "", "none":
+		return tls.NoClientCert, nil
+	case "request":
+		return tls.RequestClientCert, nil
+	case "require":
+		return tls.RequireAnyClientCert, nil
+	case "verify_if_given":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require_and_verify":
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("unknown client auth type %s", mode)
+	}
+}
+
+// CertReloader 网关监听的证书，在tls握手时检查证书文件是否被修改，修改后重新加载
+type CertReloader struct {
+	opts      *TLSOptions
+	mu        sync.RWMutex
+	config    *tls.Config
+	modTimes  map[string]time.Time
+	checkedAt time.Time
+}
+
+func NewCertReloader(opts *TLSOptions) (*CertReloader, error) {
+	r := &CertReloader{opts: opts}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *CertReloader) files() []string {
+	files := []string{r.opts.CertFile, r.opts.KeyFile}
+	if r.opts.ClientCAFile != "" {
+		files = append(files, r.opts.ClientCAFile)
+	}
+	return files
+}
+
+func (r *CertReloader) stat() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// Reload 重新加载证书和客户端CA
+func (r *CertReloader) Reload() error {
+	modTimes, err := r.stat()
+	if err != nil {
+		return fmt.Errorf("stat tls files error:%w", err)
+	}
+	cert, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load x509 key pair error:%w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+		ClientAuth:   r.opts.ClientAuth,
+		MinVersion:   tls.VersionTLS12,
+	}
+	if r.opts.ClientCAFile != "" {
+		ca, err := os.ReadFile(r.opts.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client ca error:%w", err)
+		}
+		config.ClientCAs, err = newCertPool(ca)
+		if err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.config = config
+	r.modTimes = modTimes
+	r.checkedAt = time.Now()
+	return nil
+}
+
+// maybeReload 距离上次检查超过间隔且文件被修改时重新加载，加载失败时继续使用旧的证书
+func (r *CertReloader) maybeReload() {
+	if r.opts.ReloadInterval <= 0 {
+		return
+	}
+	r.mu.Lock()
+	if time.Since(r.checkedAt) < r.opts.ReloadInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.checkedAt = time.Now()
+	current := r.modTimes
+	r.mu.Unlock()
+	modTimes, err := r.stat()
+	if err != nil {
+		Log.Errorf(context.Background(), "stat tls files error:%v", err)
+		return
+	}
+	changed := false
+	for file, modTime := range modTimes {
+		if !modTime.Equal(current[file]) {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return
+	}
+	if err := r.Reload(); err != nil {
+		Log.Errorf(context.Background(), "reload tls certificate error:%v", err)
+		return
+	}
+	Log.Infof(context.Background(), "tls certificate %s reloaded", r.opts.CertFile)
+}
+
+func (r *CertReloader) GetConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.maybeReload()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.config, nil
+}
+
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.maybeReload()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &r.config.Certificates[0], nil
+}
+
+// TLSConfig 网关监听使用的tls配置，每次握手使用最新加载的证书
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		NextProtos:         []string{"h2", "http/1.1"},
+		MinVersion:         tls.VersionTLS12,
+		GetCertificate:     r.GetCertificate,
+		GetConfigForClient: r.GetConfigForClient,
+	}
+}
+
+func newCertPool(pem []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid certificate found in ca")
+	}
+	return pool, nil
+}
+
+// UpstreamTLS 代理端点的tls配置，证书和私钥为PEM格式的内容
+type UpstreamTLS struct {
+	// 校验端点证书的CA，为空时使用系统CA
+	CA string `json:"ca,omitempty"`
+	// 客户端证书和私钥，用于双向认证，私钥加密后存储
+	Cert string `json:"cert,omitempty"`
+	Key  string `json:"key,omitempty" aes:"true"`
+	// 校验端点证书使用的域名，为空时使用端点地址
+	ServerName         string `json:"server_name,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+}
+
+func (u *UpstreamTLS) config() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         u.ServerName,
+		InsecureSkipVerify: u.InsecureSkipVerify, // #nosec G402 由端点配置显式开启
+		MinVersion:         tls.VersionTLS12,
+	}
+	if u.CA != "" {
+		pool, err := newCertPool([]byte(u.CA))
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	if (u.Cert == "") != (u.Key == "") {
+		return nil, fmt.Errorf("cert and key must be set together")
+	}
+	if u.Cert != "" {
+		cert, err := tls.X509KeyPair([]byte(u.Cert), []byte(u.Key))
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate error:%w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// Validate 校验证书和私钥是否有效
+func (u *UpstreamTLS) Validate() error {
+	_, err := u.config()
+	return err
+}
+
+// Credentials 端点连接使用的传输凭证，未配置tls时使用明文连接
+func (u *UpstreamTLS) Credentials() (credentials.TransportCredentials, error) {
+	if u == nil {
+		return insecure.NewCredentials(), nil
+	}
+	config, err := u.config()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// Redacted 隐藏私钥后的配置，用于接口返回
+func (u *UpstreamTLS) Redacted() *UpstreamTLS {
+	if u == nil {
+		return nil
+	}
+	redacted := *u
+	if redacted.Key != "" {
+		redacted.Key = RedactedSecret
+	}
+	return &redacted
+}
@@ -0,0 +1,241 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(cn string, serial int64, parent *testCert, isCA bool) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: cn, Organization: []string{"begonia"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:              []string{"localhost"},
+	}
+	parentCert, parentKey := tmpl, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		panic(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}
+}
+
+func (t *testCert) tlsCertificate() tls.Certificate {
+	cert, err := tls.X509KeyPair(t.certPEM, t.keyPEM)
+	if err != nil {
+		panic(err)
+	}
+	return cert
+}
+
+func certSerial(cert *tls.Certificate) int64 {
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		panic(err)
+	}
+	return leaf.SerialNumber.Int64()
+}
+
+func TestParseClientAuth(t *testing.T) {
+	c.Convey("test parse client auth", t, func() {
+		cases := map[string]tls.ClientAuthType{
+			"":                   tls.NoClientCert,
+			"none":               tls.NoClientCert,
+			"request":            tls.RequestClientCert,
+			"require":            tls.RequireAnyClientCert,
+			"verify_if_given":    tls.VerifyClientCertIfGiven,
+			"require_and_verify": tls.RequireAndVerifyClientCert,
+		}
+		for mode, expected := range cases {
+			auth, err := ParseClientAuth(mode)
+			c.So(err, c.ShouldBeNil)
+			c.So(auth, c.ShouldEqual, expected)
+		}
+		_, err := ParseClientAuth("unknown")
+		c.So(err, c.ShouldNotBeNil)
+	})
+}
+
+func TestCertReloader(t *testing.T) {
+	c.Convey("test cert reloader", t, func() {
+		ca := newTestCert("begonia-ca", 1, nil, true)
+		server := newTestCert("gateway", 2, ca, false)
+		client := newTestCert("client-app", 3, ca, false)
+
+		dir := t.TempDir()
+		certFile := filepath.Join(dir, "tls.crt")
+		keyFile := filepath.Join(dir, "tls.key")
+		caFile := filepath.Join(dir, "ca.crt")
+		c.So(os.WriteFile(certFile, server.certPEM, 0600), c.ShouldBeNil)
+		c.So(os.WriteFile(keyFile, server.keyPEM, 0600), c.ShouldBeNil)
+		c.So(os.WriteFile(caFile, ca.certPEM, 0600), c.ShouldBeNil)
+
+		_, err := NewCertReloader(&TLSOptions{CertFile: filepath.Join(dir, "not-exists.crt"), KeyFile: keyFile})
+		c.So(err, c.ShouldNotBeNil)
+
+		reloader, err := NewCertReloader(&TLSOptions{
+			CertFile:       certFile,
+			KeyFile:        keyFile,
+			ClientCAFile:   caFile,
+			ClientAuth:     tls.VerifyClientCertIfGiven,
+			ReloadInterval: 10 * time.Millisecond,
+		})
+		c.So(err, c.ShouldBeNil)
+		cert, err := reloader.GetCertificate(nil)
+		c.So(err, c.ShouldBeNil)
+		c.So(certSerial(cert), c.ShouldEqual, 2)
+
+		// 客户端证书的subject写入x-client-cert-subject，客户端传入的身份头被丢弃
+		srv := httptest.NewUnstartedServer(TrustedHeadersHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Header.Get(XIdentity) + r.Header.Get(XUID) + r.Header.Get(XClientCertSubject)))
+		})))
+		srv.TLS = reloader.TLSConfig()
+		srv.StartTLS()
+		defer srv.Close()
+		roots := x509.NewCertPool()
+		roots.AddCert(ca.cert)
+		request := func(certs ...tls.Certificate) string {
+			cli := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs}}}
+			req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			req.Header.Set(XIdentity, "spoofed")
+			req.Header.Set(XUID, "spoofed")
+			req.Header.Set(XClientCertSubject, "spoofed")
+			rsp, err := cli.Do(req)
+			c.So(err, c.ShouldBeNil)
+			defer rsp.Body.Close()
+			body, _ := io.ReadAll(rsp.Body)
+			return string(body)
+		}
+		c.So(request(client.tlsCertificate()), c.ShouldEqual, client.cert.Subject.String())
+		c.So(request(), c.ShouldBeEmpty)
+
+		// 证书文件更新后重新加载
+		renewed := newTestCert("gateway", 4, ca, false)
+		c.So(os.WriteFile(certFile, renewed.certPEM, 0600), c.ShouldBeNil)
+		c.So(os.WriteFile(keyFile, renewed.keyPEM, 0600), c.ShouldBeNil)
+		future := time.Now().Add(time.Minute)
+		c.So(os.Chtimes(certFile, future, future), c.ShouldBeNil)
+		c.So(os.Chtimes(keyFile, future, future), c.ShouldBeNil)
+		time.Sleep(20 * time.Millisecond)
+		cert, err = reloader.GetCertificate(nil)
+		c.So(err, c.ShouldBeNil)
+		c.So(certSerial(cert), c.ShouldEqual, 4)
+
+		// 加载失败时继续使用旧的证书
+		c.So(os.WriteFile(keyFile, []byte("invalid"), 0600), c.ShouldBeNil)
+		future = future.Add(time.Minute)
+		c.So(os.Chtimes(keyFile, future, future), c.ShouldBeNil)
+		time.Sleep(20 * time.Millisecond)
+		cert, err = reloader.GetCertificate(nil)
+		c.So(err, c.ShouldBeNil)
+		c.So(certSerial(cert), c.ShouldEqual, 4)
+	})
+}
+
+func TestUpstreamTLS(t *testing.T) {
+	c.Convey("test upstream tls", t, func() {
+		ca := newTestCert("begonia-ca", 1, nil, true)
+		server := newTestCert("upstream", 2, ca, false)
+		client := newTestCert("gateway", 3, ca, false)
+
+		creds, err := (*UpstreamTLS)(nil).Credentials()
+		c.So(err, c.ShouldBeNil)
+		c.So(creds.Info().SecurityProtocol, c.ShouldEqual, "insecure")
+		c.So((*UpstreamTLS)(nil).Redacted(), c.ShouldBeNil)
+
+		c.So((&UpstreamTLS{CA: "invalid"}).Validate(), c.ShouldNotBeNil)
+		c.So((&UpstreamTLS{Cert: string(client.certPEM)}).Validate(), c.ShouldNotBeNil)
+		c.So((&UpstreamTLS{Cert: string(client.certPEM), Key: string(server.keyPEM)}).Validate(), c.ShouldNotBeNil)
+
+		upstream := &UpstreamTLS{
+			CA:         string(ca.certPEM),
+			Cert:       string(client.certPEM),
+			Key:        string(client.keyPEM),
+			ServerName: "localhost",
+		}
+		c.So(upstream.Validate(), c.ShouldBeNil)
+		redacted := upstream.Redacted()
+		c.So(redacted.Key, c.ShouldEqual, RedactedSecret)
+		c.So(redacted.Cert, c.ShouldEqual, upstream.Cert)
+		c.So(upstream.Key, c.ShouldEqual, string(client.keyPEM))
+
+		// 端点要求双向认证
+		roots := x509.NewCertPool()
+		roots.AddCert(ca.cert)
+		srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{server.tlsCertificate()},
+			ClientCAs:    roots,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		})))
+		healthpb.RegisterHealthServer(srv, health.NewServer())
+		addr := serveGrpc(srv)
+		defer srv.Stop()
+
+		check := func(upstream *UpstreamTLS) error {
+			creds, err := upstream.Credentials()
+			c.So(err, c.ShouldBeNil)
+			pool := NewGrpcConnPoolWithCredentials(addr, creds, loadbalance.WithPoolSize(1))
+			defer pool.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+			cn, err := pool.Get(ctx)
+			c.So(err, c.ShouldBeNil)
+			defer pool.Release(ctx, cn)
+			conn := cn.ConnInstance().(*grpc.ClientConn)
+			_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+			return err
+		}
+		c.So(check(upstream), c.ShouldBeNil)
+		c.So(check(&UpstreamTLS{CA: string(ca.certPEM), ServerName: "localhost"}), c.ShouldNotBeNil)
+		c.So(check(nil), c.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,75 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	c "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTracing(t *testing.T) {
+	c.Convey("test tracing propagation", t, func() {
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+		otel.SetTracerProvider(provider)
+		SetTextMapPropagator()
+		defer func() {
+			otel.SetTracerProvider(prevProvider)
+			otel.SetTextMapPropagator(prevPropagator)
+		}()
+		traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+		// http入口span继承客户端的traceparent，并通过metadata传递给grpc
+		var md metadata.MD
+		handler := TracingHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			md = metadata.MD{}
+			InjectTraceContext(r.Context(), md)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/example/tracing", nil)
+		req.Header.Set("traceparent", traceparent)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		c.So(w.Code, c.ShouldEqual, http.StatusBadGateway)
+		c.So(md.Get("traceparent"), c.ShouldHaveLength, 1)
+		c.So(md.Get("traceparent")[0], c.ShouldStartWith, "00-4bf92f3577b34da6a3ce929d0e0e4736-")
+		c.So(md.Get("traceparent")[0], c.ShouldNotEqual, traceparent)
+
+		// grpc入口span和上游调用的client span
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		info := &grpc.UnaryServerInfo{FullMethod: "/helloworld.Greeter/SayHello"}
+		var outgoing metadata.MD
+		_, err := TracingUnaryInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			ctx, span := startClientSpan(ctx, info.FullMethod, "127.0.0.1:9527")
+			outgoing, _ = metadata.FromOutgoingContext(ctx)
+			err := status.Error(codes.Unavailable, "unavailable")
+			EndSpan(span, err)
+			return nil, err
+		})
+		c.So(status.Code(err), c.ShouldEqual, codes.Unavailable)
+		c.So(outgoing.Get("traceparent")[0], c.ShouldStartWith, "00-4bf92f3577b34da6a3ce929d0e0e4736-")
+
+		spans := recorder.Ended()
+		c.So(spans, c.ShouldHaveLength, 3)
+		kinds := make(map[trace.SpanKind]sdktrace.ReadOnlySpan)
+		for _, span := range spans {
+			c.So(span.SpanContext().TraceID().String(), c.ShouldEqual, "4bf92f3577b34da6a3ce929d0e0e4736")
+			kinds[span.SpanKind()] = span
+		}
+		client := kinds[trace.SpanKindClient]
+		c.So(client.Name(), c.ShouldEqual, "helloworld.Greeter/SayHello")
+		c.So(client.Status().Code.String(), c.ShouldEqual, "Error")
+		c.So(outgoing.Get("traceparent")[0], c.ShouldContainSubstring, client.SpanContext().SpanID().String())
+	})
+}
@@ -0,0 +1,630 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// 请求字段的转换操作
+const (
+	TransformRename  = "rename"
+	TransformAdd     = "add"
+	TransformRemove  = "remove"
+	TransformDefault = "default"
+)
+
+// TransformRedacted 响应中脱敏字段的替换值，非字符串字段直接清空
+const TransformRedacted = "******"
+
+// FieldTransform 请求字段的转换规则，字段使用proto字段名，嵌套字段以"."分隔
+type FieldTransform struct {
+	Op    string `json:"op"`
+	Field string `json:"field"`
+	// rename的源字段
+	From string `json:"from,omitempty"`
+	// add和default的取值，Value、Header、Claim只能设置一个
+	Value interface{} `json:"value,omitempty"`
+	// 从请求头获取值
+	Header string `json:"header,omitempty"`
+	// 从jwt claims获取值，需要在jwt鉴权之后执行
+	Claim string `json:"claim,omitempty"`
+}
+
+// ResponseTransform 响应的转换规则，先投影再脱敏
+type ResponseTransform struct {
+	// 只保留的字段，为空时保留所有字段
+	Fields []string `json:"fields,omitempty"`
+	// 脱敏的字段
+	Redact []string `json:"redact,omitempty"`
+}
+
+type TransformRule struct {
+	Request  []*FieldTransform  `json:"request,omitempty"`
+	Response *ResponseTransform `json:"response,omitempty"`
+}
+
+// EndpointTransform 端点的请求响应转换规则，键的匹配方式与EndpointPolicy相同，
+// 服务和全局的规则只对包含规则中所有字段的方法生效
+type EndpointTransform map[string]*TransformRule
+
+func (t EndpointTransform) Validate() error {
+	for key, rule := range t {
+		if rule == nil {
+			continue
+		}
+		for i, field := range rule.Request {
+			if field == nil {
+				return fmt.Errorf("%s:request[%d] is empty", key, i)
+			}
+			if field.Field == "" {
+				return fmt.Errorf("%s:request[%d].field is required", key, i)
+			}
+			sources := 0
+			for _, set := range []bool{field.Value != nil, field.Header != "", field.Claim != ""} {
+				if set {
+					sources++
+				}
+			}
+			switch field.Op {
+			case TransformRename:
+				if field.From == "" {
+					return fmt.Errorf("%s:request[%d].from is required", key, i)
+				}
+			case TransformAdd, TransformDefault:
+				if sources != 1 {
+					return fmt.Errorf("%s:request[%d] requires exactly one of value,header,claim", key, i)
+				}
+			case TransformRemove:
+			default:
+				return fmt.Errorf("%s:request[%d] unknown op %s", key, i, field.Op)
+			}
+		}
+	}
+	return nil
+}
+
+// lookup 按方法、服务、全局的顺序匹配转换规则，exact表示规则是否为该方法单独配置
+func (t EndpointTransform) lookup(service, method string) (rule *TransformRule, exact bool) {
+	if rule, ok := t[fmt.Sprintf("%s/%s", service, method)]; ok {
+		return rule, true
+	}
+	if rule, ok := t[service]; ok {
+		return rule, false
+	}
+	return t["*"], false
+}
+
+// MethodTransform 绑定了方法输入输出类型的转换规则
+type MethodTransform struct {
+	rule *TransformRule
+	in   protoreflect.MessageDescriptor
+	out  protoreflect.MessageDescriptor
+}
+
+// compile 检查规则中的字段是否存在于方法的输入输出类型中
+func (t *MethodTransform) compile() error {
+	for _, field := range t.rule.Request {
+		target, err := requestFieldPath(t.in, field.Field)
+		if err != nil {
+			return err
+		}
+		if field.Op != TransformRename {
+			continue
+		}
+		from, err := requestFieldPath(t.in, field.From)
+		if err != nil {
+			return err
+		}
+		src, dst := from[len(from)-1], target[len(target)-1]
+		if src.Kind() != dst.Kind() || src.Cardinality() != dst.Cardinality() || src.IsMap() != dst.IsMap() ||
+			(src.Message() != nil && src.Message().FullName() != dst.Message().FullName()) {
+			return fmt.Errorf("can not rename %s to %s with different type", field.From, field.Field)
+		}
+	}
+	if t.rule.Response != nil {
+		for _, path := range append(append([]string{}, t.rule.Response.Fields...), t.rule.Response.Redact...) {
+			if _, err := fieldPath(t.out, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldPath 解析字段路径，map字段不能再包含子字段
+func fieldPath(md protoreflect.MessageDescriptor, path string) ([]protoreflect.FieldDescriptor, error) {
+	names := strings.Split(path, ".")
+	fields := make([]protoreflect.FieldDescriptor, 0, len(names))
+	for i, name := range names {
+		if md == nil {
+			return nil, fmt.Errorf("field %s is not a message", strings.Join(names[:i], "."))
+		}
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, fmt.Errorf("field %s not found in %s", path, md.FullName())
+		}
+		fields = append(fields, fd)
+		md = nil
+		if fd.Message() != nil && !fd.IsMap() {
+			md = fd.Message()
+		}
+	}
+	return fields, nil
+}
+
+// requestFieldPath 请求中修改的字段，中间的字段不能是repeated
+func requestFieldPath(md protoreflect.MessageDescriptor, path string) ([]protoreflect.FieldDescriptor, error) {
+	fields, err := fieldPath(md, path)
+	if err != nil {
+		return nil, err
+	}
+	for _, fd := range fields[:len(fields)-1] {
+		if fd.IsList() {
+			return nil, fmt.Errorf("field %s is repeated", fd.Name())
+		}
+	}
+	return fields, nil
+}
+
+// Request 按规则修改请求，网关代理的请求为原始字节，使用端点描述文件中的类型解码
+func (t *MethodTransform) Request(ctx context.Context, msg interface{}) error {
+	if len(t.rule.Request) == 0 {
+		return nil
+	}
+	return transformMessage(msg, t.in, func(m protoreflect.Message) error {
+		return t.transformRequest(ctx, m)
+	})
+}
+
+// Response 按规则对响应进行投影和脱敏
+func (t *MethodTransform) Response(msg interface{}) error {
+	if t.rule.Response == nil {
+		return nil
+	}
+	return transformMessage(msg, t.out, func(m protoreflect.Message) error {
+		if len(t.rule.Response.Fields) > 0 {
+			project(m, newFieldTree(t.rule.Response.Fields))
+		}
+		for _, path := range t.rule.Response.Redact {
+			redact(m, strings.Split(path, "."))
+		}
+		return nil
+	})
+}
+
+func transformMessage(msg interface{}, md protoreflect.MessageDescriptor, fn func(m protoreflect.Message) error) error {
+	pm, ok := msg.(proto.Message)
+	if !ok || pm == nil {
+		return nil
+	}
+	raw, ok := pm.(*emptypb.Empty)
+	if !ok {
+		if pm.ProtoReflect().Descriptor().FullName() != md.FullName() {
+			return nil
+		}
+		return fn(pm.ProtoReflect())
+	}
+	dm := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(raw.ProtoReflect().GetUnknown(), dm); err != nil {
+		return fmt.Errorf("unmarshal %s error:%w", md.FullName(), err)
+	}
+	if err := fn(dm); err != nil {
+		return err
+	}
+	data, err := proto.Marshal(dm)
+	if err != nil {
+		return fmt.Errorf("marshal %s error:%w", md.FullName(), err)
+	}
+	raw.ProtoReflect().SetUnknown(data)
+	return nil
+}
+
+func (t *MethodTransform) transformRequest(ctx context.Context, m protoreflect.Message) error {
+	var claims map[string]interface{}
+	for _, field := range t.rule.Request {
+		path := strings.Split(field.Field, ".")
+		switch field.Op {
+		case TransformRemove:
+			if parent, fd := lookupField(m, path, false); parent != nil {
+				parent.Clear(fd)
+			}
+		case TransformRename:
+			src, srcFd := lookupField(m, strings.Split(field.From, "."), false)
+			if src == nil || !src.Has(srcFd) {
+				continue
+			}
+			value := src.Get(srcFd)
+			dst, dstFd := lookupField(m, path, true)
+			dst.Set(dstFd, value)
+			src.Clear(srcFd)
+		case TransformAdd, TransformDefault:
+			if field.Op == TransformDefault {
+				if parent, fd := lookupField(m, path, false); parent != nil && parent.Has(fd) {
+					continue
+				}
+			}
+			var value interface{}
+			switch {
+			case field.Header != "":
+				v, ok := headerValue(ctx, field.Header)
+				if !ok {
+					continue
+				}
+				value = v
+			case field.Claim != "":
+				if claims == nil {
+					claims = jwtClaims(ctx)
+				}
+				v, ok := claimValue(claims, field.Claim)
+				if !ok {
+					continue
+				}
+				value = v
+			default:
+				value = field.Value
+			}
+			parent, fd := lookupField(m, path, true)
+			v, err := protoValue(parent, fd, value)
+			if err != nil {
+				return fmt.Errorf("set field %s error:%w", field.Field, err)
+			}
+			parent.Set(fd, v)
+		}
+	}
+	return nil
+}
+
+// lookupField 获取字段所在的消息，create为true时创建中间的消息，否则中间消息不存在时返回nil
+func lookupField(m protoreflect.Message, path []string, create bool) (protoreflect.Message, protoreflect.FieldDescriptor) {
+	for i, name := range path {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, nil
+		}
+		if i == len(path)-1 {
+			return m, fd
+		}
+		if !create && !m.Has(fd) {
+			return nil, nil
+		}
+		m = m.Mutable(fd).Message()
+	}
+	return nil, nil
+}
+
+func headerValue(ctx context.Context, key string) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0], true
+	}
+	return "", false
+}
+
+type jwtClaimsKey struct{}
+
+// WithJWTClaims jwt鉴权插件校验token签名后将claims写入请求上下文
+func WithJWTClaims(ctx context.Context, claims map[string]interface{}) context.Context {
+	return context.WithValue(ctx, jwtClaimsKey{}, claims)
+}
+
+// JWTClaimsFromContext 获取jwt鉴权插件写入上下文的claims，未经过jwt鉴权的请求返回false
+func JWTClaimsFromContext(ctx context.Context) (map[string]interface{}, bool) {
+	claims, ok := ctx.Value(jwtClaimsKey{}).(map[string]interface{})
+	return claims, ok
+}
+
+func jwtClaims(ctx context.Context) map[string]interface{} {
+	if claims, ok := JWTClaimsFromContext(ctx); ok {
+		return claims
+	}
+	return make(map[string]interface{})
+}
+
+func claimValue(claims map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = claims
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = obj[key]; !ok || value == nil {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// protoValue 将json类型的值转换为字段类型的值，字符串可以转换为数字、布尔和枚举类型
+func protoValue(parent protoreflect.Message, fd protoreflect.FieldDescriptor, value interface{}) (protoreflect.Value, error) {
+	if fd.IsMap() || fd.IsList() || fd.Message() != nil {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		// 借助protojson解析复合类型的字段
+		wrapper := parent.New()
+		data = []byte(fmt.Sprintf("{%q:%s}", fd.JSONName(), data))
+		if err := protojson.Unmarshal(data, wrapper.Interface()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return wrapper.Get(fd), nil
+	}
+	return scalarValue(fd, value)
+}
+
+func scalarValue(fd protoreflect.FieldDescriptor, value interface{}) (protoreflect.Value, error) {
+	str, isString := value.(string)
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		if isString {
+			return protoreflect.ValueOfString(str), nil
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfString(string(data)), nil
+	case protoreflect.BytesKind:
+		if !isString {
+			return protoreflect.Value{}, fmt.Errorf("bytes field requires string value")
+		}
+		return protoreflect.ValueOfBytes([]byte(str)), nil
+	case protoreflect.BoolKind:
+		if b, ok := value.(bool); ok {
+			return protoreflect.ValueOfBool(b), nil
+		}
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid bool value %v", value)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.EnumKind:
+		if isString {
+			if v := fd.Enum().Values().ByName(protoreflect.Name(str)); v != nil {
+				return protoreflect.ValueOfEnum(v.Number()), nil
+			}
+		}
+		n, err := numberValue(value)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid enum value %v", value)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	case protoreflect.FloatKind:
+		n, err := numberValue(value)
+		return protoreflect.ValueOfFloat32(float32(n)), err
+	case protoreflect.DoubleKind:
+		n, err := numberValue(value)
+		return protoreflect.ValueOfFloat64(n), err
+	}
+	n, err := numberValue(value)
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	if n != math.Trunc(n) {
+		return protoreflect.Value{}, fmt.Errorf("invalid integer value %v", value)
+	}
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(n)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(int64(n)), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(n)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(n)), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", fd.Kind())
+}
+
+func numberValue(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number value %s", v)
+		}
+		return n, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("invalid number value %v", value)
+}
+
+// fieldTree 投影字段的树形结构，叶子节点保留字段的所有内容
+type fieldTree map[string]fieldTree
+
+func newFieldTree(paths []string) fieldTree {
+	tree := fieldTree{}
+	for _, path := range paths {
+		node := tree
+		names := strings.Split(path, ".")
+		for i, name := range names {
+			child, ok := node[name]
+			if ok && len(child) == 0 {
+				// 已经保留了整个字段
+				break
+			}
+			if !ok {
+				child = fieldTree{}
+				node[name] = child
+			}
+			if i == len(names)-1 {
+				// 保留整个字段，覆盖之前的子字段
+				node[name] = fieldTree{}
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+func project(m protoreflect.Message, tree fieldTree) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		child, ok := tree[string(fd.Name())]
+		switch {
+		case !ok:
+			m.Clear(fd)
+		case len(child) == 0 || fd.IsMap() || fd.Message() == nil:
+		case fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				project(v.List().Get(i).Message(), child)
+			}
+		default:
+			project(v.Message(), child)
+		}
+		return true
+	})
+}
+
+func redact(m protoreflect.Message, path []string) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil || !m.Has(fd) {
+		return
+	}
+	if len(path) == 1 {
+		if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+			m.Set(fd, protoreflect.ValueOfString(TransformRedacted))
+			return
+		}
+		m.Clear(fd)
+		return
+	}
+	if fd.Message() == nil || fd.IsMap() {
+		return
+	}
+	if fd.IsList() {
+		list := m.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			redact(list.Get(i).Message(), path[1:])
+		}
+		return
+	}
+	redact(m.Mutable(fd).Message(), path[1:])
+}
+
+// TransformRegistry 按方法保存转换规则，端点更新时由EndpointWatcher重新注册
+type TransformRegistry struct {
+	mu         sync.RWMutex
+	transforms map[string]*MethodTransform
+}
+
+var transforms = &TransformRegistry{transforms: make(map[string]*MethodTransform)}
+
+// Transforms 全局的转换规则，转换插件在网关启动前创建，因此不挂载在GatewayServer上
+func Transforms() *TransformRegistry {
+	return transforms
+}
+
+// Check 检查规则中的字段是否存在于端点描述文件的方法中
+func (t EndpointTransform) Check(pd ProtobufDescription) error {
+	_, err := t.compile(pd)
+	return err
+}
+
+func (t EndpointTransform) compile(pd ProtobufDescription) (map[string]*MethodTransform, error) {
+	methods := make(map[string]*MethodTransform)
+	if len(t) == 0 {
+		return methods, nil
+	}
+	files, err := protodesc.NewFiles(pd.GetFileDescriptorSet())
+	if err != nil {
+		return nil, fmt.Errorf("new transform files error:%w", err)
+	}
+	var rangeErr error
+	walkMethods(pd, func(key string, service string, method string) {
+		rule, exact := t.lookup(service, method)
+		if rule == nil || rangeErr != nil {
+			return
+		}
+		desc, err := files.FindDescriptorByName(protoreflect.FullName(fmt.Sprintf("%s.%s", service, method)))
+		if err != nil {
+			rangeErr = fmt.Errorf("find method %s/%s error:%w", service, method, err)
+			return
+		}
+		md, ok := desc.(protoreflect.MethodDescriptor)
+		if !ok {
+			rangeErr = fmt.Errorf("%s/%s is not a method", service, method)
+			return
+		}
+		mt := &MethodTransform{rule: rule, in: md.Input(), out: md.Output()}
+		if err := mt.compile(); err != nil {
+			if !exact {
+				return
+			}
+			rangeErr = fmt.Errorf("%s/%s:%w", service, method, err)
+			return
+		}
+		methods[key] = mt
+	})
+	if rangeErr != nil {
+		return nil, rangeErr
+	}
+	return methods, nil
+}
+
+// Register 注册端点所有方法的转换规则，规则中的字段不存在时返回错误且不修改已注册的规则
+func (r *TransformRegistry) Register(pd ProtobufDescription, transform EndpointTransform) error {
+	methods, err := transform.compile(pd)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	walkMethods(pd, func(key string, _ string, _ string) {
+		delete(r.transforms, key)
+		if t, ok := methods[key]; ok {
+			r.transforms[key] = t
+		}
+	})
+	return nil
+}
+
+func (r *TransformRegistry) Delete(pd ProtobufDescription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	walkMethods(pd, func(key string, _ string, _ string) {
+		delete(r.transforms, key)
+	})
+}
+
+// Get 获取方法的转换规则，没有规则时返回nil
+func (r *TransformRegistry) Get(fullMethod string) *MethodTransform {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !strings.HasPrefix(fullMethod, "/") {
+		fullMethod = "/" + fullMethod
+	}
+	return r.transforms[strings.ToUpper(fullMethod)]
+}
+
+func walkMethods(pd ProtobufDescription, fn func(key string, service string, method string)) {
+	for _, file := range pd.GetFileDescriptorSet().GetFile() {
+		for _, service := range file.GetService() {
+			serviceName := fmt.Sprintf("%s.%s", file.GetPackage(), service.GetName())
+			for _, method := range service.GetMethod() {
+				fn(strings.ToUpper(fmt.Sprintf("/%s/%s", serviceName, method.GetName())), serviceName, method.GetName())
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func newTransformTestDescription() ProtobufDescription {
+	_, filename, _, _ := runtime.Caller(0)
+	pbFile := filepath.Join(filepath.Dir(filepath.Dir(filename)), "testdata", "helloworld.pb")
+	pb, err := os.ReadFile(pbFile)
+	if err != nil {
+		panic(err)
+	}
+	pd, err := NewDescriptionFromBinary(pb, filepath.Join("tmp", "test-transform"))
+	if err != nil {
+		panic(err)
+	}
+	return pd
+}
+
+func dynamicField(m protoreflect.Message, name string) protoreflect.Value {
+	return m.Get(m.Descriptor().Fields().ByName(protoreflect.Name(name)))
+}
+
+func TestTransform(t *testing.T) {
+	c.Convey("test transform", t, func() {
+		pd := newTransformTestDescription()
+		defer Transforms().Delete(pd)
+
+		c.So(EndpointTransform{"*": {Request: []*FieldTransform{{Op: "copy", Field: "name"}}}}.Validate(), c.ShouldNotBeNil)
+		c.So(EndpointTransform{"*": {Request: []*FieldTransform{{Op: TransformRename, Field: "name"}}}}.Validate(), c.ShouldNotBeNil)
+		c.So(EndpointTransform{"*": {Request: []*FieldTransform{{Op: TransformAdd, Field: "name", Value: "a", Header: "x-name"}}}}.Validate(), c.ShouldNotBeNil)
+		c.So(EndpointTransform{"helloworld.Greeter/SayHello": {Request: []*FieldTransform{{Op: TransformAdd, Field: "not_exists", Value: "a"}}}}.Check(pd), c.ShouldNotBeNil)
+		c.So(EndpointTransform{"helloworld.Greeter/SayHelloClientStream": {Response: &ResponseTransform{Redact: []string{"replies.not_exists"}}}}.Check(pd), c.ShouldNotBeNil)
+		c.So(EndpointTransform{"helloworld.Greeter/SayHello": {Request: []*FieldTransform{{Op: TransformRename, Field: "name", From: "msg.name"}}}}.Check(pd), c.ShouldNotBeNil)
+
+		transform := EndpointTransform{
+			"helloworld.Greeter/SayHello": {
+				Request: []*FieldTransform{
+					{Op: TransformRename, Field: "name", From: "msg"},
+					{Op: TransformAdd, Field: "msg", Header: "x-client"},
+					{Op: TransformDefault, Field: "name", Claim: "user.name"},
+				},
+				Response: &ResponseTransform{Fields: []string{"message"}, Redact: []string{"message"}},
+			},
+			"helloworld.Greeter": {
+				Request: []*FieldTransform{{Op: TransformRemove, Field: "msg"}},
+			},
+		}
+		c.So(transform.Validate(), c.ShouldBeNil)
+		c.So(Transforms().Register(pd, transform), c.ShouldBeNil)
+		c.So(Transforms().Get("/helloworld.Greeter/SayHelloError"), c.ShouldNotBeNil)
+		c.So(Transforms().Get("/other.Greeter/SayHello"), c.ShouldBeNil)
+		// 服务的规则不作用于没有对应字段的方法
+		c.So(Transforms().Get("/helloworld.Greeter/SayHelloBody"), c.ShouldBeNil)
+		method := Transforms().Get("helloworld.Greeter/SayHello")
+		c.So(method, c.ShouldNotBeNil)
+
+		in := pd.GetMessageTypeByFullName("helloworld.HelloRequest")
+		out := pd.GetMessageTypeByFullName("helloworld.HelloReply")
+		claims := base64.RawURLEncoding.EncodeToString([]byte(`{"user":{"name":"claim-user"}}`))
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-client", "web", "x-token", "header."+claims+".sign"))
+
+		// 只读取jwt鉴权插件写入上下文的claims，不解析请求头中的token
+		req := dynamicpb.NewMessage(in)
+		c.So(method.Request(ctx, req), c.ShouldBeNil)
+		c.So(req.Has(in.Fields().ByName("name")), c.ShouldBeFalse)
+		ctx = WithJWTClaims(ctx, map[string]interface{}{"user": map[string]interface{}{"name": "claim-user"}})
+
+		// 动态消息
+		req = dynamicpb.NewMessage(in)
+		req.Set(in.Fields().ByName("msg"), protoreflect.ValueOfString("hello"))
+		c.So(method.Request(ctx, req), c.ShouldBeNil)
+		c.So(dynamicField(req, "name").String(), c.ShouldEqual, "hello")
+		c.So(dynamicField(req, "msg").String(), c.ShouldEqual, "web")
+
+		// 没有可重命名的字段时使用claims中的默认值
+		req = dynamicpb.NewMessage(in)
+		c.So(method.Request(ctx, req), c.ShouldBeNil)
+		c.So(dynamicField(req, "name").String(), c.ShouldEqual, "claim-user")
+
+		// 代理转发的原始消息
+		data, err := proto.Marshal(req)
+		c.So(err, c.ShouldBeNil)
+		raw := &emptypb.Empty{}
+		raw.ProtoReflect().SetUnknown(data)
+		c.So(Transforms().Get("/helloworld.Greeter/SayHelloGet").Request(ctx, raw), c.ShouldBeNil)
+		req = dynamicpb.NewMessage(in)
+		c.So(proto.Unmarshal(raw.ProtoReflect().GetUnknown(), req), c.ShouldBeNil)
+		c.So(dynamicField(req, "name").String(), c.ShouldEqual, "claim-user")
+		c.So(req.Has(in.Fields().ByName("msg")), c.ShouldBeFalse)
+
+		rsp := dynamicpb.NewMessage(out)
+		rsp.Set(out.Fields().ByName("message"), protoreflect.ValueOfString("secret"))
+		rsp.Set(out.Fields().ByName("name"), protoreflect.ValueOfString("name"))
+		c.So(method.Response(rsp), c.ShouldBeNil)
+		c.So(dynamicField(rsp, "message").String(), c.ShouldEqual, TransformRedacted)
+		c.So(rsp.Has(out.Fields().ByName("name")), c.ShouldBeFalse)
+
+		// 字段不存在时不修改已注册的规则
+		c.So(Transforms().Register(pd, EndpointTransform{"helloworld.Greeter/SayHello": {Request: []*FieldTransform{{Op: TransformRemove, Field: "not_exists"}}}}), c.ShouldNotBeNil)
+		c.So(Transforms().Get("/helloworld.Greeter/SayHello"), c.ShouldNotBeNil)
+		c.So(Transforms().Register(pd, nil), c.ShouldBeNil)
+		c.So(Transforms().Get("/helloworld.Greeter/SayHello"), c.ShouldBeNil)
+	})
+}
+
+func TestTransformValue(t *testing.T) {
+	c.Convey("test transform value", t, func() {
+		pd := newTransformTestDescription()
+		defer Transforms().Delete(pd)
+		transform := EndpointTransform{
+			"helloworld.Greeter/SayHelloClientStream": {
+				Response: &ResponseTransform{Fields: []string{"replies.name"}},
+			},
+			"helloworld.Greeter/SayHello": {
+				Request: []*FieldTransform{{Op: TransformAdd, Field: "name", Value: 1.0}},
+			},
+			"helloworld.Greeter/SayHelloError": {
+				Request: []*FieldTransform{{Op: TransformAdd, Field: "code", Value: "404"}},
+			},
+		}
+		c.So(Transforms().Register(pd, transform), c.ShouldBeNil)
+
+		in := pd.GetMessageTypeByFullName("helloworld.ErrorRequest")
+		req := dynamicpb.NewMessage(in)
+		c.So(Transforms().Get("/helloworld.Greeter/SayHelloError").Request(context.Background(), req), c.ShouldBeNil)
+		c.So(dynamicField(req, "code").Int(), c.ShouldEqual, 404)
+
+		hello := dynamicpb.NewMessage(pd.GetMessageTypeByFullName("helloworld.HelloRequest"))
+		c.So(Transforms().Get("/helloworld.Greeter/SayHello").Request(context.Background(), hello), c.ShouldBeNil)
+		c.So(dynamicField(hello, "name").String(), c.ShouldEqual, "1")
+
+		// 嵌套在repeated中的字段投影
+		out := pd.GetMessageTypeByFullName("helloworld.RepeatedReply")
+		reply := pd.GetMessageTypeByFullName("helloworld.HelloReply")
+		rsp := dynamicpb.NewMessage(out)
+		list := rsp.Mutable(out.Fields().ByName("replies")).List()
+		item := dynamicpb.NewMessage(reply)
+		item.Set(reply.Fields().ByName("name"), protoreflect.ValueOfString("name"))
+		item.Set(reply.Fields().ByName("message"), protoreflect.ValueOfString("message"))
+		list.Append(protoreflect.ValueOfMessage(item))
+		c.So(Transforms().Get("/helloworld.Greeter/SayHelloClientStream").Response(rsp), c.ShouldBeNil)
+		c.So(item.Has(reply.Fields().ByName("message")), c.ShouldBeFalse)
+		c.So(dynamicField(item, "name").String(), c.ShouldEqual, "name")
+
+		c.So(newFieldTree([]string{"a.b", "a", "c.d", "c.e"}), c.ShouldResemble, fieldTree{"a": {}, "c": {"d": {}, "e": {}}})
+	})
+}
@@ -27,12 +27,21 @@ func JSONCamelCase(s string) string {
 }
 
 func NewLoadBalanceEndpoint(lb loadbalance.BalanceType, endpoints []*api.EndpointMeta) ([]loadbalance.Endpoint, error) {
+	return NewLoadBalanceEndpointWithTLS(lb, endpoints, nil)
+}
+
+// NewLoadBalanceEndpointWithTLS 创建负载均衡的端点，upstream为空时使用明文连接
+func NewLoadBalanceEndpointWithTLS(lb loadbalance.BalanceType, endpoints []*api.EndpointMeta, upstream *UpstreamTLS) ([]loadbalance.Endpoint, error) {
 	eps := make([]loadbalance.Endpoint, 0)
 	gw := Get()
 
 	opts := gw.GetOptions()
+	creds, err := upstream.Credentials()
+	if err != nil {
+		return nil, fmt.Errorf("new upstream tls credentials error:%w", err)
+	}
 	for _, ep := range endpoints {
-		pool := NewGrpcConnPool(ep.GetAddr(), opts.PoolOptions...)
+		pool := NewGrpcConnPoolWithCredentials(ep.GetAddr(), creds, opts.PoolOptions...)
 		eps = append(eps, NewGrpcEndpoint(ep.GetAddr(), pool))
 	}
 	switch lb {
@@ -0,0 +1,244 @@
+package biz
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	ak "github.com/begonia-org/begonia/api/accesskey/v1"
+	"github.com/begonia-org/begonia/internal/pkg"
+	gosdk "github.com/begonia-org/go-sdk"
+	api "github.com/begonia-org/go-sdk/api/app/v1"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// 密钥可以更新的字段
+var accessKeyUpdatePaths = []string{"name", "enabled", "expires_at", "scopes"}
+
+func newAccessKey(snowflake *tiga.Snowflake, appid, accessKey, secret, owner string) *ak.AccessKey {
+	return &ak.AccessKey{
+		Uid:       snowflake.GenerateIDString(),
+		Appid:     appid,
+		AccessKey: accessKey,
+		Secret:    secret,
+		Name:      "default",
+		Enabled:   true,
+		Scopes:    &ak.AccessKeyScope{},
+		Owner:     owner,
+	}
+}
+
+// loadAccessKey 获取密钥，多密钥之前创建的app只在apps表中保存了一对密钥，首次使用时导入密钥表
+func loadAccessKey(ctx context.Context, repo AppRepo, snowflake *tiga.Snowflake, accessKey string) (*ak.AccessKey, error) {
+	key, err := repo.GetKey(ctx, accessKey)
+	if err == nil || !isNotFound(err) {
+		return key, err
+	}
+	app, appErr := repo.Get(ctx, accessKey)
+	if appErr != nil || app.AccessKey != accessKey {
+		return nil, err
+	}
+	key = newAccessKey(snowflake, app.Appid, app.AccessKey, app.Secret, app.Owner)
+	if err := repo.AddKey(ctx, key); err != nil {
+		// 并发请求已经导入
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return repo.GetKey(ctx, accessKey)
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+// accessKeyExpired 未设置过期时间的密钥永不过期
+func accessKeyExpired(key *ak.AccessKey, now time.Time) bool {
+	return key.ExpiresAt.GetSeconds() > 0 && !key.ExpiresAt.AsTime().After(now)
+}
+
+// AccessKeyAllowed 密钥的权限范围是否包含方法，未设置权限范围时不限制，
+// 服务名和方法名不区分大小写，tags为方法所属端点的标签
+func AccessKeyAllowed(scopes *ak.AccessKeyScope, fullMethod string, tags []string) bool {
+	if len(scopes.GetServices()) == 0 && len(scopes.GetMethods()) == 0 && len(scopes.GetTags()) == 0 {
+		return true
+	}
+	method := "/" + strings.TrimPrefix(fullMethod, "/")
+	for _, m := range scopes.GetMethods() {
+		if strings.EqualFold("/"+strings.TrimPrefix(m, "/"), method) {
+			return true
+		}
+	}
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		service := method[1:i]
+		for _, s := range scopes.GetServices() {
+			if strings.EqualFold(s, service) {
+				return true
+			}
+		}
+	}
+	for _, t := range scopes.GetTags() {
+		for _, tag := range tags {
+			if t == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *AppUsecase) getKey(ctx context.Context, appid, accessKey string) (*api.Apps, *ak.AccessKey, error) {
+	app, err := a.Get(ctx, appid)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := loadAccessKey(ctx, a.repo, a.snowflake, accessKey)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil, gosdk.NewError(pkg.ErrAccessKeyNotFound, int32(common.Code_NOT_FOUND), codes.NotFound, "get_access_key")
+		}
+		return nil, nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "get_access_key")
+	}
+	if key.Appid != app.Appid {
+		return nil, nil, gosdk.NewError(pkg.ErrAccessKeyNotFound, int32(common.Code_NOT_FOUND), codes.NotFound, "get_access_key")
+	}
+	return app, key, nil
+}
+
+// CreateKey 为app新增密钥，只在创建时返回密钥明文
+func (a *AppUsecase) CreateKey(ctx context.Context, in *ak.CreateAccessKeyRequest, owner string) (*ak.AccessKey, error) {
+	app, err := a.Get(ctx, in.Appid)
+	if err != nil {
+		return nil, err
+	}
+	accessKey, err := GenerateAppAccessKey()
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(api.APPSvrCode_APP_CREATE_ERR), codes.Internal, "generate_app_access_key")
+	}
+	secret, err := GenerateAppSecret()
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(api.APPSvrCode_APP_CREATE_ERR), codes.Internal, "generate_app_secret_key")
+	}
+	key := newAccessKey(a.snowflake, app.Appid, accessKey, secret, owner)
+	key.Name = in.Name
+	key.ExpiresAt = in.ExpiresAt
+	if in.Scopes != nil {
+		key.Scopes = in.Scopes
+	}
+	if err := a.repo.AddKey(ctx, key); err != nil {
+		return nil, gosdk.NewError(err, int32(api.APPSvrCode_APP_CREATE_ERR), codes.Internal, "create_access_key")
+	}
+	return key, nil
+}
+
+// ListKeys app的所有密钥，不返回密钥明文
+func (a *AppUsecase) ListKeys(ctx context.Context, appid string) ([]*ak.AccessKey, error) {
+	app, err := a.Get(ctx, appid)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := loadAccessKey(ctx, a.repo, a.snowflake, app.AccessKey); err != nil && !isNotFound(err) {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_access_keys")
+	}
+	keys, err := a.repo.ListKeys(ctx, app.Appid)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_access_keys")
+	}
+	for _, key := range keys {
+		key.Secret = ""
+		key.PreviousSecret = ""
+	}
+	return keys, nil
+}
+
+// UpdateKey 按update_mask更新密钥，未指定时更新名称、启用状态、过期时间和权限范围
+func (a *AppUsecase) UpdateKey(ctx context.Context, in *ak.UpdateAccessKeyRequest) (*ak.AccessKey, error) {
+	_, key, err := a.getKey(ctx, in.Appid, in.AccessKey)
+	if err != nil {
+		return nil, err
+	}
+	paths := accessKeyUpdatePaths
+	if in.UpdateMask != nil && len(in.UpdateMask.Paths) > 0 {
+		paths = make([]string, 0)
+		for _, path := range in.UpdateMask.Paths {
+			for _, allowed := range accessKeyUpdatePaths {
+				if path == allowed {
+					paths = append(paths, path)
+				}
+			}
+		}
+	}
+	for _, path := range paths {
+		switch path {
+		case "name":
+			key.Name = in.Name
+		case "enabled":
+			key.Enabled = in.Enabled
+		case "expires_at":
+			key.ExpiresAt = in.ExpiresAt
+		case "scopes":
+			key.Scopes = in.Scopes
+			if key.Scopes == nil {
+				key.Scopes = &ak.AccessKeyScope{}
+			}
+		}
+	}
+	key.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
+	if err := a.repo.PatchKey(ctx, key); err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "update_access_key")
+	}
+	key.Secret = ""
+	key.PreviousSecret = ""
+	return key, nil
+}
+
+// RotateKey 生成新的密钥，旧密钥在宽限期内仍然可以使用，
+// 宽限期内再次轮换时上一次轮换前的密钥立即失效
+func (a *AppUsecase) RotateKey(ctx context.Context, in *ak.RotateAccessKeyRequest) (*ak.AccessKey, error) {
+	app, key, err := a.getKey(ctx, in.Appid, in.AccessKey)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := GenerateAppSecret()
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "generate_app_secret_key")
+	}
+	grace := time.Duration(in.GracePeriod) * time.Second
+	if in.GracePeriod == 0 {
+		grace = time.Duration(a.config.GetAPPKeyGracePeriod()) * time.Second
+	}
+	key.PreviousSecret = ""
+	key.PreviousExpiresAt = timestamppb.Now()
+	if grace > 0 {
+		key.PreviousSecret = key.Secret
+		key.PreviousExpiresAt = timestamppb.New(time.Now().Add(grace))
+	}
+	key.Secret = secret
+	key.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"secret", "previous_secret", "previous_expires_at"}}
+	if err := a.repo.PatchKey(ctx, key); err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "rotate_access_key")
+	}
+	// apps表中保存的是app创建时的密钥，保持一致
+	if app.AccessKey == key.AccessKey {
+		app.Secret = secret
+		app.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"secret"}}
+		if err := a.repo.Patch(ctx, app); err != nil {
+			return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "rotate_access_key")
+		}
+		_ = a.repo.Cache(ctx, a.config.GetAPPAccessKeyPrefix(), app, time.Duration(0)*time.Second)
+	}
+	key.PreviousSecret = ""
+	return key, nil
+}
+
+func (a *AppUsecase) DelKey(ctx context.Context, appid, accessKey string) error {
+	_, key, err := a.getKey(ctx, appid, accessKey)
+	if err != nil {
+		return err
+	}
+	if err := a.repo.DelKey(ctx, key); err != nil {
+		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "delete_access_key")
+	}
+	return nil
+}
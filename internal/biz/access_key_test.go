@@ -0,0 +1,374 @@
+package biz_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/begonia-org/begonia"
+	ak "github.com/begonia-org/begonia/api/accesskey/v1"
+	"github.com/begonia-org/begonia/config"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/pkg"
+	cfg "github.com/begonia-org/begonia/internal/pkg/config"
+	gosdk "github.com/begonia-org/go-sdk"
+	api "github.com/begonia-org/go-sdk/api/app/v1"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+type memoryAppRepo struct {
+	apps map[string]*api.Apps
+	keys map[string]*ak.AccessKey
+	// x-nonce过期时间
+	nonces map[string]time.Time
+}
+
+func (m *memoryAppRepo) Add(ctx context.Context, apps *api.Apps) error {
+	m.apps[apps.Appid] = proto.Clone(apps).(*api.Apps)
+	return nil
+}
+func (m *memoryAppRepo) Get(ctx context.Context, key string) (*api.Apps, error) {
+	for _, app := range m.apps {
+		if app.Appid == key || app.AccessKey == key {
+			return proto.Clone(app).(*api.Apps), nil
+		}
+	}
+	return nil, fmt.Errorf("get app failed: %w", gorm.ErrRecordNotFound)
+}
+func (m *memoryAppRepo) Cache(ctx context.Context, prefix string, models *api.Apps, exp time.Duration) error {
+	return nil
+}
+func (m *memoryAppRepo) Del(ctx context.Context, key string) error {
+	delete(m.apps, key)
+	return nil
+}
+func (m *memoryAppRepo) List(ctx context.Context, tags []string, status []api.APPStatus, page, pageSize int32) ([]*api.Apps, error) {
+	return nil, nil
+}
+func (m *memoryAppRepo) Patch(ctx context.Context, model *api.Apps) error {
+	return m.Add(ctx, model)
+}
+func (m *memoryAppRepo) GetSecret(ctx context.Context, accessKey string) (string, error) {
+	app, err := m.Get(ctx, accessKey)
+	if err != nil {
+		return "", err
+	}
+	return app.Secret, nil
+}
+func (m *memoryAppRepo) GetAppid(ctx context.Context, accessKey string) (string, error) {
+	app, err := m.Get(ctx, accessKey)
+	if err != nil {
+		return "", err
+	}
+	return app.Appid, nil
+}
+func (m *memoryAppRepo) AddKey(ctx context.Context, key *ak.AccessKey) error {
+	if _, ok := m.keys[key.AccessKey]; ok {
+		return fmt.Errorf("Error 1062 (23000): Duplicate entry '%s'", key.AccessKey)
+	}
+	m.keys[key.AccessKey] = proto.Clone(key).(*ak.AccessKey)
+	return nil
+}
+func (m *memoryAppRepo) GetKey(ctx context.Context, accessKey string) (*ak.AccessKey, error) {
+	if key, ok := m.keys[accessKey]; ok {
+		return proto.Clone(key).(*ak.AccessKey), nil
+	}
+	return nil, fmt.Errorf("get access key failed: %w", gorm.ErrRecordNotFound)
+}
+func (m *memoryAppRepo) ListKeys(ctx context.Context, appid string) ([]*ak.AccessKey, error) {
+	keys := make([]*ak.AccessKey, 0)
+	for _, key := range m.keys {
+		if key.Appid == appid {
+			keys = append(keys, proto.Clone(key).(*ak.AccessKey))
+		}
+	}
+	return keys, nil
+}
+func (m *memoryAppRepo) PatchKey(ctx context.Context, key *ak.AccessKey) error {
+	m.keys[key.AccessKey] = proto.Clone(key).(*ak.AccessKey)
+	return nil
+}
+func (m *memoryAppRepo) DelKey(ctx context.Context, key *ak.AccessKey) error {
+	delete(m.keys, key.AccessKey)
+	return nil
+}
+func (m *memoryAppRepo) TouchKey(ctx context.Context, uid string, t time.Time) error {
+	for _, key := range m.keys {
+		if key.Uid == uid {
+			key.LastUsedAt = timestamppb.New(t)
+		}
+	}
+	return nil
+}
+
+func (m *memoryAppRepo) UseNonce(ctx context.Context, key string, exp time.Duration) (bool, error) {
+	if m.nonces == nil {
+		m.nonces = make(map[string]time.Time)
+	}
+	if t, ok := m.nonces[key]; ok && t.After(time.Now()) {
+		return false, nil
+	}
+	m.nonces[key] = time.Now().Add(exp)
+	return true, nil
+}
+
+func newHelloRequest(headers map[string]string) *gosdk.GatewayRequest {
+	req, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:1949/api/v1/helloworld", strings.NewReader(`{"msg":"hello"}`))
+	req.Header.Add("content-type", "application/json")
+	for k, v := range headers {
+		req.Header.Add(k, v)
+	}
+	gw, _ := gosdk.NewGatewayRequestFromHttp(req)
+	return gw
+}
+
+func signedRequest(accessKey, secret string) *gosdk.GatewayRequest {
+	gw := newHelloRequest(nil)
+	_ = gosdk.NewAppAuthSigner(accessKey, secret).SignRequest(gw)
+	return gw
+}
+
+// signedRequestAt 按客户端时钟t签名，sdk的SignRequest不接受一分钟以前的x-date
+func signedRequestAt(accessKey, secret string, t time.Time, headers map[string]string) *gosdk.GatewayRequest {
+	gw := newHelloRequest(headers)
+	gw.Headers.Del("content-type")
+	gw.Headers.Set(gosdk.HeaderXDateTime, t.UTC().Format(gosdk.DateFormat))
+	gw.Headers.Set(gosdk.HeaderXAccessKey, accessKey)
+	signer := &gosdk.AppAuthSignerImpl{Key: accessKey, Secret: secret}
+	signedHeaders := signer.SignedHeaders(gw)
+	canonicalRequest, _ := signer.CanonicalRequest(gw, signedHeaders)
+	stringToSign, _ := signer.StringToSign(canonicalRequest, t)
+	signature, _ := signer.SignStringToSign(stringToSign, []byte(secret))
+	gw.Headers.Set(gosdk.HeaderXAuthorization, signer.AuthHeaderValue(signature, accessKey, signedHeaders))
+	return gw
+}
+
+func TestAccessKeyAllowed(t *testing.T) {
+	c.Convey("test access key scopes", t, func() {
+		c.So(biz.AccessKeyAllowed(nil, "/begonia.org.sdk.AppsService/Get", nil), c.ShouldBeTrue)
+		c.So(biz.AccessKeyAllowed(&ak.AccessKeyScope{}, "/begonia.org.sdk.AppsService/Get", nil), c.ShouldBeTrue)
+
+		scopes := &ak.AccessKeyScope{Methods: []string{"begonia.org.sdk.AppsService/Get"}}
+		c.So(biz.AccessKeyAllowed(scopes, "/BEGONIA.ORG.SDK.APPSSERVICE/GET", nil), c.ShouldBeTrue)
+		c.So(biz.AccessKeyAllowed(scopes, "/begonia.org.sdk.AppsService/Post", nil), c.ShouldBeFalse)
+
+		scopes = &ak.AccessKeyScope{Services: []string{"begonia.org.sdk.AppsService"}}
+		c.So(biz.AccessKeyAllowed(scopes, "/begonia.org.sdk.AppsService/Post", nil), c.ShouldBeTrue)
+		c.So(biz.AccessKeyAllowed(scopes, "/begonia.org.sdk.AppsServiceV2/Post", nil), c.ShouldBeFalse)
+
+		scopes = &ak.AccessKeyScope{Tags: []string{"public"}}
+		c.So(biz.AccessKeyAllowed(scopes, "/example.v1.HelloService/SayHello", []string{"internal", "public"}), c.ShouldBeTrue)
+		c.So(biz.AccessKeyAllowed(scopes, "/example.v1.HelloService/SayHello", []string{"internal"}), c.ShouldBeFalse)
+	})
+}
+
+func TestAccessKeyRotation(t *testing.T) {
+	c.Convey("test multiple access keys, rotation and scopes", t, func() {
+		env := "dev"
+		if begonia.Env != "" {
+			env = begonia.Env
+		}
+		conf := config.ReadConfig(env)
+		conf.Set("auth.app.rotation_grace_period", 3600)
+		cnf := cfg.NewConfig(conf)
+		repo := &memoryAppRepo{apps: make(map[string]*api.Apps), keys: make(map[string]*ak.AccessKey)}
+		apps := biz.NewAppUsecase(repo, cnf)
+		aksk := biz.NewAccessKeyAuth(repo, cnf, gateway.Log)
+		ctx := context.Background()
+
+		// 创建app时写入默认密钥
+		app, err := apps.CreateApp(ctx, &api.AppsRequest{Name: "app-keys"}, "owner")
+		c.So(err, c.ShouldBeNil)
+		c.So(repo.keys[app.AccessKey].Secret, c.ShouldEqual, app.Secret)
+		accessKey, err := aksk.AppValidator(ctx, signedRequest(app.AccessKey, app.Secret))
+		c.So(err, c.ShouldBeNil)
+		c.So(accessKey, c.ShouldEqual, app.AccessKey)
+		c.So(repo.keys[app.AccessKey].LastUsedAt, c.ShouldNotBeNil)
+
+		// 同一个app的第二个密钥
+		key, err := apps.CreateKey(ctx, &ak.CreateAccessKeyRequest{Appid: app.Appid, Name: "ci", Scopes: &ak.AccessKeyScope{Services: []string{"begonia.org.sdk.AppsService"}}}, "owner")
+		c.So(err, c.ShouldBeNil)
+		c.So(key.Secret, c.ShouldNotBeEmpty)
+		_, err = aksk.AppValidator(ctx, signedRequest(key.AccessKey, key.Secret))
+		c.So(err, c.ShouldBeNil)
+		appid, err := aksk.GetAppid(ctx, key.AccessKey)
+		c.So(err, c.ShouldBeNil)
+		c.So(appid, c.ShouldEqual, app.Appid)
+		c.So(aksk.CheckScope(ctx, key.AccessKey, "/begonia.org.sdk.AppsService/Get"), c.ShouldBeNil)
+		err = aksk.CheckScope(ctx, key.AccessKey, "/begonia.org.sdk.EndpointService/Get")
+		c.So(status.Code(err), c.ShouldEqual, codes.PermissionDenied)
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrAccessKeyScope.Error())
+		c.So(aksk.CheckScope(ctx, app.AccessKey, "/begonia.org.sdk.EndpointService/Get"), c.ShouldBeNil)
+
+		keys, err := apps.ListKeys(ctx, app.Appid)
+		c.So(err, c.ShouldBeNil)
+		c.So(keys, c.ShouldHaveLength, 2)
+		for _, item := range keys {
+			c.So(item.Secret, c.ShouldBeEmpty)
+		}
+
+		// 轮换后宽限期内新旧密钥都有效
+		rotated, err := apps.RotateKey(ctx, &ak.RotateAccessKeyRequest{Appid: app.Appid, AccessKey: app.AccessKey})
+		c.So(err, c.ShouldBeNil)
+		c.So(rotated.Secret, c.ShouldNotEqual, app.Secret)
+		c.So(rotated.PreviousExpiresAt.AsTime(), c.ShouldHappenAfter, time.Now().Add(59*time.Minute))
+		c.So(repo.apps[app.Appid].Secret, c.ShouldEqual, rotated.Secret)
+		_, err = aksk.AppValidator(ctx, signedRequest(app.AccessKey, app.Secret))
+		c.So(err, c.ShouldBeNil)
+		_, err = aksk.AppValidator(ctx, signedRequest(app.AccessKey, rotated.Secret))
+		c.So(err, c.ShouldBeNil)
+		_, err = aksk.AppValidator(ctx, signedRequest(app.AccessKey, "invalid-secret"))
+		c.So(err, c.ShouldNotBeNil)
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrAppSignatureInvalid.Error())
+
+		// 宽限期结束后旧密钥失效
+		repo.keys[app.AccessKey].PreviousExpiresAt = timestamppb.New(time.Now().Add(-time.Second))
+		_, err = aksk.AppValidator(ctx, signedRequest(app.AccessKey, app.Secret))
+		c.So(err, c.ShouldNotBeNil)
+
+		// 不保留旧密钥的轮换
+		again, err := apps.RotateKey(ctx, &ak.RotateAccessKeyRequest{Appid: app.Appid, AccessKey: key.AccessKey, GracePeriod: -1})
+		c.So(err, c.ShouldBeNil)
+		_, err = aksk.AppValidator(ctx, signedRequest(key.AccessKey, key.Secret))
+		c.So(err, c.ShouldNotBeNil)
+		_, err = aksk.AppValidator(ctx, signedRequest(key.AccessKey, again.Secret))
+		c.So(err, c.ShouldBeNil)
+		c.So(repo.apps[app.Appid].Secret, c.ShouldEqual, rotated.Secret)
+
+		// 禁用和过期的密钥
+		_, err = apps.UpdateKey(ctx, &ak.UpdateAccessKeyRequest{Appid: app.Appid, AccessKey: key.AccessKey, Enabled: false, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"enabled"}}})
+		c.So(err, c.ShouldBeNil)
+		c.So(repo.keys[key.AccessKey].Name, c.ShouldEqual, "ci")
+		_, err = aksk.AppValidator(ctx, signedRequest(key.AccessKey, again.Secret))
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrAccessKeyDisabled.Error())
+		_, err = apps.UpdateKey(ctx, &ak.UpdateAccessKeyRequest{Appid: app.Appid, AccessKey: key.AccessKey, Enabled: true, ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute)), UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"enabled", "expires_at"}}})
+		c.So(err, c.ShouldBeNil)
+		_, err = aksk.AppValidator(ctx, signedRequest(key.AccessKey, again.Secret))
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrAccessKeyExpired.Error())
+
+		// 密钥不属于app
+		_, err = apps.RotateKey(ctx, &ak.RotateAccessKeyRequest{Appid: app.Appid, AccessKey: "not-exists"})
+		c.So(status.Code(err), c.ShouldEqual, codes.NotFound)
+		other, _ := apps.CreateApp(ctx, &api.AppsRequest{Name: "app-other"}, "owner")
+		err = apps.DelKey(ctx, other.Appid, key.AccessKey)
+		c.So(status.Code(err), c.ShouldEqual, codes.NotFound)
+		c.So(apps.DelKey(ctx, app.Appid, key.AccessKey), c.ShouldBeNil)
+		_, err = aksk.GetSecret(ctx, key.AccessKey)
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+	})
+	c.Convey("test access key of app created before multiple keys", t, func() {
+		env := "dev"
+		if begonia.Env != "" {
+			env = begonia.Env
+		}
+		cnf := cfg.NewConfig(config.ReadConfig(env))
+		repo := &memoryAppRepo{apps: make(map[string]*api.Apps), keys: make(map[string]*ak.AccessKey)}
+		repo.apps["legacy"] = &api.Apps{Appid: "legacy", AccessKey: "legacy-access-key", Secret: "legacy-secret", Status: api.APPStatus_APP_ENABLED}
+		aksk := biz.NewAccessKeyAuth(repo, cnf, gateway.Log)
+
+		_, err := aksk.AppValidator(context.Background(), signedRequest("legacy-access-key", "legacy-secret"))
+		c.So(err, c.ShouldBeNil)
+		c.So(repo.keys["legacy-access-key"], c.ShouldNotBeNil)
+		c.So(repo.keys["legacy-access-key"].Appid, c.ShouldEqual, "legacy")
+		_, err = aksk.GetSecret(context.Background(), "legacy")
+		c.So(err, c.ShouldNotBeNil)
+	})
+}
+
+// errorCode 获取gosdk.NewError携带的业务错误码
+func errorCode(err error) int32 {
+	st, _ := status.FromError(err)
+	for _, detail := range st.Details() {
+		if anyType, ok := detail.(*anypb.Any); ok {
+			errDetail := &common.Errors{}
+			if anyType.UnmarshalTo(errDetail) == nil {
+				return errDetail.Code
+			}
+		}
+	}
+	return 0
+}
+
+func TestAccessKeyNonce(t *testing.T) {
+	c.Convey("test replay protection with x-nonce and skew of app", t, func() {
+		env := "dev"
+		if begonia.Env != "" {
+			env = begonia.Env
+		}
+		conf := config.ReadConfig(env)
+		cnf := cfg.NewConfig(conf)
+		repo := &memoryAppRepo{apps: make(map[string]*api.Apps), keys: make(map[string]*ak.AccessKey)}
+		apps := biz.NewAppUsecase(repo, cnf)
+		ctx := context.Background()
+		app, err := apps.CreateApp(ctx, &api.AppsRequest{Name: "app-nonce"}, "owner")
+		c.So(err, c.ShouldBeNil)
+		strict, err := apps.CreateApp(ctx, &api.AppsRequest{Name: "app-nonce-strict"}, "owner")
+		c.So(err, c.ShouldBeNil)
+		conf.Set("auth.app.signature.skew", 60)
+		conf.Set("auth.app.signature.require_nonce", false)
+		conf.Set("auth.app.signature.apps", []map[string]interface{}{{"appid": strict.Appid, "skew": 300, "require_nonce": true}})
+		aksk := biz.NewAccessKeyAuth(repo, cnf, gateway.Log)
+
+		// 未开启时可以不携带x-nonce
+		_, err = aksk.AppValidator(ctx, signedRequest(app.AccessKey, app.Secret))
+		c.So(err, c.ShouldBeNil)
+
+		nonce := map[string]string{biz.HeaderXNonce: "a9f0c2d4e6b81357"}
+		_, err = aksk.AppValidator(ctx, signedRequestAt(app.AccessKey, app.Secret, time.Now(), nonce))
+		c.So(err, c.ShouldBeNil)
+		_, err = aksk.AppValidator(ctx, signedRequestAt(app.AccessKey, app.Secret, time.Now(), nonce))
+		c.So(err, c.ShouldNotBeNil)
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrAppNonceReplayed.Error())
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+		c.So(errorCode(err), c.ShouldEqual, int32(ak.AccessKeySvrCode_ACCESS_KEY_NONCE_REPLAYED_ERR))
+
+		// 签名错误的请求不占用nonce
+		other := map[string]string{biz.HeaderXNonce: "b7e1d3c5a9f02468"}
+		_, err = aksk.AppValidator(ctx, signedRequestAt(app.AccessKey, "invalid-secret", time.Now(), other))
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrAppSignatureInvalid.Error())
+		_, err = aksk.AppValidator(ctx, signedRequestAt(app.AccessKey, app.Secret, time.Now(), other))
+		c.So(err, c.ShouldBeNil)
+
+		// 签名后添加的x-nonce
+		req := signedRequest(app.AccessKey, app.Secret)
+		req.Headers.Set(biz.HeaderXNonce, "c3a5e7f9b1d20468")
+		_, err = aksk.AppValidator(ctx, req)
+		c.So(err, c.ShouldNotBeNil)
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrAppNonceUnsigned.Error())
+		c.So(errorCode(err), c.ShouldEqual, int32(ak.AccessKeySvrCode_ACCESS_KEY_NONCE_UNSIGNED_ERR))
+
+		// 过长的x-nonce
+		_, err = aksk.AppValidator(ctx, signedRequestAt(app.AccessKey, app.Secret, time.Now(), map[string]string{biz.HeaderXNonce: strings.Repeat("a", 129)}))
+		c.So(err, c.ShouldNotBeNil)
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrAppNonceInvalid.Error())
+		c.So(errorCode(err), c.ShouldEqual, int32(ak.AccessKeySvrCode_ACCESS_KEY_NONCE_INVALID_ERR))
+
+		// 超出全局允许的时间偏差
+		_, err = aksk.AppValidator(ctx, signedRequestAt(app.AccessKey, app.Secret, time.Now().Add(-3*time.Minute), nil))
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrRequestExpired.Error())
+
+		// 单独配置的app要求x-nonce并允许更大的时间偏差
+		_, err = aksk.AppValidator(ctx, signedRequest(strict.AccessKey, strict.Secret))
+		c.So(err, c.ShouldNotBeNil)
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrAppNonceMissing.Error())
+		c.So(errorCode(err), c.ShouldEqual, int32(ak.AccessKeySvrCode_ACCESS_KEY_NONCE_MISSING_ERR))
+		_, err = aksk.AppValidator(ctx, signedRequestAt(strict.AccessKey, strict.Secret, time.Now().Add(-3*time.Minute), nonce))
+		c.So(err, c.ShouldBeNil)
+		_, err = aksk.AppValidator(ctx, signedRequestAt(strict.AccessKey, strict.Secret, time.Now().Add(-3*time.Minute), nonce))
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrAppNonceReplayed.Error())
+		_, err = aksk.AppValidator(ctx, signedRequestAt(strict.AccessKey, strict.Secret, time.Now().Add(-6*time.Minute), other))
+		c.So(err.Error(), c.ShouldContainSubstring, pkg.ErrRequestExpired.Error())
+	})
+}
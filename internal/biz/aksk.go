@@ -2,9 +2,12 @@ package biz
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	ak "github.com/begonia-org/begonia/api/accesskey/v1"
 	"github.com/begonia-org/begonia/internal/pkg"
 	"github.com/begonia-org/begonia/internal/pkg/config"
 	"github.com/begonia-org/begonia/internal/pkg/routers"
@@ -12,20 +15,47 @@ import (
 	api "github.com/begonia-org/go-sdk/api/app/v1"
 	common "github.com/begonia-org/go-sdk/common/api/v1"
 	"github.com/begonia-org/go-sdk/logger"
+	"github.com/spark-lence/tiga"
 	"google.golang.org/grpc/codes"
 )
 
+const (
+	// 记录密钥最近使用时间的最小间隔，避免每次请求都写数据库
+	accessKeyTouchInterval = time.Minute
+	// 未配置时x-date与服务器时间允许的偏差
+	defaultSignatureSkew = time.Minute
+	maxNonceLength       = 128
+)
+
+// HeaderXNonce 请求的唯一标识，参与签名后同一个值在允许的时间偏差内只能使用一次
+const HeaderXNonce = "X-Nonce"
+
 type AccessKeyAuth struct {
-	app    AppRepo
-	config *config.Config
-	log    logger.Logger
+	app       AppRepo
+	config    *config.Config
+	log       logger.Logger
+	snowflake *tiga.Snowflake
+	// 密钥最近一次记录使用时间的时间
+	touched   sync.Map
+	signature *config.AppSignature
 }
 
-func NewAccessKeyAuth(app AppRepo, config *config.Config, log logger.Logger) *AccessKeyAuth {
+func NewAccessKeyAuth(app AppRepo, cfg *config.Config, log logger.Logger) *AccessKeyAuth {
+	sn, _ := tiga.NewSnowflake(1)
+	signature, err := cfg.GetAppSignature()
+	if err != nil {
+		signature = &config.AppSignature{}
+	}
+	// 兼容未携带x-nonce的客户端，所有客户端升级后应开启require_nonce
+	if !signature.RequireNonce {
+		log.Warnf(context.TODO(), "auth.app.signature.require_nonce is disabled, signed requests can be replayed within the x-date skew, enable it after all clients send x-nonce")
+	}
 	return &AccessKeyAuth{
-		app:    app,
-		config: config,
-		log:    log,
+		app:       app,
+		config:    cfg,
+		log:       log,
+		snowflake: sn,
+		signature: signature,
 	}
 }
 
@@ -43,6 +73,7 @@ func (a *AccessKeyAuth) AppValidator(ctx context.Context, req *gosdk.GatewayRequ
 	xDate := ""
 	auth := ""
 	accessKey := ""
+	nonce := ""
 	for _, k := range req.Headers.Keys() {
 		v := req.Headers.Get(k)
 		if strings.EqualFold(k, gosdk.HeaderXDateTime) {
@@ -55,6 +86,9 @@ func (a *AccessKeyAuth) AppValidator(ctx context.Context, req *gosdk.GatewayRequ
 			accessKey = v
 
 		}
+		if strings.EqualFold(k, HeaderXNonce) {
+			nonce = v
+		}
 	}
 	if xDate == "" {
 		return "", gosdk.NewError(pkg.ErrAppXDateMissing, int32(api.APPSvrCode_APP_XDATE_MISSING_ERR), codes.Unauthenticated, "app_timestamp")
@@ -69,27 +103,133 @@ func (a *AccessKeyAuth) AppValidator(ctx context.Context, req *gosdk.GatewayRequ
 	if err != nil {
 		return "", gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Unauthenticated, "sign_request")
 	}
+	skew, requireNonce := a.signatureOptions(ctx, accessKey)
 	// check timestamp
-	if time.Since(t).Abs() > time.Minute*1 {
+	if time.Since(t).Abs() > skew {
 
 		return "", gosdk.NewError(pkg.ErrRequestExpired, int32(api.APPSvrCode_APP_REQUEST_EXPIRED_ERR), codes.DeadlineExceeded, "app_timestamp")
 	}
+	if err := a.checkNonce(auth, nonce, requireNonce); err != nil {
+		return "", err
+	}
 	secret, err := a.GetSecret(ctx, accessKey)
 	if err != nil {
 		return "", gosdk.NewError(err, int32(api.APPSvrCode_APP_UNKNOWN), codes.Unauthenticated, "app_secret")
 	}
-	signer := gosdk.NewAppAuthSigner(accessKey, secret)
-	sign, err := signer.Sign(req)
+	sign, err := a.sign(req, accessKey, secret, t)
 	if err != nil {
 		return "", gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Unauthenticated, "sign_request")
 	}
-	if sign != a.getSignature(auth) {
+	signature := a.getSignature(auth)
+	if sign != signature && !a.matchPreviousSecret(ctx, accessKey, req, signature, t) {
 
 		return "", gosdk.NewError(pkg.ErrAppSignatureInvalid, int32(api.APPSvrCode_APP_SIGNATURE_ERR), codes.Unauthenticated, "app签名校验")
 	}
+	// 签名通过后才记录x-nonce，避免伪造的请求占用nonce
+	if nonce != "" {
+		// x-date超出允许的偏差后请求会被拒绝，nonce只需要保存到这个时间
+		exp := time.Until(t.Add(skew)) + time.Second
+		ok, err := a.app.UseNonce(ctx, a.config.GetAPPNonceKey(accessKey, nonce), exp)
+		if err != nil {
+			return "", gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "app_nonce")
+		}
+		if !ok {
+			return "", gosdk.NewError(pkg.ErrAppNonceReplayed, int32(ak.AccessKeySvrCode_ACCESS_KEY_NONCE_REPLAYED_ERR), codes.Unauthenticated, "app_nonce")
+		}
+	}
+	a.touch(ctx, accessKey)
 	return accessKey, nil
 }
 
+// signatureOptions 密钥所属app允许的时间偏差和是否要求x-nonce，
+// 获取密钥失败时使用全局配置，由后续的密钥校验返回错误
+func (a *AccessKeyAuth) signatureOptions(ctx context.Context, accessKey string) (time.Duration, bool) {
+	skew := defaultSignatureSkew
+	if a.signature.Skew > 0 {
+		skew = time.Duration(a.signature.Skew) * time.Second
+	}
+	requireNonce := a.signature.RequireNonce
+	if len(a.signature.Apps) == 0 {
+		return skew, requireNonce
+	}
+	key, err := a.GetKey(ctx, accessKey)
+	if err != nil {
+		return skew, requireNonce
+	}
+	for _, app := range a.signature.Apps {
+		if app.Appid != key.Appid {
+			continue
+		}
+		if app.Skew > 0 {
+			skew = time.Duration(app.Skew) * time.Second
+		}
+		return skew, app.RequireNonce
+	}
+	return skew, requireNonce
+}
+
+// checkNonce x-nonce必须参与签名，否则可以在不改变签名的情况下替换
+func (a *AccessKeyAuth) checkNonce(auth string, nonce string, required bool) error {
+	if nonce == "" {
+		if required {
+			return gosdk.NewError(pkg.ErrAppNonceMissing, int32(ak.AccessKeySvrCode_ACCESS_KEY_NONCE_MISSING_ERR), codes.Unauthenticated, "app_nonce")
+		}
+		return nil
+	}
+	if len(nonce) > maxNonceLength {
+		return gosdk.NewError(pkg.ErrAppNonceInvalid, int32(ak.AccessKeySvrCode_ACCESS_KEY_NONCE_INVALID_ERR), codes.Unauthenticated, "app_nonce")
+	}
+	for _, h := range a.getSignedHeaders(auth) {
+		if strings.EqualFold(h, HeaderXNonce) {
+			return nil
+		}
+	}
+	return gosdk.NewError(pkg.ErrAppNonceUnsigned, int32(ak.AccessKeySvrCode_ACCESS_KEY_NONCE_UNSIGNED_ERR), codes.Unauthenticated, "app_nonce")
+}
+
+// sign 计算请求的签名，sdk只接受一分钟以内的x-date，
+// app允许更大的时间偏差时按sdk相同的步骤计算
+func (a *AccessKeyAuth) sign(req *gosdk.GatewayRequest, accessKey string, secret string, t time.Time) (string, error) {
+	if time.Since(t) <= time.Minute {
+		return gosdk.NewAppAuthSigner(accessKey, secret).Sign(req)
+	}
+	signer := &gosdk.AppAuthSignerImpl{Key: accessKey, Secret: secret}
+	canonicalRequest, err := signer.CanonicalRequest(req, signer.SignedHeaders(req))
+	if err != nil {
+		return "", err
+	}
+	stringToSign, err := signer.StringToSign(canonicalRequest, t)
+	if err != nil {
+		return "", err
+	}
+	return signer.SignStringToSign(stringToSign, []byte(secret))
+}
+
+// matchPreviousSecret 密钥轮换后的宽限期内，使用旧密钥的签名仍然有效
+func (a *AccessKeyAuth) matchPreviousSecret(ctx context.Context, accessKey string, req *gosdk.GatewayRequest, signature string, t time.Time) bool {
+	key, err := a.GetKey(ctx, accessKey)
+	if err != nil || key.PreviousSecret == "" || !key.PreviousExpiresAt.AsTime().After(time.Now()) {
+		return false
+	}
+	sign, err := a.sign(req, accessKey, key.PreviousSecret, t)
+	return err == nil && sign == signature
+}
+
+func (a *AccessKeyAuth) touch(ctx context.Context, accessKey string) {
+	now := time.Now()
+	if last, ok := a.touched.Load(accessKey); ok && now.Sub(last.(time.Time)) < accessKeyTouchInterval {
+		return
+	}
+	a.touched.Store(accessKey, now)
+	key, err := a.GetKey(ctx, accessKey)
+	if err != nil {
+		return
+	}
+	if err := a.app.TouchKey(ctx, key.Uid, now); err != nil {
+		a.log.Errorf(ctx, "update last used time of access key %s error: %s", accessKey, err.Error())
+	}
+}
+
 func (a *AccessKeyAuth) getSignature(auth string) string {
 	strArr := strings.Split(auth, ",")
 	for _, v := range strArr {
@@ -102,18 +242,60 @@ func (a *AccessKeyAuth) getSignature(auth string) string {
 	}
 	return ""
 }
+
+func (a *AccessKeyAuth) getSignedHeaders(auth string) []string {
+	for _, v := range strings.Split(auth, ",") {
+		kv := strings.SplitN(strings.TrimSpace(v), "=", 2)
+		if len(kv) == 2 && strings.EqualFold(kv[0], "SignedHeaders") {
+			return strings.Split(kv[1], ";")
+		}
+	}
+	return nil
+}
+
+// GetKey 获取可用的密钥，已禁用或过期的密钥返回错误
+func (a *AccessKeyAuth) GetKey(ctx context.Context, accessKey string) (*ak.AccessKey, error) {
+	key, err := loadAccessKey(ctx, a.app, a.snowflake, accessKey)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(api.APPSvrCode_APP_UNKNOWN), codes.Unauthenticated, "app_secret")
+	}
+	if !key.Enabled {
+		return nil, gosdk.NewError(pkg.ErrAccessKeyDisabled, int32(api.APPSvrCode_APP_UNKNOWN), codes.Unauthenticated, "app_secret")
+	}
+	if accessKeyExpired(key, time.Now()) {
+		return nil, gosdk.NewError(pkg.ErrAccessKeyExpired, int32(api.APPSvrCode_APP_UNKNOWN), codes.Unauthenticated, "app_secret")
+	}
+	return key, nil
+}
+
 func (a *AccessKeyAuth) GetSecret(ctx context.Context, accessKey string) (string, error) {
-	secret, err := a.app.GetSecret(ctx, accessKey)
+	key, err := a.GetKey(ctx, accessKey)
 	if err != nil {
-		return "", gosdk.NewError(err, int32(api.APPSvrCode_APP_UNKNOWN), codes.Unauthenticated, "app_secret")
+		return "", err
 	}
-	return secret, nil
+	return key.Secret, nil
 }
 
 func (a *AccessKeyAuth) GetAppid(ctx context.Context, accessKey string) (string, error) {
-	appid, err := a.app.GetAppid(ctx, accessKey)
+	key, err := a.GetKey(ctx, accessKey)
 	if err != nil {
-		return "", gosdk.NewError(err, int32(api.APPSvrCode_APP_UNKNOWN), codes.Unauthenticated, "app_secret")
+		return "", err
+	}
+	return key.Appid, nil
+}
+
+// CheckScope 检查密钥是否可以调用方法
+func (a *AccessKeyAuth) CheckScope(ctx context.Context, accessKey string, fullMethod string) error {
+	key, err := a.GetKey(ctx, accessKey)
+	if err != nil {
+		return err
+	}
+	var tags []string
+	if router := routers.Get().GetRouteByGrpcMethod(fullMethod); router != nil {
+		tags = router.Tags
+	}
+	if !AccessKeyAllowed(key.Scopes, fullMethod, tags) {
+		return gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrAccessKeyScope, fullMethod), int32(common.Code_PREMISSION_DENIED), codes.PermissionDenied, "app_scope")
 	}
-	return appid, nil
+	return nil
 }
@@ -7,6 +7,9 @@ import (
 	"strings"
 	"time"
 
+	ak "github.com/begonia-org/begonia/api/accesskey/v1"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/pkg"
 	"github.com/begonia-org/begonia/internal/pkg/config"
 	gosdk "github.com/begonia-org/go-sdk"
 	api "github.com/begonia-org/go-sdk/api/app/v1"
@@ -26,6 +29,15 @@ type AppRepo interface {
 	Patch(ctx context.Context, model *api.Apps) error
 	GetSecret(ctx context.Context, accessKey string) (string, error)
 	GetAppid(ctx context.Context, accessKey string) (string, error)
+	AddKey(ctx context.Context, key *ak.AccessKey) error
+	GetKey(ctx context.Context, accessKey string) (*ak.AccessKey, error)
+	ListKeys(ctx context.Context, appid string) ([]*ak.AccessKey, error)
+	PatchKey(ctx context.Context, key *ak.AccessKey) error
+	DelKey(ctx context.Context, key *ak.AccessKey) error
+	// TouchKey 记录密钥最近使用时间
+	TouchKey(ctx context.Context, uid string, t time.Time) error
+	// UseNonce 记录x-nonce，在exp内重复使用时返回false
+	UseNonce(ctx context.Context, key string, exp time.Duration) (bool, error)
 }
 
 type AppUsecase struct {
@@ -120,6 +132,11 @@ func (a *AppUsecase) Put(ctx context.Context, apps *api.Apps, owner string) (err
 	if err != nil {
 		return err
 	}
+	// app创建时的密钥同时写入密钥表，可以轮换和限制权限
+	err = a.repo.AddKey(ctx, newAccessKey(a.snowflake, apps.Appid, apps.AccessKey, apps.Secret, owner))
+	if err != nil {
+		return err
+	}
 	prefix := a.config.GetAPPAccessKeyPrefix()
 	err = a.repo.Cache(ctx, prefix, apps, time.Duration(0)*time.Second)
 	return err
@@ -177,3 +194,17 @@ func (a *AppUsecase) List(ctx context.Context, in *api.AppsListRequest) ([]*api.
 	}
 	return apps, nil
 }
+
+// SetCapture 开启或关闭应用在所有端点的录制，只在当前网关实例生效，capture为nil时关闭
+func (a *AppUsecase) SetCapture(ctx context.Context, appid string, capture *gateway.AppCapture) (*gateway.AppCapture, error) {
+	if _, err := a.Get(ctx, appid); err != nil {
+		return nil, err
+	}
+	if capture != nil {
+		if err := capture.Validate(); err != nil {
+			return nil, gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrInvalidCapture, err.Error()), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_capture")
+		}
+	}
+	gateway.Captures().SetApp(appid, capture)
+	return gateway.Captures().App(appid), nil
+}
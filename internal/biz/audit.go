@@ -0,0 +1,245 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	api "github.com/begonia-org/begonia/api/audit/v1"
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	gosdk "github.com/begonia-org/go-sdk"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"github.com/begonia-org/go-sdk/logger"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// 审计记录中脱敏的字段
+var auditRedactFields = map[string]bool{
+	"secret":          true,
+	"previous_secret": true,
+	"password":        true,
+	"token":           true,
+	"access_token":    true,
+	"refresh_token":   true,
+	"totp_secret":     true,
+	"client_secret":   true,
+	"private_key":     true,
+	"content":         true,
+	"descriptor_set":  true,
+}
+
+const auditRedacted = "******"
+
+// AuditWriter 审计记录的写入方式，可以是mysql、文件或kafka
+type AuditWriter interface {
+	Write(ctx context.Context, log *api.AuditLog) error
+}
+
+// AuditRepo mysql中的审计记录，查询接口只能查询写入mysql的记录
+type AuditRepo interface {
+	AuditWriter
+	List(ctx context.Context, in *api.ListAuditLogsRequest) ([]*api.AuditLog, error)
+}
+
+// AuditResource 需要审计的服务实现，网关在方法执行前后分别获取资源的状态，
+// 作为审计记录中操作前后的变化
+type AuditResource interface {
+	// AuditResource 资源类型和请求或响应中资源id的字段，按顺序取第一个非空的字段
+	AuditResource() (resource string, idFields []string)
+	// AuditSnapshot 资源当前的状态，资源不存在或无法获取时返回nil
+	AuditSnapshot(ctx context.Context, id string) proto.Message
+}
+
+// AuditEntry 执行中的操作，在方法返回后补全并写入
+type AuditEntry struct {
+	Log      *api.AuditLog
+	source   AuditResource
+	idFields []string
+	before   map[string]interface{}
+}
+
+type AuditUsecase struct {
+	repo      AuditRepo
+	writer    AuditWriter
+	config    *config.Config
+	log       logger.Logger
+	snowflake *tiga.Snowflake
+}
+
+func NewAuditUsecase(repo AuditRepo, writer AuditWriter, config *config.Config, log logger.Logger) *AuditUsecase {
+	sn, _ := tiga.NewSnowflake(1)
+	return &AuditUsecase{repo: repo, writer: writer, config: config, log: log, snowflake: sn}
+}
+
+// Begin 记录操作前资源的状态，server未实现AuditResource时返回nil
+func (a *AuditUsecase) Begin(ctx context.Context, log *api.AuditLog, server any, req any) *AuditEntry {
+	source, ok := server.(AuditResource)
+	if !ok {
+		return nil
+	}
+	if i := strings.LastIndex(log.Method, "/"); i >= 0 {
+		log.Action = log.Method[i+1:]
+	}
+	resource, idFields := source.AuditResource()
+	log.Resource = resource
+	log.ResourceId = auditResourceID(req, idFields)
+	entry := &AuditEntry{Log: log, source: source, idFields: idFields}
+	if log.ResourceId != "" {
+		entry.before = auditSnapshot(source.AuditSnapshot(ctx, log.ResourceId))
+	}
+	return entry
+}
+
+// End 记录操作后资源的状态和结果并写入，创建资源时资源id从响应中获取，
+// 资源不存在时以响应作为操作后的状态
+func (a *AuditUsecase) End(ctx context.Context, entry *AuditEntry, rsp any, err error) {
+	log := entry.Log
+	if log.ResourceId == "" {
+		log.ResourceId = auditResourceID(rsp, entry.idFields)
+	}
+	var after map[string]interface{}
+	if log.ResourceId != "" {
+		after = auditSnapshot(entry.source.AuditSnapshot(ctx, log.ResourceId))
+	}
+	if after == nil && err == nil {
+		if msg, ok := rsp.(proto.Message); ok {
+			after = auditSnapshot(msg)
+		}
+	}
+	// 未鉴权的请求，如登录，以资源id作为操作者
+	if log.Actor == "" {
+		log.Actor = log.ResourceId
+	}
+	log.Before = auditJSON(entry.before)
+	log.After = auditJSON(after)
+	log.Changes = auditChanges(entry.before, after)
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Code = int32(st.Code())
+		log.Message = st.Message()
+	}
+	a.Record(ctx, log)
+}
+
+// Record 写入审计记录，写入失败不影响请求的结果
+func (a *AuditUsecase) Record(ctx context.Context, log *api.AuditLog) {
+	log.Uid = a.snowflake.GenerateIDString()
+	log.CreatedAt = timestamppb.Now()
+	log.UpdatedAt = log.CreatedAt
+	if log.Changes == nil {
+		log.Changes = make([]string, 0)
+	}
+	if err := a.writer.Write(ctx, log); err != nil {
+		a.log.Errorf(ctx, "write audit log of %s error:%s", log.Method, err.Error())
+	}
+}
+
+func (a *AuditUsecase) List(ctx context.Context, in *api.ListAuditLogsRequest) ([]*api.AuditLog, error) {
+	if in.StartTime.GetSeconds() > 0 && in.EndTime.GetSeconds() > 0 && in.StartTime.AsTime().After(in.EndTime.AsTime()) {
+		return nil, gosdk.NewError(pkg.ErrAuditTimeRange, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "list_audit_logs")
+	}
+	logs, err := a.repo.List(ctx, in)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_audit_logs")
+	}
+	return logs, nil
+}
+
+// auditResourceID 按字段顺序取消息中第一个非空的字符串字段，支持user.uid形式的嵌套字段
+func auditResourceID(msg any, fields []string) string {
+	m, ok := msg.(proto.Message)
+	if !ok || m == nil || reflect.ValueOf(m).IsNil() {
+		return ""
+	}
+	for _, field := range fields {
+		if id := auditField(m.ProtoReflect(), strings.Split(field, ".")); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func auditField(m protoreflect.Message, path []string) string {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil || fd.IsList() || fd.IsMap() || !m.Has(fd) {
+		return ""
+	}
+	if len(path) > 1 {
+		if fd.Message() == nil {
+			return ""
+		}
+		return auditField(m.Get(fd).Message(), path[1:])
+	}
+	if fd.Kind() != protoreflect.StringKind {
+		return ""
+	}
+	return m.Get(fd).String()
+}
+
+// auditSnapshot 资源转换为脱敏后的map
+func auditSnapshot(msg proto.Message) map[string]interface{} {
+	if msg == nil || reflect.ValueOf(msg).IsNil() {
+		return nil
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	snapshot := make(map[string]interface{})
+	if err := json.Unmarshal(data, &snapshot); err != nil || len(snapshot) == 0 {
+		return nil
+	}
+	auditRedact(snapshot)
+	return snapshot
+}
+
+func auditRedact(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if auditRedactFields[key] {
+				v[key] = auditRedacted
+				continue
+			}
+			auditRedact(item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			auditRedact(item)
+		}
+	}
+}
+
+func auditJSON(snapshot map[string]interface{}) string {
+	if snapshot == nil {
+		return ""
+	}
+	data, _ := json.Marshal(snapshot)
+	return string(data)
+}
+
+// auditChanges 操作前后值不同的字段
+func auditChanges(before, after map[string]interface{}) []string {
+	changes := make([]string, 0)
+	for key, value := range before {
+		if !reflect.DeepEqual(value, after[key]) {
+			changes = append(changes, key)
+		}
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			changes = append(changes, key)
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
@@ -33,10 +33,12 @@ type AuthzUsecase struct {
 	authCrypto *crypto.UsersAuth
 	config     *config.Config
 	user       UserRepo
+	jwks       *JWKSUsecase
 }
 
-func NewAuthzUsecase(repo AuthzRepo, user UserRepo, log logger.Logger, crypto *crypto.UsersAuth, config *config.Config) *AuthzUsecase {
-	return &AuthzUsecase{repo: repo, log: log, authCrypto: crypto, config: config, user: user}
+// NewAuthzUsecase jwks为nil时使用jwt_secret签发token
+func NewAuthzUsecase(repo AuthzRepo, user UserRepo, log logger.Logger, crypto *crypto.UsersAuth, config *config.Config, jwks *JWKSUsecase) *AuthzUsecase {
+	return &AuthzUsecase{repo: repo, log: log, authCrypto: crypto, config: config, user: user, jwks: jwks}
 }
 
 func (u *AuthzUsecase) DelToken(ctx context.Context, key string) error {
@@ -78,14 +80,12 @@ func (u *AuthzUsecase) getUserAuth(_ context.Context, in *api.LoginAPIRequest) (
 	}
 	return userAuth, nil
 }
-func (u *AuthzUsecase) GenerateJWT(ctx context.Context, user *api.Users, isKeepLogin bool) (string, error) {
-	exp := time.Duration(u.config.GetJWTExpiration()) * time.Second
-	if isKeepLogin {
-		exp = time.Hour * 24 * 3
-	}
+
+// NewBasicAuth 网关签发的token的payload
+func (u *AuthzUsecase) NewBasicAuth(user *api.Users, exp time.Duration, isKeepLogin bool) *api.BasicAuth {
 	secret := u.config.GetString("auth.jwt_secret")
 	validateToken := tiga.ComputeHmacSha256(fmt.Sprintf("%s:%d", user.Uid, time.Now().Unix()), secret)
-	payload := &api.BasicAuth{
+	return &api.BasicAuth{
 		Uid:         user.Uid,
 		Name:        user.Name,
 		Role:        user.Role,
@@ -97,9 +97,16 @@ func (u *AuthzUsecase) GenerateJWT(ctx context.Context, user *api.Users, isKeepL
 		IsKeepLogin: isKeepLogin,
 		Token:       validateToken,
 	}
+}
+func (u *AuthzUsecase) GenerateJWT(ctx context.Context, user *api.Users, isKeepLogin bool) (string, error) {
+	exp := time.Duration(u.config.GetJWTExpiration()) * time.Second
+	if isKeepLogin {
+		exp = time.Hour * 24 * 3
+	}
+	payload := u.NewBasicAuth(user, exp, isKeepLogin)
 	// err := u.repo.DelToken(ctx, u.config.GetUserBlackListKey(user.Uid))
 
-	token, err := tiga.GenerateJWT(payload, secret)
+	token, err := u.SignJWT(ctx, payload)
 	if err != nil {
 		return "", gosdk.NewError(err, int32(api.UserSvrCode_USER_UNKNOWN), codes.Internal, "jwt_generate")
 
@@ -107,7 +114,36 @@ func (u *AuthzUsecase) GenerateJWT(ctx context.Context, user *api.Users, isKeepL
 	return token, nil
 }
 
-func (u *AuthzUsecase) Login(ctx context.Context, in *api.LoginAPIRequest) (*api.LoginAPIResponse, error) {
+// SignJWT 配置了非对称签名算法时使用当前的签名密钥，否则使用jwt_secret
+func (u *AuthzUsecase) SignJWT(ctx context.Context, claims interface{}) (string, error) {
+	if u.jwks != nil && u.jwks.Enabled() {
+		return u.jwks.Sign(ctx, claims)
+	}
+	return tiga.GenerateJWT(claims, u.config.GetJWTSecret())
+}
+
+// VerifyJWT 校验非对称密钥签发的token并解析payload
+func (u *AuthzUsecase) VerifyJWT(ctx context.Context, token string) (*api.BasicAuth, error) {
+	if u.jwks == nil {
+		return nil, pkg.ErrSigningKeyNotReady
+	}
+	claims, err := u.jwks.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	payloadBytes, err := json.Marshal(claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", pkg.ErrDecode, err)
+	}
+	payload := &api.BasicAuth{}
+	if err := json.Unmarshal(payloadBytes, payload); err != nil {
+		return nil, fmt.Errorf("%w:%w", pkg.ErrDecode, err)
+	}
+	return payload, nil
+}
+
+// Authenticate 校验登录的账号密码
+func (u *AuthzUsecase) Authenticate(ctx context.Context, in *api.LoginAPIRequest) (*api.Users, error) {
 	// 解密账号密码
 	userAuth, err := u.getUserAuth(ctx, in)
 	if err != nil {
@@ -139,6 +175,14 @@ func (u *AuthzUsecase) Login(ctx context.Context, in *api.LoginAPIRequest) (*api
 
 	}
 	user.Password = ""
+	return user, nil
+}
+
+func (u *AuthzUsecase) Login(ctx context.Context, in *api.LoginAPIRequest) (*api.LoginAPIResponse, error) {
+	user, err := u.Authenticate(ctx, in)
+	if err != nil {
+		return nil, err
+	}
 	// 生成jwt
 	token, err := u.GenerateJWT(ctx, user, in.IsKeepLogin)
 	if err != nil {
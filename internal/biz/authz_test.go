@@ -49,7 +49,7 @@ func newAuthzBiz() *biz.AuthzUsecase {
 	cnf := cfg.NewConfig(config)
 	crypto := crypto.NewUsersAuth(cnf)
 
-	return biz.NewAuthzUsecase(repo, user, gateway.Log, crypto, cnf)
+	return biz.NewAuthzUsecase(repo, user, gateway.Log, crypto, cnf, nil)
 }
 
 func testAuthSeed(t *testing.T) {
@@ -12,5 +12,11 @@ var ProviderSet = wire.NewSet(NewAuthzUsecase,
 	file.NewFileUsecase,
 	endpoint.NewEndpointUsecase,
 	NewAppUsecase,
+	NewRBACUsecase,
+	NewOIDCUsecase,
+	NewJWKSUsecase,
+	NewSessionUsecase,
+	NewMFAUsecase,
+	NewAuditUsecase,
 	endpoint.NewWatcher,
 	NewDataOperatorUsecase)
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/begonia-org/begonia/gateway"
 	"github.com/begonia-org/begonia/internal/biz/endpoint"
 	"github.com/begonia-org/begonia/internal/pkg/config"
 	api "github.com/begonia-org/go-sdk/api/app/v1"
@@ -39,21 +40,46 @@ type DataOperatorUsecase struct {
 	config          *config.Config
 	log             logger.Logger
 	endpointWatcher *endpoint.EndpointWatcher
+	// 取消etcd监听并等待监听协程退出
+	cancel   context.CancelFunc
+	mu       sync.Mutex
+	watchers sync.WaitGroup
+	// 端点初始加载完成后关闭
+	loaded     chan struct{}
+	loadedOnce sync.Once
 }
 
 func NewDataOperatorUsecase(repo DataOperatorRepo, config *config.Config, log logger.Logger, endpointWatch *endpoint.EndpointWatcher, endpoint endpoint.EndpointRepo) *DataOperatorUsecase {
 	log.WithField("module", "data")
 	log.SetReportCaller(true)
-	return &DataOperatorUsecase{repo: repo, config: config, log: log, endpointWatcher: endpointWatch, endpoint: endpoint}
+	return &DataOperatorUsecase{repo: repo, config: config, log: log, endpointWatcher: endpointWatch, endpoint: endpoint, loaded: make(chan struct{})}
 }
 
 func (d *DataOperatorUsecase) Do(ctx context.Context) {
 	// d.LoadCache(context.Background())
+	ctx, cancel := context.WithCancel(ctx)
+	d.mu.Lock()
+	d.cancel = cancel
+	d.mu.Unlock()
+	d.watchers.Add(1)
 	go func() {
-		err := d.OnStart(ctx)
-		if err != nil {
+		defer d.watchers.Done()
+		// 初始加载失败时重试，加载完成前网关不会就绪
+		for {
+			err := d.OnStart(ctx)
+			if err == nil {
+				break
+			}
 			d.log.Error(ctx, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(3 * time.Second):
+			}
 		}
+		d.loadedOnce.Do(func() {
+			close(d.loaded)
+		})
 		if err := d.doWatchEndpoint(ctx); err != nil {
 			d.log.Error(ctx, err)
 
@@ -65,6 +91,31 @@ func (d *DataOperatorUsecase) Do(ctx context.Context) {
 
 }
 
+// Loaded 端点从etcd初始加载完成后关闭
+func (d *DataOperatorUsecase) Loaded() <-chan struct{} {
+	return d.loaded
+}
+
+// Drain 停止etcd监听，等待正在处理的事件完成
+func (d *DataOperatorUsecase) Drain(ctx context.Context) error {
+	d.mu.Lock()
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		d.watchers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("drain watchers error,%w", ctx.Err())
+	}
+}
+
 func (d *DataOperatorUsecase) Handle(ctx context.Context) {
 	errChan := make(chan error, 3)
 	wg := &sync.WaitGroup{}
@@ -188,5 +239,9 @@ func (d *DataOperatorUsecase) doWatchEndpoint(ctx context.Context) error {
 func (d *DataOperatorUsecase) doWatch(ctx context.Context, prefix string, handle EtcdWatchHandle) error {
 	// prefix := d.config.GetServicePrefix()
 
-	return d.repo.Watcher(ctx, prefix, handle)
+	return d.repo.Watcher(ctx, prefix, func(ctx context.Context, op mvccpb.Event_EventType, key, value string) error {
+		err := handle(ctx, op, key, value)
+		gateway.ObserveEtcdWatch(prefix, op.String(), err)
+		return err
+	})
 }
@@ -0,0 +1,144 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/begonia-org/begonia/gateway"
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	api "github.com/begonia-org/go-sdk/api/endpoint/v1"
+)
+
+const (
+	// 被替换的负载均衡器在关闭前等待进行中的请求完成
+	drainTimeout = time.Minute
+	// 发现来源异常退出后重新开始的间隔
+	discoveryRetryInterval = 5 * time.Second
+)
+
+// endpointDiscovery 端点成员的动态发现，成员变化时在原路由上替换负载均衡器，
+// 字段由EndpointWatcher在持有锁时读写
+type endpointDiscovery struct {
+	key      string
+	source   string
+	cancel   context.CancelFunc
+	endpoint *api.Endpoints
+	ext      *EndpointExtensions
+	pd       gateway.ProtobufDescription
+	// 最近一次生效的成员，首次发现之前为nil
+	members []*api.EndpointMeta
+	lb      loadbalance.LoadBalance
+}
+
+func (d *endpointDiscovery) resolver(repo EndpointRepo) (gateway.Resolver, error) {
+	cfg := d.ext.Discovery
+	if cfg.Type == gateway.DiscoveryEtcd {
+		return gateway.ResolverFunc(func(ctx context.Context, update func([]*api.EndpointMeta, error)) error {
+			return repo.WatchMembers(ctx, cfg.Prefix, update)
+		}), nil
+	}
+	return gateway.NewResolver(cfg)
+}
+
+// discovered 发现来源不变时返回已发现的成员，没有配置或者尚未发现时返回nil
+func (g *EndpointWatcher) discovered(endpoint *api.Endpoints, ext *EndpointExtensions) []*api.EndpointMeta {
+	current := g.discoveries[endpoint.Key]
+	if ext.Discovery == nil || current == nil {
+		return nil
+	}
+	if source, _ := json.Marshal(ext.Discovery); current.source != string(source) {
+		return nil
+	}
+	return current.members
+}
+
+// discover 端点注册后开始、更新或者停止端点的动态发现，lb为端点当前使用的负载均衡器
+func (g *EndpointWatcher) discover(ctx context.Context, endpoint *api.Endpoints, ext *EndpointExtensions, pd gateway.ProtobufDescription, lb loadbalance.LoadBalance) {
+	if ext.Discovery == nil {
+		g.stopDiscovery(endpoint.Key)
+		return
+	}
+	source, _ := json.Marshal(ext.Discovery)
+	if current := g.discoveries[endpoint.Key]; current != nil && current.source == string(source) {
+		current.endpoint, current.ext, current.pd, current.lb = endpoint, ext, pd, lb
+		return
+	}
+	g.stopDiscovery(endpoint.Key)
+	d := &endpointDiscovery{key: endpoint.Key, source: string(source), endpoint: endpoint, ext: ext, pd: pd, lb: lb}
+	resolver, err := d.resolver(g.repo)
+	if err != nil {
+		gateway.Log.Errorf(ctx, "new resolver of %s error: %s", endpoint.Key, err.Error())
+		return
+	}
+	var runCtx context.Context
+	runCtx, d.cancel = context.WithCancel(context.Background())
+	g.discoveries[endpoint.Key] = d
+	go g.runDiscovery(runCtx, d, resolver)
+}
+
+func (g *EndpointWatcher) stopDiscovery(key string) {
+	if d, ok := g.discoveries[key]; ok {
+		d.cancel()
+		delete(g.discoveries, key)
+	}
+}
+
+func (g *EndpointWatcher) runDiscovery(ctx context.Context, d *endpointDiscovery, resolver gateway.Resolver) {
+	for {
+		err := resolver.Resolve(ctx, func(members []*api.EndpointMeta, err error) {
+			g.applyMembers(ctx, d, members, err)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		gateway.Log.Errorf(ctx, "discovery of %s stopped: %v", d.key, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(discoveryRetryInterval):
+		}
+	}
+}
+
+// applyMembers 成员变化时使用新成员创建负载均衡器并替换，查询失败或者没有成员时保留原有成员
+func (g *EndpointWatcher) applyMembers(ctx context.Context, d *endpointDiscovery, members []*api.EndpointMeta, err error) {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	if ctx.Err() != nil || g.discoveries[d.key] != d {
+		return
+	}
+	source := d.ext.Discovery.Type
+	if err != nil {
+		gateway.Log.Warnf(ctx, "discover members of %s error: %s", d.key, err.Error())
+		gateway.ObserveDiscovery(d.key, source, len(d.members), false, err)
+		return
+	}
+	if len(members) == 0 {
+		gateway.Log.Warnf(ctx, "no member of %s discovered, keep the current members", d.key)
+		gateway.ObserveDiscovery(d.key, source, len(d.members), false, nil)
+		return
+	}
+	previous := d.members
+	if previous == nil {
+		previous = d.endpoint.GetEndpoints()
+	}
+	if gateway.SameMembers(members, previous) {
+		d.members = members
+		gateway.ObserveDiscovery(d.key, source, len(members), false, nil)
+		return
+	}
+	lb, err := newLoadBalance(d.endpoint, d.ext, "", members)
+	if err != nil {
+		gateway.Log.Errorf(ctx, "new loadbalance of %s error: %s", d.key, err.Error())
+		gateway.ObserveDiscovery(d.key, source, len(d.members), false, err)
+		return
+	}
+	gateway.Get().UpdateLoadbalance(d.pd, lb)
+	gateway.Log.Infof(ctx, "members of %s changed to %d endpoints", d.key, len(members))
+	gateway.ObserveDiscovery(d.key, source, len(members), true, nil)
+	if old := d.lb; old != nil {
+		closeAfterDrain(old)
+	}
+	d.members, d.lb = members, lb
+}
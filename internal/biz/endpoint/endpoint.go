@@ -7,6 +7,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/begonia-org/begonia/gateway"
 	"github.com/begonia-org/begonia/internal/biz/file"
 	"github.com/begonia-org/begonia/internal/pkg"
 	"github.com/begonia-org/begonia/internal/pkg/config"
@@ -29,6 +30,8 @@ type EndpointRepo interface {
 	Patch(ctx context.Context, id string, patch map[string]interface{}) error
 	PutTags(ctx context.Context, id string, tags []string) error
 	GetKeysByTags(ctx context.Context, tags []string) ([]string, error)
+	// etcd
+	WatchMembers(ctx context.Context, prefix string, update func(members []*api.EndpointMeta, err error)) error
 }
 
 type EndpointUsecase struct {
@@ -97,25 +100,148 @@ func (e *EndpointUsecase) Patch(ctx context.Context, srvConfig *api.EndpointSrvU
 		}
 	}
 
+	return e.patch(ctx, srvConfig.UniqueKey, patch)
+}
+
+// patch 合并更新端点配置并重新加载
+func (e *EndpointUsecase) patch(ctx context.Context, uniqueKey string, patch map[string]interface{}) (string, error) {
 	updated_at := timestamppb.New(time.Now()).AsTime().Format(time.RFC3339)
 	patch["updated_at"] = updated_at
-	err = e.repo.Patch(ctx, srvConfig.UniqueKey, patch)
+	err := e.repo.Patch(ctx, uniqueKey, patch)
 	if err != nil {
 		return "", gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "patch_config")
 	}
-	detailsKey := e.config.GetServiceKey(srvConfig.UniqueKey)
+	detailsKey := e.config.GetServiceKey(uniqueKey)
 
 	newVal, err := e.repo.Get(ctx, detailsKey)
 	if err != nil {
 		return "", gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "get_endpoint")
 	}
-	err = e.watcher.Update(ctx, srvConfig.UniqueKey, newVal)
+	err = e.watcher.Update(ctx, uniqueKey, newVal)
 	if err != nil {
 		return "", gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "watcher_update")
 	}
 	return updated_at, err
 }
 
+// GetExtensions 获取端点的扩展配置
+func (e *EndpointUsecase) GetExtensions(ctx context.Context, uniqueKey string) (*EndpointExtensions, error) {
+	value, err := e.repo.Get(ctx, e.config.GetServiceKey(uniqueKey))
+	if err != nil || value == "" {
+		return nil, gosdk.NewError(pkg.ErrEndpointNotExists, int32(common.Code_NOT_FOUND), codes.NotFound, "get_endpoint")
+	}
+	ext, err := parseExtensions(e.config, value)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "unmarshal_extensions")
+	}
+	return ext, nil
+}
+
+// PatchPolicy 更新端点的重试、对冲和超时策略
+func (e *EndpointUsecase) PatchPolicy(ctx context.Context, uniqueKey string, policy gateway.EndpointPolicy) (string, error) {
+	if err := policy.Validate(); err != nil {
+		return "", gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrInvalidEndpointPolicy, err.Error()), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_policy")
+	}
+	return e.patch(ctx, uniqueKey, map[string]interface{}{"policy": policy})
+}
+
+// PatchTransform 更新端点的请求响应转换规则，规则中的字段需要存在于端点的描述文件中
+func (e *EndpointUsecase) PatchTransform(ctx context.Context, uniqueKey string, transform gateway.EndpointTransform) (string, error) {
+	if err := transform.Validate(); err != nil {
+		return "", gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrInvalidEndpointTransform, err.Error()), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_transform")
+	}
+	endpoint, err := e.Get(ctx, uniqueKey)
+	if err != nil {
+		return "", err
+	}
+	pd, err := getDescriptorSet(e.config, uniqueKey, endpoint.DescriptorSet)
+	if err != nil {
+		return "", err
+	}
+	if err := transform.Check(pd); err != nil {
+		return "", gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrInvalidEndpointTransform, err.Error()), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_transform")
+	}
+	return e.patch(ctx, uniqueKey, map[string]interface{}{"transform": transform})
+}
+
+// PatchCache 更新端点GET方法的响应缓存策略
+func (e *EndpointUsecase) PatchCache(ctx context.Context, uniqueKey string, cache gateway.EndpointCache) (string, error) {
+	if err := cache.Validate(); err != nil {
+		return "", gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrInvalidEndpointCache, err.Error()), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_cache")
+	}
+	return e.patch(ctx, uniqueKey, map[string]interface{}{"cache": cache})
+}
+
+// PurgeCache 清除端点的响应缓存，通过更新缓存版本使所有网关实例的缓存失效
+func (e *EndpointUsecase) PurgeCache(ctx context.Context, uniqueKey string) (string, error) {
+	if _, err := e.Get(ctx, uniqueKey); err != nil {
+		return "", err
+	}
+	return e.patch(ctx, uniqueKey, map[string]interface{}{"cache_version": time.Now().UnixNano()})
+}
+
+// PatchMirror 更新端点的流量复制配置，为空时关闭复制
+func (e *EndpointUsecase) PatchMirror(ctx context.Context, uniqueKey string, mirror *EndpointMirror) (string, error) {
+	if mirror != nil {
+		if err := mirror.Validate(); err != nil {
+			return "", gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrInvalidEndpointMirror, err.Error()), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_mirror")
+		}
+		if len(mirror.Endpoints) == 0 {
+			return "", gosdk.NewError(fmt.Errorf("%w:endpoints is required", pkg.ErrInvalidEndpointMirror), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_mirror")
+		}
+		if mirror.Balance != "" && !loadbalance.CheckBalanceType(mirror.Balance) {
+			return "", gosdk.NewError(pkg.ErrUnknownLoadBalancer, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "balance_type")
+		}
+	}
+	return e.patch(ctx, uniqueKey, map[string]interface{}{"mirror": mirror})
+}
+
+// PatchCapture 更新端点的录制策略，为空时关闭录制
+func (e *EndpointUsecase) PatchCapture(ctx context.Context, uniqueKey string, capture *gateway.CapturePolicy) (string, error) {
+	if capture != nil {
+		if err := capture.Validate(); err != nil {
+			return "", gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrInvalidCapture, err.Error()), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_capture")
+		}
+	}
+	return e.patch(ctx, uniqueKey, map[string]interface{}{"capture": capture})
+}
+
+// PatchDiscovery 更新端点成员的动态发现配置，为空时关闭动态发现并回到配置中的地址列表
+func (e *EndpointUsecase) PatchDiscovery(ctx context.Context, uniqueKey string, discovery *gateway.DiscoveryConfig) (string, error) {
+	if discovery != nil {
+		if err := discovery.Validate(); err != nil {
+			return "", gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrInvalidEndpointDiscovery, err.Error()), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_discovery")
+		}
+	}
+	return e.patch(ctx, uniqueKey, map[string]interface{}{"discovery": discovery})
+}
+
+// PatchTLS 更新连接端点使用的tls配置，为空时使用明文连接，
+// 私钥为脱敏后的值时保留原有的私钥，私钥加密后存储
+func (e *EndpointUsecase) PatchTLS(ctx context.Context, uniqueKey string, upstream *gateway.UpstreamTLS) (string, error) {
+	if upstream != nil && upstream.Key == gateway.RedactedSecret {
+		ext, err := e.GetExtensions(ctx, uniqueKey)
+		if err != nil {
+			return "", err
+		}
+		if ext.TLS == nil {
+			return "", gosdk.NewError(fmt.Errorf("%w:key is required", pkg.ErrInvalidEndpointTLS), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_tls")
+		}
+		upstream.Key = ext.TLS.Key
+	}
+	if upstream != nil {
+		if err := upstream.Validate(); err != nil {
+			return "", gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrInvalidEndpointTLS, err.Error()), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_tls")
+		}
+		encrypted, err := encryptTLS(e.config, upstream)
+		if err != nil {
+			return "", gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "encrypt_tls")
+		}
+		upstream = encrypted
+	}
+	return e.patch(ctx, uniqueKey, map[string]interface{}{"tls": upstream})
+}
+
 func (u *EndpointUsecase) Delete(ctx context.Context, uniqueKey string) error {
 	detailsKey := u.config.GetServiceKey(uniqueKey)
 
@@ -0,0 +1,130 @@
+package endpoint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	api "github.com/begonia-org/go-sdk/api/endpoint/v1"
+	"github.com/spark-lence/tiga"
+)
+
+// EndpointExtensions 存储在端点配置中的扩展配置，
+// api.Endpoints 未定义这些字段，通过Patch合并到etcd中的端点配置
+type EndpointExtensions struct {
+	Policy gateway.EndpointPolicy `json:"policy,omitempty"`
+	// 连接端点使用的tls配置，为空时使用明文连接
+	TLS *gateway.UpstreamTLS `json:"tls,omitempty"`
+	// 请求响应的转换规则，由transform插件执行
+	Transform gateway.EndpointTransform `json:"transform,omitempty"`
+	// GET方法的响应缓存策略，优先于描述文件中方法的cache选项
+	Cache gateway.EndpointCache `json:"cache,omitempty"`
+	// 清除响应缓存时更新，使端点的缓存版本变化
+	CacheVersion int64 `json:"cache_version,omitempty"`
+	// 与稳定版本同时在线的其他版本
+	Releases []*EndpointRelease `json:"releases,omitempty"`
+	// 版本之间的流量分配
+	Traffic *gateway.TrafficSplit `json:"traffic,omitempty"`
+	// 一元调用的流量复制
+	Mirror *EndpointMirror `json:"mirror,omitempty"`
+	// 请求响应的录制策略，用于调试和回放
+	Capture *gateway.CapturePolicy `json:"capture,omitempty"`
+	// 端点成员的动态发现，发现的成员替换endpoints中的地址
+	Discovery *gateway.DiscoveryConfig `json:"discovery,omitempty"`
+}
+
+// EndpointMirror 接收复制流量的影子端点组
+type EndpointMirror struct {
+	gateway.MirrorPolicy
+	Endpoints []*api.EndpointMeta `json:"endpoints"`
+	// 为空时使用稳定版本的负载均衡策略
+	Balance string `json:"balance,omitempty"`
+}
+
+// EndpointRelease 端点的一个版本，提升为稳定版本之前与稳定版本共用路由，
+// 因此版本的描述文件需要兼容稳定版本路由的方法
+type EndpointRelease struct {
+	Version   string              `json:"version"`
+	Endpoints []*api.EndpointMeta `json:"endpoints"`
+	// 为空时使用稳定版本的负载均衡策略
+	Balance string `json:"balance,omitempty"`
+	// 版本的描述文件，提升为稳定版本时与后端成员一起替换端点的描述文件，为空时沿用稳定版本的描述文件
+	DescriptorSet []byte `json:"descriptor_set,omitempty"`
+}
+
+func (e *EndpointExtensions) release(version string) *EndpointRelease {
+	for _, release := range e.Releases {
+		if release.Version == version {
+			return release
+		}
+	}
+	return nil
+}
+
+func (e *EndpointExtensions) versions() []string {
+	versions := make([]string, 0, len(e.Releases))
+	for _, release := range e.Releases {
+		versions = append(versions, release.Version)
+	}
+	return versions
+}
+
+// newLoadBalance 创建端点组的负载均衡器，与稳定版本使用相同的tls配置，balance为空时使用稳定版本的策略
+func newLoadBalance(endpoint *api.Endpoints, ext *EndpointExtensions, balance string, endpoints []*api.EndpointMeta) (loadbalance.LoadBalance, error) {
+	if balance == "" {
+		balance = endpoint.Balance
+	}
+	eps, err := gateway.NewLoadBalanceEndpointWithTLS(loadbalance.BalanceType(balance), endpoints, ext.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("new endpoints error: %w", err)
+	}
+	return loadbalance.New(loadbalance.BalanceType(balance), eps)
+}
+
+// newReleaseLoadBalances 创建各个版本的负载均衡器
+func newReleaseLoadBalances(endpoint *api.Endpoints, ext *EndpointExtensions) (map[string]loadbalance.LoadBalance, error) {
+	lbs := make(map[string]loadbalance.LoadBalance)
+	for _, release := range ext.Releases {
+		lb, err := newLoadBalance(endpoint, ext, release.Balance, release.Endpoints)
+		if err != nil {
+			return nil, fmt.Errorf("new loadbalance of release %s error: %w", release.Version, err)
+		}
+		lbs[release.Version] = lb
+	}
+	return lbs, nil
+}
+
+// cacheVersion 端点配置的摘要，端点的任何更新都会使之前的响应缓存失效
+func cacheVersion(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// parseExtensions 解析端点的扩展配置，tls私钥解密后返回
+func parseExtensions(config *config.Config, value string) (*EndpointExtensions, error) {
+	ext := &EndpointExtensions{}
+	err := json.Unmarshal([]byte(value), ext)
+	if err != nil {
+		return nil, err
+	}
+	if ext.TLS != nil {
+		if err := tiga.DecryptStructAES([]byte(config.GetAesKey()), ext.TLS, config.GetAesIv()); err != nil {
+			return nil, fmt.Errorf("%w:%w", pkg.ErrAuthDecrypt, err)
+		}
+	}
+	return ext, nil
+}
+
+// encryptTLS 使用aes加密tls私钥，etcd中不存储明文私钥
+func encryptTLS(config *config.Config, upstream *gateway.UpstreamTLS) (*gateway.UpstreamTLS, error) {
+	encrypted := *upstream
+	if err := tiga.EncryptStructAES([]byte(config.GetAesKey()), &encrypted, config.GetAesIv()); err != nil {
+		return nil, fmt.Errorf("%w:%w", pkg.ErrEncrypt, err)
+	}
+	return &encrypted, nil
+}
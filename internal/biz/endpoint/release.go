@@ -0,0 +1,216 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/pkg"
+	loadbalance "github.com/begonia-org/go-loadbalancer"
+	gosdk "github.com/begonia-org/go-sdk"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func invalidRelease(err error) error {
+	return gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrInvalidEndpointRelease, err.Error()), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "validate_release")
+}
+
+func releaseNotExists(version string) error {
+	return gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrEndpointReleaseNotExists, version), int32(common.Code_NOT_FOUND), codes.NotFound, "get_release")
+}
+
+func newFiles(value []byte) (*protoregistry.Files, error) {
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(value, fds); err != nil {
+		return nil, err
+	}
+	return protodesc.NewFiles(fds)
+}
+
+// validateDescriptorSet 版本的描述文件需要包含稳定版本路由的所有方法，
+// 方法的流类型、入参和出参类型不变，同一编号的字段类型兼容，分流到该版本的请求才能按稳定版本的路由转发
+func validateDescriptorSet(stable []byte, value []byte) error {
+	files, err := newFiles(value)
+	if err != nil {
+		return err
+	}
+	stableFiles, err := newFiles(stable)
+	if err != nil {
+		return fmt.Errorf("parse descriptor set of stable version error:%w", err)
+	}
+	stableFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				if err = compatibleMethod(files, methods.Get(j)); err != nil {
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return err
+}
+
+func compatibleMethod(files *protoregistry.Files, stable protoreflect.MethodDescriptor) error {
+	desc, err := files.FindDescriptorByName(stable.FullName())
+	if err != nil {
+		return fmt.Errorf("method %s of stable version not found", stable.FullName())
+	}
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return fmt.Errorf("%s is not a method", stable.FullName())
+	}
+	if method.IsStreamingClient() != stable.IsStreamingClient() || method.IsStreamingServer() != stable.IsStreamingServer() {
+		return fmt.Errorf("streaming type of method %s changed", stable.FullName())
+	}
+	visited := make(map[protoreflect.FullName]bool)
+	if err := compatibleMessage(stable.Input(), method.Input(), visited); err != nil {
+		return fmt.Errorf("input of method %s is incompatible:%w", stable.FullName(), err)
+	}
+	if err := compatibleMessage(stable.Output(), method.Output(), visited); err != nil {
+		return fmt.Errorf("output of method %s is incompatible:%w", stable.FullName(), err)
+	}
+	return nil
+}
+
+// compatibleMessage 可以新增或删除字段，已有编号的字段类型和基数不能变化
+func compatibleMessage(stable, message protoreflect.MessageDescriptor, visited map[protoreflect.FullName]bool) error {
+	if stable.FullName() != message.FullName() {
+		return fmt.Errorf("message type changed from %s to %s", stable.FullName(), message.FullName())
+	}
+	if visited[stable.FullName()] {
+		return nil
+	}
+	visited[stable.FullName()] = true
+	fields := stable.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		other := message.Fields().ByNumber(field.Number())
+		if other == nil {
+			continue
+		}
+		if other.Kind() != field.Kind() || other.Cardinality() != field.Cardinality() || other.IsMap() != field.IsMap() {
+			return fmt.Errorf("field %d of %s changed", field.Number(), stable.FullName())
+		}
+		if field.Message() != nil {
+			if err := compatibleMessage(field.Message(), other.Message(), visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PutRelease 新增或更新端点的一个版本，新版本在配置分流规则之前不会接收流量
+func (e *EndpointUsecase) PutRelease(ctx context.Context, uniqueKey string, release *EndpointRelease) (string, error) {
+	endpoint, err := e.Get(ctx, uniqueKey)
+	if err != nil {
+		return "", err
+	}
+	ext, err := e.GetExtensions(ctx, uniqueKey)
+	if err != nil {
+		return "", err
+	}
+	if release.Version == "" || release.Version == endpoint.Version {
+		return "", invalidRelease(fmt.Errorf("version must be different from the stable version"))
+	}
+	if len(release.Endpoints) == 0 {
+		return "", invalidRelease(fmt.Errorf("endpoints is required"))
+	}
+	if release.Balance != "" && !loadbalance.CheckBalanceType(release.Balance) {
+		return "", gosdk.NewError(pkg.ErrUnknownLoadBalancer, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "balance_type")
+	}
+	if len(release.DescriptorSet) > 0 {
+		if err := validateDescriptorSet(endpoint.DescriptorSet, release.DescriptorSet); err != nil {
+			return "", invalidRelease(err)
+		}
+	}
+	releases := make([]*EndpointRelease, 0, len(ext.Releases)+1)
+	for _, item := range ext.Releases {
+		if item.Version != release.Version {
+			releases = append(releases, item)
+		}
+	}
+	releases = append(releases, release)
+	return e.patch(ctx, uniqueKey, map[string]interface{}{"releases": releases})
+}
+
+// PatchTraffic 更新端点在各个版本之间的流量分配
+func (e *EndpointUsecase) PatchTraffic(ctx context.Context, uniqueKey string, split *gateway.TrafficSplit) (string, error) {
+	ext, err := e.GetExtensions(ctx, uniqueKey)
+	if err != nil {
+		return "", err
+	}
+	if err := split.Validate(ext.versions()); err != nil {
+		return "", invalidRelease(err)
+	}
+	return e.patch(ctx, uniqueKey, map[string]interface{}{"traffic": split})
+}
+
+// Rollback 下线端点的一个版本，该版本的流量回到稳定版本。
+// 提升后的回滚通过再次提升原稳定版本完成
+func (e *EndpointUsecase) Rollback(ctx context.Context, uniqueKey string, version string) (string, error) {
+	ext, err := e.GetExtensions(ctx, uniqueKey)
+	if err != nil {
+		return "", err
+	}
+	if ext.release(version) == nil {
+		return "", releaseNotExists(version)
+	}
+	releases := make([]*EndpointRelease, 0, len(ext.Releases))
+	for _, release := range ext.Releases {
+		if release.Version != version {
+			releases = append(releases, release)
+		}
+	}
+	return e.patch(ctx, uniqueKey, map[string]interface{}{"releases": releases, "traffic": ext.Traffic.Without(version)})
+}
+
+// Promote 将一个版本提升为稳定版本，原稳定版本保留为不接收流量的版本以便回滚。
+// 版本号、后端成员、描述文件和分流规则在同一次更新中替换，网关按新的描述文件重新注册路由
+func (e *EndpointUsecase) Promote(ctx context.Context, uniqueKey string, version string) (string, error) {
+	endpoint, err := e.Get(ctx, uniqueKey)
+	if err != nil {
+		return "", err
+	}
+	ext, err := e.GetExtensions(ctx, uniqueKey)
+	if err != nil {
+		return "", err
+	}
+	release := ext.release(version)
+	if release == nil {
+		return "", releaseNotExists(version)
+	}
+	previous := &EndpointRelease{Version: endpoint.Version, Endpoints: endpoint.Endpoints, Balance: endpoint.Balance}
+	patch := map[string]interface{}{
+		"version":   release.Version,
+		"endpoints": release.Endpoints,
+	}
+	if release.Balance != "" {
+		patch["balance"] = release.Balance
+	}
+	if len(release.DescriptorSet) > 0 {
+		previous.DescriptorSet = endpoint.DescriptorSet
+		patch["descriptor_set"] = release.DescriptorSet
+	}
+	releases := make([]*EndpointRelease, 0, len(ext.Releases))
+	if previous.Version != "" {
+		releases = append(releases, previous)
+	}
+	for _, item := range ext.Releases {
+		if item.Version != version && item.Version != previous.Version {
+			releases = append(releases, item)
+		}
+	}
+	patch["releases"] = releases
+	patch["traffic"] = ext.Traffic.Without(version)
+	return e.patch(ctx, uniqueKey, patch)
+}
@@ -0,0 +1,87 @@
+package endpoint
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestValidateDescriptorSet(t *testing.T) {
+	c.Convey("test descriptor set of release compatible with routed methods", t, func() {
+		_, filename, _, _ := runtime.Caller(0)
+		stable, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(filename)))), "testdata", "helloworld.pb"))
+		c.So(err, c.ShouldBeNil)
+		c.So(validateDescriptorSet(stable, stable), c.ShouldBeNil)
+
+		modify := func(f func(file *descriptorpb.FileDescriptorProto)) []byte {
+			fds := &descriptorpb.FileDescriptorSet{}
+			c.So(proto.Unmarshal(stable, fds), c.ShouldBeNil)
+			for _, file := range fds.File {
+				if file.GetName() == "helloworld.proto" {
+					f(file)
+				}
+			}
+			value, err := proto.Marshal(fds)
+			c.So(err, c.ShouldBeNil)
+			return value
+		}
+		message := func(file *descriptorpb.FileDescriptorProto, name string) *descriptorpb.DescriptorProto {
+			for _, msg := range file.MessageType {
+				if msg.GetName() == name {
+					return msg
+				}
+			}
+			return nil
+		}
+
+		// 新增字段和方法
+		value := modify(func(file *descriptorpb.FileDescriptorProto) {
+			msg := message(file, "HelloRequest")
+			msg.Field = append(msg.Field, &descriptorpb.FieldDescriptorProto{
+				Name:     proto.String("lang"),
+				JsonName: proto.String("lang"),
+				Number:   proto.Int32(3),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			})
+			method := proto.Clone(file.Service[0].Method[0]).(*descriptorpb.MethodDescriptorProto)
+			method.Name = proto.String("SayHelloV2")
+			method.Options = nil
+			file.Service[0].Method = append(file.Service[0].Method, method)
+		})
+		c.So(validateDescriptorSet(stable, value), c.ShouldBeNil)
+
+		// 删除稳定版本路由的方法
+		value = modify(func(file *descriptorpb.FileDescriptorProto) {
+			file.Service[0].Method = file.Service[0].Method[1:]
+		})
+		err = validateDescriptorSet(stable, value)
+		c.So(err, c.ShouldNotBeNil)
+		c.So(err.Error(), c.ShouldContainSubstring, "helloworld.Greeter.SayHello of stable version not found")
+
+		// 修改方法的出参类型
+		value = modify(func(file *descriptorpb.FileDescriptorProto) {
+			file.Service[0].Method[0].OutputType = proto.String(".helloworld.ErrorRequest")
+		})
+		err = validateDescriptorSet(stable, value)
+		c.So(err, c.ShouldNotBeNil)
+		c.So(err.Error(), c.ShouldContainSubstring, "message type changed")
+
+		// 修改字段类型
+		value = modify(func(file *descriptorpb.FileDescriptorProto) {
+			message(file, "HelloRequest").Field[1].Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+		})
+		err = validateDescriptorSet(stable, value)
+		c.So(err, c.ShouldNotBeNil)
+		c.So(err.Error(), c.ShouldContainSubstring, "field 2 of helloworld.HelloRequest changed")
+
+		_, err = newFiles([]byte("invalid"))
+		c.So(err, c.ShouldNotBeNil)
+		c.So(validateDescriptorSet(stable, []byte("invalid")), c.ShouldNotBeNil)
+	})
+}
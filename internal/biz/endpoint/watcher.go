@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/begonia-org/begonia/internal/pkg"
 	"github.com/begonia-org/begonia/internal/pkg/config"
@@ -21,9 +22,16 @@ import (
 )
 
 type EndpointWatcher struct {
-	config *config.Config
-	repo   EndpointRepo
-	mux    sync.Mutex
+	config      *config.Config
+	repo        EndpointRepo
+	mux         sync.Mutex
+	discoveries map[string]*endpointDiscovery
+	// 端点当前使用的各个版本的负载均衡器，更新后关闭被替换的负载均衡器
+	releases map[string]map[string]loadbalance.LoadBalance
+	// 端点当前使用的影子端点组负载均衡器
+	mirrors map[string]loadbalance.LoadBalance
+	// 端点当前注册的描述文件，替换描述文件时删除新描述文件中已经不存在的方法的路由
+	descriptors map[string]gateway.ProtobufDescription
 }
 
 // update
@@ -43,6 +51,21 @@ func (g *EndpointWatcher) Update(ctx context.Context, key string, value string)
 	if err != nil {
 		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "unmarshal_endpoint")
 	}
+	ext, err := parseExtensions(g.config, value)
+	if err != nil {
+		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "unmarshal_extensions")
+	}
+	if _, err := newFiles(endpoint.DescriptorSet); err != nil {
+		gateway.Log.Errorf(ctx, "get descriptor set error: %s", err.Error())
+		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "get_descriptor_set")
+	}
+	// 新的描述文件会覆盖输出目录中的文件，需要先删除当前描述文件的路由
+	if previous, ok := g.descriptors[endpoint.Key]; ok {
+		if err = deleteAll(ctx, previous); err != nil {
+			return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "delete_descriptor")
+		}
+		delete(g.descriptors, endpoint.Key)
+	}
 	pd, err := getDescriptorSet(g.config, key, endpoint.DescriptorSet)
 	if err != nil {
 		gateway.Log.Errorf(ctx, "get descriptor set error: %s", err.Error())
@@ -52,7 +75,12 @@ func (g *EndpointWatcher) Update(ctx context.Context, key string, value string)
 	if err != nil {
 		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "delete_descriptor")
 	}
-	eps, err := gateway.NewLoadBalanceEndpoint(loadbalance.BalanceType(endpoint.Balance), endpoint.GetEndpoints())
+	// 使用动态发现的成员，避免更新端点配置时回到配置中的地址列表
+	members := endpoint.GetEndpoints()
+	if discovered := g.discovered(endpoint, ext); discovered != nil {
+		members = discovered
+	}
+	eps, err := gateway.NewLoadBalanceEndpointWithTLS(loadbalance.BalanceType(endpoint.Balance), members, ext.TLS)
 	if err != nil {
 		return gosdk.NewError(pkg.ErrUnknownLoadBalancer, int32(api.EndpointSvrStatus_NOT_SUPPORT_BALANCE), codes.InvalidArgument, "new_endpoint")
 	}
@@ -63,12 +91,45 @@ func (g *EndpointWatcher) Update(ctx context.Context, key string, value string)
 	// register routers
 	// log.Print("register router")
 	routersList.LoadAllRouters(pd)
+	routersList.SetTags(pd, endpoint.Tags)
 	// register service to gateway
 	gw := gateway.Get()
 	err = gw.RegisterService(ctx, pd, lb)
 	if err != nil {
 		return gosdk.NewError(fmt.Errorf("register service error: %w", err), int32(common.Code_INTERNAL_ERROR), codes.Internal, "register_service")
 	}
+	g.descriptors[endpoint.Key] = pd
+	g.discover(ctx, endpoint, ext, pd, lb)
+	gw.RegisterPolicy(pd, ext.Policy)
+	if err = gw.RegisterTransform(pd, ext.Transform); err != nil {
+		gateway.Log.Errorf(ctx, "register transform of %s error: %s", key, err.Error())
+	}
+	gw.RegisterCache(pd, ext.Cache, cacheVersion(value))
+	releases, err := newReleaseLoadBalances(endpoint, ext)
+	if err != nil {
+		gateway.Log.Errorf(ctx, "register releases of %s error: %s", key, err.Error())
+		g.replaceReleases(endpoint.Key, nil)
+	} else {
+		gw.RegisterReleases(pd, ext.Traffic, releases)
+		g.replaceReleases(endpoint.Key, releases)
+	}
+	if ext.Mirror == nil {
+		gw.RegisterMirror(pd, nil, nil)
+		g.replaceMirror(endpoint.Key, nil)
+	} else if lb, err := newLoadBalance(endpoint, ext, ext.Mirror.Balance, ext.Mirror.Endpoints); err != nil {
+		gateway.Log.Errorf(ctx, "register mirror of %s error: %s", key, err.Error())
+		g.replaceMirror(endpoint.Key, nil)
+	} else {
+		gw.RegisterMirror(pd, &ext.Mirror.MirrorPolicy, lb)
+		g.replaceMirror(endpoint.Key, lb)
+	}
+	if err = gw.RegisterCapture(pd, endpoint.Key, ext.Capture); err != nil {
+		gateway.Log.Errorf(ctx, "register capture of %s error: %s", key, err.Error())
+	}
+	err = gw.RegisterOpenAPI(&gateway.OpenAPIService{ID: endpoint.Key, Tags: endpoint.Tags, Pd: pd})
+	if err != nil {
+		gateway.Log.Errorf(ctx, "register openapi of %s error: %s", key, err.Error())
+	}
 
 	// err = g.repo.PutTags(ctx, endpoint.Key, endpoint.Tags)
 	return nil
@@ -84,17 +145,55 @@ func (g *EndpointWatcher) Del(ctx context.Context, key string, value string) err
 	if err != nil {
 		return gosdk.NewError(err, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "unmarshal_endpoint")
 	}
-	pd, err := getDescriptorSet(g.config, key, endpoint.DescriptorSet)
-	if err != nil {
-		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "get_descriptor_set")
+	// 优先删除当前注册的描述文件的路由
+	pd, ok := g.descriptors[endpoint.Key]
+	if !ok {
+		pd, err = getDescriptorSet(g.config, key, endpoint.DescriptorSet)
+		if err != nil {
+			return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "get_descriptor_set")
+		}
 	}
 	err = deleteAll(ctx, pd)
 	if err != nil {
 		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "delete_descriptor")
 	}
+	delete(g.descriptors, endpoint.Key)
+	g.stopDiscovery(endpoint.Key)
+	g.replaceReleases(endpoint.Key, nil)
+	g.replaceMirror(endpoint.Key, nil)
+	gateway.Get().DeleteOpenAPI(endpoint.Key)
 	return nil
 }
 
+// replaceReleases 记录端点新的版本负载均衡器，被替换的负载均衡器等待进行中的请求完成后关闭
+func (g *EndpointWatcher) replaceReleases(key string, lbs map[string]loadbalance.LoadBalance) {
+	for _, old := range g.releases[key] {
+		closeAfterDrain(old)
+	}
+	delete(g.releases, key)
+	if len(lbs) > 0 {
+		g.releases[key] = lbs
+	}
+}
+
+// replaceMirror 记录端点新的影子端点组负载均衡器，被替换的负载均衡器等待进行中的复制请求完成后关闭
+func (g *EndpointWatcher) replaceMirror(key string, lb loadbalance.LoadBalance) {
+	if old, ok := g.mirrors[key]; ok {
+		closeAfterDrain(old)
+	}
+	delete(g.mirrors, key)
+	if lb != nil {
+		g.mirrors[key] = lb
+	}
+}
+
+// closeAfterDrain 等待使用被替换的负载均衡器的请求完成后关闭
+func closeAfterDrain(lb loadbalance.LoadBalance) {
+	time.AfterFunc(drainTimeout, func() {
+		_ = lb.Close()
+	})
+}
+
 func (g *EndpointWatcher) Handle(ctx context.Context, op mvccpb.Event_EventType, key, value string) error {
 	switch op {
 	case mvccpb.PUT:
@@ -108,8 +207,12 @@ func (g *EndpointWatcher) Handle(ctx context.Context, op mvccpb.Event_EventType,
 
 func NewWatcher(config *config.Config, repo EndpointRepo) *EndpointWatcher {
 	return &EndpointWatcher{
-		config: config,
-		repo:   repo,
-		mux:    sync.Mutex{},
+		config:      config,
+		repo:        repo,
+		mux:         sync.Mutex{},
+		discoveries: make(map[string]*endpointDiscovery),
+		releases:    make(map[string]map[string]loadbalance.LoadBalance),
+		mirrors:     make(map[string]loadbalance.LoadBalance),
+		descriptors: make(map[string]gateway.ProtobufDescription),
 	}
 }
@@ -0,0 +1,411 @@
+package biz
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	api "github.com/begonia-org/begonia/api/jwks/v1"
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/begonia/internal/pkg/oidc"
+	"github.com/begonia-org/go-sdk/logger"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type JWKSRepo interface {
+	// List 获取所有未删除的密钥，私钥已解密
+	List(ctx context.Context) ([]*api.SigningKey, error)
+	Add(ctx context.Context, key *api.SigningKey) error
+	Del(ctx context.Context, key *api.SigningKey) error
+	Locker(ctx context.Context, key string, exp time.Duration) (DataLock, error)
+}
+
+const (
+	defaultJWTRotationInterval = time.Hour * 24 * 7
+	defaultJWTPublishAhead     = time.Minute * 10
+	// 默认不小于保持登录的token有效期
+	defaultJWTOverlap         = time.Hour * 24 * 4
+	defaultJWTRefreshInterval = time.Minute
+)
+
+type signingKey struct {
+	kid         string
+	alg         string
+	signer      crypto.Signer
+	jwk         *oidc.JSONWebKey
+	activatedAt time.Time
+	expiredAt   time.Time
+}
+
+// JWKSUsecase 管理网关签发jwt的非对称密钥，
+// 新密钥提前publish_ahead发布到jwks，停止签发后overlap时间内仍可用于校验
+type JWKSUsecase struct {
+	repo      JWKSRepo
+	config    *config.Config
+	log       logger.Logger
+	snowflake *tiga.Snowflake
+
+	algorithm        string
+	rotationInterval time.Duration
+	publishAhead     time.Duration
+	overlap          time.Duration
+	refreshInterval  time.Duration
+
+	mu         sync.RWMutex
+	keys       []*signingKey
+	loaded     bool
+	reloadedAt time.Time
+	// 串行化从数据库加载密钥
+	reloadMu sync.Mutex
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewJWKSUsecase(repo JWKSRepo, config *config.Config, log logger.Logger) *JWKSUsecase {
+	signing, err := config.GetJWTSigning()
+	if err != nil {
+		panic(fmt.Sprintf("get jwt signing error:%v", err))
+	}
+	sn, _ := tiga.NewSnowflake(1)
+	u := &JWKSUsecase{
+		repo:             repo,
+		config:           config,
+		log:              log,
+		snowflake:        sn,
+		algorithm:        signing.Algorithm,
+		rotationInterval: defaultJWTRotationInterval,
+		publishAhead:     defaultJWTPublishAhead,
+		overlap:          defaultJWTOverlap,
+		refreshInterval:  defaultJWTRefreshInterval,
+	}
+	if u.Enabled() {
+		if _, err := oidc.GenerateKey(u.algorithm); err != nil {
+			panic(fmt.Sprintf("jwt signing algorithm error:%v", err))
+		}
+	}
+	if signing.RotationInterval > 0 {
+		u.rotationInterval = time.Duration(signing.RotationInterval) * time.Second
+	}
+	if signing.PublishAhead > 0 {
+		u.publishAhead = time.Duration(signing.PublishAhead) * time.Second
+	}
+	if signing.Overlap > 0 {
+		u.overlap = time.Duration(signing.Overlap) * time.Second
+	}
+	if signing.RefreshInterval > 0 {
+		u.refreshInterval = time.Duration(signing.RefreshInterval) * time.Second
+	}
+	return u
+}
+
+// Enabled 是否使用非对称密钥签发，HS256时使用jwt_secret
+func (u *JWKSUsecase) Enabled() bool {
+	return u.algorithm != "" && u.algorithm != "HS256"
+}
+
+func (u *JWKSUsecase) parse(key *api.SigningKey) (*signingKey, error) {
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("decode signing key %s failed", key.Uid)
+	}
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse signing key %s failed:%w", key.Uid, err)
+	}
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not a signer", key.Uid)
+	}
+	jwk, err := oidc.NewJSONWebKey(key.Uid, key.Algorithm, signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &signingKey{
+		kid:         key.Uid,
+		alg:         key.Algorithm,
+		signer:      signer,
+		jwk:         jwk,
+		activatedAt: key.ActivatedAt.AsTime(),
+		expiredAt:   key.ExpiredAt.AsTime(),
+	}, nil
+}
+
+// reload 从数据库加载未过期的密钥
+func (u *JWKSUsecase) reload(ctx context.Context) error {
+	u.reloadMu.Lock()
+	defer u.reloadMu.Unlock()
+	models, err := u.repo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("list signing keys error:%w", err)
+	}
+	now := time.Now()
+	keys := make([]*signingKey, 0, len(models))
+	for _, model := range models {
+		if !model.ExpiredAt.AsTime().After(now) {
+			continue
+		}
+		key, err := u.parse(model)
+		if err != nil {
+			u.log.Errorf(ctx, "load signing key error:%s", err.Error())
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].activatedAt.Before(keys[j].activatedAt)
+	})
+	u.mu.Lock()
+	u.keys = keys
+	u.loaded = true
+	u.reloadedAt = now
+	u.mu.Unlock()
+	return nil
+}
+
+func (u *JWKSUsecase) ensureLoaded(ctx context.Context) error {
+	u.mu.RLock()
+	loaded := u.loaded
+	u.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+	return u.reload(ctx)
+}
+
+// current 当前用于签发的密钥，即已激活的最新密钥
+func (u *JWKSUsecase) current(now time.Time) *signingKey {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	for i := len(u.keys) - 1; i >= 0; i-- {
+		key := u.keys[i]
+		if key.alg == u.algorithm && !key.activatedAt.After(now) && key.expiredAt.After(now) {
+			return key
+		}
+	}
+	return nil
+}
+
+func (u *JWKSUsecase) lookup(kid string) (*signingKey, time.Time) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	for _, key := range u.keys {
+		if key.kid == kid {
+			return key, u.reloadedAt
+		}
+	}
+	return nil, u.reloadedAt
+}
+
+// nextActivation 计算下一个密钥的激活时间，最新密钥到期前publish_ahead生成下一个密钥
+func (u *JWKSUsecase) nextActivation(now time.Time) (time.Time, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	var latest time.Time
+	for _, key := range u.keys {
+		if key.alg == u.algorithm && key.expiredAt.After(now) && key.activatedAt.After(latest) {
+			latest = key.activatedAt
+		}
+	}
+	if latest.IsZero() {
+		return now, true
+	}
+	next := latest.Add(u.rotationInterval)
+	if next.Add(-u.publishAhead).After(now) {
+		return time.Time{}, false
+	}
+	if next.Before(now) {
+		next = now
+	}
+	return next, true
+}
+
+func (u *JWKSUsecase) newSigningKey(activatedAt time.Time) (*api.SigningKey, error) {
+	signer, err := oidc.GenerateKey(u.algorithm)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(signer)
+	if err != nil {
+		return nil, fmt.Errorf("marshal signing key error:%w", err)
+	}
+	return &api.SigningKey{
+		Uid:         u.snowflake.GenerateIDString(),
+		Algorithm:   u.algorithm,
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		ActivatedAt: timestamppb.New(activatedAt),
+		ExpiredAt:   timestamppb.New(activatedAt.Add(u.rotationInterval + u.overlap)),
+	}, nil
+}
+
+// Rotate 同步数据库中的密钥，需要时生成下一个密钥并删除过期的密钥，
+// 多个网关实例通过分布式锁保证只有一个实例生成密钥
+func (u *JWKSUsecase) Rotate(ctx context.Context) error {
+	if err := u.reload(ctx); err != nil {
+		return err
+	}
+	if _, ok := u.nextActivation(time.Now()); !ok {
+		return nil
+	}
+	lock, err := u.repo.Locker(ctx, u.config.GetJWTSigningLockKey(), time.Minute)
+	if err != nil {
+		return fmt.Errorf("get signing key lock error:%w", err)
+	}
+	if err := lock.Lock(ctx); err != nil {
+		return fmt.Errorf("lock signing keys error:%w", err)
+	}
+	defer func() {
+		if err := lock.UnLock(ctx); err != nil {
+			u.log.Errorf(ctx, "unlock signing keys error:%s", err.Error())
+		}
+	}()
+	// 其他实例可能已经完成轮换
+	if err := u.reload(ctx); err != nil {
+		return err
+	}
+	now := time.Now()
+	models, err := u.repo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("list signing keys error:%w", err)
+	}
+	for _, model := range models {
+		if !model.ExpiredAt.AsTime().After(now) {
+			if err := u.repo.Del(ctx, model); err != nil {
+				u.log.Errorf(ctx, "delete expired signing key error:%s", err.Error())
+			}
+		}
+	}
+	if activatedAt, ok := u.nextActivation(now); ok {
+		key, err := u.newSigningKey(activatedAt)
+		if err != nil {
+			return err
+		}
+		if err := u.repo.Add(ctx, key); err != nil {
+			return fmt.Errorf("add signing key error:%w", err)
+		}
+		u.log.Infof(ctx, "new signing key %s will be activated at %s", key.Uid, activatedAt.Format(time.RFC3339))
+	}
+	return u.reload(ctx)
+}
+
+// Sign 使用当前密钥签发jwt，首次签发时没有可用的密钥则立即生成
+func (u *JWKSUsecase) Sign(ctx context.Context, claims interface{}) (string, error) {
+	if err := u.ensureLoaded(ctx); err != nil {
+		return "", err
+	}
+	key := u.current(time.Now())
+	if key == nil {
+		if err := u.Rotate(ctx); err != nil {
+			return "", fmt.Errorf("%w:%w", pkg.ErrSigningKeyNotReady, err)
+		}
+		if key = u.current(time.Now()); key == nil {
+			return "", pkg.ErrSigningKeyNotReady
+		}
+	}
+	return oidc.Sign(key.alg, key.kid, key.signer, claims)
+}
+
+// Verify 校验网关签发的jwt签名，未知的kid会重新加载密钥，
+// 其他实例刚生成的密钥无需等待下一次同步
+func (u *JWKSUsecase) Verify(ctx context.Context, raw string) (oidc.Claims, error) {
+	token, err := oidc.ParseToken(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+	key, reloadedAt := u.lookup(token.Header.Kid)
+	if key == nil && time.Since(reloadedAt) >= oidc.MinJWKSRefreshInterval {
+		if err := u.reload(ctx); err != nil {
+			return nil, err
+		}
+		key, _ = u.lookup(token.Header.Kid)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w:%s", pkg.ErrJWKNotFound, token.Header.Kid)
+	}
+	if key.alg != token.Header.Alg {
+		return nil, fmt.Errorf("%w:%s", pkg.ErrTokenAlgorithm, token.Header.Alg)
+	}
+	if !key.expiredAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w:%s", pkg.ErrTokenKeyExpired, key.kid)
+	}
+	if err := token.Verify(key.signer.Public()); err != nil {
+		return nil, err
+	}
+	return token.Claims, nil
+}
+
+// JWKS 发布所有未过期的公钥，包括尚未激活的密钥
+func (u *JWKSUsecase) JWKS(ctx context.Context) (*oidc.JSONWebKeySet, error) {
+	if err := u.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	set := &oidc.JSONWebKeySet{Keys: make([]*oidc.JSONWebKey, 0)}
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	for _, key := range u.keys {
+		if key.expiredAt.After(now) {
+			set.Keys = append(set.Keys, key.jwk)
+		}
+	}
+	return set, nil
+}
+
+// CacheMaxAge 下游缓存jwks的最长时间
+func (u *JWKSUsecase) CacheMaxAge() time.Duration {
+	return u.publishAhead / 2
+}
+
+// Start 定期同步和轮换密钥
+func (u *JWKSUsecase) Start(ctx context.Context) {
+	if !u.Enabled() {
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	u.cancel = cancel
+	u.wg.Add(1)
+	go func() {
+		defer u.wg.Done()
+		ticker := time.NewTicker(u.refreshInterval)
+		defer ticker.Stop()
+		for {
+			if err := u.Rotate(ctx); err != nil {
+				u.log.Errorf(ctx, "rotate signing keys error:%s", err.Error())
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop 停止密钥轮换并等待其退出
+func (u *JWKSUsecase) Stop(ctx context.Context) error {
+	if u.cancel == nil {
+		return nil
+	}
+	u.cancel()
+	done := make(chan struct{})
+	go func() {
+		u.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("stop signing key rotation error,%w", ctx.Err())
+	}
+}
@@ -0,0 +1,160 @@
+package biz_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/begonia-org/begonia"
+	api "github.com/begonia-org/begonia/api/jwks/v1"
+	"github.com/begonia-org/begonia/config"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/biz"
+	cfg "github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/begonia/internal/pkg/oidc"
+	v1 "github.com/begonia-org/go-sdk/api/user/v1"
+	c "github.com/smartystreets/goconvey/convey"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type memoryLock struct{}
+
+func (memoryLock) Lock(ctx context.Context) error   { return nil }
+func (memoryLock) UnLock(ctx context.Context) error { return nil }
+
+type memoryJWKSRepo struct {
+	keys []*api.SigningKey
+}
+
+func (m *memoryJWKSRepo) List(ctx context.Context) ([]*api.SigningKey, error) {
+	keys := make([]*api.SigningKey, 0)
+	for _, key := range m.keys {
+		if !key.IsDeleted {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+func (m *memoryJWKSRepo) Add(ctx context.Context, key *api.SigningKey) error {
+	m.keys = append(m.keys, key)
+	return nil
+}
+func (m *memoryJWKSRepo) Del(ctx context.Context, key *api.SigningKey) error {
+	key.IsDeleted = true
+	return nil
+}
+func (m *memoryJWKSRepo) Locker(ctx context.Context, key string, exp time.Duration) (biz.DataLock, error) {
+	return memoryLock{}, nil
+}
+
+// shift 将所有密钥的时间提前，模拟时间流逝
+func (m *memoryJWKSRepo) shift(d time.Duration) {
+	for _, key := range m.keys {
+		key.ActivatedAt = timestamppb.New(key.ActivatedAt.AsTime().Add(-d))
+		key.ExpiredAt = timestamppb.New(key.ExpiredAt.AsTime().Add(-d))
+	}
+}
+
+func kid(token string) string {
+	t, _ := oidc.ParseToken(token)
+	return t.Header.Kid
+}
+
+func TestJWKSUsecase(t *testing.T) {
+	c.Convey("test jwt signing key rotation", t, func() {
+		env := "dev"
+		if begonia.Env != "" {
+			env = begonia.Env
+		}
+		conf := config.ReadConfig(env)
+		conf.Set("auth.jwt_signing", map[string]interface{}{
+			"algorithm":         "ES256",
+			"rotation_interval": 3600,
+			"publish_ahead":     600,
+			"overlap":           7200,
+		})
+		cnf := cfg.NewConfig(conf)
+		repo := &memoryJWKSRepo{}
+		jwks := biz.NewJWKSUsecase(repo, cnf, gateway.Log)
+		ctx := context.Background()
+		c.So(jwks.Enabled(), c.ShouldBeTrue)
+
+		// 首次签发时生成密钥
+		first, err := jwks.Sign(ctx, oidc.Claims{"uid": "1"})
+		c.So(err, c.ShouldBeNil)
+		c.So(repo.keys, c.ShouldHaveLength, 1)
+		claims, err := jwks.Verify(ctx, first)
+		c.So(err, c.ShouldBeNil)
+		c.So(claims.String("uid"), c.ShouldEqual, "1")
+
+		// 下游服务通过jwks校验
+		set, err := jwks.JWKS(ctx)
+		c.So(err, c.ShouldBeNil)
+		c.So(set.Keys, c.ShouldHaveLength, 1)
+		pub, err := set.Keys[0].PublicKey()
+		c.So(err, c.ShouldBeNil)
+		token, _ := oidc.ParseToken(first)
+		c.So(token.Verify(pub), c.ShouldBeNil)
+
+		// 未到轮换时间不生成新密钥
+		c.So(jwks.Rotate(ctx), c.ShouldBeNil)
+		c.So(repo.keys, c.ShouldHaveLength, 1)
+
+		// 到期前提前发布下一个密钥，激活前仍使用旧密钥签发
+		repo.shift(time.Minute * 55)
+		c.So(jwks.Rotate(ctx), c.ShouldBeNil)
+		c.So(repo.keys, c.ShouldHaveLength, 2)
+		set, _ = jwks.JWKS(ctx)
+		c.So(set.Keys, c.ShouldHaveLength, 2)
+		second, err := jwks.Sign(ctx, oidc.Claims{"uid": "1"})
+		c.So(err, c.ShouldBeNil)
+		c.So(kid(second), c.ShouldEqual, kid(first))
+
+		// 新密钥激活后使用新密钥签发，旧密钥签发的token仍然有效
+		repo.shift(time.Minute * 10)
+		c.So(jwks.Rotate(ctx), c.ShouldBeNil)
+		third, err := jwks.Sign(ctx, oidc.Claims{"uid": "1"})
+		c.So(err, c.ShouldBeNil)
+		c.So(kid(third), c.ShouldEqual, repo.keys[1].Uid)
+		_, err = jwks.Verify(ctx, first)
+		c.So(err, c.ShouldBeNil)
+
+		// 其他实例生成的密钥，遇到未知kid时重新加载
+		other := biz.NewJWKSUsecase(repo, cnf, gateway.Log)
+		_, err = other.Verify(ctx, third)
+		c.So(err, c.ShouldBeNil)
+		_, err = jwks.Verify(ctx, first[:len(first)-4]+"AAAA")
+		c.So(err, c.ShouldNotBeNil)
+
+		// 重叠时间后旧密钥删除
+		repo.shift(time.Hour * 3)
+		c.So(jwks.Rotate(ctx), c.ShouldBeNil)
+		c.So(repo.keys[0].IsDeleted, c.ShouldBeTrue)
+		_, err = jwks.Verify(ctx, first)
+		c.So(err, c.ShouldNotBeNil)
+		set, _ = jwks.JWKS(ctx)
+		for _, key := range set.Keys {
+			c.So(key.Kid, c.ShouldNotEqual, repo.keys[0].Uid)
+		}
+
+		// 网关签发的token使用当前密钥，payload与jwt_secret签发的一致
+		authz := biz.NewAuthzUsecase(nil, nil, gateway.Log, nil, cnf, jwks)
+		jwt, err := authz.GenerateJWT(ctx, &v1.Users{Uid: "1", Name: "test"}, false)
+		c.So(err, c.ShouldBeNil)
+		payload, err := authz.VerifyJWT(ctx, jwt)
+		c.So(err, c.ShouldBeNil)
+		c.So(payload.Uid, c.ShouldEqual, "1")
+		c.So(payload.Issuer, c.ShouldEqual, "gateway")
+		c.So(payload.Expiration, c.ShouldBeGreaterThan, time.Now().Unix())
+
+		// 未配置非对称算法时使用jwt_secret
+		conf.Set("auth.jwt_signing", map[string]interface{}{"algorithm": "HS256"})
+		legacy := biz.NewAuthzUsecase(nil, nil, gateway.Log, nil, cnf, biz.NewJWKSUsecase(repo, cnf, gateway.Log))
+		jwt, err = legacy.GenerateJWT(ctx, &v1.Users{Uid: "1", Name: "test"}, false)
+		c.So(err, c.ShouldBeNil)
+		parts := strings.Split(jwt, ".")
+		c.So(tiga.ComputeHmacSha256(parts[0]+"."+parts[1], cnf.GetJWTSecret()), c.ShouldEqual, parts[2])
+	})
+}
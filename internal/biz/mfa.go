@@ -0,0 +1,593 @@
+package biz
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/begonia-org/begonia/api/mfa/v1"
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/begonia/internal/pkg/mfa"
+	"github.com/begonia-org/begonia/internal/pkg/oidc"
+	gosdk "github.com/begonia-org/go-sdk"
+	user "github.com/begonia-org/go-sdk/api/user/v1"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"github.com/begonia-org/go-sdk/logger"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type MFARepo interface {
+	GetTOTP(ctx context.Context, user string) (*api.UserTOTP, error)
+	AddTOTP(ctx context.Context, totp *api.UserTOTP) error
+	PatchTOTP(ctx context.Context, totp *api.UserTOTP) error
+	ListCredentials(ctx context.Context, user string) ([]*api.WebAuthnCredential, error)
+	AddCredential(ctx context.Context, credential *api.WebAuthnCredential) error
+	PatchCredential(ctx context.Context, credential *api.WebAuthnCredential) error
+	DelCredential(ctx context.Context, credential *api.WebAuthnCredential) error
+	PutChallenge(ctx context.Context, id string, value []byte, exp time.Duration) error
+	// GetChallenge 获取并删除挑战
+	GetChallenge(ctx context.Context, id string) ([]byte, error)
+	// UseCode 标记验证码已使用，已使用过时返回false
+	UseCode(ctx context.Context, key string, exp time.Duration) (bool, error)
+}
+
+const (
+	MFAMethodTOTP       = "totp"
+	MFAMethodBackupCode = "backup_code"
+	MFAMethodWebAuthn   = "webauthn"
+
+	defaultMFAChallengeExpiration = time.Minute * 5
+	defaultMFAMaxAttempts         = 5
+)
+
+// mfaChallenge 账号密码验证通过后保存，第二步验证时校验，webauthn注册时也使用
+type mfaChallenge struct {
+	Uid         string    `json:"uid"`
+	IsKeepLogin bool      `json:"is_keep_login"`
+	Attempts    int       `json:"attempts"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// webauthn的challenge
+	WebAuthn string `json:"webauthn"`
+	Register bool   `json:"register"`
+}
+
+type MFAUsecase struct {
+	repo     MFARepo
+	user     UserRepo
+	sessions *SessionUsecase
+	config   *config.Config
+	log      logger.Logger
+	// 未配置rp_id时为nil
+	rp        *mfa.RelyingParty
+	issuer    string
+	snowflake *tiga.Snowflake
+
+	challengeExpiration time.Duration
+	maxAttempts         int
+}
+
+func NewMFAUsecase(repo MFARepo, user UserRepo, sessions *SessionUsecase, config *config.Config, log logger.Logger) *MFAUsecase {
+	conf, err := config.GetMFA()
+	if err != nil {
+		panic(fmt.Sprintf("get mfa config error:%v", err))
+	}
+	sn, _ := tiga.NewSnowflake(1)
+	u := &MFAUsecase{
+		repo:                repo,
+		user:                user,
+		sessions:            sessions,
+		config:              config,
+		log:                 log,
+		issuer:              conf.Issuer,
+		snowflake:           sn,
+		challengeExpiration: defaultMFAChallengeExpiration,
+		maxAttempts:         defaultMFAMaxAttempts,
+	}
+	if u.issuer == "" {
+		u.issuer = "begonia"
+	}
+	if conf.ChallengeExpiration > 0 {
+		u.challengeExpiration = time.Duration(conf.ChallengeExpiration) * time.Second
+	}
+	if conf.MaxAttempts > 0 {
+		u.maxAttempts = conf.MaxAttempts
+	}
+	if conf.WebAuthn.RPID != "" {
+		u.rp = &mfa.RelyingParty{
+			ID:               conf.WebAuthn.RPID,
+			Name:             conf.WebAuthn.RPName,
+			Origins:          conf.WebAuthn.Origins,
+			UserVerification: conf.WebAuthn.UserVerification,
+			Timeout:          time.Duration(conf.WebAuthn.Timeout) * time.Second,
+		}
+		if u.rp.Timeout <= 0 {
+			u.rp.Timeout = u.challengeExpiration
+		}
+	}
+	return u
+}
+
+func mfaCodeError(err error, action string) error {
+	return gosdk.NewError(err, int32(common.Code_AUTH_ERROR), codes.Unauthenticated, action)
+}
+
+// getTOTP 获取用户绑定的totp，未绑定时返回nil
+func (m *MFAUsecase) getTOTP(ctx context.Context, uid string) *api.UserTOTP {
+	totp, err := m.repo.GetTOTP(ctx, uid)
+	if err != nil {
+		return nil
+	}
+	return totp
+}
+
+func backupCodes(totp *api.UserTOTP) []string {
+	if totp.BackupCodes == "" {
+		return []string{}
+	}
+	return strings.Split(totp.BackupCodes, ",")
+}
+
+// verifyTOTP 校验totp验证码，同一个验证码在有效期内只能使用一次
+func (m *MFAUsecase) verifyTOTP(ctx context.Context, totp *api.UserTOTP, code string) error {
+	step, ok := mfa.ValidateTOTP(totp.Secret, code, time.Now())
+	if !ok {
+		return pkg.ErrMFACodeInvalid
+	}
+	exp := time.Duration(2*mfa.TOTPSkew+1) * mfa.TOTPPeriod * time.Second
+	fresh, err := m.repo.UseCode(ctx, m.config.GetMFACodeKey(totp.User, step), exp)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return pkg.ErrMFACodeReused
+	}
+	return nil
+}
+
+// verifyCode 校验totp验证码或备用码，使用备用码后移除该备用码
+func (m *MFAUsecase) verifyCode(ctx context.Context, totp *api.UserTOTP, code string, allowBackup bool) error {
+	err := m.verifyTOTP(ctx, totp, code)
+	if err == nil || !allowBackup || !errors.Is(err, pkg.ErrMFACodeInvalid) {
+		return err
+	}
+	return m.useBackupCode(ctx, totp, code)
+}
+
+func (m *MFAUsecase) useBackupCode(ctx context.Context, totp *api.UserTOTP, code string) error {
+	remain, ok := mfa.UseBackupCode(backupCodes(totp), code)
+	if !ok {
+		return pkg.ErrMFACodeInvalid
+	}
+	totp.BackupCodes = strings.Join(remain, ",")
+	totp.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"backup_codes"}}
+	return m.repo.PatchTOTP(ctx, totp)
+}
+
+func (m *MFAUsecase) Status(ctx context.Context, uid string) (*api.MFAStatus, error) {
+	status := &api.MFAStatus{}
+	if totp := m.getTOTP(ctx, uid); totp != nil && totp.Enabled {
+		status.TotpEnabled = true
+		status.BackupCodesRemaining = int32(len(backupCodes(totp)))
+	}
+	credentials, err := m.repo.ListCredentials(ctx, uid)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_webauthn_credentials")
+	}
+	status.WebauthnCredentials = int32(len(credentials))
+	return status, nil
+}
+
+// Methods 用户启用的第二步验证方式，未启用多因素认证时为空
+func (m *MFAUsecase) Methods(ctx context.Context, uid string) ([]string, error) {
+	methods := make([]string, 0)
+	if totp := m.getTOTP(ctx, uid); totp != nil && totp.Enabled {
+		methods = append(methods, MFAMethodTOTP)
+		if len(backupCodes(totp)) > 0 {
+			methods = append(methods, MFAMethodBackupCode)
+		}
+	}
+	if m.rp != nil {
+		credentials, err := m.repo.ListCredentials(ctx, uid)
+		if err != nil {
+			return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_webauthn_credentials")
+		}
+		if len(credentials) > 0 {
+			methods = append(methods, MFAMethodWebAuthn)
+		}
+	}
+	return methods, nil
+}
+
+// EnrollTOTP 生成新的totp密钥，使用验证码确认后才启用
+func (m *MFAUsecase) EnrollTOTP(ctx context.Context, uid string) (*api.EnrollTOTPResponse, error) {
+	u, err := m.user.Get(ctx, uid)
+	if err != nil || u == nil {
+		return nil, gosdk.NewError(pkg.ErrUserNotFound, int32(user.UserSvrCode_USER_NOT_FOUND_ERR), codes.NotFound, "user_query")
+	}
+	secret, err := mfa.GenerateTOTPSecret()
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "totp_secret")
+	}
+	totp := m.getTOTP(ctx, uid)
+	if totp != nil && totp.Enabled {
+		return nil, gosdk.NewError(pkg.ErrMFAAlreadyEnabled, int32(common.Code_CONFLICT), codes.AlreadyExists, "totp_enroll")
+	}
+	if totp == nil {
+		err = m.repo.AddTOTP(ctx, &api.UserTOTP{Uid: m.snowflake.GenerateIDString(), User: uid, Secret: secret})
+	} else {
+		totp.Secret = secret
+		totp.BackupCodes = ""
+		totp.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"secret", "backup_codes"}}
+		err = m.repo.PatchTOTP(ctx, totp)
+	}
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "totp_enroll")
+	}
+	return &api.EnrollTOTPResponse{Secret: secret, Url: mfa.TOTPURL(m.issuer, u.Name, secret)}, nil
+}
+
+// ActivateTOTP 使用验证码确认绑定，返回备用码
+func (m *MFAUsecase) ActivateTOTP(ctx context.Context, uid, code string) (*api.BackupCodesResponse, error) {
+	totp := m.getTOTP(ctx, uid)
+	if totp == nil || totp.Secret == "" {
+		return nil, gosdk.NewError(pkg.ErrMFANotEnrolled, int32(common.Code_NOT_FOUND), codes.FailedPrecondition, "totp_query")
+	}
+	if totp.Enabled {
+		return nil, gosdk.NewError(pkg.ErrMFAAlreadyEnabled, int32(common.Code_CONFLICT), codes.AlreadyExists, "totp_activate")
+	}
+	if err := m.verifyTOTP(ctx, totp, code); err != nil {
+		return nil, mfaCodeError(err, "totp_verify")
+	}
+	backup, hashes, err := mfa.GenerateBackupCodes()
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "backup_codes")
+	}
+	totp.Enabled = true
+	totp.BackupCodes = strings.Join(hashes, ",")
+	totp.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"enabled", "backup_codes"}}
+	if err := m.repo.PatchTOTP(ctx, totp); err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "totp_activate")
+	}
+	return &api.BackupCodesResponse{Codes: backup}, nil
+}
+
+// DisableTOTP 使用验证码或备用码解绑totp
+func (m *MFAUsecase) DisableTOTP(ctx context.Context, uid, code string) error {
+	totp := m.getTOTP(ctx, uid)
+	if totp == nil || !totp.Enabled {
+		return gosdk.NewError(pkg.ErrMFANotEnrolled, int32(common.Code_NOT_FOUND), codes.FailedPrecondition, "totp_query")
+	}
+	if err := m.verifyCode(ctx, totp, code, true); err != nil {
+		return mfaCodeError(err, "totp_verify")
+	}
+	totp.Enabled = false
+	totp.Secret = ""
+	totp.BackupCodes = ""
+	totp.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"enabled", "secret", "backup_codes"}}
+	if err := m.repo.PatchTOTP(ctx, totp); err != nil {
+		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "totp_disable")
+	}
+	return nil
+}
+
+// RegenerateBackupCodes 重新生成备用码，之前的备用码失效
+func (m *MFAUsecase) RegenerateBackupCodes(ctx context.Context, uid, code string) (*api.BackupCodesResponse, error) {
+	totp := m.getTOTP(ctx, uid)
+	if totp == nil || !totp.Enabled {
+		return nil, gosdk.NewError(pkg.ErrMFANotEnrolled, int32(common.Code_NOT_FOUND), codes.FailedPrecondition, "totp_query")
+	}
+	if err := m.verifyTOTP(ctx, totp, code); err != nil {
+		return nil, mfaCodeError(err, "totp_verify")
+	}
+	backup, hashes, err := mfa.GenerateBackupCodes()
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "backup_codes")
+	}
+	totp.BackupCodes = strings.Join(hashes, ",")
+	totp.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"backup_codes"}}
+	if err := m.repo.PatchTOTP(ctx, totp); err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "backup_codes")
+	}
+	return &api.BackupCodesResponse{Codes: backup}, nil
+}
+
+func (m *MFAUsecase) relyingParty() (*mfa.RelyingParty, error) {
+	if m.rp == nil {
+		return nil, gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrMFAMethodNotSupported, MFAMethodWebAuthn), int32(common.Code_PARAMS_ERROR), codes.FailedPrecondition, "webauthn_config")
+	}
+	return m.rp, nil
+}
+
+func (m *MFAUsecase) putChallenge(ctx context.Context, id string, challenge *mfaChallenge) error {
+	exp := time.Until(challenge.ExpiresAt)
+	if exp <= 0 {
+		return pkg.ErrMFAChallengeInvalid
+	}
+	val, _ := json.Marshal(challenge)
+	return m.repo.PutChallenge(ctx, id, val, exp)
+}
+
+func (m *MFAUsecase) getChallenge(ctx context.Context, id string, register bool) (*mfaChallenge, error) {
+	challenge := &mfaChallenge{}
+	val, err := m.repo.GetChallenge(ctx, id)
+	if err != nil || json.Unmarshal(val, challenge) != nil || challenge.Register != register {
+		return nil, gosdk.NewError(pkg.ErrMFAChallengeInvalid, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "mfa_challenge")
+	}
+	return challenge, nil
+}
+
+func credentialIDs(credentials []*api.WebAuthnCredential) [][]byte {
+	ids := make([][]byte, 0, len(credentials))
+	for _, credential := range credentials {
+		if id, err := base64.RawURLEncoding.DecodeString(credential.CredentialId); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (m *MFAUsecase) webauthnOptions(id string, options interface{}) (*api.WebAuthnOptions, error) {
+	val, err := json.Marshal(options)
+	if err != nil {
+		return nil, gosdk.NewError(fmt.Errorf("%w:%w", pkg.ErrEncode, err), int32(common.Code_INTERNAL_ERROR), codes.Internal, "webauthn_options")
+	}
+	return &api.WebAuthnOptions{ChallengeId: id, Options: string(val)}, nil
+}
+
+func newChallengeID() (string, string, error) {
+	id, errID := oidc.RandomString()
+	challenge, errChallenge := oidc.RandomString()
+	if err := errors.Join(errID, errChallenge); err != nil {
+		return "", "", gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "mfa_challenge")
+	}
+	return id, challenge, nil
+}
+
+// BeginRegistration 生成注册webauthn凭证的参数
+func (m *MFAUsecase) BeginRegistration(ctx context.Context, uid string) (*api.WebAuthnOptions, error) {
+	rp, err := m.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	u, err := m.user.Get(ctx, uid)
+	if err != nil || u == nil {
+		return nil, gosdk.NewError(pkg.ErrUserNotFound, int32(user.UserSvrCode_USER_NOT_FOUND_ERR), codes.NotFound, "user_query")
+	}
+	credentials, err := m.repo.ListCredentials(ctx, uid)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_webauthn_credentials")
+	}
+	id, challenge, err := newChallengeID()
+	if err != nil {
+		return nil, err
+	}
+	state := &mfaChallenge{Uid: uid, WebAuthn: challenge, Register: true, ExpiresAt: time.Now().Add(rp.Timeout)}
+	if err := m.putChallenge(ctx, id, state); err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "mfa_challenge")
+	}
+	return m.webauthnOptions(id, rp.NewCreationOptions(challenge, uid, u.Name, credentialIDs(credentials)))
+}
+
+func decodeBase64URL(fields ...string) ([][]byte, error) {
+	values := make([][]byte, 0, len(fields))
+	for _, field := range fields {
+		val, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(field, "="))
+		if err != nil {
+			return nil, fmt.Errorf("%w:%w", pkg.ErrDecode, err)
+		}
+		values = append(values, val)
+	}
+	return values, nil
+}
+
+// FinishRegistration 校验认证器返回的凭证并保存
+func (m *MFAUsecase) FinishRegistration(ctx context.Context, uid string, in *api.FinishWebAuthnRegistrationRequest) (*api.WebAuthnCredential, error) {
+	rp, err := m.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := m.getChallenge(ctx, in.ChallengeId, true)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.Uid != uid {
+		return nil, gosdk.NewError(pkg.ErrMFAChallengeInvalid, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "mfa_challenge")
+	}
+	values, err := decodeBase64URL(in.ClientDataJson, in.AttestationObject)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "webauthn_decode")
+	}
+	credential, err := rp.VerifyRegistration(challenge.WebAuthn, &mfa.AttestationResponse{ClientDataJSON: values[0], AttestationObject: values[1]})
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_AUTH_ERROR), codes.InvalidArgument, "webauthn_register")
+	}
+	name := in.Name
+	if name == "" {
+		name = fmt.Sprintf("passkey-%s", time.Now().Format("20060102150405"))
+	}
+	item := &api.WebAuthnCredential{
+		Uid:          m.snowflake.GenerateIDString(),
+		User:         uid,
+		CredentialId: base64.RawURLEncoding.EncodeToString(credential.ID),
+		PublicKey:    base64.RawURLEncoding.EncodeToString(credential.PublicKey),
+		Aaguid:       credential.AAGUID,
+		SignCount:    credential.SignCount,
+		Name:         name,
+	}
+	rsp := &api.WebAuthnCredential{Uid: item.Uid, User: uid, CredentialId: item.CredentialId, Aaguid: item.Aaguid, SignCount: item.SignCount, Name: name}
+	if err := m.repo.AddCredential(ctx, item); err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "webauthn_register")
+	}
+	rsp.CreatedAt = item.CreatedAt
+	return rsp, nil
+}
+
+func (m *MFAUsecase) ListCredentials(ctx context.Context, uid string) ([]*api.WebAuthnCredential, error) {
+	credentials, err := m.repo.ListCredentials(ctx, uid)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_webauthn_credentials")
+	}
+	for _, credential := range credentials {
+		credential.PublicKey = ""
+	}
+	return credentials, nil
+}
+
+func (m *MFAUsecase) DeleteCredential(ctx context.Context, uid, id string) error {
+	credentials, err := m.repo.ListCredentials(ctx, uid)
+	if err != nil {
+		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_webauthn_credentials")
+	}
+	for _, credential := range credentials {
+		if credential.Uid == id {
+			if err := m.repo.DelCredential(ctx, credential); err != nil {
+				return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "delete_webauthn_credential")
+			}
+			return nil
+		}
+	}
+	return gosdk.NewError(pkg.ErrWebAuthnCredential, int32(common.Code_NOT_FOUND), codes.NotFound, "webauthn_credential")
+}
+
+// Challenge 账号密码验证通过后，用户启用了多因素认证时创建第二步验证的挑战，未启用时返回空
+func (m *MFAUsecase) Challenge(ctx context.Context, u *user.Users, isKeepLogin bool) (string, []string, error) {
+	methods, err := m.Methods(ctx, u.Uid)
+	if err != nil || len(methods) == 0 {
+		return "", nil, err
+	}
+	id, err := oidc.RandomString()
+	if err != nil {
+		return "", nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "mfa_challenge")
+	}
+	challenge := &mfaChallenge{Uid: u.Uid, IsKeepLogin: isKeepLogin, ExpiresAt: time.Now().Add(m.challengeExpiration)}
+	if err := m.putChallenge(ctx, id, challenge); err != nil {
+		return "", nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "mfa_challenge")
+	}
+	return id, methods, nil
+}
+
+// BeginLogin 生成第二步验证使用的webauthn断言参数
+func (m *MFAUsecase) BeginLogin(ctx context.Context, id string) (*api.WebAuthnOptions, error) {
+	rp, err := m.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := m.getChallenge(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := m.repo.ListCredentials(ctx, challenge.Uid)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_webauthn_credentials")
+	}
+	webauthn, err := oidc.RandomString()
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "mfa_challenge")
+	}
+	challenge.WebAuthn = webauthn
+	if err := m.putChallenge(ctx, id, challenge); err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "mfa_challenge")
+	}
+	return m.webauthnOptions(id, rp.NewRequestOptions(webauthn, credentialIDs(credentials)))
+}
+
+func (m *MFAUsecase) verifyWebAuthn(ctx context.Context, challenge *mfaChallenge, in *api.VerifyMFARequest) error {
+	rp, err := m.relyingParty()
+	if err != nil {
+		return err
+	}
+	if challenge.WebAuthn == "" {
+		return pkg.ErrMFAChallengeInvalid
+	}
+	values, err := decodeBase64URL(in.ClientDataJson, in.AuthenticatorData, in.Signature)
+	if err != nil {
+		return err
+	}
+	credentials, err := m.repo.ListCredentials(ctx, challenge.Uid)
+	if err != nil {
+		return err
+	}
+	for _, credential := range credentials {
+		if credential.CredentialId != strings.TrimRight(in.CredentialId, "=") {
+			continue
+		}
+		publicKey, err := base64.RawURLEncoding.DecodeString(credential.PublicKey)
+		if err != nil {
+			return fmt.Errorf("%w:%w", pkg.ErrDecode, err)
+		}
+		count, err := rp.VerifyAssertion(challenge.WebAuthn, publicKey, credential.SignCount, &mfa.AssertionResponse{ClientDataJSON: values[0], AuthenticatorData: values[1], Signature: values[2]})
+		if err != nil {
+			return err
+		}
+		credential.SignCount = count
+		credential.LastUsedAt = timestamppb.Now()
+		credential.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"sign_count", "last_used_at"}}
+		if err := m.repo.PatchCredential(ctx, credential); err != nil {
+			m.log.Errorf(ctx, "update webauthn credential error:%s", err.Error())
+		}
+		return nil
+	}
+	return pkg.ErrWebAuthnCredential
+}
+
+// Verify 完成第二步验证并创建会话，失败次数超过限制后挑战失效
+func (m *MFAUsecase) Verify(ctx context.Context, in *api.VerifyMFARequest) (*api.MFALoginResponse, error) {
+	challenge, err := m.getChallenge(ctx, in.ChallengeId, false)
+	if err != nil {
+		return nil, err
+	}
+	switch in.Method {
+	case MFAMethodTOTP, MFAMethodBackupCode:
+		totp := m.getTOTP(ctx, challenge.Uid)
+		if totp == nil || !totp.Enabled {
+			err = pkg.ErrMFANotEnrolled
+		} else if in.Method == MFAMethodTOTP {
+			err = m.verifyTOTP(ctx, totp, in.Code)
+		} else {
+			err = m.useBackupCode(ctx, totp, in.Code)
+		}
+	case MFAMethodWebAuthn:
+		err = m.verifyWebAuthn(ctx, challenge, in)
+	default:
+		return nil, gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrMFAMethodNotSupported, in.Method), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "mfa_method")
+	}
+	if err != nil {
+		challenge.Attempts++
+		if challenge.Attempts >= m.maxAttempts {
+			m.log.Warnf(ctx, "too many mfa attempts of user %s", challenge.Uid)
+			return nil, mfaCodeError(fmt.Errorf("%w:%w", pkg.ErrMFATooManyAttempts, err), "mfa_verify")
+		}
+		// 验证失败时挑战仍然有效
+		if errPut := m.putChallenge(ctx, in.ChallengeId, challenge); errPut != nil {
+			m.log.Errorf(ctx, "save mfa challenge error:%s", errPut.Error())
+		}
+		return nil, mfaCodeError(err, "mfa_verify")
+	}
+	u, err := m.user.Get(ctx, challenge.Uid)
+	if err != nil || u == nil {
+		return nil, gosdk.NewError(pkg.ErrUserNotFound, int32(user.UserSvrCode_USER_NOT_FOUND_ERR), codes.Unauthenticated, "user_query")
+	}
+	if u.Status != user.USER_STATUS_ACTIVE {
+		return nil, gosdk.NewError(pkg.ErrUserDisabled, int32(user.UserSvrCode_USER_DISABLED_ERR), codes.Unauthenticated, "user_status")
+	}
+	token, err := m.sessions.Create(ctx, u, challenge.IsKeepLogin)
+	if err != nil {
+		return nil, err
+	}
+	return &api.MFALoginResponse{
+		Token:        token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresIn:    token.ExpiresIn,
+		SessionId:    token.SessionId,
+		Uid:          u.Uid,
+		Name:         u.Name,
+	}, nil
+}
@@ -0,0 +1,240 @@
+package biz_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/begonia-org/begonia"
+	api "github.com/begonia-org/begonia/api/mfa/v1"
+	"github.com/begonia-org/begonia/config"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/biz"
+	cfg "github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/begonia/internal/pkg/mfa"
+	"github.com/begonia-org/begonia/internal/pkg/mfa/mfatest"
+	v1 "github.com/begonia-org/go-sdk/api/user/v1"
+	"github.com/redis/go-redis/v9"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+type memoryMFARepo struct {
+	totps       map[string]*api.UserTOTP
+	credentials []*api.WebAuthnCredential
+	challenges  map[string][]byte
+	codes       map[string]bool
+}
+
+func (m *memoryMFARepo) GetTOTP(ctx context.Context, user string) (*api.UserTOTP, error) {
+	if totp, ok := m.totps[user]; ok {
+		return proto.Clone(totp).(*api.UserTOTP), nil
+	}
+	return nil, fmt.Errorf("get user totp failed: %w", gorm.ErrRecordNotFound)
+}
+func (m *memoryMFARepo) AddTOTP(ctx context.Context, totp *api.UserTOTP) error {
+	m.totps[totp.User] = proto.Clone(totp).(*api.UserTOTP)
+	return nil
+}
+func (m *memoryMFARepo) PatchTOTP(ctx context.Context, totp *api.UserTOTP) error {
+	return m.AddTOTP(ctx, totp)
+}
+func (m *memoryMFARepo) ListCredentials(ctx context.Context, user string) ([]*api.WebAuthnCredential, error) {
+	credentials := make([]*api.WebAuthnCredential, 0)
+	for _, credential := range m.credentials {
+		if credential.User == user && !credential.IsDeleted {
+			credentials = append(credentials, proto.Clone(credential).(*api.WebAuthnCredential))
+		}
+	}
+	return credentials, nil
+}
+func (m *memoryMFARepo) AddCredential(ctx context.Context, credential *api.WebAuthnCredential) error {
+	m.credentials = append(m.credentials, proto.Clone(credential).(*api.WebAuthnCredential))
+	return nil
+}
+func (m *memoryMFARepo) PatchCredential(ctx context.Context, credential *api.WebAuthnCredential) error {
+	for i, item := range m.credentials {
+		if item.Uid == credential.Uid {
+			m.credentials[i] = proto.Clone(credential).(*api.WebAuthnCredential)
+		}
+	}
+	return nil
+}
+func (m *memoryMFARepo) DelCredential(ctx context.Context, credential *api.WebAuthnCredential) error {
+	credential.IsDeleted = true
+	return m.PatchCredential(ctx, credential)
+}
+func (m *memoryMFARepo) PutChallenge(ctx context.Context, id string, value []byte, exp time.Duration) error {
+	m.challenges[id] = value
+	return nil
+}
+func (m *memoryMFARepo) GetChallenge(ctx context.Context, id string) ([]byte, error) {
+	val, ok := m.challenges[id]
+	if !ok {
+		return nil, redis.Nil
+	}
+	delete(m.challenges, id)
+	return val, nil
+}
+func (m *memoryMFARepo) UseCode(ctx context.Context, key string, exp time.Duration) (bool, error) {
+	if m.codes[key] {
+		return false, nil
+	}
+	m.codes[key] = true
+	return true, nil
+}
+
+func TestMFAUsecase(t *testing.T) {
+	c.Convey("test totp, backup codes and webauthn two-step login", t, func() {
+		env := "dev"
+		if begonia.Env != "" {
+			env = begonia.Env
+		}
+		conf := config.ReadConfig(env)
+		conf.Set("auth.mfa", map[string]interface{}{
+			"issuer":       "begonia",
+			"max_attempts": 3,
+			"webauthn": map[string]interface{}{
+				"rp_id":   "example.com",
+				"rp_name": "begonia",
+				"origins": []string{"https://example.com"},
+			},
+		})
+		cnf := cfg.NewConfig(conf)
+		users := &memoryUserRepo{users: map[string]*v1.Users{
+			"mfa-1": {Uid: "mfa-1", Name: "admin", Status: v1.USER_STATUS_ACTIVE},
+			"mfa-2": {Uid: "mfa-2", Name: "guest", Status: v1.USER_STATUS_ACTIVE},
+		}, config: cnf}
+		repo := &memoryMFARepo{totps: make(map[string]*api.UserTOTP), challenges: make(map[string][]byte), codes: make(map[string]bool)}
+		authz := biz.NewAuthzUsecase(&memoryBlackList{tokens: make(map[string]bool)}, users, gateway.Log, nil, cnf, nil)
+		sessions := biz.NewSessionUsecase(&memorySessionRepo{sessions: make(map[string]*biz.UserSession)}, users, authz, cnf, gateway.Log)
+		m := biz.NewMFAUsecase(repo, users, sessions, cnf, gateway.Log)
+		ctx := context.Background()
+
+		// 未启用多因素认证时直接登录
+		challenge, _, err := m.Challenge(ctx, users.users["mfa-2"], false)
+		c.So(err, c.ShouldBeNil)
+		c.So(challenge, c.ShouldBeEmpty)
+
+		// 绑定totp，确认前不生效
+		enroll, err := m.EnrollTOTP(ctx, "mfa-1")
+		c.So(err, c.ShouldBeNil)
+		c.So(enroll.Url, c.ShouldStartWith, "otpauth://totp/begonia:admin?")
+		challenge, _, _ = m.Challenge(ctx, users.users["mfa-1"], false)
+		c.So(challenge, c.ShouldBeEmpty)
+		_, err = m.ActivateTOTP(ctx, "mfa-1", "000000")
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+		now := time.Now()
+		code, _ := mfa.TOTPCode(enroll.Secret, now)
+		backup, err := m.ActivateTOTP(ctx, "mfa-1", code)
+		c.So(err, c.ShouldBeNil)
+		c.So(backup.Codes, c.ShouldHaveLength, 10)
+		_, err = m.EnrollTOTP(ctx, "mfa-1")
+		c.So(status.Code(err), c.ShouldEqual, codes.AlreadyExists)
+
+		// 两步登录，同一个验证码不能重复使用
+		challenge, methods, err := m.Challenge(ctx, users.users["mfa-1"], true)
+		c.So(err, c.ShouldBeNil)
+		c.So(challenge, c.ShouldNotBeEmpty)
+		c.So(methods, c.ShouldResemble, []string{biz.MFAMethodTOTP, biz.MFAMethodBackupCode})
+		_, err = m.Verify(ctx, &api.VerifyMFARequest{ChallengeId: challenge, Method: biz.MFAMethodTOTP, Code: code})
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+		c.So(err.Error(), c.ShouldContainSubstring, "已使用")
+		previous, _ := mfa.TOTPCode(enroll.Secret, now.Add(-mfa.TOTPPeriod*time.Second))
+		rsp, err := m.Verify(ctx, &api.VerifyMFARequest{ChallengeId: challenge, Method: biz.MFAMethodTOTP, Code: previous})
+		c.So(err, c.ShouldBeNil)
+		c.So(rsp.Uid, c.ShouldEqual, "mfa-1")
+		c.So(biz.TokenSessionID(rsp.Token), c.ShouldEqual, rsp.SessionId)
+		c.So(rsp.RefreshToken, c.ShouldStartWith, rsp.SessionId+".")
+		// 挑战只能使用一次
+		_, err = m.Verify(ctx, &api.VerifyMFARequest{ChallengeId: challenge, Method: biz.MFAMethodTOTP, Code: previous})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+
+		// 备用码只能使用一次
+		challenge, _, _ = m.Challenge(ctx, users.users["mfa-1"], false)
+		_, err = m.Verify(ctx, &api.VerifyMFARequest{ChallengeId: challenge, Method: biz.MFAMethodBackupCode, Code: backup.Codes[0]})
+		c.So(err, c.ShouldBeNil)
+		state, _ := m.Status(ctx, "mfa-1")
+		c.So(state.TotpEnabled, c.ShouldBeTrue)
+		c.So(state.BackupCodesRemaining, c.ShouldEqual, 9)
+		challenge, _, _ = m.Challenge(ctx, users.users["mfa-1"], false)
+		_, err = m.Verify(ctx, &api.VerifyMFARequest{ChallengeId: challenge, Method: biz.MFAMethodBackupCode, Code: backup.Codes[0]})
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+
+		// 失败次数过多后挑战失效
+		_, err = m.Verify(ctx, &api.VerifyMFARequest{ChallengeId: challenge, Method: biz.MFAMethodTOTP, Code: "000000"})
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+		_, err = m.Verify(ctx, &api.VerifyMFARequest{ChallengeId: challenge, Method: biz.MFAMethodTOTP, Code: "000000"})
+		c.So(err.Error(), c.ShouldContainSubstring, "次数过多")
+		_, err = m.Verify(ctx, &api.VerifyMFARequest{ChallengeId: challenge, Method: biz.MFAMethodBackupCode, Code: backup.Codes[1]})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+		_, err = m.Verify(ctx, &api.VerifyMFARequest{ChallengeId: "invalid", Method: biz.MFAMethodTOTP, Code: previous})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+
+		// 注册webauthn凭证
+		authenticator, err := mfatest.NewAuthenticator("https://example.com")
+		c.So(err, c.ShouldBeNil)
+		register, err := m.BeginRegistration(ctx, "mfa-1")
+		c.So(err, c.ShouldBeNil)
+		creation := &mfa.CredentialCreationOptions{}
+		c.So(json.Unmarshal([]byte(register.Options), creation), c.ShouldBeNil)
+		c.So(creation.RP.ID, c.ShouldEqual, "example.com")
+		// 注册挑战与用户绑定
+		_, err = m.FinishRegistration(ctx, "mfa-2", &api.FinishWebAuthnRegistrationRequest{ChallengeId: register.ChallengeId})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+		register, _ = m.BeginRegistration(ctx, "mfa-1")
+		_ = json.Unmarshal([]byte(register.Options), creation)
+		attestation := authenticator.Register(creation)
+		credential, err := m.FinishRegistration(ctx, "mfa-1", &api.FinishWebAuthnRegistrationRequest{
+			ChallengeId:       register.ChallengeId,
+			Name:              "yubikey",
+			ClientDataJson:    base64.RawURLEncoding.EncodeToString(attestation.ClientDataJSON),
+			AttestationObject: base64.RawURLEncoding.EncodeToString(attestation.AttestationObject),
+		})
+		c.So(err, c.ShouldBeNil)
+		c.So(credential.CredentialId, c.ShouldEqual, authenticator.ID())
+		c.So(credential.PublicKey, c.ShouldBeEmpty)
+		c.So(repo.credentials[0].PublicKey, c.ShouldNotBeEmpty)
+
+		// 使用webauthn完成第二步验证
+		challenge, methods, _ = m.Challenge(ctx, users.users["mfa-1"], false)
+		c.So(methods, c.ShouldContain, biz.MFAMethodWebAuthn)
+		_, err = m.Verify(ctx, &api.VerifyMFARequest{ChallengeId: challenge, Method: biz.MFAMethodWebAuthn})
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+		options, err := m.BeginLogin(ctx, challenge)
+		c.So(err, c.ShouldBeNil)
+		request := &mfa.CredentialRequestOptions{}
+		c.So(json.Unmarshal([]byte(options.Options), request), c.ShouldBeNil)
+		c.So(request.AllowCredentials[0].ID, c.ShouldEqual, authenticator.ID())
+		assertion, err := authenticator.Login(request)
+		c.So(err, c.ShouldBeNil)
+		rsp, err = m.Verify(ctx, &api.VerifyMFARequest{
+			ChallengeId:       challenge,
+			Method:            biz.MFAMethodWebAuthn,
+			CredentialId:      authenticator.ID(),
+			ClientDataJson:    base64.RawURLEncoding.EncodeToString(assertion.ClientDataJSON),
+			AuthenticatorData: base64.RawURLEncoding.EncodeToString(assertion.AuthenticatorData),
+			Signature:         base64.RawURLEncoding.EncodeToString(assertion.Signature),
+		})
+		c.So(err, c.ShouldBeNil)
+		c.So(rsp.Token, c.ShouldNotBeEmpty)
+		c.So(repo.credentials[0].SignCount, c.ShouldEqual, 1)
+		c.So(repo.credentials[0].LastUsedAt, c.ShouldNotBeNil)
+
+		// 使用备用码解绑totp，删除webauthn凭证后不再需要第二步验证
+		c.So(m.DisableTOTP(ctx, "mfa-1", strings.ToUpper(backup.Codes[2])), c.ShouldBeNil)
+		c.So(repo.totps["mfa-1"].Secret, c.ShouldBeEmpty)
+		err = m.DeleteCredential(ctx, "mfa-2", credential.Uid)
+		c.So(status.Code(err), c.ShouldEqual, codes.NotFound)
+		c.So(m.DeleteCredential(ctx, "mfa-1", credential.Uid), c.ShouldBeNil)
+		challenge, _, _ = m.Challenge(ctx, users.users["mfa-1"], false)
+		c.So(challenge, c.ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,269 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/begonia-org/begonia/api/oidc/v1"
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/begonia/internal/pkg/oidc"
+	gosdk "github.com/begonia-org/go-sdk"
+	user "github.com/begonia-org/go-sdk/api/user/v1"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"github.com/begonia-org/go-sdk/logger"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/grpc/codes"
+)
+
+type OIDCRepo interface {
+	PutState(ctx context.Context, state string, value []byte, exp time.Duration) error
+	// GetState 获取并删除state
+	GetState(ctx context.Context, state string) ([]byte, error)
+	GetIdentity(ctx context.Context, issuer, subject string) (*api.UserIdentity, error)
+	AddIdentity(ctx context.Context, identity *api.UserIdentity) error
+}
+
+// 授权码登录需要在该时间内完成
+const oidcStateExpiration = time.Minute * 10
+
+// oidcState 发起授权码登录时保存，回调时校验
+type oidcState struct {
+	Provider string `json:"provider"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+type OIDCUsecase struct {
+	repo      OIDCRepo
+	user      UserRepo
+	sessions  *SessionUsecase
+	providers *oidc.Providers
+	config    *config.Config
+	log       logger.Logger
+	snowflake *tiga.Snowflake
+}
+
+func NewOIDCUsecase(repo OIDCRepo, user UserRepo, sessions *SessionUsecase, config *config.Config, log logger.Logger) *OIDCUsecase {
+	issuers, err := config.GetOIDCIssuers()
+	if err != nil {
+		panic(fmt.Sprintf("get oidc issuers error:%v", err))
+	}
+	sn, _ := tiga.NewSnowflake(1)
+	return &OIDCUsecase{
+		repo:      repo,
+		user:      user,
+		sessions:  sessions,
+		providers: oidc.NewProviders(issuers, nil),
+		config:    config,
+		log:       log,
+		snowflake: sn,
+	}
+}
+
+func (o *OIDCUsecase) provider(name string) (*oidc.Provider, error) {
+	provider, ok := o.providers.Get(name)
+	if !ok {
+		return nil, gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrOIDCProviderNotFound, name), int32(common.Code_NOT_FOUND), codes.NotFound, "oidc_provider")
+	}
+	return provider, nil
+}
+
+// Authorize 生成签发方的授权码登录地址
+func (o *OIDCUsecase) Authorize(ctx context.Context, name string) (*api.AuthorizeResponse, error) {
+	provider, err := o.provider(name)
+	if err != nil {
+		return nil, err
+	}
+	key, errKey := oidc.RandomString()
+	nonce, errNonce := oidc.RandomString()
+	verifier, errVerifier := oidc.RandomString()
+	if err := errors.Join(errKey, errNonce, errVerifier); err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "oidc_state")
+	}
+	url, err := provider.AuthCodeURL(ctx, key, nonce, verifier)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Unavailable, "oidc_discovery")
+	}
+	val, _ := json.Marshal(&oidcState{Provider: name, Nonce: nonce, Verifier: verifier})
+	if err := o.repo.PutState(ctx, key, val, oidcStateExpiration); err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "oidc_state")
+	}
+	return &api.AuthorizeResponse{Url: url, State: key}, nil
+}
+
+// Callback 使用授权码换取id token，首次登录时关联或创建用户，返回网关签发的token
+func (o *OIDCUsecase) Callback(ctx context.Context, in *api.CallbackRequest) (*api.OIDCLoginResponse, error) {
+	provider, err := o.provider(in.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if in.Code == "" {
+		return nil, gosdk.NewError(pkg.ErrOIDCCodeMissing, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "oidc_code")
+	}
+	state := &oidcState{}
+	val, err := o.repo.GetState(ctx, in.State)
+	if err != nil || json.Unmarshal(val, state) != nil || state.Provider != in.Provider {
+		return nil, gosdk.NewError(pkg.ErrOIDCStateInvalid, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "oidc_state")
+	}
+	token, err := provider.Exchange(ctx, in.Code, state.Verifier)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_AUTH_ERROR), codes.Unauthenticated, "oidc_exchange")
+	}
+	claims, err := provider.Verify(ctx, token.IDToken)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(user.UserSvrCode_USER_TOKEN_INVALIDATE_ERR), codes.Unauthenticated, "oidc_verify")
+	}
+	if claims.String("nonce") != state.Nonce {
+		return nil, gosdk.NewError(pkg.ErrOIDCNonceNotMatch, int32(user.UserSvrCode_USER_TOKEN_INVALIDATE_ERR), codes.Unauthenticated, "oidc_nonce")
+	}
+	u, created, err := o.login(ctx, provider, claims)
+	if err != nil {
+		return nil, err
+	}
+	if u.Status != user.USER_STATUS_ACTIVE {
+		return nil, gosdk.NewError(pkg.ErrUserDisabled, int32(user.UserSvrCode_USER_DISABLED_ERR), codes.Unauthenticated, "user_query")
+	}
+	session, err := o.sessions.Create(ctx, u, false)
+	if err != nil {
+		return nil, err
+	}
+	return &api.OIDCLoginResponse{
+		Token:        session.AccessToken,
+		RefreshToken: session.RefreshToken,
+		ExpiresIn:    session.ExpiresIn,
+		SessionId:    session.SessionId,
+		Uid:          u.Uid,
+		Name:         u.Name,
+		Created:      created,
+	}, nil
+}
+
+// login 获取外部身份关联的用户，未关联时按配置关联已有用户或者创建用户
+func (o *OIDCUsecase) login(ctx context.Context, provider *oidc.Provider, claims oidc.Claims) (*user.Users, bool, error) {
+	subject := provider.Subject(claims)
+	if subject == "" {
+		return nil, false, gosdk.NewError(pkg.ErrUidMissing, int32(user.UserSvrCode_USER_TOKEN_INVALIDATE_ERR), codes.Unauthenticated, "oidc_subject")
+	}
+	identity, err := o.repo.GetIdentity(ctx, provider.Issuer(), subject)
+	if err == nil {
+		u, err := o.user.Get(ctx, identity.User)
+		if err != nil {
+			return nil, false, gosdk.NewError(pkg.ErrUserNotFound, int32(user.UserSvrCode_USER_NOT_FOUND_ERR), codes.NotFound, "user_query")
+		}
+		return u, false, nil
+	}
+	if !isNotFound(err) {
+		return nil, false, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "oidc_identity")
+	}
+	cfg := provider.Config()
+	var u *user.Users
+	created := false
+	email := claims.String("email")
+	if cfg.LinkByEmail && email != "" && claims.Bool("email_verified") {
+		key, iv := o.config.GetAesConfig()
+		account, err := tiga.EncryptAES([]byte(key), email, iv)
+		if err != nil {
+			return nil, false, gosdk.NewError(pkg.ErrEncrypt, int32(user.UserSvrCode_USER_ACCOUNT_ERR), codes.InvalidArgument, "accout_encrypt")
+		}
+		u, _ = o.user.Get(ctx, account)
+	}
+	if u == nil {
+		if !cfg.AutoCreate {
+			return nil, false, gosdk.NewError(pkg.ErrOIDCUserNotAllowed, int32(user.UserSvrCode_USER_NOT_FOUND_ERR), codes.PermissionDenied, "oidc_user")
+		}
+		if u, err = o.createUser(ctx, provider, subject, claims); err != nil {
+			return nil, false, err
+		}
+		created = true
+	}
+	identity = &api.UserIdentity{
+		Uid:      o.snowflake.GenerateIDString(),
+		Provider: provider.Name(),
+		Issuer:   provider.Issuer(),
+		Subject:  subject,
+		User:     u.Uid,
+	}
+	if err := o.repo.AddIdentity(ctx, identity); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, false, gosdk.NewError(err, int32(common.Code_CONFLICT), codes.AlreadyExists, "oidc_identity")
+		}
+		return nil, false, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "oidc_identity")
+	}
+	return u, created, nil
+}
+
+func (o *OIDCUsecase) createUser(ctx context.Context, provider *oidc.Provider, subject string, claims oidc.Claims) (*user.Users, error) {
+	uid := o.snowflake.GenerateIDString()
+	// 随机密码，外部身份创建的用户不能使用密码登录
+	password, err := oidc.RandomString()
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "oidc_user")
+	}
+	u := &user.Users{
+		Uid:      uid,
+		Name:     firstNotEmpty(claims.String("preferred_username"), claims.String("name"), claims.String("email"), subject),
+		Password: password,
+		// email和phone为唯一索引，签发方没有提供时使用uid占位
+		Email:  firstNotEmpty(claims.String("email"), fmt.Sprintf("%s@%s", uid, provider.Name())),
+		Phone:  firstNotEmpty(claims.String("phone_number"), uid),
+		Avatar: claims.String("picture"),
+		Role:   user.Role_ADMIN,
+		Status: user.USER_STATUS_ACTIVE,
+	}
+	if err := o.user.Add(ctx, u); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, gosdk.NewError(err, int32(user.UserSvrCode_USER_USERNAME_DUPLICATE_ERR), codes.AlreadyExists, "oidc_user")
+		}
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "oidc_user")
+	}
+	// 写入时加密了字段，重新读取解密后的用户信息
+	u, err = o.user.Get(ctx, uid)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(user.UserSvrCode_USER_NOT_FOUND_ERR), codes.NotFound, "user_query")
+	}
+	return u, nil
+}
+
+func firstNotEmpty(values ...string) string {
+	for _, val := range values {
+		if val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+// IsExternal token是否由配置的外部签发方签发
+func (o *OIDCUsecase) IsExternal(token string) bool {
+	_, ok := o.providers.GetByIssuer(oidc.Issuer(token))
+	return ok
+}
+
+// Verify 校验外部签发方的token，返回关联的用户uid
+func (o *OIDCUsecase) Verify(ctx context.Context, token string) (string, error) {
+	provider, ok := o.providers.GetByIssuer(oidc.Issuer(token))
+	if !ok {
+		return "", gosdk.NewError(pkg.ErrTokenIssuer, int32(user.UserSvrCode_USER_TOKEN_INVALIDATE_ERR), codes.Unauthenticated, "check_issuer")
+	}
+	claims, err := provider.Verify(ctx, token)
+	if err != nil {
+		return "", gosdk.NewError(err, int32(user.UserSvrCode_USER_TOKEN_INVALIDATE_ERR), codes.Unauthenticated, "check_token")
+	}
+	subject := provider.Subject(claims)
+	if subject == "" {
+		return "", gosdk.NewError(pkg.ErrUidMissing, int32(user.UserSvrCode_USER_TOKEN_INVALIDATE_ERR), codes.Unauthenticated, "check_token")
+	}
+	identity, err := o.repo.GetIdentity(ctx, provider.Issuer(), subject)
+	if err != nil {
+		if isNotFound(err) {
+			return "", gosdk.NewError(pkg.ErrOIDCIdentityNotLink, int32(user.UserSvrCode_USER_NOT_FOUND_ERR), codes.Unauthenticated, "check_identity")
+		}
+		return "", gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "check_identity")
+	}
+	return identity.User, nil
+}
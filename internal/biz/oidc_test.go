@@ -0,0 +1,200 @@
+package biz_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/begonia-org/begonia"
+	api "github.com/begonia-org/begonia/api/oidc/v1"
+	"github.com/begonia-org/begonia/config"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/biz"
+	cfg "github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/begonia/internal/pkg/oidc"
+	"github.com/begonia-org/begonia/internal/pkg/oidc/oidctest"
+	v1 "github.com/begonia-org/go-sdk/api/user/v1"
+	"github.com/redis/go-redis/v9"
+	c "github.com/smartystreets/goconvey/convey"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type memoryOIDCRepo struct {
+	states     map[string][]byte
+	identities map[string]*api.UserIdentity
+}
+
+func (m *memoryOIDCRepo) PutState(ctx context.Context, state string, value []byte, exp time.Duration) error {
+	m.states[state] = value
+	return nil
+}
+func (m *memoryOIDCRepo) GetState(ctx context.Context, state string) ([]byte, error) {
+	val, ok := m.states[state]
+	if !ok {
+		return nil, redis.Nil
+	}
+	delete(m.states, state)
+	return val, nil
+}
+func (m *memoryOIDCRepo) GetIdentity(ctx context.Context, issuer, subject string) (*api.UserIdentity, error) {
+	if identity, ok := m.identities[issuer+subject]; ok {
+		return identity, nil
+	}
+	return nil, fmt.Errorf("get user identity failed: %w", gorm.ErrRecordNotFound)
+}
+func (m *memoryOIDCRepo) AddIdentity(ctx context.Context, identity *api.UserIdentity) error {
+	m.identities[identity.Issuer+identity.Subject] = identity
+	return nil
+}
+
+// memoryUserRepo 与数据库中一样，可以使用加密后的账号查询
+type memoryUserRepo struct {
+	users  map[string]*v1.Users
+	config *cfg.Config
+}
+
+func (m *memoryUserRepo) Add(ctx context.Context, user *v1.Users) error {
+	m.users[user.Uid] = user
+	return nil
+}
+func (m *memoryUserRepo) Get(ctx context.Context, key string) (*v1.Users, error) {
+	aesKey, iv := m.config.GetAesConfig()
+	for _, user := range m.users {
+		for _, val := range []string{user.Uid, user.Name, user.Email, user.Phone} {
+			encrypted, _ := tiga.EncryptAES([]byte(aesKey), val, iv)
+			if key == val || key == encrypted {
+				return user, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("get user failed: %w", gorm.ErrRecordNotFound)
+}
+func (m *memoryUserRepo) Del(ctx context.Context, key string) error {
+	return nil
+}
+func (m *memoryUserRepo) List(ctx context.Context, dept []string, status []v1.USER_STATUS, page, pageSize int32) ([]*v1.Users, error) {
+	return nil, nil
+}
+func (m *memoryUserRepo) Patch(ctx context.Context, model *v1.Users) error {
+	return nil
+}
+func (m *memoryUserRepo) Cache(ctx context.Context, prefix string, models []*v1.Users, exp time.Duration, getValue func(user *v1.Users) ([]byte, interface{})) (redis.Pipeliner, error) {
+	return nil, nil
+}
+
+// oidcLogin 模拟浏览器完成授权码登录，返回回调参数
+func oidcLogin(o *biz.OIDCUsecase, provider string) (*api.CallbackRequest, error) {
+	rsp, err := o.Authorize(context.Background(), provider)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	redirect, err := client.Get(rsp.Url)
+	if err != nil {
+		return nil, err
+	}
+	redirect.Body.Close()
+	location, err := url.Parse(redirect.Header.Get("Location"))
+	if err != nil {
+		return nil, err
+	}
+	return &api.CallbackRequest{Provider: provider, Code: location.Query().Get("code"), State: location.Query().Get("state")}, nil
+}
+
+func TestOIDCUsecase(t *testing.T) {
+	c.Convey("test oidc login and external token", t, func() {
+		env := "dev"
+		if begonia.Env != "" {
+			env = begonia.Env
+		}
+		conf := config.ReadConfig(env)
+		cnf := cfg.NewConfig(conf)
+		issuer, err := oidctest.NewIssuer("begonia", "secret")
+		c.So(err, c.ShouldBeNil)
+		defer issuer.Close()
+		corp, err := oidctest.NewIssuer("begonia", "secret")
+		c.So(err, c.ShouldBeNil)
+		defer corp.Close()
+		conf.Set("auth.oidc.issuers", []map[string]interface{}{
+			{"name": "mock", "issuer": issuer.URL, "client_id": "begonia", "client_secret": "secret", "redirect_url": "http://127.0.0.1/callback", "auto_create": true},
+			{"name": "corp", "issuer": corp.URL, "client_id": "begonia", "client_secret": "secret", "redirect_url": "http://127.0.0.1/callback", "link_by_email": true},
+		})
+		users := &memoryUserRepo{users: make(map[string]*v1.Users), config: cnf}
+		repo := &memoryOIDCRepo{states: make(map[string][]byte), identities: make(map[string]*api.UserIdentity)}
+		authz := biz.NewAuthzUsecase(nil, users, gateway.Log, nil, cnf, nil)
+		sessions := biz.NewSessionUsecase(&memorySessionRepo{sessions: make(map[string]*biz.UserSession)}, users, authz, cnf, gateway.Log)
+		o := biz.NewOIDCUsecase(repo, users, sessions, cnf, gateway.Log)
+		ctx := context.Background()
+
+		_, err = o.Authorize(ctx, "unknown")
+		c.So(status.Code(err), c.ShouldEqual, codes.NotFound)
+
+		// 首次登录创建用户
+		issuer.SetUser(oidc.Claims{"sub": "external-1", "preferred_username": "oidc-user", "email": "oidc-user@example.com"})
+		in, err := oidcLogin(o, "mock")
+		c.So(err, c.ShouldBeNil)
+		_, err = o.Callback(ctx, &api.CallbackRequest{Provider: "mock", Code: in.Code, State: "invalid"})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+		rsp, err := o.Callback(ctx, in)
+		c.So(status.Code(err), c.ShouldEqual, codes.OK)
+		c.So(rsp.Created, c.ShouldBeTrue)
+		c.So(rsp.Token, c.ShouldNotBeEmpty)
+		c.So(rsp.RefreshToken, c.ShouldStartWith, rsp.SessionId+".")
+		c.So(rsp.Name, c.ShouldEqual, "oidc-user")
+		c.So(users.users[rsp.Uid].Password, c.ShouldNotBeEmpty)
+		_, err = o.Callback(ctx, in)
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+
+		in, err = oidcLogin(o, "mock")
+		c.So(err, c.ShouldBeNil)
+		again, err := o.Callback(ctx, in)
+		c.So(err, c.ShouldBeNil)
+		c.So(again.Created, c.ShouldBeFalse)
+		c.So(again.Uid, c.ShouldEqual, rsp.Uid)
+
+		// 外部签发的token映射为关联的用户
+		token, _ := issuer.Sign(oidc.Claims{"sub": "external-1"})
+		c.So(o.IsExternal(token), c.ShouldBeTrue)
+		c.So(o.IsExternal(rsp.Token), c.ShouldBeFalse)
+		uid, err := o.Verify(ctx, token)
+		c.So(err, c.ShouldBeNil)
+		c.So(uid, c.ShouldEqual, rsp.Uid)
+		token, _ = issuer.Sign(oidc.Claims{"sub": "external-2"})
+		_, err = o.Verify(ctx, token)
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+		token, _ = issuer.Sign(oidc.Claims{"sub": "external-1", "exp": time.Now().Add(-time.Hour).Unix()})
+		_, err = o.Verify(ctx, token)
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+
+		// 不允许自动创建时只能按已验证的email关联已有用户
+		users.users["local-1"] = &v1.Users{Uid: "local-1", Name: "local", Email: "local@example.com", Phone: "10000000000", Status: v1.USER_STATUS_ACTIVE}
+		corp.SetUser(oidc.Claims{"sub": "corp-1", "email": "local@example.com", "email_verified": false})
+		in, err = oidcLogin(o, "corp")
+		c.So(err, c.ShouldBeNil)
+		_, err = o.Callback(ctx, in)
+		c.So(status.Code(err), c.ShouldEqual, codes.PermissionDenied)
+
+		corp.SetUser(oidc.Claims{"sub": "corp-1", "email": "local@example.com", "email_verified": true})
+		in, err = oidcLogin(o, "corp")
+		c.So(err, c.ShouldBeNil)
+		rsp, err = o.Callback(ctx, in)
+		c.So(err, c.ShouldBeNil)
+		c.So(rsp.Created, c.ShouldBeFalse)
+		c.So(rsp.Uid, c.ShouldEqual, "local-1")
+
+		// state只能用于发起登录的签发方
+		in, err = oidcLogin(o, "corp")
+		c.So(err, c.ShouldBeNil)
+		in.Provider = "mock"
+		_, err = o.Callback(ctx, in)
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+	})
+}
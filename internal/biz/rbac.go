@@ -0,0 +1,216 @@
+package biz
+
+import (
+	"context"
+	"strings"
+
+	api "github.com/begonia-org/begonia/api/rbac/v1"
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	gosdk "github.com/begonia-org/go-sdk"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gorm.io/gorm"
+)
+
+type RBACRepo interface {
+	AddRole(ctx context.Context, role *api.Role) error
+	GetRole(ctx context.Context, uid string) (*api.Role, error)
+	PatchRole(ctx context.Context, role *api.Role) error
+	// DelRole 删除角色及其所有绑定
+	DelRole(ctx context.Context, role *api.Role) error
+	ListRoles(ctx context.Context, page, pageSize int32) ([]*api.Role, error)
+	AddBinding(ctx context.Context, binding *api.RoleBinding) error
+	GetBinding(ctx context.Context, uid string) (*api.RoleBinding, error)
+	DelBinding(ctx context.Context, binding *api.RoleBinding) error
+	ListBindings(ctx context.Context, subjectType api.SubjectType, subject, role string, page, pageSize int32) ([]*api.RoleBinding, error)
+	// GetPermissions 用户或app通过角色获得的所有权限
+	GetPermissions(ctx context.Context, subjectType api.SubjectType, subject string) ([]string, error)
+}
+
+type RBACUsecase struct {
+	repo      RBACRepo
+	config    *config.Config
+	snowflake *tiga.Snowflake
+}
+
+// 角色可以更新的字段
+var roleUpdatePaths = []string{"name", "description", "permissions"}
+
+func NewRBACUsecase(repo RBACRepo, config *config.Config) *RBACUsecase {
+	sn, _ := tiga.NewSnowflake(1)
+	return &RBACUsecase{repo: repo, config: config, snowflake: sn}
+}
+
+func isNotFound(err error) bool {
+	return strings.Contains(err.Error(), gorm.ErrRecordNotFound.Error())
+}
+
+func (r *RBACUsecase) PutRole(ctx context.Context, in *api.RoleRequest) (*api.Role, error) {
+	if in.Name == "" {
+		return nil, gosdk.NewError(pkg.ErrRoleNameMissing, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "put_role")
+	}
+	role := &api.Role{
+		Uid:         r.snowflake.GenerateIDString(),
+		Name:        in.Name,
+		Description: in.Description,
+		Permissions: in.Permissions,
+	}
+	if role.Permissions == nil {
+		role.Permissions = make([]string, 0)
+	}
+	if err := r.repo.AddRole(ctx, role); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, gosdk.NewError(err, int32(common.Code_CONFLICT), codes.AlreadyExists, "put_role")
+		}
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "put_role")
+	}
+	return role, nil
+}
+
+func (r *RBACUsecase) GetRole(ctx context.Context, uid string) (*api.Role, error) {
+	role, err := r.repo.GetRole(ctx, uid)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, gosdk.NewError(pkg.ErrRoleNotFound, int32(common.Code_NOT_FOUND), codes.NotFound, "get_role")
+		}
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "get_role")
+	}
+	return role, nil
+}
+
+// PatchRole 按update_mask更新角色，未指定时更新名称、描述和权限
+func (r *RBACUsecase) PatchRole(ctx context.Context, in *api.RoleRequest) (*api.Role, error) {
+	role, err := r.GetRole(ctx, in.Uid)
+	if err != nil {
+		return nil, err
+	}
+	paths := roleUpdatePaths
+	if in.UpdateMask != nil && len(in.UpdateMask.Paths) > 0 {
+		paths = make([]string, 0)
+		for _, path := range in.UpdateMask.Paths {
+			for _, allowed := range roleUpdatePaths {
+				if path == allowed {
+					paths = append(paths, path)
+				}
+			}
+		}
+	}
+	for _, path := range paths {
+		switch path {
+		case "name":
+			if in.Name == "" {
+				return nil, gosdk.NewError(pkg.ErrRoleNameMissing, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "patch_role")
+			}
+			role.Name = in.Name
+		case "description":
+			role.Description = in.Description
+		case "permissions":
+			role.Permissions = in.Permissions
+			if role.Permissions == nil {
+				role.Permissions = make([]string, 0)
+			}
+		}
+	}
+	role.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
+	if err := r.repo.PatchRole(ctx, role); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, gosdk.NewError(err, int32(common.Code_CONFLICT), codes.AlreadyExists, "patch_role")
+		}
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "patch_role")
+	}
+	return role, nil
+}
+
+func (r *RBACUsecase) DelRole(ctx context.Context, uid string) error {
+	role, err := r.GetRole(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if err := r.repo.DelRole(ctx, role); err != nil {
+		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "delete_role")
+	}
+	return nil
+}
+
+func (r *RBACUsecase) ListRoles(ctx context.Context, in *api.ListRolesRequest) ([]*api.Role, error) {
+	roles, err := r.repo.ListRoles(ctx, in.Page, in.PageSize)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_roles")
+	}
+	return roles, nil
+}
+
+// Bind 为用户或app绑定角色
+func (r *RBACUsecase) Bind(ctx context.Context, in *api.BindRequest) (*api.RoleBinding, error) {
+	if (in.SubjectType != api.SubjectType_SUBJECT_USER && in.SubjectType != api.SubjectType_SUBJECT_APP) || in.Subject == "" {
+		return nil, gosdk.NewError(pkg.ErrInvalidSubject, int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "bind_role")
+	}
+	if _, err := r.GetRole(ctx, in.Role); err != nil {
+		return nil, err
+	}
+	exists, err := r.repo.ListBindings(ctx, in.SubjectType, in.Subject, in.Role, 1, 1)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "bind_role")
+	}
+	if len(exists) > 0 {
+		return nil, gosdk.NewError(pkg.ErrRoleBindingExists, int32(common.Code_CONFLICT), codes.AlreadyExists, "bind_role")
+	}
+	binding := &api.RoleBinding{
+		Uid:         r.snowflake.GenerateIDString(),
+		SubjectType: in.SubjectType,
+		Subject:     in.Subject,
+		Role:        in.Role,
+	}
+	if err := r.repo.AddBinding(ctx, binding); err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "bind_role")
+	}
+	return binding, nil
+}
+
+func (r *RBACUsecase) Unbind(ctx context.Context, uid string) error {
+	binding, err := r.repo.GetBinding(ctx, uid)
+	if err != nil {
+		if isNotFound(err) {
+			return gosdk.NewError(pkg.ErrRoleBindingNotFound, int32(common.Code_NOT_FOUND), codes.NotFound, "unbind_role")
+		}
+		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "unbind_role")
+	}
+	if err := r.repo.DelBinding(ctx, binding); err != nil {
+		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "unbind_role")
+	}
+	return nil
+}
+
+func (r *RBACUsecase) ListBindings(ctx context.Context, in *api.ListBindingsRequest) ([]*api.RoleBinding, error) {
+	bindings, err := r.repo.ListBindings(ctx, in.SubjectType, in.Subject, in.Role, in.Page, in.PageSize)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_bindings")
+	}
+	return bindings, nil
+}
+
+// Check 用户或app是否拥有权限
+func (r *RBACUsecase) Check(ctx context.Context, subjectType api.SubjectType, subject, permission string) (bool, error) {
+	permissions, err := r.repo.GetPermissions(ctx, subjectType, subject)
+	if err != nil {
+		return false, err
+	}
+	return MatchPermission(permissions, permission), nil
+}
+
+// MatchPermission 已授予的权限是否包含需要的权限，
+// *表示所有权限，以*结尾的权限按前缀匹配，如begonia.org.sdk.AppsService/*
+func MatchPermission(granted []string, permission string) bool {
+	for _, g := range granted {
+		if g == "*" || g == permission {
+			return true
+		}
+		if strings.HasSuffix(g, "*") && strings.HasPrefix(permission, strings.TrimSuffix(g, "*")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package biz_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	api "github.com/begonia-org/begonia/api/rbac/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gorm.io/gorm"
+)
+
+type memoryRBACRepo struct {
+	roles    map[string]*api.Role
+	bindings map[string]*api.RoleBinding
+}
+
+func newMemoryRBACRepo() *memoryRBACRepo {
+	return &memoryRBACRepo{roles: make(map[string]*api.Role), bindings: make(map[string]*api.RoleBinding)}
+}
+
+func (m *memoryRBACRepo) AddRole(ctx context.Context, role *api.Role) error {
+	for _, r := range m.roles {
+		if r.Name == role.Name {
+			return fmt.Errorf("Duplicate entry '%s'", role.Name)
+		}
+	}
+	m.roles[role.Uid] = role
+	return nil
+}
+func (m *memoryRBACRepo) GetRole(ctx context.Context, uid string) (*api.Role, error) {
+	if role, ok := m.roles[uid]; ok {
+		return role, nil
+	}
+	return nil, fmt.Errorf("get role failed: %w", gorm.ErrRecordNotFound)
+}
+func (m *memoryRBACRepo) PatchRole(ctx context.Context, role *api.Role) error {
+	m.roles[role.Uid] = role
+	return nil
+}
+func (m *memoryRBACRepo) DelRole(ctx context.Context, role *api.Role) error {
+	for uid, binding := range m.bindings {
+		if binding.Role == role.Uid {
+			delete(m.bindings, uid)
+		}
+	}
+	delete(m.roles, role.Uid)
+	return nil
+}
+func (m *memoryRBACRepo) ListRoles(ctx context.Context, page, pageSize int32) ([]*api.Role, error) {
+	roles := make([]*api.Role, 0)
+	for _, role := range m.roles {
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+func (m *memoryRBACRepo) AddBinding(ctx context.Context, binding *api.RoleBinding) error {
+	m.bindings[binding.Uid] = binding
+	return nil
+}
+func (m *memoryRBACRepo) GetBinding(ctx context.Context, uid string) (*api.RoleBinding, error) {
+	if binding, ok := m.bindings[uid]; ok {
+		return binding, nil
+	}
+	return nil, fmt.Errorf("get role binding failed: %w", gorm.ErrRecordNotFound)
+}
+func (m *memoryRBACRepo) DelBinding(ctx context.Context, binding *api.RoleBinding) error {
+	delete(m.bindings, binding.Uid)
+	return nil
+}
+func (m *memoryRBACRepo) ListBindings(ctx context.Context, subjectType api.SubjectType, subject, role string, page, pageSize int32) ([]*api.RoleBinding, error) {
+	bindings := make([]*api.RoleBinding, 0)
+	for _, binding := range m.bindings {
+		if (subjectType == api.SubjectType_SUBJECT_UNKNOWN || binding.SubjectType == subjectType) &&
+			(subject == "" || binding.Subject == subject) && (role == "" || binding.Role == role) {
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings, nil
+}
+func (m *memoryRBACRepo) GetPermissions(ctx context.Context, subjectType api.SubjectType, subject string) ([]string, error) {
+	bindings, _ := m.ListBindings(ctx, subjectType, subject, "", 1, -1)
+	permissions := make([]string, 0)
+	for _, binding := range bindings {
+		if role, ok := m.roles[binding.Role]; ok {
+			permissions = append(permissions, role.Permissions...)
+		}
+	}
+	return permissions, nil
+}
+
+func TestMatchPermission(t *testing.T) {
+	c.Convey("test match permission", t, func() {
+		c.So(biz.MatchPermission([]string{"*"}, "rbac:roles:write"), c.ShouldBeTrue)
+		c.So(biz.MatchPermission([]string{"rbac:roles:read"}, "rbac:roles:read"), c.ShouldBeTrue)
+		c.So(biz.MatchPermission([]string{"rbac:roles:read"}, "rbac:roles:write"), c.ShouldBeFalse)
+		c.So(biz.MatchPermission([]string{"rbac:*"}, "rbac:bindings:write"), c.ShouldBeTrue)
+		c.So(biz.MatchPermission([]string{"begonia.org.sdk.AppsService/*"}, "begonia.org.sdk.AppsService/Put"), c.ShouldBeTrue)
+		c.So(biz.MatchPermission([]string{"begonia.org.sdk.AppsService/*"}, "begonia.org.sdk.FileService/Upload"), c.ShouldBeFalse)
+		c.So(biz.MatchPermission(nil, "rbac:roles:read"), c.ShouldBeFalse)
+	})
+}
+
+func TestRBACUsecase(t *testing.T) {
+	c.Convey("test rbac usecase", t, func() {
+		ctx := context.Background()
+		repo := newMemoryRBACRepo()
+		rbac := biz.NewRBACUsecase(repo, nil)
+
+		_, err := rbac.PutRole(ctx, &api.RoleRequest{})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+
+		role, err := rbac.PutRole(ctx, &api.RoleRequest{Name: "reader", Description: "read roles", Permissions: []string{"rbac:roles:read"}})
+		c.So(err, c.ShouldBeNil)
+		c.So(role.Uid, c.ShouldNotBeEmpty)
+		_, err = rbac.PutRole(ctx, &api.RoleRequest{Name: "reader"})
+		c.So(status.Code(err), c.ShouldEqual, codes.AlreadyExists)
+
+		_, err = rbac.GetRole(ctx, "not-exists")
+		c.So(status.Code(err), c.ShouldEqual, codes.NotFound)
+
+		// 只更新mask中的字段
+		patched, err := rbac.PatchRole(ctx, &api.RoleRequest{Uid: role.Uid, Description: "updated", Permissions: []string{"*"}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description", "uid"}}})
+		c.So(err, c.ShouldBeNil)
+		c.So(patched.Description, c.ShouldEqual, "updated")
+		c.So(patched.Permissions, c.ShouldResemble, []string{"rbac:roles:read"})
+		c.So(patched.UpdateMask.Paths, c.ShouldResemble, []string{"description"})
+
+		_, err = rbac.Bind(ctx, &api.BindRequest{SubjectType: api.SubjectType_SUBJECT_UNKNOWN, Subject: "user-1", Role: role.Uid})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+		_, err = rbac.Bind(ctx, &api.BindRequest{SubjectType: api.SubjectType_SUBJECT_USER, Subject: "user-1", Role: "not-exists"})
+		c.So(status.Code(err), c.ShouldEqual, codes.NotFound)
+
+		binding, err := rbac.Bind(ctx, &api.BindRequest{SubjectType: api.SubjectType_SUBJECT_USER, Subject: "user-1", Role: role.Uid})
+		c.So(err, c.ShouldBeNil)
+		_, err = rbac.Bind(ctx, &api.BindRequest{SubjectType: api.SubjectType_SUBJECT_USER, Subject: "user-1", Role: role.Uid})
+		c.So(status.Code(err), c.ShouldEqual, codes.AlreadyExists)
+
+		ok, err := rbac.Check(ctx, api.SubjectType_SUBJECT_USER, "user-1", "rbac:roles:read")
+		c.So(err, c.ShouldBeNil)
+		c.So(ok, c.ShouldBeTrue)
+		ok, _ = rbac.Check(ctx, api.SubjectType_SUBJECT_APP, "user-1", "rbac:roles:read")
+		c.So(ok, c.ShouldBeFalse)
+
+		c.So(rbac.Unbind(ctx, binding.Uid), c.ShouldBeNil)
+		c.So(status.Code(rbac.Unbind(ctx, binding.Uid)), c.ShouldEqual, codes.NotFound)
+		ok, _ = rbac.Check(ctx, api.SubjectType_SUBJECT_USER, "user-1", "rbac:roles:read")
+		c.So(ok, c.ShouldBeFalse)
+
+		_, err = rbac.Bind(ctx, &api.BindRequest{SubjectType: api.SubjectType_SUBJECT_APP, Subject: "app-1", Role: role.Uid})
+		c.So(err, c.ShouldBeNil)
+		c.So(rbac.DelRole(ctx, role.Uid), c.ShouldBeNil)
+		bindings, err := rbac.ListBindings(ctx, &api.ListBindingsRequest{Role: role.Uid})
+		c.So(err, c.ShouldBeNil)
+		c.So(bindings, c.ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,342 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/begonia-org/begonia/api/session/v1"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/begonia/internal/pkg/oidc"
+	gosdk "github.com/begonia-org/go-sdk"
+	user "github.com/begonia-org/go-sdk/api/user/v1"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"github.com/begonia-org/go-sdk/logger"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UserSession 会话的存储结构，只保存token的摘要
+type UserSession struct {
+	ID          string    `json:"id"`
+	Uid         string    `json:"uid"`
+	Device      string    `json:"device"`
+	IP          string    `json:"ip"`
+	IsKeepLogin bool      `json:"is_keep_login"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// 当前有效的refresh token
+	RefreshToken string `json:"refresh_token"`
+	// 已经轮换掉的refresh token，再次使用视为泄露
+	UsedRefreshTokens []string `json:"used_refresh_tokens"`
+	// 当前access token的md5，注销会话时加入黑名单
+	AccessToken string `json:"access_token"`
+}
+
+type SessionRepo interface {
+	Put(ctx context.Context, session *UserSession) error
+	Get(ctx context.Context, id string) (*UserSession, error)
+	Del(ctx context.Context, session *UserSession) error
+	List(ctx context.Context, uid string) ([]*UserSession, error)
+	Locker(ctx context.Context, key string, exp time.Duration) (DataLock, error)
+}
+
+const (
+	defaultAccessTokenExpiration  = time.Minute * 15
+	defaultRefreshTokenExpiration = time.Hour * 24
+	defaultKeepLoginExpiration    = time.Hour * 24 * 30
+	// 保留的已轮换refresh token数量
+	maxUsedRefreshTokens = 16
+)
+
+// sessionClaims 会话签发的access token，sid用于关联会话，不会被静默刷新
+type sessionClaims struct {
+	*user.BasicAuth
+	SessionID string `json:"sid"`
+}
+
+type SessionUsecase struct {
+	repo      SessionRepo
+	user      UserRepo
+	authz     *AuthzUsecase
+	config    *config.Config
+	log       logger.Logger
+	snowflake *tiga.Snowflake
+
+	accessExpiration    time.Duration
+	refreshExpiration   time.Duration
+	keepLoginExpiration time.Duration
+}
+
+func NewSessionUsecase(repo SessionRepo, user UserRepo, authz *AuthzUsecase, config *config.Config, log logger.Logger) *SessionUsecase {
+	session, err := config.GetSession()
+	if err != nil {
+		panic(fmt.Sprintf("get session config error:%v", err))
+	}
+	sn, _ := tiga.NewSnowflake(1)
+	u := &SessionUsecase{
+		repo:                repo,
+		user:                user,
+		authz:               authz,
+		config:              config,
+		log:                 log,
+		snowflake:           sn,
+		accessExpiration:    defaultAccessTokenExpiration,
+		refreshExpiration:   defaultRefreshTokenExpiration,
+		keepLoginExpiration: defaultKeepLoginExpiration,
+	}
+	if session.AccessTokenExpiration > 0 {
+		u.accessExpiration = time.Duration(session.AccessTokenExpiration) * time.Second
+	}
+	if session.RefreshTokenExpiration > 0 {
+		u.refreshExpiration = time.Duration(session.RefreshTokenExpiration) * time.Second
+	}
+	if session.KeepLoginExpiration > 0 {
+		u.keepLoginExpiration = time.Duration(session.KeepLoginExpiration) * time.Second
+	}
+	return u
+}
+
+// TokenSessionID 获取已校验的access token中的会话id，非会话签发的token返回空
+func TokenSessionID(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	// jwt_secret签发的token使用带填充的base64编码
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ""
+	}
+	claims := struct {
+		SessionID string `json:"sid"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.SessionID
+}
+
+func hashRefreshToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// clientInfo 从请求元数据中获取登录设备和ip
+func clientInfo(ctx context.Context) (string, string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ""
+	}
+	get := func(keys ...string) string {
+		for _, key := range keys {
+			if values := md.Get(key); len(values) > 0 && values[0] != "" {
+				return values[0]
+			}
+		}
+		return ""
+	}
+	ip := get(gateway.XRemoteAddr, "x-forwarded-for")
+	if index := strings.Index(ip, ","); index > 0 {
+		ip = strings.TrimSpace(ip[:index])
+	}
+	return get("grpcgateway-user-agent", "user-agent"), ip
+}
+
+func (s *SessionUsecase) expiration(isKeepLogin bool) time.Duration {
+	if isKeepLogin {
+		return s.keepLoginExpiration
+	}
+	return s.refreshExpiration
+}
+
+// issue 签发新的access token和refresh token并更新会话
+func (s *SessionUsecase) issue(ctx context.Context, session *UserSession, u *user.Users) (*api.TokenResponse, error) {
+	claims := &sessionClaims{BasicAuth: s.authz.NewBasicAuth(u, s.accessExpiration, session.IsKeepLogin), SessionID: session.ID}
+	accessToken, err := s.authz.SignJWT(ctx, claims)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(user.UserSvrCode_USER_UNKNOWN), codes.Internal, "jwt_generate")
+	}
+	secret, err := oidc.RandomString()
+	if err != nil {
+		return nil, gosdk.NewError(fmt.Errorf("generate refresh token failed:%w", err), int32(common.Code_INTERNAL_ERROR), codes.Internal, "refresh_token_generate")
+	}
+	now := time.Now()
+	if session.RefreshToken != "" {
+		session.UsedRefreshTokens = append(session.UsedRefreshTokens, session.RefreshToken)
+		if len(session.UsedRefreshTokens) > maxUsedRefreshTokens {
+			session.UsedRefreshTokens = session.UsedRefreshTokens[len(session.UsedRefreshTokens)-maxUsedRefreshTokens:]
+		}
+	}
+	session.RefreshToken = hashRefreshToken(secret)
+	session.AccessToken = tiga.GetMd5(accessToken)
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(s.expiration(session.IsKeepLogin))
+	if err := s.repo.Put(ctx, session); err != nil {
+		return nil, gosdk.NewError(fmt.Errorf("save session failed:%w", err), int32(common.Code_INTERNAL_ERROR), codes.Internal, "save_session")
+	}
+	return &api.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: fmt.Sprintf("%s.%s", session.ID, secret),
+		ExpiresIn:    int64(s.accessExpiration.Seconds()),
+		TokenType:    "Bearer",
+		SessionId:    session.ID,
+	}, nil
+}
+
+// Create 登录成功后创建会话
+func (s *SessionUsecase) Create(ctx context.Context, u *user.Users, isKeepLogin bool) (*api.TokenResponse, error) {
+	device, ip := clientInfo(ctx)
+	session := &UserSession{
+		ID:          s.snowflake.GenerateIDString(),
+		Uid:         u.Uid,
+		Device:      device,
+		IP:          ip,
+		IsKeepLogin: isKeepLogin,
+		CreatedAt:   time.Now(),
+	}
+	return s.issue(ctx, session, u)
+}
+
+// Refresh 轮换refresh token，已轮换的refresh token再次使用时注销整个会话
+func (s *SessionUsecase) Refresh(ctx context.Context, refreshToken string) (*api.TokenResponse, error) {
+	id, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || id == "" || secret == "" {
+		return nil, gosdk.NewError(pkg.ErrRefreshTokenInvalid, int32(common.Code_AUTH_ERROR), codes.Unauthenticated, "refresh_token_format")
+	}
+	lock, err := s.repo.Locker(ctx, s.config.GetSessionLockKey(id), time.Second*10)
+	if err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "session_lock")
+	}
+	if err := lock.Lock(ctx); err != nil {
+		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "session_lock")
+	}
+	defer func() {
+		if err := lock.UnLock(ctx); err != nil {
+			s.log.Errorf(ctx, "unlock session error:%s", err.Error())
+		}
+	}()
+	session, err := s.repo.Get(ctx, id)
+	if err != nil || session == nil {
+		return nil, gosdk.NewError(pkg.ErrRefreshTokenInvalid, int32(common.Code_AUTH_ERROR), codes.Unauthenticated, "session_query")
+	}
+	hash := hashRefreshToken(secret)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(session.RefreshToken)) != 1 {
+		for _, used := range session.UsedRefreshTokens {
+			if subtle.ConstantTimeCompare([]byte(hash), []byte(used)) == 1 {
+				s.log.Warnf(ctx, "refresh token of session %s reused, revoke session", session.ID)
+				if err := s.revoke(ctx, session); err != nil {
+					s.log.Errorf(ctx, "revoke session error:%s", err.Error())
+				}
+				return nil, gosdk.NewError(pkg.ErrRefreshTokenReused, int32(common.Code_AUTH_ERROR), codes.Unauthenticated, "refresh_token_reused")
+			}
+		}
+		return nil, gosdk.NewError(pkg.ErrRefreshTokenInvalid, int32(common.Code_AUTH_ERROR), codes.Unauthenticated, "refresh_token_match")
+	}
+	u, err := s.user.Get(ctx, session.Uid)
+	if err != nil || u == nil {
+		return nil, gosdk.NewError(pkg.ErrUserNotFound, int32(user.UserSvrCode_USER_NOT_FOUND_ERR), codes.Unauthenticated, "user_query")
+	}
+	if u.Status != user.USER_STATUS_ACTIVE {
+		if err := s.revoke(ctx, session); err != nil {
+			s.log.Errorf(ctx, "revoke session error:%s", err.Error())
+		}
+		return nil, gosdk.NewError(pkg.ErrUserDisabled, int32(user.UserSvrCode_USER_DISABLED_ERR), codes.Unauthenticated, "user_status")
+	}
+	// 旧的access token不再使用
+	if err := s.authz.PutBlackList(ctx, session.AccessToken); err != nil {
+		s.log.Errorf(ctx, "put access token to blacklist error:%s", err.Error())
+	}
+	if device, ip := clientInfo(ctx); device != "" || ip != "" {
+		session.Device, session.IP = device, ip
+	}
+	return s.issue(ctx, session, u)
+}
+
+func (s *SessionUsecase) revoke(ctx context.Context, session *UserSession) error {
+	if err := s.repo.Del(ctx, session); err != nil {
+		return err
+	}
+	// 黑名单通过cuckoo filter的pubsub同步到所有网关实例
+	return s.authz.PutBlackList(ctx, session.AccessToken)
+}
+
+// List 用户未过期的会话，按最近使用时间倒序
+func (s *SessionUsecase) List(ctx context.Context, uid, current string) ([]*api.Session, error) {
+	sessions, err := s.repo.List(ctx, uid)
+	if err != nil {
+		return nil, gosdk.NewError(fmt.Errorf("list sessions failed:%w", err), int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_sessions")
+	}
+	rsp := make([]*api.Session, 0, len(sessions))
+	for _, session := range sessions {
+		rsp = append(rsp, &api.Session{
+			Id:          session.ID,
+			Uid:         session.Uid,
+			Device:      session.Device,
+			Ip:          session.IP,
+			IsKeepLogin: session.IsKeepLogin,
+			CreatedAt:   timestamppb.New(session.CreatedAt),
+			LastSeenAt:  timestamppb.New(session.LastSeenAt),
+			ExpiresAt:   timestamppb.New(session.ExpiresAt),
+			Current:     session.ID == current,
+		})
+	}
+	return rsp, nil
+}
+
+// Revoke 注销用户的一个会话
+func (s *SessionUsecase) Revoke(ctx context.Context, uid, id string) error {
+	session, err := s.repo.Get(ctx, id)
+	if err != nil || session == nil || session.Uid != uid {
+		return gosdk.NewError(pkg.ErrSessionNotFound, int32(common.Code_NOT_FOUND), codes.NotFound, "session_query")
+	}
+	if err := s.revoke(ctx, session); err != nil {
+		return gosdk.NewError(fmt.Errorf("revoke session failed:%w", err), int32(common.Code_INTERNAL_ERROR), codes.Internal, "revoke_session")
+	}
+	return nil
+}
+
+// RevokeAll 注销用户的所有会话，except不为空时保留该会话
+func (s *SessionUsecase) RevokeAll(ctx context.Context, uid, except string) (int, error) {
+	sessions, err := s.repo.List(ctx, uid)
+	if err != nil {
+		return 0, gosdk.NewError(fmt.Errorf("list sessions failed:%w", err), int32(common.Code_INTERNAL_ERROR), codes.Internal, "list_sessions")
+	}
+	count := 0
+	var errs []error
+	for _, session := range sessions {
+		if session.ID == except {
+			continue
+		}
+		if err := s.revoke(ctx, session); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		count++
+	}
+	if len(errs) > 0 {
+		return count, gosdk.NewError(fmt.Errorf("revoke sessions failed:%w", errors.Join(errs...)), int32(common.Code_INTERNAL_ERROR), codes.Internal, "revoke_session")
+	}
+	return count, nil
+}
+
+// ForceLogout 管理员强制用户下线，注销用户的所有会话
+func (s *SessionUsecase) ForceLogout(ctx context.Context, uid string) (int, error) {
+	count, err := s.RevokeAll(ctx, uid, "")
+	if err == nil {
+		s.log.Infof(ctx, "force logout user %s, revoked %d sessions", uid, count)
+	}
+	return count, err
+}
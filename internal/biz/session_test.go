@@ -0,0 +1,179 @@
+package biz_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/begonia-org/begonia"
+	"github.com/begonia-org/begonia/config"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/biz"
+	cfg "github.com/begonia-org/begonia/internal/pkg/config"
+	v1 "github.com/begonia-org/go-sdk/api/user/v1"
+	"github.com/redis/go-redis/v9"
+	c "github.com/smartystreets/goconvey/convey"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type memorySessionRepo struct {
+	sessions map[string]*biz.UserSession
+}
+
+func (m *memorySessionRepo) Put(ctx context.Context, session *biz.UserSession) error {
+	// 与redis一样保存副本
+	copied := *session
+	copied.UsedRefreshTokens = append([]string{}, session.UsedRefreshTokens...)
+	m.sessions[session.ID] = &copied
+	return nil
+}
+func (m *memorySessionRepo) Get(ctx context.Context, id string) (*biz.UserSession, error) {
+	session, ok := m.sessions[id]
+	if !ok || session.ExpiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("get session failed: %w", redis.Nil)
+	}
+	copied := *session
+	return &copied, nil
+}
+func (m *memorySessionRepo) Del(ctx context.Context, session *biz.UserSession) error {
+	delete(m.sessions, session.ID)
+	return nil
+}
+func (m *memorySessionRepo) List(ctx context.Context, uid string) ([]*biz.UserSession, error) {
+	sessions := make([]*biz.UserSession, 0)
+	for _, session := range m.sessions {
+		if session.Uid == uid {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+func (m *memorySessionRepo) Locker(ctx context.Context, key string, exp time.Duration) (biz.DataLock, error) {
+	return memoryLock{}, nil
+}
+
+type memoryBlackList struct {
+	tokens map[string]bool
+}
+
+func (m *memoryBlackList) CacheToken(ctx context.Context, key, token string, exp time.Duration) error {
+	return nil
+}
+func (m *memoryBlackList) GetToken(ctx context.Context, key string) string {
+	return ""
+}
+func (m *memoryBlackList) DelToken(ctx context.Context, key string) error {
+	return nil
+}
+func (m *memoryBlackList) CheckInBlackList(ctx context.Context, key string) (bool, error) {
+	return m.tokens[key], nil
+}
+func (m *memoryBlackList) PutBlackList(ctx context.Context, token string) error {
+	m.tokens[token] = true
+	return nil
+}
+
+func TestSessionUsecase(t *testing.T) {
+	c.Convey("test refresh token rotation and session management", t, func() {
+		env := "dev"
+		if begonia.Env != "" {
+			env = begonia.Env
+		}
+		conf := config.ReadConfig(env)
+		conf.Set("auth.session", map[string]interface{}{
+			"access_token_expiration":  300,
+			"refresh_token_expiration": 3600,
+			"keep_login_expiration":    7200,
+		})
+		cnf := cfg.NewConfig(conf)
+		users := &memoryUserRepo{users: map[string]*v1.Users{
+			"session-1": {Uid: "session-1", Name: "session", Status: v1.USER_STATUS_ACTIVE},
+		}, config: cnf}
+		blacklist := &memoryBlackList{tokens: make(map[string]bool)}
+		repo := &memorySessionRepo{sessions: make(map[string]*biz.UserSession)}
+		authz := biz.NewAuthzUsecase(blacklist, users, gateway.Log, nil, cnf, nil)
+		sessions := biz.NewSessionUsecase(repo, users, authz, cnf, gateway.Log)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-agent", "test-agent", gateway.XRemoteAddr, "10.0.0.1"))
+
+		// 登录创建会话，access token关联会话id
+		first, err := sessions.Create(ctx, users.users["session-1"], false)
+		c.So(err, c.ShouldBeNil)
+		c.So(first.ExpiresIn, c.ShouldEqual, 300)
+		c.So(biz.TokenSessionID(first.AccessToken), c.ShouldEqual, first.SessionId)
+		c.So(strings.HasPrefix(first.RefreshToken, first.SessionId+"."), c.ShouldBeTrue)
+		c.So(biz.TokenSessionID("a.b.c"), c.ShouldBeEmpty)
+		session := repo.sessions[first.SessionId]
+		c.So(session.Device, c.ShouldEqual, "test-agent")
+		c.So(session.IP, c.ShouldEqual, "10.0.0.1")
+		c.So(session.ExpiresAt.Sub(time.Now()), c.ShouldBeGreaterThan, 59*time.Minute)
+		// 只保存refresh token的摘要
+		c.So(session.RefreshToken, c.ShouldNotContainSubstring, strings.SplitN(first.RefreshToken, ".", 2)[1])
+
+		// 刷新后轮换refresh token，旧的access token加入黑名单
+		second, err := sessions.Refresh(ctx, first.RefreshToken)
+		c.So(err, c.ShouldBeNil)
+		c.So(second.SessionId, c.ShouldEqual, first.SessionId)
+		c.So(second.RefreshToken, c.ShouldNotEqual, first.RefreshToken)
+		c.So(blacklist.tokens[tiga.GetMd5(first.AccessToken)], c.ShouldBeTrue)
+
+		_, err = sessions.Refresh(ctx, "invalid")
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+		_, err = sessions.Refresh(ctx, first.SessionId+".invalid")
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+		c.So(repo.sessions, c.ShouldContainKey, first.SessionId)
+
+		// 已轮换的refresh token再次使用，注销整个会话
+		_, err = sessions.Refresh(ctx, first.RefreshToken)
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+		c.So(err.Error(), c.ShouldContainSubstring, "重复使用")
+		c.So(repo.sessions, c.ShouldNotContainKey, first.SessionId)
+		c.So(blacklist.tokens[tiga.GetMd5(second.AccessToken)], c.ShouldBeTrue)
+		_, err = sessions.Refresh(ctx, second.RefreshToken)
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+
+		// 列出和注销会话
+		web, err := sessions.Create(ctx, users.users["session-1"], true)
+		c.So(err, c.ShouldBeNil)
+		c.So(repo.sessions[web.SessionId].ExpiresAt.Sub(time.Now()), c.ShouldBeGreaterThan, time.Hour)
+		app, err := sessions.Create(ctx, users.users["session-1"], false)
+		c.So(err, c.ShouldBeNil)
+		list, err := sessions.List(ctx, "session-1", web.SessionId)
+		c.So(err, c.ShouldBeNil)
+		c.So(list, c.ShouldHaveLength, 2)
+		for _, s := range list {
+			c.So(s.Current, c.ShouldEqual, s.Id == web.SessionId)
+		}
+		err = sessions.Revoke(ctx, "other", app.SessionId)
+		c.So(status.Code(err), c.ShouldEqual, codes.NotFound)
+		c.So(sessions.Revoke(ctx, "session-1", app.SessionId), c.ShouldBeNil)
+		c.So(blacklist.tokens[tiga.GetMd5(app.AccessToken)], c.ShouldBeTrue)
+
+		app, err = sessions.Create(ctx, users.users["session-1"], false)
+		c.So(err, c.ShouldBeNil)
+		count, err := sessions.RevokeAll(ctx, "session-1", web.SessionId)
+		c.So(err, c.ShouldBeNil)
+		c.So(count, c.ShouldEqual, 1)
+		c.So(repo.sessions, c.ShouldContainKey, web.SessionId)
+
+		// 管理员强制下线
+		count, err = sessions.ForceLogout(ctx, "session-1")
+		c.So(err, c.ShouldBeNil)
+		c.So(count, c.ShouldEqual, 1)
+		c.So(blacklist.tokens[tiga.GetMd5(web.AccessToken)], c.ShouldBeTrue)
+		_, err = sessions.Refresh(ctx, web.RefreshToken)
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+
+		// 用户禁用后不能再刷新
+		disabled, err := sessions.Create(ctx, users.users["session-1"], false)
+		c.So(err, c.ShouldBeNil)
+		users.users["session-1"].Status = v1.USER_STATUS_LOCKED
+		_, err = sessions.Refresh(ctx, disabled.RefreshToken)
+		c.So(status.Code(err), c.ShouldEqual, codes.Unauthenticated)
+		c.So(repo.sessions, c.ShouldNotContainKey, disabled.SessionId)
+	})
+}
@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"context"
+	"errors"
 
 	"github.com/begonia-org/begonia/internal/biz"
 	"github.com/begonia-org/begonia/internal/pkg/config"
@@ -12,17 +13,23 @@ var ProviderSet = wire.NewSet(NewDaemonImpl)
 
 type Daemon interface {
 	Start(ctx context.Context)
+	// Stop 停止后台任务并等待其退出
+	Stop(ctx context.Context) error
+	// Loaded 端点初始加载完成后关闭
+	Loaded() <-chan struct{}
 }
 
 type DaemonImpl struct {
 	config   *config.Config
 	operator *biz.DataOperatorUsecase
+	jwks     *biz.JWKSUsecase
 }
 
-func NewDaemonImpl(config *config.Config, operator *biz.DataOperatorUsecase) Daemon {
+func NewDaemonImpl(config *config.Config, operator *biz.DataOperatorUsecase, jwks *biz.JWKSUsecase) Daemon {
 	return &DaemonImpl{
 		config:   config,
 		operator: operator,
+		jwks:     jwks,
 	}
 }
 
@@ -32,4 +39,15 @@ func NewDaemonImpl(config *config.Config, operator *biz.DataOperatorUsecase) Dae
 // It is a blocking function
 func (d *DaemonImpl) Start(ctx context.Context) {
 	go d.operator.Do(ctx)
+	d.jwks.Start(ctx)
+}
+
+// Stop stops the operator watchers and the signing key rotation and waits for them to exit
+func (d *DaemonImpl) Stop(ctx context.Context) error {
+	return errors.Join(d.operator.Drain(ctx), d.jwks.Stop(ctx))
+}
+
+// Loaded is closed after the endpoints are loaded from etcd for the first time
+func (d *DaemonImpl) Loaded() <-chan struct{} {
+	return d.operator.Loaded()
 }
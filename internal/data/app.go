@@ -5,20 +5,25 @@ import (
 	"fmt"
 	"time"
 
+	ak "github.com/begonia-org/begonia/api/accesskey/v1"
 	"github.com/begonia-org/begonia/internal/biz"
 	"github.com/begonia-org/begonia/internal/pkg/config"
 	api "github.com/begonia-org/go-sdk/api/app/v1"
 	"github.com/spark-lence/tiga"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type appRepoImpl struct {
 	local *LayeredCache
 	cfg   *config.Config
 	curd  biz.CURD
+	rdb   *tiga.RedisDao
 }
 
-func NewAppRepoImpl(curd biz.CURD, local *LayeredCache, cfg *config.Config) biz.AppRepo {
-	return &appRepoImpl{curd: curd, local: local, cfg: cfg}
+func NewAppRepoImpl(curd biz.CURD, local *LayeredCache, rdb *tiga.RedisDao, cfg *config.Config) biz.AppRepo {
+	return &appRepoImpl{curd: curd, local: local, rdb: rdb, cfg: cfg}
 }
 
 func (r *appRepoImpl) Add(ctx context.Context, apps *api.Apps) error {
@@ -57,6 +62,16 @@ func (r *appRepoImpl) Del(ctx context.Context, key string) error {
 		return err
 	}
 	_ = r.local.Del(ctx, r.cfg.GetAPPAccessKey(app.AccessKey))
+	// app删除后其所有密钥失效
+	keys, err := r.ListKeys(ctx, app.Appid)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := r.DelKey(ctx, key); err != nil {
+			return err
+		}
+	}
 	return r.curd.Del(ctx, app, false)
 }
 func (r *appRepoImpl) Patch(ctx context.Context, model *api.Apps) error {
@@ -128,3 +143,80 @@ func (a *appRepoImpl) GetAppid(ctx context.Context, accessKey string) (string, e
 	}
 	return appid, nil
 }
+
+// AddKey 密钥加密后写入，加密会修改传入的对象，所以使用副本
+func (r *appRepoImpl) AddKey(ctx context.Context, key *ak.AccessKey) error {
+	if err := r.curd.Add(ctx, proto.Clone(key).(*ak.AccessKey), true); err != nil {
+		return fmt.Errorf("add access key failed: %w", err)
+	}
+	return nil
+}
+
+// GetKey 优先从缓存获取密钥，密钥更新或删除时清除缓存
+func (r *appRepoImpl) GetKey(ctx context.Context, accessKey string) (*ak.AccessKey, error) {
+	cacheKey := r.cfg.GetAPPKeyCacheKey(accessKey)
+	if val, err := r.local.Get(ctx, cacheKey); err == nil && len(val) > 0 {
+		key := &ak.AccessKey{}
+		if err := proto.Unmarshal(val, key); err == nil {
+			return key, nil
+		}
+	}
+	key := &ak.AccessKey{}
+	if err := r.curd.Get(ctx, key, true, "access_key = ?", accessKey); err != nil {
+		return nil, fmt.Errorf("get access key failed: %w", err)
+	}
+	if val, err := proto.Marshal(key); err == nil {
+		exp := r.cfg.GetAPPAccessKeyExpiration()
+		_ = r.local.Set(ctx, cacheKey, val, time.Duration(exp)*time.Second)
+	}
+	return key, nil
+}
+
+func (r *appRepoImpl) ListKeys(ctx context.Context, appid string) ([]*ak.AccessKey, error) {
+	keys := make([]*ak.AccessKey, 0)
+	pagination := &tiga.Pagination{Page: 1, PageSize: -1, Query: "appid = ?", Args: []interface{}{appid}}
+	if err := r.curd.List(ctx, &keys, pagination); err != nil {
+		return nil, fmt.Errorf("list access keys failed: %w", err)
+	}
+	ivKey := r.cfg.GetAesIv()
+	aseKey := r.cfg.GetAesKey()
+	for _, key := range keys {
+		if err := tiga.DecryptStructAES([]byte(aseKey), key, ivKey); err != nil {
+			return nil, fmt.Errorf("decrypt access key failed: %w", err)
+		}
+	}
+	return keys, nil
+}
+
+func (r *appRepoImpl) PatchKey(ctx context.Context, key *ak.AccessKey) error {
+	if err := r.curd.Update(ctx, proto.Clone(key).(*ak.AccessKey), true); err != nil {
+		return fmt.Errorf("update access key failed: %w", err)
+	}
+	return r.local.Del(ctx, r.cfg.GetAPPKeyCacheKey(key.AccessKey))
+}
+
+func (r *appRepoImpl) DelKey(ctx context.Context, key *ak.AccessKey) error {
+	accessKey := key.AccessKey
+	if err := r.curd.Del(ctx, key, false); err != nil {
+		return fmt.Errorf("delete access key failed: %w", err)
+	}
+	return r.local.Del(ctx, r.cfg.GetAPPKeyCacheKey(accessKey))
+}
+
+// TouchKey 只更新最近使用时间，不清除缓存
+func (r *appRepoImpl) TouchKey(ctx context.Context, uid string, t time.Time) error {
+	key := &ak.AccessKey{
+		Uid:        uid,
+		LastUsedAt: timestamppb.New(t),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"last_used_at"}},
+	}
+	if err := r.curd.Update(ctx, key, false); err != nil {
+		return fmt.Errorf("update access key last used time failed: %w", err)
+	}
+	return nil
+}
+
+// UseNonce 记录x-nonce，已经存在时返回false
+func (r *appRepoImpl) UseNonce(ctx context.Context, key string, exp time.Duration) (bool, error) {
+	return r.rdb.GetClient().SetNX(ctx, key, 1, exp).Result()
+}
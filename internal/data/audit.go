@@ -0,0 +1,161 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	api "github.com/begonia-org/begonia/api/audit/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/segmentio/kafka-go"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const auditDefaultPageSize = 20
+
+type auditRepoImpl struct {
+	curd biz.CURD
+}
+
+func NewAuditRepoImpl(curd biz.CURD) biz.AuditRepo {
+	return &auditRepoImpl{curd: curd}
+}
+
+func (r *auditRepoImpl) Write(ctx context.Context, log *api.AuditLog) error {
+	if err := r.curd.Add(ctx, log, false); err != nil {
+		return fmt.Errorf("add audit log failed: %w", err)
+	}
+	return nil
+}
+
+func (r *auditRepoImpl) List(ctx context.Context, in *api.ListAuditLogsRequest) ([]*api.AuditLog, error) {
+	conds := make([]string, 0)
+	args := make([]interface{}, 0)
+	if in.Actor != "" {
+		conds = append(conds, "actor = ?")
+		args = append(args, in.Actor)
+	}
+	if in.Resource != "" {
+		conds = append(conds, "resource = ?")
+		args = append(args, in.Resource)
+	}
+	if in.ResourceId != "" {
+		conds = append(conds, "resource_id = ?")
+		args = append(args, in.ResourceId)
+	}
+	if in.Action != "" {
+		conds = append(conds, "action = ?")
+		args = append(args, in.Action)
+	}
+	if in.StartTime.GetSeconds() > 0 {
+		conds = append(conds, "created_at >= ?")
+		args = append(args, in.StartTime.AsTime())
+	}
+	if in.EndTime.GetSeconds() > 0 {
+		conds = append(conds, "created_at <= ?")
+		args = append(args, in.EndTime.AsTime())
+	}
+	page, pageSize := in.Page, in.PageSize
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = auditDefaultPageSize
+	}
+	logs := make([]*api.AuditLog, 0)
+	pagination := &tiga.Pagination{Page: page, PageSize: pageSize, Query: strings.Join(conds, " and "), Args: args}
+	if err := r.curd.List(ctx, &logs, pagination); err != nil {
+		return nil, fmt.Errorf("list audit logs failed: %w", err)
+	}
+	return logs, nil
+}
+
+// fileAuditWriter 以json lines格式追加写入文件，可以由日志采集工具收集
+type fileAuditWriter struct {
+	path string
+	mux  sync.Mutex
+	file *os.File
+}
+
+func (w *fileAuditWriter) Write(ctx context.Context, log *api.AuditLog) error {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(log)
+	if err != nil {
+		return fmt.Errorf("marshal audit log failed: %w", err)
+	}
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.file == nil {
+		if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+			return fmt.Errorf("create audit log dir failed: %w", err)
+		}
+		file, err := os.OpenFile(w.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+		if err != nil {
+			return fmt.Errorf("open audit log file failed: %w", err)
+		}
+		w.file = file
+	}
+	_, err = w.file.Write(append(data, '\n'))
+	return err
+}
+
+// kafkaAuditWriter 以资源id作为消息的key，同一资源的记录写入同一分区
+type kafkaAuditWriter struct {
+	writer *kafka.Writer
+}
+
+func (w *kafkaAuditWriter) Write(ctx context.Context, log *api.AuditLog) error {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(log)
+	if err != nil {
+		return fmt.Errorf("marshal audit log failed: %w", err)
+	}
+	return w.writer.WriteMessages(ctx, kafka.Message{Key: []byte(log.ResourceId), Value: data})
+}
+
+type multiAuditWriter []biz.AuditWriter
+
+func (w multiAuditWriter) Write(ctx context.Context, log *api.AuditLog) error {
+	errs := make([]string, 0)
+	for _, writer := range w {
+		if err := writer.Write(ctx, log); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("write audit log failed: %s", strings.Join(errs, ";"))
+	}
+	return nil
+}
+
+// NewAuditWriter 按audit.sinks配置的写入方式创建审计记录的writer
+func NewAuditWriter(repo biz.AuditRepo, cfg *config.Config) biz.AuditWriter {
+	audit, err := cfg.GetAudit()
+	if err != nil {
+		panic(fmt.Sprintf("get audit config error:%v", err))
+	}
+	if len(audit.Sinks) == 0 {
+		return repo
+	}
+	writers := make(multiAuditWriter, 0)
+	for _, sink := range audit.Sinks {
+		switch sink {
+		case "mysql":
+			writers = append(writers, repo)
+		case "file":
+			writers = append(writers, &fileAuditWriter{path: audit.File.Path})
+		case "kafka":
+			writers = append(writers, &kafkaAuditWriter{writer: &kafka.Writer{
+				Addr:     kafka.TCP(audit.Kafka.Brokers...),
+				Topic:    audit.Kafka.Topic,
+				Balancer: &kafka.Hash{},
+			}})
+		default:
+			panic(fmt.Sprintf("audit sink %s not found", sink))
+		}
+	}
+	return writers
+}
@@ -0,0 +1,40 @@
+package data
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/begonia-org/begonia"
+	api "github.com/begonia-org/begonia/api/audit/v1"
+	cfg "github.com/begonia-org/begonia/config"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	c "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAuditWriter(t *testing.T) {
+	c.Convey("test audit writer sinks", t, func() {
+		env := "dev"
+		if begonia.Env != "" {
+			env = begonia.Env
+		}
+		conf := cfg.ReadConfig(env)
+		path := filepath.Join(t.TempDir(), "audit", "audit.log")
+		conf.Set("audit.sinks", []string{"file"})
+		conf.Set("audit.file.path", path)
+
+		writer := NewAuditWriter(nil, config.NewConfig(conf))
+		c.So(writer.Write(context.Background(), &api.AuditLog{Uid: "1", Actor: "user-1", Action: "Post"}), c.ShouldBeNil)
+		c.So(writer.Write(context.Background(), &api.AuditLog{Uid: "2", Actor: "user-1", Action: "Delete"}), c.ShouldBeNil)
+		data, err := os.ReadFile(path)
+		c.So(err, c.ShouldBeNil)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		c.So(lines, c.ShouldHaveLength, 2)
+		c.So(lines[1], c.ShouldContainSubstring, `"action":"Delete"`)
+
+		conf.Set("audit.sinks", []string{"unknown"})
+		c.So(func() { NewAuditWriter(nil, config.NewConfig(conf)) }, c.ShouldPanicWith, "audit sink unknown not found")
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/begonia-org/begonia/gateway"
 	"github.com/begonia-org/begonia/internal/pkg/config"
 	glc "github.com/begonia-org/go-layered-cache"
 	"github.com/begonia-org/go-layered-cache/gocuckoo"
@@ -72,19 +73,24 @@ func (l *LayeredCache) Set(ctx context.Context, key string, value []byte, exp ti
 	return l.kv.Set(ctx, key, value, exp)
 }
 func (l *LayeredCache) Get(ctx context.Context, key string) ([]byte, error) {
-	return l.kv.Get(ctx, key)
+	val, err := l.kv.Get(ctx, key)
+	gateway.ObserveCache("layered", err == nil && val != nil)
+	return val, err
 }
 func (l *LayeredCache) GetFromLocal(ctx context.Context, key string) ([]byte, error) {
 	values, err := l.kv.GetFromLocal(ctx, key)
 	if err != nil {
+		gateway.ObserveCache("local", false)
 		return nil, err
 	}
 
 	for _, val := range values {
 		if val, ok := val.([]byte); ok {
+			gateway.ObserveCache("local", true)
 			return val, nil
 		}
 	}
+	gateway.ObserveCache("local", false)
 	return nil, fmt.Errorf("local cache value is not found")
 }
 func (l *LayeredCache) Del(ctx context.Context, key string) error {
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/begonia-org/begonia/gateway"
 	"github.com/google/wire"
 	"github.com/redis/go-redis/v9"
 	"github.com/spark-lence/tiga"
@@ -56,12 +57,20 @@ var ProviderSet = wire.NewSet(NewMySQL,
 	NewData,
 	NewCurdImpl,
 	NewLayeredCache,
+	wire.Bind(new(gateway.ResponseCacheStore), new(*LayeredCache)),
 
 	NewDataLock,
 	NewAuthzRepoImpl,
 	NewUserRepoImpl,
 	NewEndpointRepoImpl,
 	NewAppRepoImpl,
+	NewRBACRepoImpl,
+	NewOIDCRepoImpl,
+	NewJWKSRepoImpl,
+	NewSessionRepoImpl,
+	NewMFARepoImpl,
+	NewAuditRepoImpl,
+	NewAuditWriter,
 	NewDataOperatorRepo)
 
 type Data struct {
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/begonia-org/begonia/gateway"
 	"github.com/begonia-org/begonia/internal/biz/endpoint"
 	"github.com/begonia-org/begonia/internal/pkg"
 	"github.com/begonia-org/begonia/internal/pkg/config"
@@ -238,3 +239,37 @@ func (e *endpointRepoImpl) GetKeysByTags(ctx context.Context, tags []string) ([]
 	}
 	return ids, nil
 }
+
+// WatchMembers 监听后端在前缀下使用租约注册的成员，前缀下有变化时重新读取所有成员
+func (e *endpointRepoImpl) WatchMembers(ctx context.Context, prefix string, update func(members []*api.EndpointMeta, err error)) error {
+	watcher := e.data.etcd.Watch(ctx, prefix, clientv3.WithPrefix())
+	list := func() {
+		kvs, err := e.data.etcd.GetWithPrefix(ctx, prefix)
+		if err != nil {
+			update(nil, err)
+			return
+		}
+		members := make([]*api.EndpointMeta, 0, len(kvs))
+		for _, kv := range kvs {
+			member, err := gateway.ParseEndpointMeta(kv.Value)
+			if err != nil {
+				update(nil, fmt.Errorf("parse member %s error: %w", string(kv.Key), err))
+				return
+			}
+			members = append(members, member)
+		}
+		update(members, nil)
+	}
+	list()
+	for wresp := range watcher {
+		if err := wresp.Err(); err != nil {
+			update(nil, err)
+			continue
+		}
+		list()
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return fmt.Errorf("watch members of %s closed", prefix)
+}
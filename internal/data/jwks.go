@@ -0,0 +1,56 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/begonia-org/begonia/api/jwks/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/spark-lence/tiga"
+)
+
+type jwksRepoImpl struct {
+	rdb  *tiga.RedisDao
+	cfg  *config.Config
+	curd biz.CURD
+}
+
+func NewJWKSRepoImpl(curd biz.CURD, rdb *tiga.RedisDao, cfg *config.Config) biz.JWKSRepo {
+	return &jwksRepoImpl{curd: curd, rdb: rdb, cfg: cfg}
+}
+
+func (r *jwksRepoImpl) List(ctx context.Context) ([]*api.SigningKey, error) {
+	keys := make([]*api.SigningKey, 0)
+	pagination := &tiga.Pagination{Page: 1, PageSize: -1, Query: "", Args: []interface{}{}}
+	if err := r.curd.List(ctx, &keys, pagination); err != nil {
+		return nil, fmt.Errorf("list signing keys failed: %w", err)
+	}
+	ivKey := r.cfg.GetAesIv()
+	aseKey := r.cfg.GetAesKey()
+	for _, key := range keys {
+		if err := tiga.DecryptStructAES([]byte(aseKey), key, ivKey); err != nil {
+			return nil, fmt.Errorf("decrypt signing key failed: %w", err)
+		}
+	}
+	return keys, nil
+}
+
+func (r *jwksRepoImpl) Add(ctx context.Context, key *api.SigningKey) error {
+	if err := r.curd.Add(ctx, key, true); err != nil {
+		return fmt.Errorf("add signing key failed: %w", err)
+	}
+	return nil
+}
+
+func (r *jwksRepoImpl) Del(ctx context.Context, key *api.SigningKey) error {
+	if err := r.curd.Del(ctx, key, false); err != nil {
+		return fmt.Errorf("delete signing key failed: %w", err)
+	}
+	return nil
+}
+
+func (r *jwksRepoImpl) Locker(ctx context.Context, key string, exp time.Duration) (biz.DataLock, error) {
+	return NewDataLock(r.rdb.GetClient(), key, exp, 3), nil
+}
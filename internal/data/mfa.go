@@ -0,0 +1,99 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/begonia-org/begonia/api/mfa/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/protobuf/proto"
+)
+
+type mfaRepoImpl struct {
+	rdb  *tiga.RedisDao
+	cfg  *config.Config
+	curd biz.CURD
+}
+
+func NewMFARepoImpl(curd biz.CURD, rdb *tiga.RedisDao, cfg *config.Config) biz.MFARepo {
+	return &mfaRepoImpl{curd: curd, rdb: rdb, cfg: cfg}
+}
+
+func (r *mfaRepoImpl) GetTOTP(ctx context.Context, user string) (*api.UserTOTP, error) {
+	totp := &api.UserTOTP{}
+	if err := r.curd.Get(ctx, totp, true, "user = ?", user); err != nil {
+		return nil, fmt.Errorf("get user totp failed: %w", err)
+	}
+	return totp, nil
+}
+
+// AddTOTP 密钥和备用码加密后写入，加密会修改传入的对象，所以使用副本
+func (r *mfaRepoImpl) AddTOTP(ctx context.Context, totp *api.UserTOTP) error {
+	if err := r.curd.Add(ctx, proto.Clone(totp).(*api.UserTOTP), true); err != nil {
+		return fmt.Errorf("add user totp failed: %w", err)
+	}
+	return nil
+}
+
+func (r *mfaRepoImpl) PatchTOTP(ctx context.Context, totp *api.UserTOTP) error {
+	if err := r.curd.Update(ctx, proto.Clone(totp).(*api.UserTOTP), true); err != nil {
+		return fmt.Errorf("update user totp failed: %w", err)
+	}
+	return nil
+}
+
+func (r *mfaRepoImpl) ListCredentials(ctx context.Context, user string) ([]*api.WebAuthnCredential, error) {
+	credentials := make([]*api.WebAuthnCredential, 0)
+	pagination := &tiga.Pagination{Page: 1, PageSize: -1, Query: "user = ?", Args: []interface{}{user}}
+	if err := r.curd.List(ctx, &credentials, pagination); err != nil {
+		return nil, fmt.Errorf("list webauthn credentials failed: %w", err)
+	}
+	ivKey := r.cfg.GetAesIv()
+	aseKey := r.cfg.GetAesKey()
+	for _, credential := range credentials {
+		if err := tiga.DecryptStructAES([]byte(aseKey), credential, ivKey); err != nil {
+			return nil, fmt.Errorf("decrypt webauthn credential failed: %w", err)
+		}
+	}
+	return credentials, nil
+}
+
+func (r *mfaRepoImpl) AddCredential(ctx context.Context, credential *api.WebAuthnCredential) error {
+	item := proto.Clone(credential).(*api.WebAuthnCredential)
+	if err := r.curd.Add(ctx, item, true); err != nil {
+		return fmt.Errorf("add webauthn credential failed: %w", err)
+	}
+	credential.CreatedAt = item.CreatedAt
+	credential.UpdatedAt = item.UpdatedAt
+	return nil
+}
+
+func (r *mfaRepoImpl) PatchCredential(ctx context.Context, credential *api.WebAuthnCredential) error {
+	if err := r.curd.Update(ctx, proto.Clone(credential).(*api.WebAuthnCredential), true); err != nil {
+		return fmt.Errorf("update webauthn credential failed: %w", err)
+	}
+	return nil
+}
+
+func (r *mfaRepoImpl) DelCredential(ctx context.Context, credential *api.WebAuthnCredential) error {
+	if err := r.curd.Del(ctx, credential, false); err != nil {
+		return fmt.Errorf("delete webauthn credential failed: %w", err)
+	}
+	return nil
+}
+
+func (r *mfaRepoImpl) PutChallenge(ctx context.Context, id string, value []byte, exp time.Duration) error {
+	return r.rdb.GetClient().Set(ctx, r.cfg.GetMFAChallengeKey(id), value, exp).Err()
+}
+
+// GetChallenge 使用GETDEL保证并发验证时只有一个请求能获取到挑战
+func (r *mfaRepoImpl) GetChallenge(ctx context.Context, id string) ([]byte, error) {
+	return r.rdb.GetClient().GetDel(ctx, r.cfg.GetMFAChallengeKey(id)).Bytes()
+}
+
+func (r *mfaRepoImpl) UseCode(ctx context.Context, key string, exp time.Duration) (bool, error) {
+	return r.rdb.GetClient().SetNX(ctx, key, 1, exp).Result()
+}
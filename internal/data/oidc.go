@@ -0,0 +1,60 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/begonia-org/begonia/api/oidc/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/protobuf/proto"
+)
+
+// 外部身份关联创建后不会修改，缓存以避免每次校验token都查询数据库
+const oidcIdentityExpiration = time.Hour
+
+type oidcRepoImpl struct {
+	rdb   *tiga.RedisDao
+	local *LayeredCache
+	cfg   *config.Config
+	curd  biz.CURD
+}
+
+func NewOIDCRepoImpl(curd biz.CURD, rdb *tiga.RedisDao, local *LayeredCache, cfg *config.Config) biz.OIDCRepo {
+	return &oidcRepoImpl{curd: curd, rdb: rdb, local: local, cfg: cfg}
+}
+
+func (r *oidcRepoImpl) PutState(ctx context.Context, state string, value []byte, exp time.Duration) error {
+	return r.rdb.GetClient().Set(ctx, r.cfg.GetOIDCStateKey(state), value, exp).Err()
+}
+
+// GetState 使用GETDEL保证state只能使用一次
+func (r *oidcRepoImpl) GetState(ctx context.Context, state string) ([]byte, error) {
+	return r.rdb.GetClient().GetDel(ctx, r.cfg.GetOIDCStateKey(state)).Bytes()
+}
+
+func (r *oidcRepoImpl) GetIdentity(ctx context.Context, issuer, subject string) (*api.UserIdentity, error) {
+	key := r.cfg.GetOIDCIdentityKey(issuer, subject)
+	identity := &api.UserIdentity{}
+	if val, err := r.local.Get(ctx, key); err == nil && val != nil {
+		if err := proto.Unmarshal(val, identity); err == nil {
+			return identity, nil
+		}
+	}
+	if err := r.curd.Get(ctx, identity, false, "issuer = ? and subject = ?", issuer, subject); err != nil {
+		return nil, fmt.Errorf("get user identity failed: %w", err)
+	}
+	if val, err := proto.Marshal(identity); err == nil {
+		_ = r.local.Set(ctx, key, val, oidcIdentityExpiration)
+	}
+	return identity, nil
+}
+
+func (r *oidcRepoImpl) AddIdentity(ctx context.Context, identity *api.UserIdentity) error {
+	if err := r.curd.Add(ctx, identity, false); err != nil {
+		return fmt.Errorf("add user identity failed: %w", err)
+	}
+	return nil
+}
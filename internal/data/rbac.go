@@ -0,0 +1,189 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/begonia-org/begonia/api/rbac/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/spark-lence/tiga"
+)
+
+const (
+	rbacDefaultPageSize = 20
+	// 权限缓存的过期时间，角色和绑定变更时主动删除
+	rbacPermissionsExpiration = time.Hour * 24
+)
+
+type rbacRepoImpl struct {
+	local *LayeredCache
+	cfg   *config.Config
+	curd  biz.CURD
+}
+
+func NewRBACRepoImpl(curd biz.CURD, local *LayeredCache, cfg *config.Config) biz.RBACRepo {
+	return &rbacRepoImpl{curd: curd, local: local, cfg: cfg}
+}
+
+func (r *rbacRepoImpl) permissionsKey(subjectType api.SubjectType, subject string) string {
+	return r.cfg.GetRBACPermissionsKey(strings.ToLower(strings.TrimPrefix(subjectType.String(), "SUBJECT_")), subject)
+}
+
+// pagination page size为负数时不分页，用于查询对象的所有绑定
+func (r *rbacRepoImpl) pagination(page, pageSize int32, query string, args []interface{}) *tiga.Pagination {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = rbacDefaultPageSize
+	}
+	return &tiga.Pagination{Page: page, PageSize: pageSize, Query: query, Args: args}
+}
+
+func (r *rbacRepoImpl) AddRole(ctx context.Context, role *api.Role) error {
+	if err := r.curd.Add(ctx, role, false); err != nil {
+		return fmt.Errorf("add role failed: %w", err)
+	}
+	return nil
+}
+
+func (r *rbacRepoImpl) GetRole(ctx context.Context, uid string) (*api.Role, error) {
+	role := &api.Role{}
+	if err := r.curd.Get(ctx, role, false, "uid = ?", uid); err != nil {
+		return nil, fmt.Errorf("get role failed: %w", err)
+	}
+	return role, nil
+}
+
+func (r *rbacRepoImpl) PatchRole(ctx context.Context, role *api.Role) error {
+	if err := r.curd.Update(ctx, role, false); err != nil {
+		return fmt.Errorf("update role failed: %w", err)
+	}
+	return r.invalidateRole(ctx, role.Uid)
+}
+
+func (r *rbacRepoImpl) DelRole(ctx context.Context, role *api.Role) error {
+	bindings, err := r.listBindings(ctx, "role = ?", []interface{}{role.Uid}, 1, -1)
+	if err != nil {
+		return err
+	}
+	for _, binding := range bindings {
+		if err := r.DelBinding(ctx, binding); err != nil {
+			return err
+		}
+	}
+	if err := r.curd.Del(ctx, role, false); err != nil {
+		return fmt.Errorf("delete role failed: %w", err)
+	}
+	return nil
+}
+
+func (r *rbacRepoImpl) ListRoles(ctx context.Context, page, pageSize int32) ([]*api.Role, error) {
+	roles := make([]*api.Role, 0)
+	if err := r.curd.List(ctx, &roles, r.pagination(page, pageSize, "", nil)); err != nil {
+		return nil, fmt.Errorf("list roles failed: %w", err)
+	}
+	return roles, nil
+}
+
+func (r *rbacRepoImpl) AddBinding(ctx context.Context, binding *api.RoleBinding) error {
+	if err := r.curd.Add(ctx, binding, false); err != nil {
+		return fmt.Errorf("add role binding failed: %w", err)
+	}
+	return r.local.Del(ctx, r.permissionsKey(binding.SubjectType, binding.Subject))
+}
+
+func (r *rbacRepoImpl) GetBinding(ctx context.Context, uid string) (*api.RoleBinding, error) {
+	binding := &api.RoleBinding{}
+	if err := r.curd.Get(ctx, binding, false, "uid = ?", uid); err != nil {
+		return nil, fmt.Errorf("get role binding failed: %w", err)
+	}
+	return binding, nil
+}
+
+func (r *rbacRepoImpl) DelBinding(ctx context.Context, binding *api.RoleBinding) error {
+	if err := r.curd.Del(ctx, binding, false); err != nil {
+		return fmt.Errorf("delete role binding failed: %w", err)
+	}
+	return r.local.Del(ctx, r.permissionsKey(binding.SubjectType, binding.Subject))
+}
+
+func (r *rbacRepoImpl) listBindings(ctx context.Context, query string, args []interface{}, page, pageSize int32) ([]*api.RoleBinding, error) {
+	bindings := make([]*api.RoleBinding, 0)
+	if err := r.curd.List(ctx, &bindings, r.pagination(page, pageSize, query, args)); err != nil {
+		return nil, fmt.Errorf("list role bindings failed: %w", err)
+	}
+	return bindings, nil
+}
+
+func (r *rbacRepoImpl) ListBindings(ctx context.Context, subjectType api.SubjectType, subject, role string, page, pageSize int32) ([]*api.RoleBinding, error) {
+	conds := make([]string, 0)
+	args := make([]interface{}, 0)
+	if subjectType != api.SubjectType_SUBJECT_UNKNOWN {
+		conds = append(conds, "subject_type = ?")
+		args = append(args, subjectType)
+	}
+	if subject != "" {
+		conds = append(conds, "subject = ?")
+		args = append(args, subject)
+	}
+	if role != "" {
+		conds = append(conds, "role = ?")
+		args = append(args, role)
+	}
+	return r.listBindings(ctx, strings.Join(conds, " and "), args, page, pageSize)
+}
+
+// invalidateRole 角色变更后删除所有绑定该角色的对象的权限缓存
+func (r *rbacRepoImpl) invalidateRole(ctx context.Context, role string) error {
+	bindings, err := r.listBindings(ctx, "role = ?", []interface{}{role}, 1, -1)
+	if err != nil {
+		return err
+	}
+	for _, binding := range bindings {
+		_ = r.local.Del(ctx, r.permissionsKey(binding.SubjectType, binding.Subject))
+	}
+	return nil
+}
+
+func (r *rbacRepoImpl) GetPermissions(ctx context.Context, subjectType api.SubjectType, subject string) ([]string, error) {
+	key := r.permissionsKey(subjectType, subject)
+	permissions := make([]string, 0)
+	if val, err := r.local.Get(ctx, key); err == nil && val != nil {
+		if err := json.Unmarshal(val, &permissions); err == nil {
+			return permissions, nil
+		}
+	}
+	bindings, err := r.listBindings(ctx, "subject_type = ? and subject = ?", []interface{}{subjectType, subject}, 1, -1)
+	if err != nil {
+		return nil, err
+	}
+	if len(bindings) > 0 {
+		uids := make([]string, 0, len(bindings))
+		for _, binding := range bindings {
+			uids = append(uids, binding.Role)
+		}
+		roles := make([]*api.Role, 0)
+		if err := r.curd.List(ctx, &roles, r.pagination(1, -1, "uid in (?)", []interface{}{uids})); err != nil {
+			return nil, fmt.Errorf("list roles failed: %w", err)
+		}
+		seen := make(map[string]bool)
+		for _, role := range roles {
+			for _, permission := range role.Permissions {
+				if !seen[permission] {
+					seen[permission] = true
+					permissions = append(permissions, permission)
+				}
+			}
+		}
+	}
+	// 没有权限时同样缓存，避免每次请求都查询数据库
+	if val, err := json.Marshal(permissions); err == nil {
+		_ = r.local.Set(ctx, key, val, rbacPermissionsExpiration)
+	}
+	return permissions, nil
+}
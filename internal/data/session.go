@@ -0,0 +1,110 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/redis/go-redis/v9"
+	"github.com/spark-lence/tiga"
+)
+
+// sessionRepoImpl 会话保存在redis中，用户的会话索引使用以过期时间为score的有序集合
+type sessionRepoImpl struct {
+	rdb *tiga.RedisDao
+	cfg *config.Config
+}
+
+func NewSessionRepoImpl(rdb *tiga.RedisDao, cfg *config.Config) biz.SessionRepo {
+	return &sessionRepoImpl{rdb: rdb, cfg: cfg}
+}
+
+func (r *sessionRepoImpl) Put(ctx context.Context, session *biz.UserSession) error {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("session %s expired", session.ID)
+	}
+	val, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("marshal session failed: %w", err)
+	}
+	index := r.cfg.GetUserSessionsKey(session.Uid)
+	pipe := r.rdb.GetClient().TxPipeline()
+	pipe.Set(ctx, r.cfg.GetSessionKey(session.ID), val, ttl)
+	pipe.ZAdd(ctx, index, redis.Z{Score: float64(session.ExpiresAt.Unix()), Member: session.ID})
+	pipe.ZRemRangeByScore(ctx, index, "-inf", strconv.FormatInt(time.Now().Unix(), 10))
+	pipe.ExpireGT(ctx, index, ttl)
+	pipe.ExpireNX(ctx, index, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("save session failed: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepoImpl) Get(ctx context.Context, id string) (*biz.UserSession, error) {
+	val, err := r.rdb.GetClient().Get(ctx, r.cfg.GetSessionKey(id)).Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("get session failed: %w", err)
+	}
+	session := &biz.UserSession{}
+	if err := json.Unmarshal(val, session); err != nil {
+		return nil, fmt.Errorf("unmarshal session failed: %w", err)
+	}
+	return session, nil
+}
+
+func (r *sessionRepoImpl) Del(ctx context.Context, session *biz.UserSession) error {
+	pipe := r.rdb.GetClient().TxPipeline()
+	pipe.Del(ctx, r.cfg.GetSessionKey(session.ID))
+	pipe.ZRem(ctx, r.cfg.GetUserSessionsKey(session.Uid), session.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("delete session failed: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepoImpl) List(ctx context.Context, uid string) ([]*biz.UserSession, error) {
+	client := r.rdb.GetClient()
+	index := r.cfg.GetUserSessionsKey(uid)
+	ids, err := client.ZRangeByScore(ctx, index, &redis.ZRangeBy{Min: strconv.FormatInt(time.Now().Unix(), 10), Max: "+inf"}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list sessions failed: %w", err)
+	}
+	sessions := make([]*biz.UserSession, 0, len(ids))
+	if len(ids) == 0 {
+		return sessions, nil
+	}
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, r.cfg.GetSessionKey(id))
+	}
+	vals, err := client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get sessions failed: %w", err)
+	}
+	for _, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			// 会话已被删除
+			continue
+		}
+		session := &biz.UserSession{}
+		if err := json.Unmarshal([]byte(str), session); err != nil {
+			return nil, fmt.Errorf("unmarshal session failed: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+	return sessions, nil
+}
+
+func (r *sessionRepoImpl) Locker(ctx context.Context, key string, exp time.Duration) (biz.DataLock, error) {
+	return NewDataLock(r.rdb.GetClient(), key, exp, 3), nil
+}
@@ -25,9 +25,21 @@ func NewEndpointRepo(cfg *tiga.Configuration, log logger.Logger) endpoint.Endpoi
 func NewAuthzRepo(cfg *tiga.Configuration, log logger.Logger) biz.AuthzRepo {
 	panic(wire.Build(ProviderSet, config.NewConfig))
 }
+func NewRBACRepo(cfg *tiga.Configuration, log logger.Logger) biz.RBACRepo {
+	panic(wire.Build(ProviderSet, config.NewConfig))
+}
+func NewOIDCRepo(cfg *tiga.Configuration, log logger.Logger) biz.OIDCRepo {
+	panic(wire.Build(ProviderSet, config.NewConfig))
+}
+func NewAuditRepo(cfg *tiga.Configuration, log logger.Logger) biz.AuditRepo {
+	panic(wire.Build(ProviderSet, config.NewConfig))
+}
 func NewUserRepo(cfg *tiga.Configuration, log logger.Logger) biz.UserRepo {
 	panic(wire.Build(ProviderSet, config.NewConfig))
 }
+func NewSessionRepo(cfg *tiga.Configuration, log logger.Logger) biz.SessionRepo {
+	panic(wire.Build(ProviderSet, config.NewConfig))
+}
 
 func NewLayered(cfg *tiga.Configuration, log logger.Logger) *LayeredCache {
 	panic(wire.Build(ProviderSet, config.NewConfig))
@@ -23,7 +23,7 @@ func NewAppRepo(cfg *tiga.Configuration, log logger.Logger) biz.AppRepo {
 	curd := NewCurdImpl(mySQLDao, configConfig)
 	redisDao := NewRDB(cfg)
 	layeredCache := NewLayeredCache(redisDao, configConfig, log)
-	appRepo := NewAppRepoImpl(curd, layeredCache, configConfig)
+	appRepo := NewAppRepoImpl(curd, layeredCache, redisDao, configConfig)
 	return appRepo
 }
 
@@ -45,6 +45,34 @@ func NewAuthzRepo(cfg *tiga.Configuration, log logger.Logger) biz.AuthzRepo {
 	return bizAuthzRepo
 }
 
+func NewRBACRepo(cfg *tiga.Configuration, log logger.Logger) biz.RBACRepo {
+	mySQLDao := NewMySQL(cfg)
+	configConfig := config.NewConfig(cfg)
+	curd := NewCurdImpl(mySQLDao, configConfig)
+	redisDao := NewRDB(cfg)
+	layeredCache := NewLayeredCache(redisDao, configConfig, log)
+	rbacRepo := NewRBACRepoImpl(curd, layeredCache, configConfig)
+	return rbacRepo
+}
+
+func NewOIDCRepo(cfg *tiga.Configuration, log logger.Logger) biz.OIDCRepo {
+	mySQLDao := NewMySQL(cfg)
+	configConfig := config.NewConfig(cfg)
+	curd := NewCurdImpl(mySQLDao, configConfig)
+	redisDao := NewRDB(cfg)
+	layeredCache := NewLayeredCache(redisDao, configConfig, log)
+	oidcRepo := NewOIDCRepoImpl(curd, redisDao, layeredCache, configConfig)
+	return oidcRepo
+}
+
+func NewAuditRepo(cfg *tiga.Configuration, log logger.Logger) biz.AuditRepo {
+	mySQLDao := NewMySQL(cfg)
+	configConfig := config.NewConfig(cfg)
+	curd := NewCurdImpl(mySQLDao, configConfig)
+	auditRepo := NewAuditRepoImpl(curd)
+	return auditRepo
+}
+
 func NewUserRepo(cfg *tiga.Configuration, log logger.Logger) biz.UserRepo {
 	mySQLDao := NewMySQL(cfg)
 	redisDao := NewRDB(cfg)
@@ -57,6 +85,13 @@ func NewUserRepo(cfg *tiga.Configuration, log logger.Logger) biz.UserRepo {
 	return userRepo
 }
 
+func NewSessionRepo(cfg *tiga.Configuration, log logger.Logger) biz.SessionRepo {
+	redisDao := NewRDB(cfg)
+	configConfig := config.NewConfig(cfg)
+	sessionRepo := NewSessionRepoImpl(redisDao, configConfig)
+	return sessionRepo
+}
+
 func NewLayered(cfg *tiga.Configuration, log logger.Logger) *LayeredCache {
 	redisDao := NewRDB(cfg)
 	configConfig := config.NewConfig(cfg)
@@ -72,7 +107,7 @@ func NewOperator(cfg *tiga.Configuration, log logger.Logger) biz.DataOperatorRep
 	configConfig := config.NewConfig(cfg)
 	curd := NewCurdImpl(mySQLDao, configConfig)
 	layeredCache := NewLayeredCache(redisDao, configConfig, log)
-	appRepo := NewAppRepoImpl(curd, layeredCache, configConfig)
+	appRepo := NewAppRepoImpl(curd, layeredCache, redisDao, configConfig)
 	userRepo := NewUserRepoImpl(data, layeredCache, curd, configConfig)
 	bizAuthzRepo := NewAuthzRepoImpl(log, layeredCache)
 	bizDataOperatorRepo := NewDataOperatorRepo(data, appRepo, userRepo, bizAuthzRepo, layeredCache, log)
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	api "github.com/begonia-org/begonia/api/audit/v1"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/pkg/routers"
+	"google.golang.org/grpc"
+)
+
+// AuditPlugin 记录实现了biz.AuditResource的服务的写操作，需要在auth插件之后执行，
+// 使用auth插件写入的x-identity作为操作者，GET和HEAD方法不记录
+type AuditPlugin struct {
+	audit    *biz.AuditUsecase
+	priority int
+	name     string
+}
+
+func NewAuditPlugin(audit *biz.AuditUsecase) *AuditPlugin {
+	return &AuditPlugin{audit: audit, name: "audit"}
+}
+
+func (a *AuditPlugin) SetPriority(priority int) {
+	a.priority = priority
+}
+
+func (a *AuditPlugin) Priority() int {
+	return a.priority
+}
+
+func (a *AuditPlugin) Name() string {
+	return a.name
+}
+
+func (a *AuditPlugin) readOnly(fullMethod string) bool {
+	router := routers.Get().GetRouteByGrpcMethod(fullMethod)
+	if router == nil {
+		return false
+	}
+	return strings.EqualFold(router.RequestMethod, "GET") || strings.EqualFold(router.RequestMethod, "HEAD")
+}
+
+func (a *AuditPlugin) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if a.readOnly(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	// jwt鉴权的操作者为x-uid，ak鉴权为x-identity，两者都只由auth插件写入
+	_, actor := authSubject(ctx)
+	log := &api.AuditLog{
+		Actor:     actor,
+		Method:    info.FullMethod,
+		ClientIp:  clientIP(ctx),
+		RequestId: getMetadataValue(ctx, gateway.XRequestID),
+	}
+	entry := a.audit.Begin(ctx, log, info.Server, req)
+	if entry == nil {
+		return handler(ctx, req)
+	}
+	rsp, err := handler(ctx, req)
+	a.audit.End(ctx, entry, rsp, err)
+	return rsp, err
+}
+
+// StreamInterceptor 流式方法不记录
+func (a *AuditPlugin) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, ss)
+}
@@ -0,0 +1,174 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/begonia-org/begonia"
+	api "github.com/begonia-org/begonia/api/audit/v1"
+	"github.com/begonia-org/begonia/config"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/middleware"
+	cfg "github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/begonia/internal/pkg/routers"
+	gosdk "github.com/begonia-org/go-sdk"
+	app "github.com/begonia-org/go-sdk/api/app/v1"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type memoryAuditRepo struct {
+	logs []*api.AuditLog
+}
+
+func (m *memoryAuditRepo) Write(ctx context.Context, log *api.AuditLog) error {
+	m.logs = append(m.logs, proto.Clone(log).(*api.AuditLog))
+	return nil
+}
+
+func (m *memoryAuditRepo) List(ctx context.Context, in *api.ListAuditLogsRequest) ([]*api.AuditLog, error) {
+	logs := make([]*api.AuditLog, 0)
+	for _, log := range m.logs {
+		if in.Actor == "" || log.Actor == in.Actor {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+type auditTestServer struct {
+	apps map[string]*app.Apps
+}
+
+func (s *auditTestServer) AuditResource() (string, []string) {
+	return "app", []string{"appid"}
+}
+
+func (s *auditTestServer) AuditSnapshot(ctx context.Context, id string) proto.Message {
+	if apps, ok := s.apps[id]; ok {
+		return proto.Clone(apps)
+	}
+	return nil
+}
+
+func TestAuditPlugin(t *testing.T) {
+	c.Convey("test audit plugin", t, func() {
+		env := "dev"
+		if begonia.Env != "" {
+			env = begonia.Env
+		}
+		cnf := cfg.NewConfig(config.ReadConfig(env))
+		repo := &memoryAuditRepo{}
+		audit := biz.NewAuditUsecase(repo, repo, cnf, gateway.Log)
+		plugin := middleware.NewAuditPlugin(audit)
+		plugin.SetPriority(3)
+		c.So(plugin.Name(), c.ShouldEqual, "audit")
+		c.So(plugin.Priority(), c.ShouldEqual, 3)
+
+		R := routers.Get()
+		R.AddRoute("/test/audit/apps/get", &routers.APIMethodDetails{GrpcFullRouter: "/TEST.AUDIT/GET", RequestMethod: "GET"})
+		R.AddRoute("/test/audit/apps", &routers.APIMethodDetails{GrpcFullRouter: "/TEST.AUDIT/POST", RequestMethod: "POST"})
+
+		server := &auditTestServer{apps: make(map[string]*app.Apps)}
+		// jwt鉴权的操作者为x-uid
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token", gateway.XUID, "user-1", gateway.XRequestID, "req-1"))
+		call := func(ctx context.Context, method string, srv any, req any, handler grpc.UnaryHandler) error {
+			_, err := plugin.UnaryInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method, Server: srv}, handler)
+			return err
+		}
+
+		// 创建时资源id从响应中获取，敏感字段脱敏
+		err := call(ctx, "/test.Audit/Post", server, &app.AppsRequest{Name: "audit"}, func(ctx context.Context, req any) (any, error) {
+			server.apps["app-1"] = &app.Apps{Appid: "app-1", Name: "audit", Secret: "secret"}
+			return &app.AddAppResponse{Appid: "app-1", Secret: "secret"}, nil
+		})
+		c.So(err, c.ShouldBeNil)
+		c.So(repo.logs, c.ShouldHaveLength, 1)
+		log := repo.logs[0]
+		c.So(log.Actor, c.ShouldEqual, "user-1")
+		c.So(log.Action, c.ShouldEqual, "Post")
+		c.So(log.Resource, c.ShouldEqual, "app")
+		c.So(log.ResourceId, c.ShouldEqual, "app-1")
+		c.So(log.RequestId, c.ShouldEqual, "req-1")
+		c.So(log.Before, c.ShouldBeEmpty)
+		c.So(log.After, c.ShouldNotContainSubstring, `"secret":"secret"`)
+		c.So(log.Changes, c.ShouldContain, "name")
+		c.So(log.Uid, c.ShouldNotBeEmpty)
+
+		// 更新记录变化的字段
+		err = call(ctx, "/test.Audit/Update", server, &app.AppsRequest{Appid: "app-1", Name: "renamed"}, func(ctx context.Context, req any) (any, error) {
+			server.apps["app-1"].Name = "renamed"
+			return server.apps["app-1"], nil
+		})
+		c.So(err, c.ShouldBeNil)
+		log = repo.logs[1]
+		c.So(log.Changes, c.ShouldResemble, []string{"name"})
+		before := make(map[string]interface{})
+		c.So(json.Unmarshal([]byte(log.Before), &before), c.ShouldBeNil)
+		c.So(before["name"], c.ShouldEqual, "audit")
+		c.So(log.After, c.ShouldContainSubstring, "renamed")
+
+		// 失败的操作记录错误码
+		err = call(ctx, "/test.Audit/Delete", server, &app.DeleteAppRequest{Appid: "app-1"}, func(ctx context.Context, req any) (any, error) {
+			return nil, gosdk.NewError(fmt.Errorf("permission denied"), int32(common.Code_PREMISSION_DENIED), codes.PermissionDenied, "delete_app")
+		})
+		c.So(status.Code(err), c.ShouldEqual, codes.PermissionDenied)
+		log = repo.logs[2]
+		c.So(log.Code, c.ShouldEqual, int32(codes.PermissionDenied))
+		c.So(log.Message, c.ShouldContainSubstring, "permission denied")
+		c.So(log.Changes, c.ShouldBeEmpty)
+
+		err = call(ctx, "/test.Audit/Delete", server, &app.DeleteAppRequest{Appid: "app-1"}, func(ctx context.Context, req any) (any, error) {
+			delete(server.apps, "app-1")
+			return &app.DeleteAppResponse{}, nil
+		})
+		c.So(err, c.ShouldBeNil)
+		log = repo.logs[3]
+		c.So(log.After, c.ShouldBeEmpty)
+		c.So(log.Changes, c.ShouldContain, "appid")
+
+		// 未鉴权的请求以资源id作为操作者
+		err = call(context.Background(), "/test.Audit/Post", server, &app.AppsRequest{}, func(ctx context.Context, req any) (any, error) {
+			return &app.AddAppResponse{Appid: "app-2"}, nil
+		})
+		c.So(err, c.ShouldBeNil)
+		c.So(repo.logs[4].Actor, c.ShouldEqual, "app-2")
+
+		// ak鉴权的操作者为x-identity
+		akCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Access appid", gateway.XIdentity, "app-3"))
+		err = call(akCtx, "/test.Audit/Post", server, &app.AppsRequest{}, func(ctx context.Context, req any) (any, error) {
+			return &app.AddAppResponse{Appid: "app-4"}, nil
+		})
+		c.So(err, c.ShouldBeNil)
+		c.So(repo.logs[5].Actor, c.ShouldEqual, "app-3")
+
+		// 读操作和未实现AuditResource的服务不记录
+		c.So(call(ctx, "/test.Audit/Get", server, &app.GetAPPRequest{Appid: "app-1"}, func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		}), c.ShouldBeNil)
+		c.So(call(ctx, "/test.Other/Post", struct{}{}, &app.AppsRequest{}, func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		}), c.ShouldBeNil)
+		c.So(repo.logs, c.ShouldHaveLength, 6)
+
+		logs, err := audit.List(context.Background(), &api.ListAuditLogsRequest{Actor: "user-1"})
+		c.So(err, c.ShouldBeNil)
+		c.So(logs, c.ShouldHaveLength, 4)
+		_, err = audit.List(context.Background(), &api.ListAuditLogsRequest{StartTime: timestamppb.Now(), EndTime: timestamppb.New(time.Now().Add(-time.Hour))})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+
+		c.So(plugin.StreamInterceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: "/test.Audit/Post"}, func(srv any, ss grpc.ServerStream) error {
+			return nil
+		}), c.ShouldBeNil)
+	})
+}
@@ -107,7 +107,12 @@ func TestRequestBeforeErr(t *testing.T) {
 		patch2 := gomonkey.ApplyFuncReturn(gosdk.NewGatewayRequestFromGrpc, nil, nil)
 		patch2 = patch2.ApplyFuncReturn((*biz.AccessKeyAuth).AppValidator, "dxadada", nil)
 		patch2 = patch2.ApplyFuncReturn((*biz.AccessKeyAuth).GetAppid, "dadad", nil)
+		patch2 = patch2.ApplyFuncReturn((*biz.AccessKeyAuth).CheckScope, fmt.Errorf("access key scope denied"))
 		defer patch2.Reset()
+		_, err2 := ak.RequestBefore(context.TODO(), &grpc.UnaryServerInfo{}, nil)
+		c.So(err2, c.ShouldNotBeNil)
+		c.So(err2.Error(), c.ShouldContainSubstring, "access key scope denied")
+		patch2 = patch2.ApplyFuncReturn((*biz.AccessKeyAuth).CheckScope, nil)
 		ctx, err2 := ak.RequestBefore(context.TODO(), &grpc.UnaryServerInfo{}, nil)
 		patch2.Reset()
 		c.So(err2, c.ShouldBeNil)
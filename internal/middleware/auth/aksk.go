@@ -59,6 +59,9 @@ func (a *AccessKeyAuthMiddleware) RequestBefore(ctx context.Context, info *grpc.
 		return ctx, err
 
 	}
+	if err := a.app.CheckScope(ctx, accessKey, info.FullMethod); err != nil {
+		return ctx, err
+	}
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		md = metadata.MD{}
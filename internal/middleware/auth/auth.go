@@ -4,12 +4,15 @@ import (
 	"context"
 	"strings"
 
+	"github.com/begonia-org/begonia/gateway"
 	"github.com/begonia-org/begonia/internal/pkg"
 	gosdk "github.com/begonia-org/go-sdk"
 	api "github.com/begonia-org/go-sdk/api/user/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -30,7 +33,45 @@ func NewAuth(ak *AccessKeyAuthMiddleware, jwt *JWTAuth, apikey ApiKeyAuth) gosdk
 	}
 }
 
+// trustedContext 丢弃客户端传入的x-uid,x-identity,x-token,x-session-id，这些元数据只能由鉴权插件写入，
+// 校验过的客户端证书subject映射为x-identity，鉴权通过后由ak鉴权覆盖。
+// 原生grpc请求从连接的tls状态中获取subject，http网关转发的请求只经过本机回环地址的grpc代理，
+// 使用网关入口写入的x-client-cert-subject
+func trustedContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	md = md.Copy()
+	subject := md.Get(gateway.XClientCertSubject)
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			subject = nil
+			if chains := tlsInfo.State.VerifiedChains; len(chains) > 0 && len(chains[0]) > 0 {
+				subject = []string{chains[0][0].Subject.String()}
+			}
+		}
+	}
+	for _, key := range gateway.TrustedHeaders {
+		md.Delete(key)
+	}
+	if len(subject) > 0 && subject[0] != "" {
+		md.Set(gateway.XIdentity, subject[0])
+	}
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+type trustedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *trustedServerStream) Context() context.Context {
+	return s.ctx
+}
+
 func (a *Auth) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	ctx = trustedContext(ctx)
 	if !IfNeedValidate(ctx, info.FullMethod) {
 		return handler(ctx, req)
 	}
@@ -56,6 +97,7 @@ func (a *Auth) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnarySe
 }
 
 func (a *Auth) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ss = &trustedServerStream{ServerStream: ss, ctx: trustedContext(ss.Context())}
 	if !IfNeedValidate(ss.Context(), info.FullMethod) {
 		return handler(srv, ss)
 	}
@@ -7,6 +7,9 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -38,7 +41,9 @@ import (
 	c "github.com/smartystreets/goconvey/convey"
 	"github.com/spark-lence/tiga"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 type testStream struct {
@@ -120,7 +125,7 @@ func getJWT() string {
 	user := data.NewUserRepo(config, gateway.Log)
 	userAuth := crypto.NewUsersAuth(cnf)
 	authzRepo := data.NewAuthzRepo(config, gateway.Log)
-	authz := biz.NewAuthzUsecase(authzRepo, user, gateway.Log, userAuth, cnf)
+	authz := biz.NewAuthzUsecase(authzRepo, user, gateway.Log, userAuth, cnf, nil)
 	adminUser := cnf.GetDefaultAdminName()
 	adminPasswd := cnf.GetDefaultAdminPasswd()
 	_, filename, _, _ := runtime.Caller(0)
@@ -212,8 +217,8 @@ func getMid() gosdk.LocalPlugin {
 	user := data.NewUserRepo(config, gateway.Log)
 	userAuth := crypto.NewUsersAuth(cnf)
 	authzRepo := data.NewAuthzRepo(config, gateway.Log)
-	authz := biz.NewAuthzUsecase(authzRepo, user, gateway.Log, userAuth, cnf)
-	jwt := auth.NewJWTAuth(cnf, tiga.NewRedisDao(config), authz, gateway.Log)
+	authz := biz.NewAuthzUsecase(authzRepo, user, gateway.Log, userAuth, cnf, nil)
+	jwt := auth.NewJWTAuth(cnf, tiga.NewRedisDao(config), authz, nil, gateway.Log)
 	ak := auth.NewAccessKeyAuth(akBiz, cnf, gateway.Log)
 	apiKey := auth.NewApiKeyAuth(cnf)
 	mid := auth.NewAuth(ak, jwt, apiKey)
@@ -464,3 +469,59 @@ func TestStreamInterceptorErr(t *testing.T) {
 	})
 
 }
+
+func TestTrustedIdentity(t *testing.T) {
+	c.Convey("test drop spoofed identity metadata", t, func() {
+		mid := auth.NewAuth(nil, nil, nil)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			gateway.XUID, "spoofed",
+			gateway.XIdentity, "spoofed",
+			gateway.XToken, "spoofed",
+			gateway.XSessionID, "spoofed",
+			gateway.XClientCertSubject, "CN=client",
+		))
+		var md metadata.MD
+		_, err := mid.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/not.exists.Service/Get"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			md, _ = metadata.FromIncomingContext(ctx)
+			return nil, nil
+		})
+		c.So(err, c.ShouldBeNil)
+		c.So(md.Get(gateway.XUID), c.ShouldBeEmpty)
+		c.So(md.Get(gateway.XToken), c.ShouldBeEmpty)
+		c.So(md.Get(gateway.XSessionID), c.ShouldBeEmpty)
+		c.So(md.Get(gateway.XClientCertSubject), c.ShouldBeEmpty)
+		// 客户端证书subject映射为x-identity
+		c.So(md.Get(gateway.XIdentity), c.ShouldResemble, []string{"CN=client"})
+
+		err = mid.StreamInterceptor(nil, &testStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(gateway.XUID, "spoofed"))}, &grpc.StreamServerInfo{FullMethod: "/not.exists.Service/Get"}, func(srv any, ss grpc.ServerStream) error {
+			md, _ = metadata.FromIncomingContext(ss.Context())
+			return nil
+		})
+		c.So(err, c.ShouldBeNil)
+		c.So(md.Get(gateway.XUID), c.ShouldBeEmpty)
+		c.So(md.Get(gateway.XIdentity), c.ShouldBeEmpty)
+	})
+	c.Convey("test identity of native grpc request from tls state", t, func() {
+		mid := auth.NewAuth(nil, nil, nil)
+		info := &grpc.UnaryServerInfo{FullMethod: "/not.exists.Service/Get"}
+		var md metadata.MD
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			md, _ = metadata.FromIncomingContext(ctx)
+			return nil, nil
+		}
+		spoofed := metadata.Pairs(gateway.XClientCertSubject, "CN=spoofed")
+		// 没有客户端证书的tls连接不能通过元数据指定身份
+		ctx := peer.NewContext(metadata.NewIncomingContext(context.Background(), spoofed), &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{}}})
+		_, err := mid.UnaryInterceptor(ctx, nil, info, handler)
+		c.So(err, c.ShouldBeNil)
+		c.So(md.Get(gateway.XIdentity), c.ShouldBeEmpty)
+		c.So(md.Get(gateway.XClientCertSubject), c.ShouldBeEmpty)
+
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: "client"}}
+		state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		ctx = peer.NewContext(metadata.NewIncomingContext(context.Background(), spoofed), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+		_, err = mid.UnaryInterceptor(ctx, nil, info, handler)
+		c.So(err, c.ShouldBeNil)
+		c.So(md.Get(gateway.XIdentity), c.ShouldResemble, []string{"CN=client"})
+	})
+}
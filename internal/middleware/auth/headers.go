@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/begonia-org/begonia/gateway"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
@@ -56,6 +57,11 @@ func (g *GrpcHeader) Set(key, value string) {
 	g.ctx = newCtx
 
 }
+
+// withClaims 将校验通过的jwt claims写入请求上下文
+func (g *GrpcHeader) withClaims(claims map[string]interface{}) {
+	g.ctx = gateway.WithJWTClaims(g.ctx, claims)
+}
 func (g *GrpcHeader) SendHeader(key, value string) {
 	g.out.Append(key, value)
 	_ = grpc.SendHeader(g.ctx, g.out)
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -21,22 +22,31 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// ExternalTokenVerifier 校验外部oidc签发方签发的token，返回关联的用户uid
+type ExternalTokenVerifier interface {
+	IsExternal(token string) bool
+	Verify(ctx context.Context, token string) (string, error)
+}
+
 type JWTAuth struct {
 	config   *config.Config
 	rdb      *tiga.RedisDao
 	biz      *biz.AuthzUsecase
+	external ExternalTokenVerifier
 	log      logger.Logger
 	priority int
 	name     string
 }
 
-func NewJWTAuth(config *config.Config, rdb *tiga.RedisDao, biz *biz.AuthzUsecase, log logger.Logger) *JWTAuth {
+// NewJWTAuth external为nil时只接受网关签发的token
+func NewJWTAuth(config *config.Config, rdb *tiga.RedisDao, biz *biz.AuthzUsecase, external ExternalTokenVerifier, log logger.Logger) *JWTAuth {
 	return &JWTAuth{
-		config: config,
-		rdb:    rdb,
-		biz:    biz,
-		log:    log,
-		name:   "jwt_auth",
+		config:   config,
+		rdb:      rdb,
+		biz:      biz,
+		external: external,
+		log:      log,
+		name:     "jwt_auth",
 	}
 }
 func (a *JWTAuth) GetAuthorizationFromMetadata(md metadata.MD) string {
@@ -48,7 +58,7 @@ func (a *JWTAuth) GetAuthorizationFromMetadata(md metadata.MD) string {
 	}
 	return ""
 }
-func (a *JWTAuth) jwt2BasicAuth(authorization string) (*api.BasicAuth, error) {
+func (a *JWTAuth) jwt2BasicAuth(ctx context.Context, authorization string) (*api.BasicAuth, error) {
 	// Typically JWT is in a header in the format "Bearer {token}"
 	strArr := strings.Split(authorization, " ")
 	token := ""
@@ -62,6 +72,14 @@ func (a *JWTAuth) jwt2BasicAuth(authorization string) (*api.BasicAuth, error) {
 	if len(jwtInfo) != 3 {
 		return nil, gosdk.NewError(pkg.ErrHeaderTokenFormat, int32(api.UserSvrCode_USER_TOKEN_INVALIDATE_ERR), codes.Unauthenticated, "check_token_format")
 	}
+	// 非对称密钥签发的token，切换算法前使用jwt_secret签发的token在过期前仍然有效
+	if alg := a.tokenAlgorithm(jwtInfo[0]); alg != "" && alg != "HS256" {
+		payload, err := a.biz.VerifyJWT(ctx, token)
+		if err != nil {
+			return nil, gosdk.NewError(fmt.Errorf("%w:%w", pkg.ErrTokenInvalid, err), int32(api.UserSvrCode_USER_TOKEN_INVALIDATE_ERR), codes.Unauthenticated, "check_sign")
+		}
+		return payload, nil
+	}
 	// 生成signature
 	sig := fmt.Sprintf("%s.%s", jwtInfo[0], jwtInfo[1])
 	secret := a.config.GetJWTSecret()
@@ -81,6 +99,44 @@ func (a *JWTAuth) jwt2BasicAuth(authorization string) (*api.BasicAuth, error) {
 	}
 	return payload, nil
 }
+
+type claimsHeader interface {
+	withClaims(claims map[string]interface{})
+}
+
+// tokenClaims 解析已经校验过签名的token中的claims
+func tokenClaims(authorization string) map[string]interface{} {
+	claims := make(map[string]interface{})
+	strArr := strings.Split(authorization, " ")
+	if len(strArr) != 2 {
+		return claims
+	}
+	parts := strings.Split(strArr[1], ".")
+	if len(parts) != 3 {
+		return claims
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return claims
+	}
+	_ = json.Unmarshal(payload, &claims)
+	return claims
+}
+
+// tokenAlgorithm jwt_secret签发的token使用带填充的base64编码
+func (a *JWTAuth) tokenAlgorithm(header string) string {
+	headerBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(header, "="))
+	if err != nil {
+		return ""
+	}
+	h := struct {
+		Alg string `json:"alg"`
+	}{}
+	if err := json.Unmarshal(headerBytes, &h); err != nil {
+		return ""
+	}
+	return h.Alg
+}
 func (a *JWTAuth) JWTLock(uid string) (*redislock.Lock, error) {
 	key := a.config.GetJWTLockKey(uid)
 	return a.rdb.Lock(context.Background(), key, time.Second*10)
@@ -104,8 +160,25 @@ func (a *JWTAuth) checkJWTItem(ctx context.Context, payload *api.BasicAuth, toke
 	}
 	return true, nil
 }
+
+// checkExternalJWT 外部签发的token由签发方负责刷新
+func (a *JWTAuth) checkExternalJWT(ctx context.Context, token string, reqHeader Header) (bool, error) {
+	if ok, err := a.biz.CheckInBlackList(ctx, tiga.GetMd5(token)); ok {
+		return false, gosdk.NewError(fmt.Errorf("%w or %w", pkg.ErrTokenBlackList, err), int32(api.UserSvrCode_USER_TOKEN_INVALIDATE_ERR), codes.Unauthenticated, "check_blacklist")
+	}
+	uid, err := a.external.Verify(ctx, token)
+	if err != nil {
+		return false, err
+	}
+	reqHeader.Set("x-token", token)
+	reqHeader.Set("x-uid", uid)
+	return true, nil
+}
 func (a *JWTAuth) checkJWT(ctx context.Context, authorization string, rspHeader Header, reqHeader Header) (ok bool, err error) {
-	payload, errAuth := a.jwt2BasicAuth(authorization)
+	if strArr := strings.Split(authorization, " "); a.external != nil && len(strArr) == 2 && a.external.IsExternal(strArr[1]) {
+		return a.checkExternalJWT(ctx, strArr[1], reqHeader)
+	}
+	payload, errAuth := a.jwt2BasicAuth(ctx, authorization)
 	err = errAuth
 	if err != nil {
 		return false, err
@@ -116,6 +189,13 @@ func (a *JWTAuth) checkJWT(ctx context.Context, authorization string, rspHeader
 	if err != nil || !ok {
 		return false, err
 	}
+	// 会话签发的短期token由客户端使用refresh token刷新
+	if sid := biz.TokenSessionID(token); sid != "" {
+		reqHeader.Set("x-token", token)
+		reqHeader.Set("x-uid", payload.Uid)
+		reqHeader.Set("x-session-id", sid)
+		return true, nil
+	}
 
 	left := payload.Expiration - time.Now().Unix()
 	// expiration := a.config.GetJWTExpiration()
@@ -149,8 +229,7 @@ func (a *JWTAuth) checkJWT(ctx context.Context, authorization string, rspHeader
 		payload.Expiration = time.Now().Add(exp).Unix()
 		payload.NotBefore = time.Now().Unix()
 		payload.IssuedAt = time.Now().Unix()
-		secret := a.config.GetJWTSecret()
-		newToken, err := tiga.GenerateJWT(payload, secret)
+		newToken, err := a.biz.SignJWT(ctx, payload)
 		if err != nil {
 			return false, gosdk.NewError(fmt.Errorf("%s:%w", "generate new token error", err), int32(api.UserSvrCode_USER_TOKEN_INVALIDATE_ERR), codes.Unauthenticated, "generate_token")
 		}
@@ -181,6 +260,10 @@ func (a *JWTAuth) jwtValidator(ctx context.Context, headers Header) (context.Con
 	if err != nil || !ok {
 		return nil, status.Errorf(codes.Unauthenticated, "check token error,%v", err)
 	}
+	// 签名校验通过后将claims写入上下文，transform插件从上下文读取
+	if h, ok := headers.(claimsHeader); ok {
+		h.withClaims(tokenClaims(token))
+	}
 
 	newCtx := metadata.NewIncomingContext(ctx, md)
 	return newCtx, nil
@@ -71,8 +71,8 @@ func TestJWTUnaryInterceptor(t *testing.T) {
 		user := data.NewUserRepo(config, gateway.Log)
 		userAuth := crypto.NewUsersAuth(cnf)
 		authzRepo := data.NewAuthzRepo(config, gateway.Log)
-		authz := biz.NewAuthzUsecase(authzRepo, user, gateway.Log, userAuth, cnf)
-		jwt := auth.NewJWTAuth(cnf, tiga.NewRedisDao(config), authz, gateway.Log)
+		authz := biz.NewAuthzUsecase(authzRepo, user, gateway.Log, userAuth, cnf, nil)
+		jwt := auth.NewJWTAuth(cnf, tiga.NewRedisDao(config), authz, nil, gateway.Log)
 		jwt.SetPriority(1)
 		c.So(jwt.Priority(), c.ShouldEqual, 1)
 		c.So(jwt.Name(), c.ShouldEqual, "jwt_auth")
@@ -297,8 +297,8 @@ func TestJWTStreamInterceptor(t *testing.T) {
 		user := data.NewUserRepo(config, gateway.Log)
 		userAuth := crypto.NewUsersAuth(cnf)
 		authzRepo := data.NewAuthzRepo(config, gateway.Log)
-		authz := biz.NewAuthzUsecase(authzRepo, user, gateway.Log, userAuth, cnf)
-		jwt := auth.NewJWTAuth(cnf, tiga.NewRedisDao(config), authz, gateway.Log)
+		authz := biz.NewAuthzUsecase(authzRepo, user, gateway.Log, userAuth, cnf, nil)
+		jwt := auth.NewJWTAuth(cnf, tiga.NewRedisDao(config), authz, nil, gateway.Log)
 		err := jwt.StreamInterceptor(&hello.HelloRequest{}, &greeterSayHelloWebsocketServer{ServerStream: &testStream{
 			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", cnf.GetAdminAPIKey())),
 		}}, &grpc.StreamServerInfo{FullMethod: "/INTEGRATION.TESTSERVICE/GET234dd"}, func(srv interface{}, ss grpc.ServerStream) error {
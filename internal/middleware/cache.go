@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/begonia-org/begonia/gateway"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const cacheStatusHeader = "x-cache"
+
+// CachePlugin 缓存开启了响应缓存的GET方法的成功响应，
+// 优先级需要低于auth和rbac，避免命中缓存时跳过鉴权
+type CachePlugin struct {
+	store    gateway.ResponseCacheStore
+	priority int
+	name     string
+}
+
+// cacheStream 代理的一元调用，返回预先读取的请求并记录响应内容
+type cacheStream struct {
+	grpc.ServerStream
+	first    *emptypb.Empty
+	received bool
+	response []byte
+}
+
+func (s *cacheStream) RecvMsg(m interface{}) error {
+	if !s.received {
+		s.received = true
+		if msg, ok := m.(proto.Message); ok {
+			proto.Merge(msg, s.first)
+			return nil
+		}
+	}
+	return s.ServerStream.RecvMsg(m)
+}
+
+func (s *cacheStream) SendMsg(m interface{}) error {
+	if msg, ok := m.(*emptypb.Empty); ok {
+		s.response = msg.ProtoReflect().GetUnknown()
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+func NewCachePlugin(store gateway.ResponseCacheStore) *CachePlugin {
+	return &CachePlugin{store: store, name: "cache"}
+}
+
+func (p *CachePlugin) SetPriority(priority int) {
+	p.priority = priority
+}
+
+func (p *CachePlugin) Priority() int {
+	return p.priority
+}
+
+func (p *CachePlugin) Name() string {
+	return p.name
+}
+
+// identity 与rbac插件相同，Bearer token为用户，否则为app
+func (p *CachePlugin) identity(ctx context.Context) string {
+	if strings.Contains(getMetadataValue(ctx, "authorization"), "Bearer") {
+		return "user:" + getMetadataValue(ctx, gateway.XUID)
+	}
+	return "app:" + getMetadataValue(ctx, gateway.XIdentity)
+}
+
+func (p *CachePlugin) key(ctx context.Context, policy *gateway.MethodCache, req []byte) string {
+	headers := make(map[string]string)
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, name := range policy.VaryHeaders {
+		headers[strings.ToLower(name)] = strings.Join(md.Get(name), ",")
+	}
+	return policy.Key(req, headers, p.identity(ctx))
+}
+
+// directives 客户端通过Cache-Control: no-cache跳过缓存读取，no-store不写入缓存
+func (p *CachePlugin) directives(ctx context.Context) (noCache bool, noStore bool) {
+	control := strings.ToLower(getMetadataValue(ctx, "cache-control"))
+	return strings.Contains(control, "no-cache") || strings.Contains(control, "no-store"), strings.Contains(control, "no-store")
+}
+
+func (p *CachePlugin) get(ctx context.Context, key string) []byte {
+	val, err := p.store.Get(ctx, key)
+	if err != nil || len(val) == 0 {
+		return nil
+	}
+	return val
+}
+
+func (p *CachePlugin) set(ctx context.Context, policy *gateway.MethodCache, key string, val []byte) {
+	if err := p.store.Set(ctx, key, val, policy.TTL()); err != nil {
+		gateway.Log.Errorf(ctx, "set response cache of %s error: %s", key, err.Error())
+	}
+}
+
+func (p *CachePlugin) hit(ctx context.Context, hit bool) {
+	status := "MISS"
+	if hit {
+		status = "HIT"
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(cacheStatusHeader, status))
+}
+
+func (p *CachePlugin) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	policy := gateway.ResponseCaches().Get(info.FullMethod)
+	msg, ok := req.(proto.Message)
+	if policy == nil || !ok {
+		return handler(ctx, req)
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return handler(ctx, req)
+	}
+	key := p.key(ctx, policy, data)
+	noCache, noStore := p.directives(ctx)
+	if !noCache {
+		if val := p.get(ctx, key); val != nil {
+			cached := &anypb.Any{}
+			if err := proto.Unmarshal(val, cached); err == nil {
+				if rsp, err := cached.UnmarshalNew(); err == nil {
+					p.hit(ctx, true)
+					return rsp, nil
+				}
+			}
+		}
+	}
+	rsp, err := handler(ctx, req)
+	if err != nil || noStore {
+		return rsp, err
+	}
+	p.hit(ctx, false)
+	if out, ok := rsp.(proto.Message); ok {
+		cached, err := anypb.New(out)
+		if err != nil {
+			return rsp, nil
+		}
+		if val, err := proto.Marshal(cached); err == nil {
+			p.set(ctx, policy, key, val)
+		}
+	}
+	return rsp, nil
+}
+
+// StreamInterceptor 代理的一元调用通过流式接口转发，预先读取请求计算缓存key，命中时直接返回缓存的原始响应
+func (p *CachePlugin) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	policy := gateway.ResponseCaches().Get(info.FullMethod)
+	if policy == nil {
+		return handler(srv, ss)
+	}
+	ctx := ss.Context()
+	in := &emptypb.Empty{}
+	if err := ss.RecvMsg(in); err != nil {
+		if err == io.EOF {
+			return handler(srv, ss)
+		}
+		return err
+	}
+	key := p.key(ctx, policy, in.ProtoReflect().GetUnknown())
+	noCache, noStore := p.directives(ctx)
+	if !noCache {
+		if val := p.get(ctx, key); val != nil {
+			_ = ss.SetHeader(metadata.Pairs(cacheStatusHeader, "HIT"))
+			out := &emptypb.Empty{}
+			out.ProtoReflect().SetUnknown(val)
+			return ss.SendMsg(out)
+		}
+	}
+	_ = ss.SetHeader(metadata.Pairs(cacheStatusHeader, "MISS"))
+	stream := &cacheStream{ServerStream: ss, first: in}
+	if err := handler(srv, stream); err != nil {
+		return err
+	}
+	if !noStore && len(stream.response) > 0 {
+		p.set(ctx, policy, key, stream.response)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/middleware"
+	hello "github.com/begonia-org/go-sdk/api/example/v1"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type memoryCacheStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (m *memoryCacheStore) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if val, ok := m.data[key]; ok {
+		return val, nil
+	}
+	return nil, fmt.Errorf("%s not found", key)
+}
+
+func (m *memoryCacheStore) Set(ctx context.Context, key string, value []byte, exp time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = value
+	return nil
+}
+
+func TestCachePlugin(t *testing.T) {
+	c.Convey("test cache plugin", t, func() {
+		_, filename, _, _ := runtime.Caller(0)
+		pb, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(filepath.Dir(filename))), "testdata", "helloworld.pb"))
+		c.So(err, c.ShouldBeNil)
+		pd, err := gateway.NewDescriptionFromBinary(pb, filepath.Join("tmp", "test-cache"))
+		c.So(err, c.ShouldBeNil)
+		defer gateway.ResponseCaches().Delete(pd)
+		gateway.ResponseCaches().Register(pd, gateway.EndpointCache{"helloworld.Greeter": {TTL: 10, VaryIdentity: true}}, "v1")
+
+		store := &memoryCacheStore{data: make(map[string][]byte)}
+		plugin := middleware.NewCachePlugin(store)
+		plugin.SetPriority(2)
+		c.So(plugin.Name(), c.ShouldEqual, "cache")
+		c.So(plugin.Priority(), c.ShouldEqual, 2)
+
+		calls := 0
+		handler := func(ctx context.Context, req any) (any, error) {
+			calls++
+			return &hello.HelloReply{Message: req.(*hello.HelloRequest).Name}, nil
+		}
+		info := &grpc.UnaryServerInfo{FullMethod: "/helloworld.Greeter/SayHelloGet"}
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(gateway.XIdentity, "app-1"))
+		for i := 0; i < 2; i++ {
+			rsp, err := plugin.UnaryInterceptor(ctx, &hello.HelloRequest{Name: "cached"}, info, handler)
+			c.So(err, c.ShouldBeNil)
+			c.So(rsp.(*hello.HelloReply).Message, c.ShouldEqual, "cached")
+		}
+		c.So(calls, c.ShouldEqual, 1)
+		c.So(len(store.data), c.ShouldEqual, 1)
+
+		// 不同的身份和请求参数不共享缓存
+		other := metadata.NewIncomingContext(context.Background(), metadata.Pairs(gateway.XIdentity, "app-2"))
+		_, _ = plugin.UnaryInterceptor(other, &hello.HelloRequest{Name: "cached"}, info, handler)
+		_, _ = plugin.UnaryInterceptor(ctx, &hello.HelloRequest{Name: "other"}, info, handler)
+		c.So(calls, c.ShouldEqual, 3)
+
+		// no-cache跳过读取，no-store不写入
+		noStore := metadata.NewIncomingContext(context.Background(), metadata.Pairs(gateway.XIdentity, "app-1", "cache-control", "no-store"))
+		_, _ = plugin.UnaryInterceptor(noStore, &hello.HelloRequest{Name: "cached"}, info, handler)
+		_, _ = plugin.UnaryInterceptor(noStore, &hello.HelloRequest{Name: "no-store"}, info, handler)
+		c.So(calls, c.ShouldEqual, 5)
+		c.So(len(store.data), c.ShouldEqual, 3)
+
+		// 没有开启缓存的方法
+		_, _ = plugin.UnaryInterceptor(ctx, &hello.HelloRequest{Name: "cached"}, &grpc.UnaryServerInfo{FullMethod: "/helloworld.Greeter/SayHello"}, handler)
+		c.So(calls, c.ShouldEqual, 6)
+
+		// 代理的请求
+		data, err := proto.Marshal(&hello.HelloRequest{Name: "proxy"})
+		c.So(err, c.ShouldBeNil)
+		streamCalls := 0
+		streamHandler := func(srv any, ss grpc.ServerStream) error {
+			streamCalls++
+			in := &emptypb.Empty{}
+			if err := ss.RecvMsg(in); err != nil {
+				return err
+			}
+			req := &hello.HelloRequest{}
+			if err := proto.Unmarshal(in.ProtoReflect().GetUnknown(), req); err != nil {
+				return err
+			}
+			out, _ := proto.Marshal(&hello.HelloReply{Message: req.Name})
+			reply := &emptypb.Empty{}
+			reply.ProtoReflect().SetUnknown(out)
+			return ss.SendMsg(reply)
+		}
+		streamInfo := &grpc.StreamServerInfo{FullMethod: "/helloworld.Greeter/SayHelloError"}
+		for i := 0; i < 2; i++ {
+			stream := &transformTestStream{testStream: testStream{ctx: ctx}, recv: data}
+			err = plugin.StreamInterceptor(nil, stream, streamInfo, streamHandler)
+			c.So(err, c.ShouldBeNil)
+			reply := &hello.HelloReply{}
+			c.So(proto.Unmarshal(stream.sent, reply), c.ShouldBeNil)
+			c.So(reply.Message, c.ShouldEqual, "proxy")
+		}
+		c.So(streamCalls, c.ShouldEqual, 1)
+
+		// 端点更新后缓存失效
+		gateway.ResponseCaches().Register(pd, gateway.EndpointCache{"helloworld.Greeter": {TTL: 10, VaryIdentity: true}}, "v2")
+		_, _ = plugin.UnaryInterceptor(ctx, &hello.HelloRequest{Name: "cached"}, info, handler)
+		c.So(calls, c.ShouldEqual, 7)
+	})
+}
@@ -34,6 +34,13 @@ import (
 //		w.Header().Set("Content-Type", "application/json")
 //		return nil
 //	}
+
+// HttpResponseExtender 内置服务在http响应的data中追加sdk消息没有定义的字段，
+// data为rsp转换后的结构，只在调用成功时执行
+type HttpResponseExtender interface {
+	ExtendHttpResponse(ctx context.Context, fullMethod string, rsp interface{}, data *structpb.Struct) error
+}
+
 type HttpStream struct {
 	grpc.ServerStream
 	FullMethod string
@@ -173,7 +180,13 @@ func (h *Http) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc
 			if _, ok := rsp.(*httpbody.HttpBody); ok {
 				return rsp, err
 			}
-			return grpcToHttpResponse(rsp, err)
+			httpRsp, err := grpcToHttpResponse(rsp, err)
+			if extender, ok := info.Server.(HttpResponseExtender); ok && err == nil && rsp != nil {
+				if err := extender.ExtendHttpResponse(ctx, info.FullMethod, rsp, httpRsp.Data); err != nil {
+					return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "extend_http_response")
+				}
+			}
+			return httpRsp, err
 		}
 	}
 
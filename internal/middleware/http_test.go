@@ -15,6 +15,7 @@ import (
 	gosdk "github.com/begonia-org/go-sdk"
 	hello "github.com/begonia-org/go-sdk/api/example/v1"
 	user "github.com/begonia-org/go-sdk/api/user/v1"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
 	c "github.com/smartystreets/goconvey/convey"
 	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc"
@@ -22,6 +23,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type testStream struct {
@@ -67,6 +69,19 @@ func (x *greeterSayHelloWebsocketServer) Context() context.Context {
 	return x.ServerStream.Context()
 
 }
+
+type extenderServer struct {
+	err error
+}
+
+func (e *extenderServer) ExtendHttpResponse(ctx context.Context, fullMethod string, rsp interface{}, data *structpb.Struct) error {
+	if e.err != nil {
+		return e.err
+	}
+	data.Fields["extra"] = structpb.NewStringValue(fullMethod)
+	return nil
+}
+
 func TestStreamInterceptor(t *testing.T) {
 	c.Convey("test stream interceptor", t, func() {
 		mid := middleware.NewHttp()
@@ -185,3 +200,32 @@ func TestUnaryInterceptor(t *testing.T) {
 
 	})
 }
+
+func TestUnaryInterceptorExtender(t *testing.T) {
+	c.Convey("test unary interceptor http response extender", t, func() {
+		mid := middleware.NewHttp()
+		R := routers.Get()
+		_, filename, _, _ := runtime.Caller(0)
+		pbFile := filepath.Join(filepath.Dir(filepath.Dir(filepath.Dir(filename))), "testdata")
+
+		pd, err := gateway.NewDescription(pbFile)
+		c.So(err, c.ShouldBeNil)
+		R.LoadAllRouters(pd)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("grpcgateway-accept", "application/json"))
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &hello.HelloReply{Message: "hello"}, nil
+		}
+		info := &grpc.UnaryServerInfo{Server: &extenderServer{}, FullMethod: "/INTEGRATION.TESTSERVICE/GET"}
+		rsp, err := mid.UnaryInterceptor(ctx, &hello.HelloRequest{}, info, handler)
+		c.So(err, c.ShouldBeNil)
+		data := rsp.(*common.HttpResponse).Data
+		c.So(data.Fields["extra"].GetStringValue(), c.ShouldEqual, "/INTEGRATION.TESTSERVICE/GET")
+		c.So(data.Fields["message"].GetStringValue(), c.ShouldEqual, "hello")
+
+		info.Server = &extenderServer{err: fmt.Errorf("extend error")}
+		rsp, err = mid.UnaryInterceptor(ctx, &hello.HelloRequest{}, info, handler)
+		c.So(err, c.ShouldNotBeNil)
+		c.So(err.Error(), c.ShouldContainSubstring, "extend error")
+		c.So(rsp, c.ShouldBeNil)
+	})
+}
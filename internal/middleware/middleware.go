@@ -15,6 +15,8 @@ import (
 	"github.com/begonia-org/go-sdk/logger"
 	"github.com/google/wire"
 	"github.com/spark-lence/tiga"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 )
 
@@ -28,8 +30,12 @@ func New(config *config.Config,
 	user *biz.AuthzUsecase,
 	log logger.Logger,
 	authz *biz.AccessKeyAuth,
+	rbac *biz.RBACUsecase,
+	oidc *biz.OIDCUsecase,
+	audit *biz.AuditUsecase,
+	cache gateway.ResponseCacheStore,
 ) *PluginsApply {
-	jwt := auth.NewJWTAuth(config, rdb, user, log)
+	jwt := auth.NewJWTAuth(config, rdb, user, oidc, log)
 	ak := auth.NewAccessKeyAuth(authz, config, log)
 	apiKey := auth.NewApiKeyAuth(config)
 	plugins := map[string]gosdk.LocalPlugin{
@@ -41,6 +47,11 @@ func New(config *config.Config,
 		"auth":              auth.NewAuth(ak, jwt, apiKey),
 		"params_validator":  NewParamsValidator(),
 		"only_api_key_auth": apiKey,
+		"rate_limit":        NewRateLimitPlugin(config, NewRedisRateLimiter(rdb), log),
+		"rbac":              NewRBACPlugin(rbac, config, log),
+		"audit":             NewAuditPlugin(audit),
+		"transform":         NewTransformPlugin(),
+		"cache":             NewCachePlugin(cache),
 		// "logger":NewLoggerMiddleware(log),
 	}
 	pluginsApply := NewPluginsApply()
@@ -90,7 +101,7 @@ func (p *PluginsApply) Register(plugin gosdk.LocalPlugin, priority int) {
 func (p *PluginsApply) UnaryInterceptorChains() []grpc.UnaryServerInterceptor {
 	chains := make([]grpc.UnaryServerInterceptor, 0)
 	for _, plugin := range p.Plugins {
-		chains = append(chains, plugin.(gosdk.LocalPlugin).UnaryInterceptor)
+		chains = append(chains, timedUnaryInterceptor(plugin.Name(), plugin.(gosdk.LocalPlugin).UnaryInterceptor))
 	}
 	return chains
 }
@@ -98,7 +109,44 @@ func (p *PluginsApply) UnaryInterceptorChains() []grpc.UnaryServerInterceptor {
 func (p *PluginsApply) StreamInterceptorChains() []grpc.StreamServerInterceptor {
 	chains := make([]grpc.StreamServerInterceptor, 0)
 	for _, plugin := range p.Plugins {
-		chains = append(chains, plugin.(gosdk.LocalPlugin).StreamInterceptor)
+		chains = append(chains, timedStreamInterceptor(plugin.Name(), plugin.(gosdk.LocalPlugin).StreamInterceptor))
 	}
 	return chains
 }
+
+// timedUnaryInterceptor 为插件创建子span，并统计插件自身的耗时，不包含后续插件和服务的处理时间
+func timedUnaryInterceptor(name string, interceptor grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var downstream time.Duration
+		ctx, span := gateway.StartSpan(ctx, fmt.Sprintf("plugin %s", name), trace.WithAttributes(attribute.String("begonia.plugin", name)))
+		start := time.Now()
+		rsp, err := interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			begin := time.Now()
+			defer func() {
+				downstream += time.Since(begin)
+			}()
+			return handler(ctx, req)
+		})
+		gateway.ObservePlugin(name, info.FullMethod, time.Since(start)-downstream)
+		gateway.EndSpan(span, err)
+		return rsp, err
+	}
+}
+
+func timedStreamInterceptor(name string, interceptor grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		var downstream time.Duration
+		ctx, span := gateway.StartSpan(ss.Context(), fmt.Sprintf("plugin %s", name), trace.WithAttributes(attribute.String("begonia.plugin", name)))
+		start := time.Now()
+		err := interceptor(srv, gateway.WithStreamContext(ss, ctx), info, func(srv any, ss grpc.ServerStream) error {
+			begin := time.Now()
+			defer func() {
+				downstream += time.Since(begin)
+			}()
+			return handler(srv, ss)
+		})
+		gateway.ObservePlugin(name, info.FullMethod, time.Since(start)-downstream)
+		gateway.EndSpan(span, err)
+		return err
+	}
+}
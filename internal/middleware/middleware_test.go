@@ -28,11 +28,16 @@ func TestMiddlewareUnaryInterceptorChains(t *testing.T) {
 		user := data.NewUserRepo(config, gateway.Log)
 		userAuth := crypto.NewUsersAuth(cnf)
 		authzRepo := data.NewAuthzRepo(config, gateway.Log)
-		authz := biz.NewAuthzUsecase(authzRepo, user, gateway.Log, userAuth, cnf)
+		authz := biz.NewAuthzUsecase(authzRepo, user, gateway.Log, userAuth, cnf, nil)
 		repo := data.NewAppRepo(config, gateway.Log)
 
 		akBiz := biz.NewAccessKeyAuth(repo, cnf, gateway.Log)
-		mid := middleware.New(cnf, tiga.NewRedisDao(config), authz, gateway.Log, akBiz)
+		rbacBiz := biz.NewRBACUsecase(data.NewRBACRepo(config, gateway.Log), cnf)
+		sessions := biz.NewSessionUsecase(data.NewSessionRepo(config, gateway.Log), user, authz, cnf, gateway.Log)
+		oidcBiz := biz.NewOIDCUsecase(data.NewOIDCRepo(config, gateway.Log), user, sessions, cnf, gateway.Log)
+		auditRepo := data.NewAuditRepo(config, gateway.Log)
+		auditBiz := biz.NewAuditUsecase(auditRepo, auditRepo, cnf, gateway.Log)
+		mid := middleware.New(cnf, tiga.NewRedisDao(config), authz, gateway.Log, akBiz, rbacBiz, oidcBiz, auditBiz, data.NewLayered(config, gateway.Log))
 		// mid.SetPriority(1)
 		c.So(len(mid.StreamInterceptorChains()), c.ShouldBeGreaterThanOrEqualTo, 0)
 		c.So(len(mid.UnaryInterceptorChains()), c.ShouldBeGreaterThanOrEqualTo, 0)
@@ -42,7 +47,7 @@ func TestMiddlewareUnaryInterceptorChains(t *testing.T) {
 		patch := gomonkey.ApplyFuncReturn((*cfg.Config).GetPlugins, plugins)
 		defer patch.Reset()
 		f := func() {
-			middleware.New(cnf, tiga.NewRedisDao(config), authz, gateway.Log, akBiz)
+			middleware.New(cnf, tiga.NewRedisDao(config), authz, gateway.Log, akBiz, rbacBiz, oidcBiz, auditBiz, data.NewLayered(config, gateway.Log))
 
 		}
 		c.So(f, c.ShouldPanicWith, "plugin test not found")
@@ -0,0 +1,325 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	gosdk "github.com/begonia-org/go-sdk"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"github.com/begonia-org/go-sdk/logger"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/spark-lence/tiga"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	RateLimitByAccessKey = "access_key"
+	RateLimitByUID       = "uid"
+	RateLimitByMethod    = "method"
+	RateLimitByIP        = "ip"
+
+	TokenBucket   = "token_bucket"
+	SlidingWindow = "sliding_window"
+)
+
+// tokenBucketScript 令牌桶，使用redis的时间避免多个网关实例之间的时钟误差
+// KEYS[1] 桶 ARGV[1] 周期(毫秒) ARGV[2] 每个周期生成的令牌数 ARGV[3] 桶容量
+// 返回 是否通过,剩余令牌数,需要等待的毫秒数,令牌桶填满需要的毫秒数
+var tokenBucketScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local rate = tonumber(ARGV[2]) / tonumber(ARGV[1])
+local capacity = tonumber(ARGV[3])
+local data = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(data[1]) or capacity
+local ts = tonumber(data[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity / rate) + 1000)
+return {allowed, math.floor(tokens), wait, math.ceil((capacity - tokens) / rate)}
+`)
+
+// slidingWindowScript 滑动窗口日志
+// KEYS[1] 窗口 ARGV[1] 窗口大小(毫秒) ARGV[2] 窗口内允许的请求数 ARGV[3] 请求id
+// 返回 是否通过,剩余请求数,需要等待的毫秒数,最早的请求移出窗口需要的毫秒数
+var slidingWindowScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], 0, now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[3])
+	redis.call('PEXPIRE', KEYS[1], window)
+	count = count + 1
+	allowed = 1
+end
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+local reset = window
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+if allowed == 1 then
+	return {1, limit - count, 0, reset}
+end
+return {0, 0, reset, reset}
+`)
+
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+// RateLimiter 限流器，key为规则名称和限流维度组成的唯一标识
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, rule *config.RateLimitRule) (*RateLimitResult, error)
+}
+
+type redisRateLimiter struct {
+	rdb *tiga.RedisDao
+}
+
+func NewRedisRateLimiter(rdb *tiga.RedisDao) RateLimiter {
+	return &redisRateLimiter{rdb: rdb}
+}
+
+func (r *redisRateLimiter) Allow(ctx context.Context, key string, rule *config.RateLimitRule) (*RateLimitResult, error) {
+	period := time.Duration(rule.Period) * time.Second
+	var (
+		ret []int64
+		err error
+	)
+	switch rule.Algorithm {
+	case SlidingWindow:
+		ret, err = slidingWindowScript.Run(ctx, r.rdb.GetClient(), []string{key}, period.Milliseconds(), rule.Limit, uuid.New().String()).Int64Slice()
+	default:
+		ret, err = tokenBucketScript.Run(ctx, r.rdb.GetClient(), []string{key}, period.Milliseconds(), rule.Limit, rateLimitBurst(rule)).Int64Slice()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("run rate limit script error:%w", err)
+	}
+	if len(ret) != 4 {
+		return nil, fmt.Errorf("unexpected rate limit script result:%v", ret)
+	}
+	return &RateLimitResult{
+		Allowed:    ret[0] == 1,
+		Limit:      rule.Limit,
+		Remaining:  int(ret[1]),
+		RetryAfter: time.Duration(ret[2]) * time.Millisecond,
+		Reset:      time.Duration(ret[3]) * time.Millisecond,
+	}, nil
+}
+
+// rateLimitBurst 令牌桶容量，默认等于每个周期的请求数
+func rateLimitBurst(rule *config.RateLimitRule) int {
+	if rule.Burst > 0 {
+		return rule.Burst
+	}
+	return rule.Limit
+}
+
+type RateLimitPlugin struct {
+	limiter  RateLimiter
+	rules    []*config.RateLimitRule
+	config   *config.Config
+	log      logger.Logger
+	priority int
+	name     string
+}
+
+func NewRateLimitPlugin(config *config.Config, limiter RateLimiter, log logger.Logger) *RateLimitPlugin {
+	rules, err := config.GetRateLimitRules()
+	if err != nil {
+		panic(fmt.Sprintf("get rate limit rules error:%v", err))
+	}
+	for _, rule := range rules {
+		if rule.Limit <= 0 || rule.Period <= 0 {
+			panic(fmt.Sprintf("invalid rate limit rule %s", rule.Name))
+		}
+		switch rule.Key {
+		case RateLimitByAccessKey, RateLimitByUID, RateLimitByMethod, RateLimitByIP:
+		default:
+			panic(fmt.Sprintf("unknown rate limit key %s of rule %s", rule.Key, rule.Name))
+		}
+	}
+	return &RateLimitPlugin{
+		limiter: limiter,
+		rules:   rules,
+		config:  config,
+		log:     log,
+		name:    "rate_limit",
+	}
+}
+
+func (r *RateLimitPlugin) SetPriority(priority int) {
+	r.priority = priority
+}
+func (r *RateLimitPlugin) Priority() int {
+	return r.priority
+}
+func (r *RateLimitPlugin) Name() string {
+	return r.name
+}
+
+func (r *RateLimitPlugin) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	md, err := r.check(ctx, info.FullMethod)
+	if len(md) > 0 {
+		_ = grpc.SetHeader(ctx, md)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (r *RateLimitPlugin) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	md, err := r.check(ss.Context(), info.FullMethod)
+	if len(md) > 0 {
+		_ = ss.SetHeader(md)
+	}
+	if err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// check 依次检查所有匹配的规则，返回最严格规则的限流信息
+func (r *RateLimitPlugin) check(ctx context.Context, fullMethod string) (metadata.MD, error) {
+	var strictest *RateLimitResult
+	for _, rule := range r.rules {
+		if !r.match(ctx, rule, fullMethod) {
+			continue
+		}
+		key := r.limitKey(ctx, rule, fullMethod)
+		if key == "" {
+			continue
+		}
+		rsp, err := r.limiter.Allow(ctx, r.config.GetRateLimitKey(rule.Name, key), rule)
+		if err != nil {
+			// redis不可用时放行，避免影响正常请求
+			r.log.Errorf(ctx, "rate limit rule %s error:%s", rule.Name, err.Error())
+			continue
+		}
+		if !rsp.Allowed {
+			md := rateLimitHeaders(rsp)
+			md.Set(gosdk.GetMetadataKey("Retry-After"), strconv.Itoa(int(math.Ceil(rsp.RetryAfter.Seconds()))))
+			return md, gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrRateLimited, rule.Name), int32(common.Code_RESOURCE_EXHAUSTED), codes.ResourceExhausted, "rate_limit")
+		}
+		if strictest == nil || rsp.Remaining < strictest.Remaining {
+			strictest = rsp
+		}
+	}
+	if strictest == nil {
+		return nil, nil
+	}
+	return rateLimitHeaders(strictest), nil
+}
+
+func rateLimitHeaders(rsp *RateLimitResult) metadata.MD {
+	md := metadata.MD{}
+	md.Set(gosdk.GetMetadataKey("X-RateLimit-Limit"), strconv.Itoa(rsp.Limit))
+	md.Set(gosdk.GetMetadataKey("X-RateLimit-Remaining"), strconv.Itoa(rsp.Remaining))
+	md.Set(gosdk.GetMetadataKey("X-RateLimit-Reset"), strconv.Itoa(int(math.Ceil(rsp.Reset.Seconds()))))
+	return md
+}
+
+// match 规则是否对当前请求生效
+func (r *RateLimitPlugin) match(ctx context.Context, rule *config.RateLimitRule, fullMethod string) bool {
+	if len(rule.Methods) > 0 {
+		matched := false
+		for _, method := range rule.Methods {
+			method = "/" + strings.TrimPrefix(method, "/")
+			if strings.EqualFold(method, fullMethod) || strings.HasPrefix(strings.ToLower(fullMethod), strings.ToLower(method)+"/") {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(rule.Apps) > 0 {
+		accessKey := getMetadataValue(ctx, gateway.XAccessKey)
+		appid := getMetadataValue(ctx, gateway.XIdentity)
+		for _, app := range rule.Apps {
+			if app != "" && (app == accessKey || app == appid) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// limitKey 获取请求在规则中的限流维度，无法获取时不限流
+func (r *RateLimitPlugin) limitKey(ctx context.Context, rule *config.RateLimitRule, fullMethod string) string {
+	switch rule.Key {
+	case RateLimitByAccessKey:
+		return getMetadataValue(ctx, gateway.XAccessKey)
+	case RateLimitByUID:
+		return getMetadataValue(ctx, gateway.XUID)
+	case RateLimitByMethod:
+		return fullMethod
+	case RateLimitByIP:
+		return clientIP(ctx)
+	}
+	return ""
+}
+
+func getMetadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// clientIP 获取客户端ip，
+// http网关通过本地回环地址转发请求，此时使用网关记录的客户端地址
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if remote := getMetadataValue(ctx, gateway.XRemoteAddr); remote != "" {
+			if remoteHost, _, err := net.SplitHostPort(remote); err == nil {
+				return remoteHost
+			}
+			return remote
+		}
+	}
+	return host
+}
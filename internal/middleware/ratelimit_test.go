@@ -0,0 +1,126 @@
+package middleware_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/begonia-org/begonia"
+	"github.com/begonia-org/begonia/config"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/middleware"
+	cfg "github.com/begonia-org/begonia/internal/pkg/config"
+	gosdk "github.com/begonia-org/go-sdk"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// memoryRateLimiter 按key计数的固定窗口限流器
+type memoryRateLimiter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (m *memoryRateLimiter) Allow(ctx context.Context, key string, rule *cfg.RateLimitRule) (*middleware.RateLimitResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[key]++
+	remaining := rule.Limit - m.counts[key]
+	if remaining < 0 {
+		return &middleware.RateLimitResult{Limit: rule.Limit, RetryAfter: 1500 * time.Millisecond, Reset: 1500 * time.Millisecond}, nil
+	}
+	return &middleware.RateLimitResult{Allowed: true, Limit: rule.Limit, Remaining: remaining, Reset: time.Second}, nil
+}
+
+type rateLimitTestStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *rateLimitTestStream) Context() context.Context {
+	return s.ctx
+}
+func (s *rateLimitTestStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestRateLimitPlugin(t *testing.T) {
+	c.Convey("test rate limit plugin", t, func() {
+		env := "dev"
+		if begonia.Env != "" {
+			env = begonia.Env
+		}
+		conf := config.ReadConfig(env)
+		conf.Set("gateway.rate_limit.rules", []map[string]interface{}{
+			{"name": "ip", "key": "ip", "limit": 2, "period": 1},
+			{"name": "app", "key": "access_key", "limit": 1, "period": 1, "apps": []string{"test-ak"}, "methods": []string{"helloworld.Greeter"}},
+		})
+		defer conf.Set("gateway.rate_limit.rules", nil)
+		cnf := cfg.NewConfig(conf)
+		plugin := middleware.NewRateLimitPlugin(cnf, &memoryRateLimiter{counts: make(map[string]int)}, gateway.Log)
+		plugin.SetPriority(3)
+		c.So(plugin.Name(), c.ShouldEqual, "rate_limit")
+		c.So(plugin.Priority(), c.ShouldEqual, 3)
+
+		newStream := func(addr string, md metadata.MD) *rateLimitTestStream {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 12345}})
+			return &rateLimitTestStream{ctx: metadata.NewIncomingContext(ctx, md)}
+		}
+		handler := func(srv interface{}, ss grpc.ServerStream) error {
+			return nil
+		}
+		info := &grpc.StreamServerInfo{FullMethod: "/helloworld.Greeter/SayHello"}
+		// http网关转发的请求按真实的客户端地址限流
+		for i := 0; i < 2; i++ {
+			ss := newStream("127.0.0.1", metadata.Pairs(gateway.XRemoteAddr, "10.0.0.1:5678"))
+			c.So(plugin.StreamInterceptor(nil, ss, info, handler), c.ShouldBeNil)
+			c.So(ss.header.Get(gosdk.GetMetadataKey("X-RateLimit-Remaining")), c.ShouldResemble, []string{[]string{"1", "0"}[i]})
+		}
+		ss := newStream("127.0.0.1", metadata.Pairs(gateway.XRemoteAddr, "10.0.0.1:5678"))
+		err := plugin.StreamInterceptor(nil, ss, info, handler)
+		c.So(status.Code(err), c.ShouldEqual, codes.ResourceExhausted)
+		c.So(ss.header.Get(gosdk.GetMetadataKey("Retry-After")), c.ShouldResemble, []string{"2"})
+		c.So(ss.header.Get(gosdk.GetMetadataKey("X-RateLimit-Limit")), c.ShouldResemble, []string{"2"})
+
+		// 直连的grpc请求不信任x-http-forwarded-for
+		ss = newStream("10.0.0.2", metadata.Pairs(gateway.XRemoteAddr, "10.0.0.1:5678"))
+		c.So(plugin.StreamInterceptor(nil, ss, info, handler), c.ShouldBeNil)
+
+		// app规则只对指定的app和服务生效
+		ss = newStream("10.0.0.3", metadata.Pairs(gateway.XAccessKey, "test-ak"))
+		c.So(plugin.StreamInterceptor(nil, ss, info, handler), c.ShouldBeNil)
+		ss = newStream("10.0.0.3", metadata.Pairs(gateway.XAccessKey, "test-ak"))
+		c.So(status.Code(plugin.StreamInterceptor(nil, ss, info, handler)), c.ShouldEqual, codes.ResourceExhausted)
+		ss = newStream("10.0.0.4", metadata.Pairs(gateway.XAccessKey, "test-ak"))
+		c.So(plugin.StreamInterceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/helloworld.Other/SayHello"}, handler), c.ShouldBeNil)
+		ss = newStream("10.0.0.5", metadata.Pairs(gateway.XAccessKey, "other-ak"))
+		c.So(plugin.StreamInterceptor(nil, ss, info, handler), c.ShouldBeNil)
+
+		// 一元调用
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.6"), Port: 12345}})
+		unaryInfo := &grpc.UnaryServerInfo{FullMethod: "/helloworld.Greeter/SayHello"}
+		unaryHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		}
+		for i := 0; i < 2; i++ {
+			rsp, err := plugin.UnaryInterceptor(ctx, nil, unaryInfo, unaryHandler)
+			c.So(err, c.ShouldBeNil)
+			c.So(rsp, c.ShouldEqual, "ok")
+		}
+		_, err = plugin.UnaryInterceptor(ctx, nil, unaryInfo, unaryHandler)
+		c.So(status.Code(err), c.ShouldEqual, codes.ResourceExhausted)
+
+		conf.Set("gateway.rate_limit.rules", []map[string]interface{}{{"name": "unknown", "key": "unknown", "limit": 1, "period": 1}})
+		c.So(func() {
+			middleware.NewRateLimitPlugin(cnf, &memoryRateLimiter{counts: make(map[string]int)}, gateway.Log)
+		}, c.ShouldPanicWith, "unknown rate limit key unknown of rule unknown")
+	})
+}
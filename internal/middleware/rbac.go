@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"sync"
+
+	api "github.com/begonia-org/begonia/api/rbac/v1"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/begonia/internal/pkg/routers"
+	gosdk "github.com/begonia-org/go-sdk"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"github.com/begonia-org/go-sdk/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// PermissionChecker 检查用户或app是否拥有权限
+type PermissionChecker interface {
+	Check(ctx context.Context, subjectType api.SubjectType, subject, permission string) (bool, error)
+}
+
+// RBACPlugin 基于角色的访问控制，需要在auth插件之后执行，
+// 使用auth插件解析出的x-uid或appid检查是否拥有方法需要的权限，
+// 方法的权限由permission选项指定，未指定时使用grpc方法全名
+type RBACPlugin struct {
+	checker  PermissionChecker
+	config   *config.Config
+	log      logger.Logger
+	priority int
+	name     string
+}
+
+func NewRBACPlugin(checker PermissionChecker, config *config.Config, log logger.Logger) *RBACPlugin {
+	return &RBACPlugin{checker: checker, config: config, log: log, name: "rbac"}
+}
+
+func (r *RBACPlugin) SetPriority(priority int) {
+	r.priority = priority
+}
+
+func (r *RBACPlugin) Priority() int {
+	return r.priority
+}
+
+func (r *RBACPlugin) Name() string {
+	return r.name
+}
+
+// authSubject 与auth插件相同，Bearer token为用户，否则为app
+func authSubject(ctx context.Context) (api.SubjectType, string) {
+	if strings.Contains(getMetadataValue(ctx, "authorization"), "Bearer") {
+		return api.SubjectType_SUBJECT_USER, getMetadataValue(ctx, gateway.XUID)
+	}
+	return api.SubjectType_SUBJECT_APP, getMetadataValue(ctx, gateway.XIdentity)
+}
+
+func (r *RBACPlugin) check(ctx context.Context, fullMethod string) error {
+	router := routers.Get().GetRouteByGrpcMethod(fullMethod)
+	if router == nil || !router.AuthRequired {
+		return nil
+	}
+	// 管理员api key拥有所有权限
+	if apikey := getMetadataValue(ctx, "x-api-key"); apikey != "" && subtle.ConstantTimeCompare([]byte(apikey), []byte(r.config.GetAdminAPIKey())) == 1 {
+		return nil
+	}
+	permission := router.Permission
+	if permission == "" {
+		permission = strings.TrimPrefix(fullMethod, "/")
+	}
+	subjectType, subject := authSubject(ctx)
+	if subject == "" {
+		return gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrPermissionDenied, permission), int32(common.Code_PREMISSION_DENIED), codes.PermissionDenied, "rbac")
+	}
+	ok, err := r.checker.Check(ctx, subjectType, subject, permission)
+	if err != nil {
+		r.log.Errorf(ctx, "check permission %s for %s error:%v", permission, subject, err)
+		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "rbac")
+	}
+	if !ok {
+		return gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrPermissionDenied, permission), int32(common.Code_PREMISSION_DENIED), codes.PermissionDenied, "rbac")
+	}
+	return nil
+}
+
+func (r *RBACPlugin) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := r.check(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor auth插件在收到第一个消息时才完成鉴权，因此在第一个消息到达后检查权限
+func (r *RBACPlugin) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &rbacServerStream{ServerStream: ss, plugin: r, fullMethod: info.FullMethod})
+}
+
+type rbacServerStream struct {
+	grpc.ServerStream
+	plugin     *RBACPlugin
+	fullMethod string
+	once       sync.Once
+	err        error
+}
+
+func (s *rbacServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.once.Do(func() {
+		s.err = s.plugin.check(s.ServerStream.Context(), s.fullMethod)
+	})
+	return s.err
+}
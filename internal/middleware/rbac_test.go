@@ -0,0 +1,117 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/begonia-org/begonia"
+	api "github.com/begonia-org/begonia/api/rbac/v1"
+	"github.com/begonia-org/begonia/config"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/middleware"
+	cfg "github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/begonia/internal/pkg/routers"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type memoryPermissionChecker struct {
+	permissions map[string][]string
+}
+
+func (m *memoryPermissionChecker) Check(ctx context.Context, subjectType api.SubjectType, subject, permission string) (bool, error) {
+	if subject == "broken" {
+		return false, fmt.Errorf("database unavailable")
+	}
+	return biz.MatchPermission(m.permissions[fmt.Sprintf("%s:%s", subjectType, subject)], permission), nil
+}
+
+type rbacTestStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *rbacTestStream) Context() context.Context {
+	return s.ctx
+}
+func (s *rbacTestStream) RecvMsg(m interface{}) error {
+	return nil
+}
+
+func TestRBACPlugin(t *testing.T) {
+	c.Convey("test rbac plugin", t, func() {
+		env := "dev"
+		if begonia.Env != "" {
+			env = begonia.Env
+		}
+		cnf := cfg.NewConfig(config.ReadConfig(env))
+		checker := &memoryPermissionChecker{permissions: map[string][]string{
+			"SUBJECT_USER:user-1": {"rbac:roles:read"},
+			"SUBJECT_APP:app-1":   {"test.RBAC/*"},
+		}}
+		plugin := middleware.NewRBACPlugin(checker, cnf, gateway.Log)
+		plugin.SetPriority(3)
+		c.So(plugin.Name(), c.ShouldEqual, "rbac")
+		c.So(plugin.Priority(), c.ShouldEqual, 3)
+
+		R := routers.Get()
+		R.AddRoute("/test/rbac/roles", &routers.APIMethodDetails{GrpcFullRouter: "/TEST.RBAC/LISTROLES", AuthRequired: true, Permission: "rbac:roles:read"})
+		R.AddRoute("/test/rbac/roles/write", &routers.APIMethodDetails{GrpcFullRouter: "/TEST.RBAC/PUTROLE", AuthRequired: true, Permission: "rbac:roles:write"})
+		R.AddRoute("/test/rbac/get", &routers.APIMethodDetails{GrpcFullRouter: "/TEST.RBAC/GET", AuthRequired: true})
+		R.AddRoute("/test/rbac/public", &routers.APIMethodDetails{GrpcFullRouter: "/TEST.RBAC/PUBLIC", AuthRequired: false})
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		}
+		call := func(method string, md metadata.MD) error {
+			_, err := plugin.UnaryInterceptor(metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+			return err
+		}
+		user := func(uid string) metadata.MD {
+			return metadata.Pairs("authorization", "Bearer token", gateway.XUID, uid)
+		}
+		app := func(appid string) metadata.MD {
+			return metadata.Pairs("authorization", "ak signature", gateway.XAccessKey, "ak", gateway.XIdentity, appid)
+		}
+
+		// 使用方法选项中的权限
+		c.So(call("/test.RBAC/ListRoles", user("user-1")), c.ShouldBeNil)
+		err := call("/test.RBAC/PutRole", user("user-1"))
+		c.So(status.Code(err), c.ShouldEqual, codes.PermissionDenied)
+		c.So(err.Error(), c.ShouldContainSubstring, "rbac:roles:write")
+
+		// 未设置选项时使用方法全名
+		c.So(call("/test.RBAC/Get", app("app-1")), c.ShouldBeNil)
+		c.So(status.Code(call("/test.RBAC/Get", user("user-1"))), c.ShouldEqual, codes.PermissionDenied)
+
+		// app请求携带x-uid时仍按app检查
+		md := app("app-2")
+		md.Set(gateway.XUID, "user-1")
+		c.So(status.Code(call("/test.RBAC/ListRoles", md)), c.ShouldEqual, codes.PermissionDenied)
+
+		// 不需要鉴权的方法和未知方法不检查权限
+		c.So(call("/test.RBAC/Public", metadata.MD{}), c.ShouldBeNil)
+		c.So(call("/test.RBAC/Unknown", metadata.MD{}), c.ShouldBeNil)
+
+		// 管理员api key拥有所有权限
+		c.So(call("/test.RBAC/PutRole", metadata.Pairs("x-api-key", cnf.GetAdminAPIKey())), c.ShouldBeNil)
+		c.So(status.Code(call("/test.RBAC/PutRole", metadata.Pairs("x-api-key", "invalid"))), c.ShouldEqual, codes.PermissionDenied)
+
+		c.So(status.Code(call("/test.RBAC/Get", user("broken"))), c.ShouldEqual, codes.Internal)
+
+		// 流式调用在收到第一个消息后检查
+		streamHandler := func(srv interface{}, ss grpc.ServerStream) error {
+			return ss.RecvMsg(nil)
+		}
+		ss := &rbacTestStream{ctx: metadata.NewIncomingContext(context.Background(), app("app-1"))}
+		c.So(plugin.StreamInterceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/test.RBAC/Get"}, streamHandler), c.ShouldBeNil)
+		ss = &rbacTestStream{ctx: metadata.NewIncomingContext(context.Background(), user("user-1"))}
+		err = plugin.StreamInterceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/test.RBAC/Get"}, streamHandler)
+		c.So(status.Code(err), c.ShouldEqual, codes.PermissionDenied)
+	})
+}
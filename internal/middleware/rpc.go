@@ -6,11 +6,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/begonia-org/begonia/gateway"
 	goloadbalancer "github.com/begonia-org/go-loadbalancer"
 	lb "github.com/begonia-org/go-loadbalancer"
 	gosdk "github.com/begonia-org/go-sdk"
 	api "github.com/begonia-org/go-sdk/api/plugin/v1"
 	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -97,12 +100,20 @@ func (p *pluginImpl) getEndpoint(ctx context.Context) (lb.Endpoint, error) {
 	return endpoint, nil
 
 }
-func (p *pluginImpl) Apply(ctx context.Context, in interface{}, fullMethodName string) (*api.PluginResponse, error) {
+func (p *pluginImpl) Apply(ctx context.Context, in interface{}, fullMethodName string) (rsp *api.PluginResponse, err error) {
 
 	endpoint, err := p.getEndpoint(ctx)
 	if err != nil {
 		return nil, err
 	}
+	// 远程插件调用的client span，链路信息通过metadata传递给插件服务
+	ctx, span := gateway.StartSpan(ctx, strings.TrimPrefix(api.PluginService_Apply_FullMethodName, "/"), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.String("begonia.plugin", p.name), attribute.String("server.address", endpoint.Addr())))
+	defer func() {
+		gateway.EndSpan(span, err)
+	}()
+	md := metadata.MD{}
+	gateway.InjectTraceContext(ctx, md)
+	ctx = metadata.NewOutgoingContext(ctx, md)
 	cn, err := endpoint.Get(ctx)
 	if err != nil {
 		return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "get_connection")
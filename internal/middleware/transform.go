@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/begonia-org/begonia/gateway"
+	gosdk "github.com/begonia-org/go-sdk"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// TransformPlugin 按端点配置中的规则转换请求和响应，
+// 优先级需要低于auth以便从jwt claims中取值，低于http以便在响应格式化之前处理
+type TransformPlugin struct {
+	priority int
+	name     string
+}
+
+type transformStream struct {
+	grpc.ServerStream
+	transform *gateway.MethodTransform
+}
+
+func (s *transformStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := s.transform.Request(s.Context(), m); err != nil {
+		return gosdk.NewError(fmt.Errorf("transform request error: %w", err), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "transform_request")
+	}
+	return nil
+}
+
+func (s *transformStream) SendMsg(m interface{}) error {
+	if err := s.transform.Response(m); err != nil {
+		return gosdk.NewError(fmt.Errorf("transform response error: %w", err), int32(common.Code_INTERNAL_ERROR), codes.Internal, "transform_response")
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+func NewTransformPlugin() *TransformPlugin {
+	return &TransformPlugin{name: "transform"}
+}
+
+func (t *TransformPlugin) SetPriority(priority int) {
+	t.priority = priority
+}
+
+func (t *TransformPlugin) Priority() int {
+	return t.priority
+}
+
+func (t *TransformPlugin) Name() string {
+	return t.name
+}
+
+func (t *TransformPlugin) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	transform := gateway.Transforms().Get(info.FullMethod)
+	if transform == nil {
+		return handler(ctx, req)
+	}
+	if err := transform.Request(ctx, req); err != nil {
+		return nil, gosdk.NewError(fmt.Errorf("transform request error: %w", err), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "transform_request")
+	}
+	rsp, err := handler(ctx, req)
+	if err != nil {
+		return rsp, err
+	}
+	if err := transform.Response(rsp); err != nil {
+		return nil, gosdk.NewError(fmt.Errorf("transform response error: %w", err), int32(common.Code_INTERNAL_ERROR), codes.Internal, "transform_response")
+	}
+	return rsp, nil
+}
+
+// StreamInterceptor 代理的请求都通过流式接口转发，在收发消息时进行转换
+func (t *TransformPlugin) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	transform := gateway.Transforms().Get(info.FullMethod)
+	if transform == nil {
+		return handler(srv, ss)
+	}
+	return handler(srv, &transformStream{ServerStream: ss, transform: transform})
+}
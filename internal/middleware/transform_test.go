@@ -0,0 +1,109 @@
+package middleware_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/middleware"
+	hello "github.com/begonia-org/go-sdk/api/example/v1"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// transformTestStream 模拟代理转发的原始消息
+type transformTestStream struct {
+	testStream
+	recv []byte
+	sent []byte
+}
+
+func (t *transformTestStream) RecvMsg(m interface{}) error {
+	m.(*emptypb.Empty).ProtoReflect().SetUnknown(t.recv)
+	return nil
+}
+
+func (t *transformTestStream) SendMsg(m interface{}) error {
+	t.sent = m.(*emptypb.Empty).ProtoReflect().GetUnknown()
+	return nil
+}
+
+func TestTransformPlugin(t *testing.T) {
+	c.Convey("test transform plugin", t, func() {
+		_, filename, _, _ := runtime.Caller(0)
+		pb, err := os.ReadFile(filepath.Join(filepath.Dir(filepath.Dir(filepath.Dir(filename))), "testdata", "helloworld.pb"))
+		c.So(err, c.ShouldBeNil)
+		pd, err := gateway.NewDescriptionFromBinary(pb, filepath.Join("tmp", "test-transform"))
+		c.So(err, c.ShouldBeNil)
+		defer gateway.Transforms().Delete(pd)
+		err = gateway.Transforms().Register(pd, gateway.EndpointTransform{
+			"helloworld.Greeter": {
+				Request:  []*gateway.FieldTransform{{Op: gateway.TransformAdd, Field: "name", Header: "x-uid"}},
+				Response: &gateway.ResponseTransform{Redact: []string{"name"}},
+			},
+			"helloworld.Greeter/SayHelloError": {
+				Request: []*gateway.FieldTransform{{Op: gateway.TransformAdd, Field: "code", Header: "x-code"}},
+			},
+		})
+		c.So(err, c.ShouldBeNil)
+
+		plugin := middleware.NewTransformPlugin()
+		plugin.SetPriority(1)
+		c.So(plugin.Name(), c.ShouldEqual, "transform")
+		c.So(plugin.Priority(), c.ShouldEqual, 1)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-uid", "user-1", "x-code", "not-a-number"))
+
+		rsp, err := plugin.UnaryInterceptor(ctx, &hello.HelloRequest{Name: "client"}, &grpc.UnaryServerInfo{FullMethod: "/helloworld.Greeter/SayHello"}, func(ctx context.Context, req any) (any, error) {
+			return &hello.HelloReply{Name: req.(*hello.HelloRequest).Name, Message: "message"}, nil
+		})
+		c.So(err, c.ShouldBeNil)
+		c.So(rsp.(*hello.HelloReply).Name, c.ShouldEqual, gateway.TransformRedacted)
+		c.So(rsp.(*hello.HelloReply).Message, c.ShouldEqual, "message")
+
+		_, err = plugin.UnaryInterceptor(ctx, &hello.ErrorRequest{}, &grpc.UnaryServerInfo{FullMethod: "/helloworld.Greeter/SayHelloError"}, func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+
+		// 代理的流式请求
+		data, err := proto.Marshal(&hello.HelloRequest{Msg: "hello"})
+		c.So(err, c.ShouldBeNil)
+		stream := &transformTestStream{testStream: testStream{ctx: ctx}, recv: data}
+		err = plugin.StreamInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/helloworld.Greeter/SayHelloServerSideEvent"}, func(srv any, ss grpc.ServerStream) error {
+			in := &emptypb.Empty{}
+			if err := ss.RecvMsg(in); err != nil {
+				return err
+			}
+			req := &hello.HelloRequest{}
+			if err := proto.Unmarshal(in.ProtoReflect().GetUnknown(), req); err != nil {
+				return err
+			}
+			c.So(req.Name, c.ShouldEqual, "user-1")
+			c.So(req.Msg, c.ShouldEqual, "hello")
+			out, _ := proto.Marshal(&hello.HelloReply{Name: req.Name, Message: req.Msg})
+			reply := &emptypb.Empty{}
+			reply.ProtoReflect().SetUnknown(out)
+			return ss.SendMsg(reply)
+		})
+		c.So(err, c.ShouldBeNil)
+		reply := &hello.HelloReply{}
+		c.So(proto.Unmarshal(stream.sent, reply), c.ShouldBeNil)
+		c.So(reply.Name, c.ShouldEqual, gateway.TransformRedacted)
+		c.So(reply.Message, c.ShouldEqual, "hello")
+
+		// 没有规则的方法不做处理
+		err = plugin.StreamInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/other.Greeter/SayHello"}, func(srv any, ss grpc.ServerStream) error {
+			c.So(ss, c.ShouldEqual, stream)
+			return nil
+		})
+		c.So(err, c.ShouldBeNil)
+	})
+}
@@ -3,6 +3,12 @@ package migrate
 import (
 	"fmt"
 
+	ak "github.com/begonia-org/begonia/api/accesskey/v1"
+	audit "github.com/begonia-org/begonia/api/audit/v1"
+	jwks "github.com/begonia-org/begonia/api/jwks/v1"
+	mfa "github.com/begonia-org/begonia/api/mfa/v1"
+	oidc "github.com/begonia-org/begonia/api/oidc/v1"
+	rbac "github.com/begonia-org/begonia/api/rbac/v1"
 	app "github.com/begonia-org/go-sdk/api/app/v1"
 	endpoint "github.com/begonia-org/go-sdk/api/endpoint/v1"
 	api "github.com/begonia-org/go-sdk/api/user/v1"
@@ -25,7 +31,7 @@ type MySQLMigrate struct {
 
 func NewTableModels() []TableModel {
 	tables := make([]TableModel, 0)
-	tables = append(tables, api.Users{}, endpoint.Endpoints{}, app.Apps{})
+	tables = append(tables, api.Users{}, endpoint.Endpoints{}, app.Apps{}, rbac.Role{}, rbac.RoleBinding{}, oidc.UserIdentity{}, jwks.SigningKey{}, mfa.UserTOTP{}, mfa.WebAuthnCredential{}, ak.AccessKey{}, audit.AuditLog{})
 	return tables
 }
 func NewMySQLMigrate(mysql *tiga.MySQLDao, models ...TableModel) *MySQLMigrate {
@@ -24,6 +24,171 @@ type RPCPlugins struct {
 	Timeout  int    `mapstructure:"timeout"`
 }
 
+type HealthCheck struct {
+	Enabled            bool    `mapstructure:"enabled"`
+	Interval           int     `mapstructure:"interval"`
+	Timeout            int     `mapstructure:"timeout"`
+	UnhealthyThreshold int     `mapstructure:"unhealthy_threshold"`
+	ErrorRate          float64 `mapstructure:"error_rate"`
+	MinRequests        int     `mapstructure:"min_requests"`
+	EjectionTime       int     `mapstructure:"ejection_time"`
+	MaxEjectionTime    int     `mapstructure:"max_ejection_time"`
+}
+
+type CircuitBreaker struct {
+	Enabled          bool    `mapstructure:"enabled"`
+	Window           int     `mapstructure:"window"`
+	MinRequests      int     `mapstructure:"min_requests"`
+	ErrorRate        float64 `mapstructure:"error_rate"`
+	SlowCallDuration int     `mapstructure:"slow_call_duration"`
+	SlowCallRate     float64 `mapstructure:"slow_call_rate"`
+	OpenTimeout      int     `mapstructure:"open_timeout"`
+	HalfOpenRequests int     `mapstructure:"half_open_requests"`
+}
+
+// Tracing 链路追踪，通过otlp grpc导出到collector
+type Tracing struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	ServiceName string  `mapstructure:"service_name"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// TLS 网关监听的tls配置，client_auth为none,request,require,verify_if_given,require_and_verify
+type TLS struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	CertFile     string `mapstructure:"cert_file"`
+	KeyFile      string `mapstructure:"key_file"`
+	ClientCAFile string `mapstructure:"client_ca_file"`
+	ClientAuth   string `mapstructure:"client_auth"`
+	// 证书文件的检查间隔，单位秒
+	ReloadInterval int `mapstructure:"reload_interval"`
+}
+
+// RateLimitRule 限流规则，apps和methods都为空时为全局规则
+type RateLimitRule struct {
+	Name string `mapstructure:"name"`
+	// 限流维度:access_key,uid,method,ip
+	Key string `mapstructure:"key"`
+	// 限流算法:token_bucket,sliding_window
+	Algorithm string `mapstructure:"algorithm"`
+	// 每个周期允许的请求数
+	Limit int `mapstructure:"limit"`
+	// 周期，单位秒
+	Period int `mapstructure:"period"`
+	// 令牌桶容量，默认等于limit
+	Burst int `mapstructure:"burst"`
+	// 生效的app，匹配access key或appid
+	Apps []string `mapstructure:"apps"`
+	// 生效的方法，支持完整方法名或者服务名
+	Methods []string `mapstructure:"methods"`
+}
+
+// JWTSigning 网关签发jwt的算法和密钥轮换，algorithm为HS256或为空时使用jwt_secret签名
+type JWTSigning struct {
+	// RS256或ES256
+	Algorithm string `mapstructure:"algorithm"`
+	// 密钥轮换周期，单位秒
+	RotationInterval int `mapstructure:"rotation_interval"`
+	// 新密钥开始签发前提前发布到jwks的时间，单位秒
+	PublishAhead int `mapstructure:"publish_ahead"`
+	// 密钥停止签发后仍可用于校验的时间，不应小于token的最长有效期，单位秒
+	Overlap int `mapstructure:"overlap"`
+	// 从数据库同步密钥的间隔，单位秒
+	RefreshInterval int `mapstructure:"refresh_interval"`
+}
+
+// Session 登录会话，access token短期有效，通过轮换的refresh token续期
+type Session struct {
+	// access token有效期，单位秒
+	AccessTokenExpiration int `mapstructure:"access_token_expiration"`
+	// refresh token有效期，每次刷新后重新计算，单位秒
+	RefreshTokenExpiration int `mapstructure:"refresh_token_expiration"`
+	// 保持登录时refresh token的有效期，单位秒
+	KeepLoginExpiration int `mapstructure:"keep_login_expiration"`
+}
+
+// AppSignature app请求签名校验，apps中可以为单个app单独设置
+type AppSignature struct {
+	// x-date与服务器时间允许的偏差，单位秒
+	Skew int `mapstructure:"skew"`
+	// 是否要求请求携带x-nonce
+	RequireNonce bool                   `mapstructure:"require_nonce"`
+	Apps         []AppSignatureOverride `mapstructure:"apps"`
+}
+
+// AppSignatureOverride 单个app的签名校验配置，skew为0时使用全局配置
+type AppSignatureOverride struct {
+	Appid        string `mapstructure:"appid"`
+	Skew         int    `mapstructure:"skew"`
+	RequireNonce bool   `mapstructure:"require_nonce"`
+}
+
+// Audit 审计记录
+type Audit struct {
+	// 写入方式，可选mysql、file、kafka，可以同时写入多个，为空时写入mysql
+	Sinks []string   `mapstructure:"sinks"`
+	File  AuditFile  `mapstructure:"file"`
+	Kafka AuditKafka `mapstructure:"kafka"`
+}
+
+// AuditFile 以json lines格式追加写入文件
+type AuditFile struct {
+	Path string `mapstructure:"path"`
+}
+
+type AuditKafka struct {
+	Brokers []string `mapstructure:"brokers"`
+	Topic   string   `mapstructure:"topic"`
+}
+
+// MFA 多因素认证，用户启用后登录需要完成第二步验证
+type MFA struct {
+	// totp验证器app中显示的签发方
+	Issuer string `mapstructure:"issuer"`
+	// 第二步验证的有效期，单位秒
+	ChallengeExpiration int `mapstructure:"challenge_expiration"`
+	// 第二步验证允许失败的次数
+	MaxAttempts int      `mapstructure:"max_attempts"`
+	WebAuthn    WebAuthn `mapstructure:"webauthn"`
+}
+
+// WebAuthn 依赖方配置，rp_id为空时不启用webauthn
+type WebAuthn struct {
+	RPID   string `mapstructure:"rp_id"`
+	RPName string `mapstructure:"rp_name"`
+	// 允许发起注册和认证的页面来源，例如https://example.com
+	Origins []string `mapstructure:"origins"`
+	// required、preferred或discouraged
+	UserVerification string `mapstructure:"user_verification"`
+	// 单位秒
+	Timeout int `mapstructure:"timeout"`
+}
+
+// OIDCIssuer 外部oidc签发方，签发的token通过jwks校验
+type OIDCIssuer struct {
+	// 签发方名称，用于登录地址/api/v1/oidc/{name}/authorize
+	Name   string `mapstructure:"name"`
+	Issuer string `mapstructure:"issuer"`
+	// 为空时通过{issuer}/.well-known/openid-configuration获取
+	JWKSURI      string   `mapstructure:"jwks_uri"`
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	RedirectURL  string   `mapstructure:"redirect_url"`
+	Scopes       []string `mapstructure:"scopes"`
+	// 接受的aud，为空时使用client_id
+	Audiences []string `mapstructure:"audiences"`
+	// 作为外部用户标识的claim，默认sub
+	UIDClaim string `mapstructure:"uid_claim"`
+	// 首次登录时是否自动创建用户
+	AutoCreate bool `mapstructure:"auto_create"`
+	// 首次登录时是否按已验证的email关联已有用户
+	LinkByEmail bool `mapstructure:"link_by_email"`
+	// jwks缓存时间，单位秒，遇到未知的kid时会提前刷新
+	JWKSCacheExpire int `mapstructure:"jwks_cache_expire"`
+}
+
 func NewConfig(config *tiga.Configuration) *Config {
 	return &Config{Configuration: config}
 }
@@ -72,6 +237,72 @@ func (c *Config) GetUserBlackListKey(uid string) string {
 	prefix := c.GetUserBlackListPrefix()
 	return fmt.Sprintf("%s:%s", prefix, uid)
 }
+func (c *Config) GetJWTSigning() (*JWTSigning, error) {
+	signing := &JWTSigning{}
+	err := c.unmarshalWithEnv("auth.jwt_signing", signing)
+	if err != nil {
+		return nil, err
+	}
+	return signing, nil
+}
+
+// GetJWTSigningLockKey 轮换签名密钥的分布式锁
+func (c *Config) GetJWTSigningLockKey() string {
+	prefix := c.GetCachePrefixKey()
+	return fmt.Sprintf("%s:jwt_signing:lock", prefix)
+}
+func (c *Config) GetSession() (*Session, error) {
+	session := &Session{}
+	err := c.unmarshalWithEnv("auth.session", session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+func (c *Config) GetSessionKey(id string) string {
+	prefix := c.GetCachePrefixKey()
+	return fmt.Sprintf("%s:session:%s", prefix, id)
+}
+
+// GetUserSessionsKey 用户所有会话的索引
+func (c *Config) GetUserSessionsKey(uid string) string {
+	prefix := c.GetCachePrefixKey()
+	return fmt.Sprintf("%s:sessions:%s", prefix, uid)
+}
+
+// GetSessionLockKey 刷新会话的分布式锁
+func (c *Config) GetSessionLockKey(id string) string {
+	prefix := c.GetCachePrefixKey()
+	return fmt.Sprintf("%s:session:lock:%s", prefix, id)
+}
+func (c *Config) GetAudit() (*Audit, error) {
+	audit := &Audit{}
+	err := c.unmarshalWithEnv("audit", audit)
+	if err != nil {
+		return nil, err
+	}
+	return audit, nil
+}
+func (c *Config) GetMFA() (*MFA, error) {
+	mfa := &MFA{}
+	err := c.unmarshalWithEnv("auth.mfa", mfa)
+	if err != nil {
+		return nil, err
+	}
+	return mfa, nil
+}
+
+// GetMFAChallengeKey 登录第二步验证和webauthn注册的挑战
+func (c *Config) GetMFAChallengeKey(id string) string {
+	prefix := c.GetCachePrefixKey()
+	return fmt.Sprintf("%s:mfa:challenge:%s", prefix, id)
+}
+
+// GetMFACodeKey 已使用的totp验证码，防止在有效期内重放
+func (c *Config) GetMFACodeKey(uid string, step int64) string {
+	prefix := c.GetCachePrefixKey()
+	return fmt.Sprintf("%s:mfa:code:%s:%d", prefix, uid, step)
+}
 func (c *Config) GetUserBlackListLockKey() string {
 	prefix := c.GetUserBlackListPrefix()
 	return fmt.Sprintf("%s:lock", prefix)
@@ -141,6 +372,29 @@ func (c *Config) GetAPPAccessKey(access string) string {
 	prefix := c.GetAPPAccessKeyPrefix()
 	return fmt.Sprintf("%s:%s", prefix, access)
 }
+func (c *Config) GetAPPKeyCacheKey(accessKey string) string {
+	prefix := c.GetAppPrefix()
+	return fmt.Sprintf("%s:key:%s", prefix, accessKey)
+}
+
+// GetAPPKeyGracePeriod 轮换密钥后旧密钥继续有效的时间，单位秒
+func (c *Config) GetAPPKeyGracePeriod() int {
+	return c.getIntWithEnv("auth.app.rotation_grace_period")
+}
+func (c *Config) GetAppSignature() (*AppSignature, error) {
+	signature := &AppSignature{}
+	err := c.unmarshalWithEnv("auth.app.signature", signature)
+	if err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+// GetAPPNonceKey 已经使用过的x-nonce
+func (c *Config) GetAPPNonceKey(accessKey, nonce string) string {
+	prefix := c.GetAppPrefix()
+	return fmt.Sprintf("%s:nonce:%s:%s", prefix, accessKey, nonce)
+}
 func (c *Config) GetAppidKey(accessKey string) string {
 	prefix := c.GetAppidPrefix()
 	return fmt.Sprintf("%s:%s", prefix, accessKey)
@@ -201,6 +455,84 @@ func (c *Config) GetRPCPlugins() ([]*goloadbalancer.Server, error) {
 	}
 	return plugins, nil
 }
+func (c *Config) GetHealthCheck() (*HealthCheck, error) {
+	key := fmt.Sprintf("%s.gateway.health_check", c.GetEnv())
+	if !c.IsSet(key) {
+		key = "gateway.health_check"
+	}
+	health := &HealthCheck{}
+	err := c.UnmarshalKey(key, health)
+	if err != nil {
+		return nil, err
+	}
+	return health, nil
+}
+func (c *Config) GetCircuitBreaker() (*CircuitBreaker, error) {
+	key := fmt.Sprintf("%s.gateway.circuit_breaker", c.GetEnv())
+	if !c.IsSet(key) {
+		key = "gateway.circuit_breaker"
+	}
+	breaker := &CircuitBreaker{}
+	err := c.UnmarshalKey(key, breaker)
+	if err != nil {
+		return nil, err
+	}
+	return breaker, nil
+}
+func (c *Config) GetTracing() (*Tracing, error) {
+	tracing := &Tracing{}
+	err := c.unmarshalWithEnv("gateway.tracing", tracing)
+	if err != nil {
+		return nil, err
+	}
+	return tracing, nil
+}
+func (c *Config) GetTLS() (*TLS, error) {
+	tlsConfig := &TLS{}
+	err := c.unmarshalWithEnv("gateway.tls", tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return tlsConfig, nil
+}
+func (c *Config) GetRateLimitRules() ([]*RateLimitRule, error) {
+	rules := make([]*RateLimitRule, 0)
+	err := c.unmarshalWithEnv("gateway.rate_limit.rules", &rules)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+func (c *Config) GetRateLimitKey(rule, key string) string {
+	prefix := c.GetCachePrefixKey()
+	return fmt.Sprintf("%s:rate_limit:%s:%s", prefix, rule, key)
+}
+
+// GetRBACPermissionsKey 用户或app拥有的权限的缓存key
+func (c *Config) GetRBACPermissionsKey(subjectType, subject string) string {
+	prefix := c.GetCachePrefixKey()
+	return fmt.Sprintf("%s:rbac:%s:%s", prefix, subjectType, subject)
+}
+func (c *Config) GetOIDCIssuers() ([]*OIDCIssuer, error) {
+	issuers := make([]*OIDCIssuer, 0)
+	err := c.unmarshalWithEnv("auth.oidc.issuers", &issuers)
+	if err != nil {
+		return nil, err
+	}
+	return issuers, nil
+}
+
+// GetOIDCStateKey 授权码登录的state缓存key
+func (c *Config) GetOIDCStateKey(state string) string {
+	prefix := c.GetCachePrefixKey()
+	return fmt.Sprintf("%s:oidc:state:%s", prefix, state)
+}
+
+// GetOIDCIdentityKey 外部身份关联的缓存key
+func (c *Config) GetOIDCIdentityKey(issuer, subject string) string {
+	prefix := c.GetCachePrefixKey()
+	return fmt.Sprintf("%s:oidc:identity:%s:%s", prefix, issuer, subject)
+}
 func (c *Config) GetEndpointsPrefix() string {
 	return fmt.Sprintf("%s%s", c.GetEnv(), c.getWithEnv("common.etcd.endpoint.prefix"))
 }
@@ -209,6 +541,11 @@ func (c *Config) GetGatewayDescriptionOut() string {
 	return c.getWithEnv("gateway.descriptor.out_dir")
 }
 
+// GetCaptureDir 录制文件所在的目录
+func (c *Config) GetCaptureDir() string {
+	return c.getWithEnv("gateway.capture.dir")
+}
+
 func (c *Config) GetAdminAPIKey() string {
 	return c.getWithEnv("auth.admin.apikey")
 }
@@ -263,6 +600,15 @@ func (c *Config) getWithEnv(key string) string {
 	}
 	return c.GetString(key)
 }
+
+// unmarshalWithEnv 优先读取当前环境下的配置，不存在时读取全局配置
+func (c *Config) unmarshalWithEnv(key string, rawVal any) error {
+	envKey := fmt.Sprintf("%s.%s", c.GetEnv(), key)
+	if c.Viper.IsSet(envKey) {
+		return c.Viper.UnmarshalKey(envKey, rawVal)
+	}
+	return c.Viper.UnmarshalKey(key, rawVal)
+}
 func (c *Config) getIntWithEnv(key string) int {
 	envKey := fmt.Sprintf("%s.%s", c.GetEnv(), key)
 	if val := c.GetInt(envKey); val != 0 {
@@ -85,6 +85,9 @@ func TestConfig(t *testing.T) {
 		c.So(config.GetRSAPriKey(), c.ShouldNotBeEmpty)
 		c.So(config.GetRSAPubKey(), c.ShouldNotBeEmpty)
 		c.So(config.GetAppPrefix(), c.ShouldNotBeEmpty)
+		_, err = config.GetRateLimitRules()
+		c.So(err, c.ShouldBeNil)
+		c.So(config.GetRateLimitKey("ip", "127.0.0.1"), c.ShouldEqual, fmt.Sprintf("%s:rate_limit:ip:127.0.0.1", prefix))
 		patch := gomonkey.ApplyFuncReturn((*viper.Viper).UnmarshalKey, fmt.Errorf("error"))
 		defer patch.Reset()
 		ss, err := config.GetRPCPlugins()
@@ -29,6 +29,15 @@ var (
 	ErrAppAccessKeyMissing = errors.New("app access key缺失")
 	ErrAppXDateMissing     = errors.New("app x-date缺失")
 	ErrRequestExpired      = errors.New("请求已过期")
+	ErrAccessKeyNotFound   = errors.New("access key不存在")
+	ErrAccessKeyDisabled   = errors.New("access key已禁用")
+	ErrAccessKeyExpired    = errors.New("access key已过期")
+	ErrAppNonceMissing     = errors.New("缺少x-nonce")
+	ErrAppNonceUnsigned    = errors.New("x-nonce未参与签名")
+	ErrAppNonceInvalid     = errors.New("x-nonce长度无效")
+	ErrAppNonceReplayed    = errors.New("x-nonce已使用，请求被重放")
+	ErrAuditTimeRange      = errors.New("审计记录查询的开始时间晚于结束时间")
+	ErrAccessKeyScope      = errors.New("access key无权调用该接口")
 
 	ErrUploadNotInitiate = errors.New("上传未初始化")
 	ErrSHA256NotMatch    = errors.New("sha256不匹配")
@@ -48,4 +57,51 @@ var (
 	ErrEndpointExists = errors.New("endpoint已存在")
 
 	ErrEndpointNotExists = errors.New("endpoint不存在")
+
+	ErrInvalidEndpointPolicy    = errors.New("无效的endpoint调用策略")
+	ErrInvalidEndpointTransform = errors.New("无效的endpoint转换规则")
+	ErrInvalidEndpointCache     = errors.New("无效的endpoint缓存策略")
+	ErrInvalidEndpointTLS       = errors.New("无效的endpoint tls配置")
+	ErrInvalidEndpointRelease   = errors.New("无效的endpoint版本")
+	ErrEndpointReleaseNotExists = errors.New("endpoint版本不存在")
+	ErrInvalidEndpointMirror    = errors.New("无效的endpoint流量复制配置")
+	ErrInvalidCapture           = errors.New("无效的录制配置")
+	ErrInvalidEndpointDiscovery = errors.New("无效的endpoint动态发现配置")
+	ErrInvalidAdminConfig       = errors.New("无效的配置内容")
+
+	ErrRateLimited = errors.New("请求过于频繁")
+
+	ErrPermissionDenied    = errors.New("没有访问权限")
+	ErrRoleNotFound        = errors.New("角色不存在")
+	ErrRoleNameMissing     = errors.New("角色名称缺失")
+	ErrRoleBindingNotFound = errors.New("角色绑定不存在")
+	ErrRoleBindingExists   = errors.New("角色绑定已存在")
+	ErrInvalidSubject      = errors.New("无效的绑定对象")
+
+	ErrOIDCProviderNotFound = errors.New("oidc签发方不存在")
+	ErrOIDCStateInvalid     = errors.New("oidc state无效或已过期")
+	ErrOIDCNonceNotMatch    = errors.New("oidc nonce不匹配")
+	ErrOIDCCodeMissing      = errors.New("oidc授权码缺失")
+	ErrOIDCIdentityNotLink  = errors.New("外部身份未关联用户")
+	ErrOIDCUserNotAllowed   = errors.New("不允许自动创建外部身份用户")
+	ErrTokenAudience        = errors.New("token接收方错误")
+	ErrTokenAlgorithm       = errors.New("不支持的token签名算法")
+	ErrJWKNotFound          = errors.New("签名公钥不存在")
+	ErrSigningKeyNotReady   = errors.New("签名密钥未就绪")
+	ErrTokenKeyExpired      = errors.New("token签名密钥已过期")
+
+	ErrSessionNotFound     = errors.New("会话不存在或已过期")
+	ErrRefreshTokenInvalid = errors.New("refresh token无效")
+	ErrRefreshTokenReused  = errors.New("refresh token重复使用，会话已注销")
+
+	ErrMFACodeInvalid        = errors.New("动态验证码错误")
+	ErrMFACodeReused         = errors.New("动态验证码已使用")
+	ErrMFANotEnrolled        = errors.New("未绑定多因素认证")
+	ErrMFAAlreadyEnabled     = errors.New("已启用totp")
+	ErrMFAChallengeInvalid   = errors.New("多因素认证挑战无效或已过期")
+	ErrMFAMethodNotSupported = errors.New("不支持的多因素认证方式")
+	ErrMFATooManyAttempts    = errors.New("多因素认证失败次数过多")
+	ErrWebAuthnInvalid       = errors.New("webauthn校验失败")
+	ErrWebAuthnCredential    = errors.New("webauthn凭证不存在")
+	ErrCBORDecode            = errors.New("cbor解码失败")
 )
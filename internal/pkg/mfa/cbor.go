@@ -0,0 +1,134 @@
+package mfa
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/begonia-org/begonia/internal/pkg"
+)
+
+// maxCBORDepth 限制嵌套深度，attestation对象的嵌套不超过4层
+const maxCBORDepth = 16
+
+// decodeCBOR 解码rfc8949 cbor数据，只支持webauthn使用的确定长度编码，
+// map解码为map[interface{}]interface{}，整数解码为int64，返回剩余未解码的数据
+func decodeCBOR(data []byte) (interface{}, []byte, error) {
+	return decodeCBORItem(data, 0)
+}
+
+func cborError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w:%s", pkg.ErrCBORDecode, fmt.Sprintf(format, args...))
+}
+
+func decodeCBORHead(data []byte) (byte, byte, uint64, []byte, error) {
+	if len(data) == 0 {
+		return 0, 0, 0, nil, cborError("unexpected end of data")
+	}
+	major, info := data[0]>>5, data[0]&0x1f
+	data = data[1:]
+	switch {
+	case info < 24:
+		return major, info, uint64(info), data, nil
+	case info <= 27:
+		size := 1 << (info - 24)
+		if len(data) < size {
+			return 0, 0, 0, nil, cborError("unexpected end of data")
+		}
+		var val uint64
+		switch size {
+		case 1:
+			val = uint64(data[0])
+		case 2:
+			val = uint64(binary.BigEndian.Uint16(data))
+		case 4:
+			val = uint64(binary.BigEndian.Uint32(data))
+		case 8:
+			val = binary.BigEndian.Uint64(data)
+		}
+		return major, info, val, data[size:], nil
+	default:
+		return 0, 0, 0, nil, cborError("unsupported additional info %d", info)
+	}
+}
+
+func decodeCBORItem(data []byte, depth int) (interface{}, []byte, error) {
+	if depth > maxCBORDepth {
+		return nil, nil, cborError("nesting too deep")
+	}
+	major, info, val, rest, err := decodeCBORHead(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch major {
+	case 0:
+		if val > math.MaxInt64 {
+			return nil, nil, cborError("integer overflow")
+		}
+		return int64(val), rest, nil
+	case 1:
+		if val > math.MaxInt64 {
+			return nil, nil, cborError("integer overflow")
+		}
+		return -1 - int64(val), rest, nil
+	case 2, 3:
+		if uint64(len(rest)) < val {
+			return nil, nil, cborError("unexpected end of data")
+		}
+		if major == 2 {
+			return append([]byte{}, rest[:val]...), rest[val:], nil
+		}
+		return string(rest[:val]), rest[val:], nil
+	case 4:
+		if uint64(len(rest)) < val {
+			return nil, nil, cborError("unexpected end of data")
+		}
+		items := make([]interface{}, 0, val)
+		for i := uint64(0); i < val; i++ {
+			var item interface{}
+			if item, rest, err = decodeCBORItem(rest, depth+1); err != nil {
+				return nil, nil, err
+			}
+			items = append(items, item)
+		}
+		return items, rest, nil
+	case 5:
+		if uint64(len(rest)) < val*2 {
+			return nil, nil, cborError("unexpected end of data")
+		}
+		items := make(map[interface{}]interface{}, val)
+		for i := uint64(0); i < val; i++ {
+			var key, item interface{}
+			if key, rest, err = decodeCBORItem(rest, depth+1); err != nil {
+				return nil, nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, cborError("unsupported map key type %T", key)
+			}
+			if item, rest, err = decodeCBORItem(rest, depth+1); err != nil {
+				return nil, nil, err
+			}
+			items[key] = item
+		}
+		return items, rest, nil
+	case 6:
+		// 忽略tag，返回tag包含的数据
+		return decodeCBORItem(rest, depth+1)
+	case 7:
+		switch info {
+		case 20:
+			return false, rest, nil
+		case 21:
+			return true, rest, nil
+		case 22, 23:
+			return nil, rest, nil
+		case 26:
+			return float64(math.Float32frombits(uint32(val))), rest, nil
+		case 27:
+			return math.Float64frombits(val), rest, nil
+		}
+	}
+	return nil, nil, cborError("unsupported major type %d info %d", major, info)
+}
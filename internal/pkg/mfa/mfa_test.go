@@ -0,0 +1,122 @@
+package mfa_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/mfa"
+	"github.com/begonia-org/begonia/internal/pkg/mfa/mfatest"
+	c "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTOTP(t *testing.T) {
+	c.Convey("test totp with rfc6238 vectors", t, func() {
+		// rfc6238附录B中SHA1的测试密钥，取8位验证码的后6位
+		secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+		vectors := map[int64]string{59: "287082", 1111111109: "081804", 1111111111: "050471", 1234567890: "005924", 2000000000: "279037"}
+		for ts, want := range vectors {
+			code, err := mfa.TOTPCode(secret, time.Unix(ts, 0))
+			c.So(err, c.ShouldBeNil)
+			c.So(code, c.ShouldEqual, want)
+		}
+
+		secret, err := mfa.GenerateTOTPSecret()
+		c.So(err, c.ShouldBeNil)
+		c.So(secret, c.ShouldHaveLength, 32)
+		now := time.Now()
+		code, _ := mfa.TOTPCode(secret, now.Add(-mfa.TOTPPeriod*time.Second))
+		step, ok := mfa.ValidateTOTP(secret, code, now)
+		c.So(ok, c.ShouldBeTrue)
+		c.So(step, c.ShouldEqual, now.Unix()/mfa.TOTPPeriod-1)
+		code, _ = mfa.TOTPCode(secret, now.Add(-3*mfa.TOTPPeriod*time.Second))
+		_, ok = mfa.ValidateTOTP(secret, code, now)
+		c.So(ok, c.ShouldBeFalse)
+		_, ok = mfa.ValidateTOTP(secret, "12345", now)
+		c.So(ok, c.ShouldBeFalse)
+
+		url := mfa.TOTPURL("begonia", "admin", secret)
+		c.So(url, c.ShouldStartWith, "otpauth://totp/begonia:admin?")
+		c.So(url, c.ShouldContainSubstring, "secret="+secret)
+	})
+	c.Convey("test backup codes", t, func() {
+		codes, hashes, err := mfa.GenerateBackupCodes()
+		c.So(err, c.ShouldBeNil)
+		c.So(codes, c.ShouldHaveLength, 10)
+		c.So(hashes, c.ShouldHaveLength, 10)
+		c.So(strings.Join(hashes, ","), c.ShouldNotContainSubstring, codes[0])
+
+		remain, ok := mfa.UseBackupCode(hashes, strings.ToUpper(codes[3]))
+		c.So(ok, c.ShouldBeTrue)
+		c.So(remain, c.ShouldHaveLength, 9)
+		_, ok = mfa.UseBackupCode(remain, codes[3])
+		c.So(ok, c.ShouldBeFalse)
+		remain, ok = mfa.UseBackupCode(remain, strings.ReplaceAll(codes[0], "-", ""))
+		c.So(ok, c.ShouldBeTrue)
+		c.So(remain, c.ShouldHaveLength, 8)
+	})
+}
+
+func TestWebAuthn(t *testing.T) {
+	c.Convey("test webauthn registration and assertion", t, func() {
+		rp := &mfa.RelyingParty{ID: "example.com", Name: "begonia", Origins: []string{"https://example.com"}, UserVerification: "preferred", Timeout: time.Minute}
+		authenticator, err := mfatest.NewAuthenticator("https://example.com")
+		c.So(err, c.ShouldBeNil)
+
+		options := rp.NewCreationOptions("register-challenge", "user-1", "admin", nil)
+		c.So(options.Timeout, c.ShouldEqual, 60000)
+		c.So(options.PubKeyCredParams[0].Alg, c.ShouldEqual, mfa.COSEAlgES256)
+		resp := authenticator.Register(options)
+		_, err = rp.VerifyRegistration("other-challenge", resp)
+		c.So(errors.Is(err, pkg.ErrWebAuthnInvalid), c.ShouldBeTrue)
+		credential, err := rp.VerifyRegistration("register-challenge", resp)
+		c.So(err, c.ShouldBeNil)
+		c.So(credential.ID, c.ShouldResemble, authenticator.CredentialID)
+		c.So(credential.AAGUID, c.ShouldEqual, "00000000-0000-0000-0000-000000000000")
+		_, alg, err := mfa.ParsePublicKey(credential.PublicKey)
+		c.So(err, c.ShouldBeNil)
+		c.So(alg, c.ShouldEqual, mfa.COSEAlgES256)
+
+		// 其他来源发起的注册
+		other := &mfa.RelyingParty{ID: "example.com", Origins: []string{"https://evil.com"}}
+		_, err = other.VerifyRegistration("register-challenge", resp)
+		c.So(err, c.ShouldNotBeNil)
+		c.So(err.Error(), c.ShouldContainSubstring, "origin")
+
+		request := rp.NewRequestOptions("login-challenge", [][]byte{credential.ID})
+		c.So(request.AllowCredentials[0].ID, c.ShouldEqual, authenticator.ID())
+		assertion, err := authenticator.Login(request)
+		c.So(err, c.ShouldBeNil)
+		count, err := rp.VerifyAssertion("login-challenge", credential.PublicKey, credential.SignCount, assertion)
+		c.So(err, c.ShouldBeNil)
+		c.So(count, c.ShouldEqual, 1)
+
+		// 签名计数没有增加
+		_, err = rp.VerifyAssertion("login-challenge", credential.PublicKey, count, assertion)
+		c.So(err, c.ShouldNotBeNil)
+		// 签名被篡改
+		assertion, _ = authenticator.Login(request)
+		assertion.Signature[len(assertion.Signature)-1] ^= 0xff
+		_, err = rp.VerifyAssertion("login-challenge", credential.PublicKey, count, assertion)
+		c.So(err, c.ShouldNotBeNil)
+		// rp id不一致
+		assertion, _ = authenticator.Login(&mfa.CredentialRequestOptions{Challenge: "login-challenge", RPID: "evil.com"})
+		_, err = rp.VerifyAssertion("login-challenge", credential.PublicKey, count, assertion)
+		c.So(err, c.ShouldNotBeNil)
+
+		// 要求用户验证时拒绝只有用户在场标记的认证
+		strict := &mfa.RelyingParty{ID: "example.com", Origins: []string{"https://example.com"}, UserVerification: "required"}
+		_, err = strict.VerifyRegistration("register-challenge", resp)
+		c.So(err, c.ShouldBeNil)
+		assertion, _ = authenticator.Login(request)
+		c.So(assertion.AuthenticatorData[32]&0x04, c.ShouldNotEqual, 0)
+		assertion.AuthenticatorData[32] = 0x01
+		_, err = strict.VerifyAssertion("login-challenge", credential.PublicKey, count, assertion)
+		c.So(err, c.ShouldNotBeNil)
+
+		_, _, err = mfa.ParsePublicKey([]byte{0xa1})
+		c.So(errors.Is(err, pkg.ErrWebAuthnInvalid), c.ShouldBeTrue)
+	})
+}
@@ -0,0 +1,142 @@
+// Package mfatest 提供用于测试的webauthn认证器
+package mfatest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/begonia-org/begonia/internal/pkg/mfa"
+)
+
+// Authenticator 使用ES256密钥的软件认证器，每次断言签名计数加1
+type Authenticator struct {
+	Origin       string
+	CredentialID []byte
+	SignCount    uint32
+	key          *ecdsa.PrivateKey
+}
+
+func NewAuthenticator(origin string) (*Authenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &Authenticator{Origin: origin, CredentialID: id, key: key}, nil
+}
+
+func (a *Authenticator) clientData(typ, challenge string) []byte {
+	data, _ := json.Marshal(map[string]interface{}{"type": typ, "challenge": challenge, "origin": a.Origin, "crossOrigin": false})
+	return data
+}
+
+func (a *Authenticator) authData(rpID string, flags byte, attested []byte) []byte {
+	hash := sha256.Sum256([]byte(rpID))
+	data := append([]byte{}, hash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.SignCount)
+	return append(data, attested...)
+}
+
+// Register 模拟navigator.credentials.create，使用none格式的证明
+func (a *Authenticator) Register(options *mfa.CredentialCreationOptions) *mfa.AttestationResponse {
+	x := a.key.PublicKey.X.FillBytes(make([]byte, 32))
+	y := a.key.PublicKey.Y.FillBytes(make([]byte, 32))
+	publicKey := encode(map[int64]interface{}{1: int64(2), 3: int64(mfa.COSEAlgES256), -1: int64(1), -2: x, -3: y})
+	attested := make([]byte, 16)
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.CredentialID)))
+	attested = append(attested, a.CredentialID...)
+	attested = append(attested, publicKey...)
+	authData := a.authData(options.RP.ID, 0x45, attested)
+	object := encode(map[string]interface{}{"fmt": "none", "attStmt": map[string]interface{}{}, "authData": authData})
+	return &mfa.AttestationResponse{ClientDataJSON: a.clientData("webauthn.create", options.Challenge), AttestationObject: object}
+}
+
+// Login 模拟navigator.credentials.get
+func (a *Authenticator) Login(options *mfa.CredentialRequestOptions) (*mfa.AssertionResponse, error) {
+	a.SignCount++
+	clientData := a.clientData("webauthn.get", options.Challenge)
+	authData := a.authData(options.RPID, 0x05, nil)
+	hash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), hash[:]...))
+	sig, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	return &mfa.AssertionResponse{CredentialID: a.CredentialID, ClientDataJSON: clientData, AuthenticatorData: authData, Signature: sig}, nil
+}
+
+// ID base64url编码的凭证id
+func (a *Authenticator) ID() string {
+	return base64.RawURLEncoding.EncodeToString(a.CredentialID)
+}
+
+func head(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n))
+	}
+}
+
+// encode 编码认证器使用的cbor数据，map的键按编码后的字节排序
+func encode(v interface{}) []byte {
+	switch val := v.(type) {
+	case int64:
+		if val < 0 {
+			return head(1, uint64(-1-val))
+		}
+		return head(0, uint64(val))
+	case []byte:
+		return append(head(2, uint64(len(val))), val...)
+	case string:
+		return append(head(3, uint64(len(val))), val...)
+	case map[string]interface{}:
+		items := make(map[interface{}]interface{}, len(val))
+		for k, v := range val {
+			items[k] = v
+		}
+		return encodeMap(items)
+	case map[int64]interface{}:
+		items := make(map[interface{}]interface{}, len(val))
+		for k, v := range val {
+			items[k] = v
+		}
+		return encodeMap(items)
+	}
+	panic(fmt.Sprintf("unsupported cbor type %T", v))
+}
+
+func encodeMap(items map[interface{}]interface{}) []byte {
+	type pair struct{ key, val []byte }
+	pairs := make([]pair, 0, len(items))
+	for k, v := range items {
+		pairs = append(pairs, pair{encode(k), encode(v)})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if len(pairs[i].key) != len(pairs[j].key) {
+			return len(pairs[i].key) < len(pairs[j].key)
+		}
+		return string(pairs[i].key) < string(pairs[j].key)
+	})
+	data := head(5, uint64(len(pairs)))
+	for _, p := range pairs {
+		data = append(append(data, p.key...), p.val...)
+	}
+	return data
+}
@@ -0,0 +1,394 @@
+package mfa
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"math/big"
+	"time"
+
+	"github.com/begonia-org/begonia/internal/pkg"
+)
+
+const (
+	flagUserPresent   = 0x01
+	flagUserVerified  = 0x04
+	flagAttestedCred  = 0x40
+	authDataMinLength = 37
+
+	// cose算法标识
+	COSEAlgES256 = -7
+	COSEAlgES384 = -35
+	COSEAlgES512 = -36
+	COSEAlgEdDSA = -8
+	COSEAlgRS256 = -257
+)
+
+// RelyingParty webauthn依赖方配置，origins为允许发起webauthn的页面来源
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+	// required、preferred或discouraged
+	UserVerification string
+	Timeout          time.Duration
+}
+
+type RelyingPartyEntity struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+type UserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type CredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey,omitempty"`
+	UserVerification string `json:"userVerification,omitempty"`
+}
+
+// CredentialCreationOptions navigator.credentials.create的publicKey参数，二进制字段使用base64url编码
+type CredentialCreationOptions struct {
+	Challenge              string                  `json:"challenge"`
+	RP                     RelyingPartyEntity      `json:"rp"`
+	User                   UserEntity              `json:"user"`
+	PubKeyCredParams       []CredentialParameter   `json:"pubKeyCredParams"`
+	Timeout                int64                   `json:"timeout,omitempty"`
+	ExcludeCredentials     []CredentialDescriptor  `json:"excludeCredentials,omitempty"`
+	AuthenticatorSelection *AuthenticatorSelection `json:"authenticatorSelection,omitempty"`
+	Attestation            string                  `json:"attestation"`
+}
+
+// CredentialRequestOptions navigator.credentials.get的publicKey参数
+type CredentialRequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout,omitempty"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials,omitempty"`
+	UserVerification string                 `json:"userVerification,omitempty"`
+}
+
+// AttestationResponse AuthenticatorAttestationResponse
+type AttestationResponse struct {
+	ClientDataJSON    []byte
+	AttestationObject []byte
+}
+
+// AssertionResponse AuthenticatorAssertionResponse
+type AssertionResponse struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+}
+
+// Credential 注册成功的凭证，PublicKey为cose编码的公钥
+type Credential struct {
+	ID        []byte
+	PublicKey []byte
+	AAGUID    string
+	SignCount uint32
+}
+
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+type authenticatorData struct {
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+	aaguid    []byte
+	credID    []byte
+	publicKey []byte
+}
+
+func webauthnError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w:%s", pkg.ErrWebAuthnInvalid, fmt.Sprintf(format, args...))
+}
+
+func descriptors(ids [][]byte) []CredentialDescriptor {
+	items := make([]CredentialDescriptor, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, CredentialDescriptor{Type: "public-key", ID: base64.RawURLEncoding.EncodeToString(id)})
+	}
+	return items
+}
+
+// NewCreationOptions 注册凭证的参数，exclude为用户已注册的凭证
+func (rp *RelyingParty) NewCreationOptions(challenge, userID, userName string, exclude [][]byte) *CredentialCreationOptions {
+	return &CredentialCreationOptions{
+		Challenge: challenge,
+		RP:        RelyingPartyEntity{ID: rp.ID, Name: rp.Name},
+		User: UserEntity{
+			ID:          base64.RawURLEncoding.EncodeToString([]byte(userID)),
+			Name:        userName,
+			DisplayName: userName,
+		},
+		PubKeyCredParams: []CredentialParameter{
+			{Type: "public-key", Alg: COSEAlgES256},
+			{Type: "public-key", Alg: COSEAlgEdDSA},
+			{Type: "public-key", Alg: COSEAlgRS256},
+		},
+		Timeout:                rp.Timeout.Milliseconds(),
+		ExcludeCredentials:     descriptors(exclude),
+		AuthenticatorSelection: &AuthenticatorSelection{ResidentKey: "preferred", UserVerification: rp.UserVerification},
+		Attestation:            "none",
+	}
+}
+
+// NewRequestOptions 断言的参数，allow为用户已注册的凭证
+func (rp *RelyingParty) NewRequestOptions(challenge string, allow [][]byte) *CredentialRequestOptions {
+	return &CredentialRequestOptions{
+		Challenge:        challenge,
+		Timeout:          rp.Timeout.Milliseconds(),
+		RPID:             rp.ID,
+		AllowCredentials: descriptors(allow),
+		UserVerification: rp.UserVerification,
+	}
+}
+
+func (rp *RelyingParty) verifyClientData(raw []byte, typ, challenge string) error {
+	data := &clientData{}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return webauthnError("decode client data:%v", err)
+	}
+	if data.Type != typ {
+		return webauthnError("unexpected client data type %s", data.Type)
+	}
+	if subtle.ConstantTimeCompare([]byte(data.Challenge), []byte(challenge)) != 1 {
+		return webauthnError("challenge not match")
+	}
+	for _, origin := range rp.Origins {
+		if origin == data.Origin {
+			return nil
+		}
+	}
+	return webauthnError("origin %s not allowed", data.Origin)
+}
+
+func (rp *RelyingParty) parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	if len(raw) < authDataMinLength {
+		return nil, webauthnError("authenticator data too short")
+	}
+	data := &authenticatorData{rpIDHash: raw[:32], flags: raw[32], signCount: binary.BigEndian.Uint32(raw[33:37])}
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(data.rpIDHash, rpIDHash[:]) != 1 {
+		return nil, webauthnError("rp id hash not match")
+	}
+	if data.flags&flagUserPresent == 0 {
+		return nil, webauthnError("user not present")
+	}
+	if rp.UserVerification == "required" && data.flags&flagUserVerified == 0 {
+		return nil, webauthnError("user not verified")
+	}
+	if data.flags&flagAttestedCred == 0 {
+		return data, nil
+	}
+	rest := raw[authDataMinLength:]
+	if len(rest) < 18 {
+		return nil, webauthnError("attested credential data too short")
+	}
+	data.aaguid = rest[:16]
+	length := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if len(rest) < length {
+		return nil, webauthnError("credential id too short")
+	}
+	data.credID = rest[:length]
+	key := rest[length:]
+	_, extensions, err := decodeCBOR(key)
+	if err != nil {
+		return nil, webauthnError("decode credential public key:%v", err)
+	}
+	data.publicKey = key[:len(key)-len(extensions)]
+	return data, nil
+}
+
+// VerifyRegistration 校验navigator.credentials.create的结果，支持none和packed格式的证明
+func (rp *RelyingParty) VerifyRegistration(challenge string, resp *AttestationResponse) (*Credential, error) {
+	if err := rp.verifyClientData(resp.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+	obj, _, err := decodeCBOR(resp.AttestationObject)
+	if err != nil {
+		return nil, webauthnError("decode attestation object:%v", err)
+	}
+	attestation, ok := obj.(map[interface{}]interface{})
+	if !ok {
+		return nil, webauthnError("invalid attestation object")
+	}
+	format, _ := attestation["fmt"].(string)
+	rawAuthData, _ := attestation["authData"].([]byte)
+	stmt, _ := attestation["attStmt"].(map[interface{}]interface{})
+	data, err := rp.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if data.credID == nil {
+		return nil, webauthnError("attested credential data missing")
+	}
+	key, alg, err := ParsePublicKey(data.publicKey)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case "none":
+	case "packed":
+		stmtAlg, _ := stmt["alg"].(int64)
+		sig, _ := stmt["sig"].([]byte)
+		clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+		signed := append(append([]byte{}, rawAuthData...), clientDataHash[:]...)
+		// 有证书链时使用证书的公钥，否则为自签名证明
+		if x5c, ok := stmt["x5c"].([]interface{}); ok && len(x5c) > 0 {
+			der, _ := x5c[0].([]byte)
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, webauthnError("parse attestation certificate:%v", err)
+			}
+			key = cert.PublicKey
+		} else if stmtAlg != alg {
+			return nil, webauthnError("attestation algorithm not match")
+		}
+		if err := verifySignature(key, stmtAlg, signed, sig); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, webauthnError("unsupported attestation format %s", format)
+	}
+	aaguid := hex.EncodeToString(data.aaguid)
+	aaguid = fmt.Sprintf("%s-%s-%s-%s-%s", aaguid[:8], aaguid[8:12], aaguid[12:16], aaguid[16:20], aaguid[20:])
+	return &Credential{ID: data.credID, PublicKey: data.publicKey, AAGUID: aaguid, SignCount: data.signCount}, nil
+}
+
+// VerifyAssertion 校验navigator.credentials.get的结果，返回新的签名计数
+func (rp *RelyingParty) VerifyAssertion(challenge string, publicKey []byte, signCount uint32, resp *AssertionResponse) (uint32, error) {
+	if err := rp.verifyClientData(resp.ClientDataJSON, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+	data, err := rp.parseAuthenticatorData(resp.AuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+	key, alg, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return 0, err
+	}
+	clientDataHash := sha256.Sum256(resp.ClientDataJSON)
+	signed := append(append([]byte{}, resp.AuthenticatorData...), clientDataHash[:]...)
+	if err := verifySignature(key, alg, signed, resp.Signature); err != nil {
+		return 0, err
+	}
+	// 计数器不增加说明凭证可能被复制，不支持计数的认证器始终为0
+	if (data.signCount != 0 || signCount != 0) && data.signCount <= signCount {
+		return 0, webauthnError("sign count not increased")
+	}
+	return data.signCount, nil
+}
+
+// ParsePublicKey 解析cose编码的公钥，返回公钥和算法
+func ParsePublicKey(raw []byte) (crypto.PublicKey, int64, error) {
+	obj, _, err := decodeCBOR(raw)
+	if err != nil {
+		return nil, 0, webauthnError("decode public key:%v", err)
+	}
+	key, ok := obj.(map[interface{}]interface{})
+	if !ok {
+		return nil, 0, webauthnError("invalid public key")
+	}
+	kty, _ := key[int64(1)].(int64)
+	alg, _ := key[int64(3)].(int64)
+	switch kty {
+	case 1:
+		crv, _ := key[int64(-1)].(int64)
+		x, _ := key[int64(-2)].([]byte)
+		if crv != 6 || len(x) != ed25519.PublicKeySize || alg != COSEAlgEdDSA {
+			return nil, 0, webauthnError("unsupported okp key")
+		}
+		return ed25519.PublicKey(x), alg, nil
+	case 2:
+		crv, _ := key[int64(-1)].(int64)
+		x, _ := key[int64(-2)].([]byte)
+		y, _ := key[int64(-3)].([]byte)
+		curves := map[int64]elliptic.Curve{1: elliptic.P256(), 2: elliptic.P384(), 3: elliptic.P521()}
+		curve, ok := curves[crv]
+		if !ok || (alg != COSEAlgES256 && alg != COSEAlgES384 && alg != COSEAlgES512) {
+			return nil, 0, webauthnError("unsupported ec key")
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, 0, webauthnError("invalid ec point")
+		}
+		return pub, alg, nil
+	case 3:
+		n, _ := key[int64(-1)].([]byte)
+		e, _ := key[int64(-2)].([]byte)
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 || alg != COSEAlgRS256 {
+			return nil, 0, webauthnError("unsupported rsa key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, alg, nil
+	}
+	return nil, 0, webauthnError("unsupported key type %d", kty)
+}
+
+func verifySignature(key crypto.PublicKey, alg int64, data, sig []byte) error {
+	var h hash.Hash
+	switch alg {
+	case COSEAlgES256, COSEAlgRS256:
+		h = sha256.New()
+	case COSEAlgES384:
+		h = sha512.New384()
+	case COSEAlgES512:
+		h = sha512.New()
+	case COSEAlgEdDSA:
+	default:
+		return webauthnError("unsupported algorithm %d", alg)
+	}
+	var digest []byte
+	if h != nil {
+		h.Write(data)
+		digest = h.Sum(nil)
+	}
+	switch pub := key.(type) {
+	case *ecdsa.PublicKey:
+		if h != nil && alg != COSEAlgRS256 && ecdsa.VerifyASN1(pub, digest, sig) {
+			return nil
+		}
+	case *rsa.PublicKey:
+		if alg == COSEAlgRS256 && rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest, sig) == nil {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if alg == COSEAlgEdDSA && ed25519.Verify(pub, data, sig) {
+			return nil
+		}
+	}
+	return webauthnError("signature invalid")
+}
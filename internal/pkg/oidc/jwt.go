@@ -0,0 +1,198 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+
+	"github.com/begonia-org/begonia/internal/pkg"
+)
+
+// 支持的非对称签名算法，不接受none和HS系列算法
+var algorithms = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"ES256": crypto.SHA256,
+	"ES384": crypto.SHA384,
+	"ES512": crypto.SHA512,
+}
+
+type Header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+	Typ string `json:"typ,omitempty"`
+}
+
+type Claims map[string]interface{}
+
+func (c Claims) String(name string) string {
+	if val, ok := c[name].(string); ok {
+		return val
+	}
+	return ""
+}
+
+// Int64 json数字解码为float64
+func (c Claims) Int64(name string) (int64, bool) {
+	if val, ok := c[name].(float64); ok {
+		return int64(val), true
+	}
+	return 0, false
+}
+
+func (c Claims) Bool(name string) bool {
+	if val, ok := c[name].(bool); ok {
+		return val
+	}
+	return false
+}
+
+// Audience aud可以是字符串或者字符串数组
+func (c Claims) Audience() []string {
+	switch aud := c["aud"].(type) {
+	case string:
+		return []string{aud}
+	case []interface{}:
+		auds := make([]string, 0, len(aud))
+		for _, val := range aud {
+			if s, ok := val.(string); ok {
+				auds = append(auds, s)
+			}
+		}
+		return auds
+	}
+	return nil
+}
+
+// Token 解析后的jwt，Verify之前不能信任其中的内容
+type Token struct {
+	Header    *Header
+	Claims    Claims
+	signed    string
+	signature []byte
+}
+
+func ParseToken(raw string) (*Token, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, pkg.ErrHeaderTokenFormat
+	}
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", pkg.ErrDecode, err)
+	}
+	header := &Header{}
+	if err := json.Unmarshal(headerBytes, header); err != nil {
+		return nil, fmt.Errorf("%w:%w", pkg.ErrDecode, err)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", pkg.ErrDecode, err)
+	}
+	claims := Claims{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w:%w", pkg.ErrDecode, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", pkg.ErrDecode, err)
+	}
+	return &Token{Header: header, Claims: claims, signed: parts[0] + "." + parts[1], signature: signature}, nil
+}
+
+// Verify 使用公钥校验签名
+func (t *Token) Verify(key crypto.PublicKey) error {
+	hash, ok := algorithms[t.Header.Alg]
+	if !ok {
+		return fmt.Errorf("%w:%s", pkg.ErrTokenAlgorithm, t.Header.Alg)
+	}
+	h := hash.New()
+	h.Write([]byte(t.signed))
+	digest := h.Sum(nil)
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(t.Header.Alg, "RS") {
+			return fmt.Errorf("%w:%s", pkg.ErrTokenAlgorithm, t.Header.Alg)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, hash, digest, t.signature); err != nil {
+			return fmt.Errorf("%w:%w", pkg.ErrTokenInvalid, err)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(t.Header.Alg, "ES") || len(t.signature) != 2*size {
+			return fmt.Errorf("%w:%s", pkg.ErrTokenAlgorithm, t.Header.Alg)
+		}
+		r := new(big.Int).SetBytes(t.signature[:size])
+		s := new(big.Int).SetBytes(t.signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return pkg.ErrTokenInvalid
+		}
+		return nil
+	}
+	return fmt.Errorf("%w:%T", pkg.ErrTokenAlgorithm, key)
+}
+
+// Sign 使用RSA或EC私钥签发jwt
+func Sign(alg, kid string, key crypto.Signer, claims interface{}) (string, error) {
+	hash, ok := algorithms[alg]
+	if !ok {
+		return "", fmt.Errorf("%w:%s", pkg.ErrTokenAlgorithm, alg)
+	}
+	header, err := json.Marshal(&Header{Alg: alg, Kid: kid, Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+	var signature []byte
+	switch priv := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, priv, hash, digest)
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, priv, digest)
+		if err == nil {
+			size := (priv.Curve.Params().BitSize + 7) / 8
+			signature = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+		}
+	default:
+		err = fmt.Errorf("%w:%T", pkg.ErrTokenAlgorithm, key)
+	}
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// GenerateKey 生成签名算法对应的私钥
+func GenerateKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case "RS256", "RS384", "RS512":
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case "ES256":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ES384":
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "ES512":
+		return ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	}
+	return nil, fmt.Errorf("%w:%s", pkg.ErrTokenAlgorithm, alg)
+}
@@ -0,0 +1,123 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/begonia-org/begonia/internal/pkg"
+)
+
+const defaultJWKSCacheExpire = time.Hour
+
+// MinJWKSRefreshInterval 两次刷新jwks的最小间隔，避免伪造的kid导致频繁请求签发方
+var MinJWKSRefreshInterval = time.Second * 10
+
+// RemoteKeySet 缓存签发方的jwks，过期或者遇到未知的kid时重新获取，
+// 签发方轮换密钥后无需重启网关
+type RemoteKeySet struct {
+	uri    string
+	client *http.Client
+	expire time.Duration
+
+	mu        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	updatedAt time.Time
+	// 串行化刷新请求
+	refreshMu   sync.Mutex
+	refreshedAt time.Time
+}
+
+func NewRemoteKeySet(uri string, client *http.Client, expire time.Duration) *RemoteKeySet {
+	if expire <= 0 {
+		expire = defaultJWKSCacheExpire
+	}
+	return &RemoteKeySet{uri: uri, client: client, expire: expire, keys: make(map[string]crypto.PublicKey)}
+}
+
+func (r *RemoteKeySet) lookup(kid string) (crypto.PublicKey, bool, time.Time) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	updatedAt := r.updatedAt
+	if kid == "" {
+		// 没有kid时只能在签发方仅有一个密钥时使用
+		if len(r.keys) == 1 {
+			for _, key := range r.keys {
+				return key, true, updatedAt
+			}
+		}
+		return nil, false, updatedAt
+	}
+	key, ok := r.keys[kid]
+	return key, ok, updatedAt
+}
+
+// Key 根据kid获取公钥
+func (r *RemoteKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	key, ok, updatedAt := r.lookup(kid)
+	if ok && time.Since(updatedAt) < r.expire {
+		return key, nil
+	}
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+	// 等待锁期间其他请求可能已经刷新
+	key, ok, updatedAt = r.lookup(kid)
+	if ok && time.Since(updatedAt) < r.expire {
+		return key, nil
+	}
+	if time.Since(r.refreshedAt) >= MinJWKSRefreshInterval {
+		r.refreshedAt = time.Now()
+		err := r.refresh(ctx)
+		// 签发方不可用时继续使用缓存的公钥
+		if err != nil && !ok {
+			return nil, err
+		}
+		if err == nil {
+			key, ok, _ = r.lookup(kid)
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w:%s", pkg.ErrJWKNotFound, kid)
+	}
+	return key, nil
+}
+
+func (r *RemoteKeySet) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.uri, nil)
+	if err != nil {
+		return err
+	}
+	rsp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch jwks from %s error: %w", r.uri, err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch jwks from %s error: status %d", r.uri, rsp.StatusCode)
+	}
+	set := &JSONWebKeySet{}
+	if err := json.NewDecoder(rsp.Body).Decode(set); err != nil {
+		return fmt.Errorf("decode jwks from %s error: %w", r.uri, err)
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			// 跳过不支持的密钥类型
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	r.mu.Lock()
+	r.keys = keys
+	r.updatedAt = time.Now()
+	r.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,154 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/begonia/internal/pkg/oidc"
+	"github.com/begonia-org/begonia/internal/pkg/oidc/oidctest"
+	c "github.com/smartystreets/goconvey/convey"
+)
+
+func TestJSONWebKey(t *testing.T) {
+	c.Convey("test ecdsa jwk sign and verify", t, func() {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		c.So(err, c.ShouldBeNil)
+		jwk, err := oidc.NewJSONWebKey("ec-1", "ES256", &priv.PublicKey)
+		c.So(err, c.ShouldBeNil)
+		c.So(jwk.Kty, c.ShouldEqual, "EC")
+		c.So(jwk.Crv, c.ShouldEqual, "P-256")
+		pub, err := jwk.PublicKey()
+		c.So(err, c.ShouldBeNil)
+
+		raw, err := oidc.Sign("ES256", "ec-1", priv, oidc.Claims{"sub": "user-1"})
+		c.So(err, c.ShouldBeNil)
+		token, err := oidc.ParseToken(raw)
+		c.So(err, c.ShouldBeNil)
+		c.So(token.Header.Kid, c.ShouldEqual, "ec-1")
+		c.So(token.Claims.String("sub"), c.ShouldEqual, "user-1")
+		c.So(token.Verify(pub), c.ShouldBeNil)
+
+		// 篡改payload
+		parts := strings.Split(raw, ".")
+		parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin"}`))
+		token, err = oidc.ParseToken(strings.Join(parts, "."))
+		c.So(err, c.ShouldBeNil)
+		c.So(errors.Is(token.Verify(pub), pkg.ErrTokenInvalid), c.ShouldBeTrue)
+
+		_, err = (&oidc.JSONWebKey{Kty: "oct"}).PublicKey()
+		c.So(err, c.ShouldNotBeNil)
+	})
+}
+
+func TestProviderVerify(t *testing.T) {
+	c.Convey("test provider verify", t, func() {
+		issuer, err := oidctest.NewIssuer("begonia", "secret")
+		c.So(err, c.ShouldBeNil)
+		defer issuer.Close()
+		provider := oidc.NewProvider(&config.OIDCIssuer{Name: "mock", Issuer: issuer.URL, ClientID: "begonia", UIDClaim: "email"}, nil)
+		ctx := context.Background()
+
+		token, err := issuer.Sign(oidc.Claims{"sub": "external-1", "email": "user@example.com"})
+		c.So(err, c.ShouldBeNil)
+		c.So(oidc.Issuer(token), c.ShouldEqual, issuer.URL)
+		claims, err := provider.Verify(ctx, token)
+		c.So(err, c.ShouldBeNil)
+		c.So(provider.Subject(claims), c.ShouldEqual, "user@example.com")
+
+		token, _ = issuer.Sign(oidc.Claims{"sub": "external-1", "aud": "other"})
+		_, err = provider.Verify(ctx, token)
+		c.So(errors.Is(err, pkg.ErrTokenAudience), c.ShouldBeTrue)
+
+		token, _ = issuer.Sign(oidc.Claims{"sub": "external-1", "exp": time.Now().Add(-time.Hour).Unix()})
+		_, err = provider.Verify(ctx, token)
+		c.So(errors.Is(err, pkg.ErrTokenExpired), c.ShouldBeTrue)
+
+		token, _ = issuer.Sign(oidc.Claims{"sub": "external-1", "iss": "https://evil.example.com"})
+		_, err = provider.Verify(ctx, token)
+		c.So(errors.Is(err, pkg.ErrTokenIssuer), c.ShouldBeTrue)
+
+		// 不接受none和HS签名
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+		payload := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"` + issuer.URL + `","sub":"admin"}`))
+		_, err = provider.Verify(ctx, header+"."+payload+".")
+		c.So(errors.Is(err, pkg.ErrTokenAlgorithm), c.ShouldBeTrue)
+
+		// 签发方轮换密钥后通过kid重新获取jwks
+		oidc.MinJWKSRefreshInterval = 0
+		defer func() { oidc.MinJWKSRefreshInterval = time.Second * 10 }()
+		old, _ := issuer.Sign(oidc.Claims{"sub": "external-1"})
+		c.So(issuer.RotateKey(), c.ShouldBeNil)
+		token, _ = issuer.Sign(oidc.Claims{"sub": "external-1"})
+		_, err = provider.Verify(ctx, token)
+		c.So(err, c.ShouldBeNil)
+		_, err = provider.Verify(ctx, old)
+		c.So(err, c.ShouldBeNil)
+
+		// 缓存过期后已删除的密钥不再有效
+		issuer.DropOldKeys()
+		provider = oidc.NewProvider(&config.OIDCIssuer{Name: "mock", Issuer: issuer.URL, ClientID: "begonia"}, nil)
+		_, err = provider.Verify(ctx, old)
+		c.So(errors.Is(err, pkg.ErrJWKNotFound), c.ShouldBeTrue)
+	})
+}
+
+func TestProviderAuthCode(t *testing.T) {
+	c.Convey("test provider auth code flow", t, func() {
+		issuer, err := oidctest.NewIssuer("begonia", "secret")
+		c.So(err, c.ShouldBeNil)
+		defer issuer.Close()
+		issuer.SetUser(oidc.Claims{"sub": "external-2", "email": "user2@example.com"})
+		providers := oidc.NewProviders([]*config.OIDCIssuer{{Name: "mock", Issuer: issuer.URL, ClientID: "begonia", ClientSecret: "secret", RedirectURL: "http://127.0.0.1/callback"}}, nil)
+		provider, ok := providers.Get("mock")
+		c.So(ok, c.ShouldBeTrue)
+		_, ok = providers.GetByIssuer(issuer.URL)
+		c.So(ok, c.ShouldBeTrue)
+		_, ok = providers.Get("unknown")
+		c.So(ok, c.ShouldBeFalse)
+
+		ctx := context.Background()
+		verifier, _ := oidc.RandomString()
+		uri, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", verifier)
+		c.So(err, c.ShouldBeNil)
+		c.So(uri, c.ShouldStartWith, issuer.URL+"/authorize?")
+
+		client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}}
+		rsp, err := client.Get(uri)
+		c.So(err, c.ShouldBeNil)
+		rsp.Body.Close()
+		c.So(rsp.StatusCode, c.ShouldEqual, http.StatusFound)
+		location, err := url.Parse(rsp.Header.Get("Location"))
+		c.So(err, c.ShouldBeNil)
+		c.So(location.Query().Get("state"), c.ShouldEqual, "state-1")
+		code := location.Query().Get("code")
+
+		_, err = provider.Exchange(ctx, code, "wrong-verifier")
+		c.So(err, c.ShouldNotBeNil)
+
+		// 授权码只能使用一次
+		_, err = provider.Exchange(ctx, code, verifier)
+		c.So(err, c.ShouldNotBeNil)
+		rsp, _ = client.Get(uri)
+		rsp.Body.Close()
+		location, _ = url.Parse(rsp.Header.Get("Location"))
+		token, err := provider.Exchange(ctx, location.Query().Get("code"), verifier)
+		c.So(err, c.ShouldBeNil)
+		claims, err := provider.Verify(ctx, token.IDToken)
+		c.So(err, c.ShouldBeNil)
+		c.So(claims.String("nonce"), c.ShouldEqual, "nonce-1")
+		c.So(provider.Subject(claims), c.ShouldEqual, "external-2")
+	})
+}
@@ -0,0 +1,177 @@
+// Package oidctest 提供用于测试的本地oidc签发方
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/begonia-org/begonia/internal/pkg/oidc"
+)
+
+type signingKey struct {
+	kid string
+	key *rsa.PrivateKey
+}
+
+type authCode struct {
+	claims    oidc.Claims
+	nonce     string
+	challenge string
+	redirect  string
+}
+
+// Issuer 提供discovery、jwks、authorize和token接口，
+// authorize接口直接以当前用户登录并跳转回redirect_uri
+type Issuer struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	mu    sync.Mutex
+	keys  []*signingKey
+	codes map[string]*authCode
+	user  oidc.Claims
+}
+
+func NewIssuer(clientID, clientSecret string) (*Issuer, error) {
+	issuer := &Issuer{ClientID: clientID, ClientSecret: clientSecret, codes: make(map[string]*authCode), user: oidc.Claims{}}
+	if err := issuer.RotateKey(); err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.discovery)
+	mux.HandleFunc("/jwks", issuer.jwks)
+	mux.HandleFunc("/authorize", issuer.authorize)
+	mux.HandleFunc("/token", issuer.token)
+	issuer.Server = httptest.NewServer(mux)
+	return issuer, nil
+}
+
+// RotateKey 生成新的签名密钥，旧的密钥仍然保留在jwks中
+func (i *Issuer) RotateKey() error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.keys = append(i.keys, &signingKey{kid: fmt.Sprintf("key-%d", len(i.keys)+1), key: key})
+	return nil
+}
+
+// DropOldKeys 只保留当前的签名密钥
+func (i *Issuer) DropOldKeys() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.keys = i.keys[len(i.keys)-1:]
+}
+
+// SetUser 设置authorize接口登录的用户
+func (i *Issuer) SetUser(claims oidc.Claims) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.user = claims
+}
+
+// Sign 使用当前密钥签发token，未设置的iss、aud、iat和exp使用默认值
+func (i *Issuer) Sign(claims oidc.Claims) (string, error) {
+	payload := oidc.Claims{
+		"iss": i.URL,
+		"aud": i.ClientID,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		payload[k] = v
+	}
+	i.mu.Lock()
+	key := i.keys[len(i.keys)-1]
+	i.mu.Unlock()
+	return oidc.Sign("RS256", key.kid, key.key, payload)
+}
+
+func (i *Issuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, &oidc.Discovery{
+		Issuer:                i.URL,
+		AuthorizationEndpoint: i.URL + "/authorize",
+		TokenEndpoint:         i.URL + "/token",
+		JWKSURI:               i.URL + "/jwks",
+	})
+}
+
+func (i *Issuer) jwks(w http.ResponseWriter, r *http.Request) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	set := &oidc.JSONWebKeySet{Keys: make([]*oidc.JSONWebKey, 0, len(i.keys))}
+	for _, key := range i.keys {
+		jwk, _ := oidc.NewJSONWebKey(key.kid, "RS256", &key.key.PublicKey)
+		set.Keys = append(set.Keys, jwk)
+	}
+	writeJSON(w, http.StatusOK, set)
+}
+
+func (i *Issuer) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != i.ClientID || query.Get("response_type") != "code" {
+		writeJSON(w, http.StatusBadRequest, &oidc.TokenResponse{Error: "invalid_request"})
+		return
+	}
+	code, _ := oidc.RandomString()
+	i.mu.Lock()
+	i.codes[code] = &authCode{claims: i.user, nonce: query.Get("nonce"), challenge: query.Get("code_challenge"), redirect: query.Get("redirect_uri")}
+	i.mu.Unlock()
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, &oidc.TokenResponse{Error: "invalid_request"})
+		return
+	}
+	values := redirect.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirect.RawQuery = values.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (i *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	clientID, secret, ok := r.BasicAuth()
+	if !ok || clientID != i.ClientID || secret != i.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, &oidc.TokenResponse{Error: "invalid_client"})
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, &oidc.TokenResponse{Error: "invalid_request"})
+		return
+	}
+	i.mu.Lock()
+	code, ok := i.codes[r.PostForm.Get("code")]
+	delete(i.codes, r.PostForm.Get("code"))
+	i.mu.Unlock()
+	if !ok || code.redirect != r.PostForm.Get("redirect_uri") || code.challenge != oidc.CodeChallenge(r.PostForm.Get("code_verifier")) {
+		writeJSON(w, http.StatusBadRequest, &oidc.TokenResponse{Error: "invalid_grant"})
+		return
+	}
+	claims := oidc.Claims{"nonce": code.nonce}
+	for k, v := range code.claims {
+		claims[k] = v
+	}
+	idToken, err := i.Sign(claims)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, &oidc.TokenResponse{Error: "server_error"})
+		return
+	}
+	accessToken, _ := i.Sign(code.claims)
+	writeJSON(w, http.StatusOK, &oidc.TokenResponse{AccessToken: accessToken, IDToken: idToken, TokenType: "Bearer", ExpiresIn: 3600})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,297 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/begonia-org/begonia/internal/pkg"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+)
+
+// 校验exp和nbf时允许的时钟偏差
+const clockSkew = time.Minute
+
+// Discovery 签发方/.well-known/openid-configuration的内容
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type TokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	RefreshToken     string `json:"refresh_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type Provider struct {
+	cfg    *config.OIDCIssuer
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *Discovery
+	keys      *RemoteKeySet
+}
+
+func NewProvider(cfg *config.OIDCIssuer, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: time.Second * 10}
+	}
+	return &Provider{cfg: cfg, client: client}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *Provider) Issuer() string {
+	return p.cfg.Issuer
+}
+
+func (p *Provider) Config() *config.OIDCIssuer {
+	return p.cfg
+}
+
+// Discover 第一次使用时获取签发方的配置，签发方不可用时不影响网关启动
+func (p *Provider) Discover(ctx context.Context) (*Discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	uri := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("discover %s error: %w", p.cfg.Issuer, err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discover %s error: status %d", p.cfg.Issuer, rsp.StatusCode)
+	}
+	discovery := &Discovery{}
+	if err := json.NewDecoder(rsp.Body).Decode(discovery); err != nil {
+		return nil, fmt.Errorf("decode discovery of %s error: %w", p.cfg.Issuer, err)
+	}
+	if discovery.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("%w:%s", pkg.ErrTokenIssuer, discovery.Issuer)
+	}
+	p.discovery = discovery
+	return discovery, nil
+}
+
+func (p *Provider) keySet(ctx context.Context) (*RemoteKeySet, error) {
+	p.mu.Lock()
+	keys := p.keys
+	p.mu.Unlock()
+	if keys != nil {
+		return keys, nil
+	}
+	uri := p.cfg.JWKSURI
+	if uri == "" {
+		discovery, err := p.Discover(ctx)
+		if err != nil {
+			return nil, err
+		}
+		uri = discovery.JWKSURI
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keys == nil {
+		p.keys = NewRemoteKeySet(uri, p.client, time.Duration(p.cfg.JWKSCacheExpire)*time.Second)
+	}
+	return p.keys, nil
+}
+
+func (p *Provider) audiences() []string {
+	if len(p.cfg.Audiences) > 0 {
+		return p.cfg.Audiences
+	}
+	if p.cfg.ClientID != "" {
+		return []string{p.cfg.ClientID}
+	}
+	return nil
+}
+
+// Verify 校验签发方签发的id token或access token
+func (p *Provider) Verify(ctx context.Context, raw string) (Claims, error) {
+	token, err := ParseToken(raw)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := algorithms[token.Header.Alg]; !ok {
+		return nil, fmt.Errorf("%w:%s", pkg.ErrTokenAlgorithm, token.Header.Alg)
+	}
+	if iss := token.Claims.String("iss"); iss != p.cfg.Issuer {
+		return nil, fmt.Errorf("%w:%s", pkg.ErrTokenIssuer, iss)
+	}
+	keys, err := p.keySet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keys.Key(ctx, token.Header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := token.Verify(key); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	exp, ok := token.Claims.Int64("exp")
+	if !ok || now.Add(-clockSkew).Unix() > exp {
+		return nil, pkg.ErrTokenExpired
+	}
+	if nbf, ok := token.Claims.Int64("nbf"); ok && now.Add(clockSkew).Unix() < nbf {
+		return nil, pkg.ErrTokenNotActive
+	}
+	if auds := p.audiences(); len(auds) > 0 && !containsAny(token.Claims.Audience(), auds) {
+		return nil, fmt.Errorf("%w:%v", pkg.ErrTokenAudience, token.Claims.Audience())
+	}
+	return token.Claims, nil
+}
+
+// Subject 作为外部用户标识的claim
+func (p *Provider) Subject(claims Claims) string {
+	if p.cfg.UIDClaim != "" {
+		return claims.String(p.cfg.UIDClaim)
+	}
+	return claims.String("sub")
+}
+
+// AuthCodeURL 授权码登录地址，使用PKCE防止授权码被截获后使用
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	discovery, err := p.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+	values := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {CodeChallenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return discovery.AuthorizationEndpoint + sep + values.Encode(), nil
+}
+
+// Exchange 使用授权码换取token
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (*TokenResponse, error) {
+	discovery, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	rsp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("exchange code from %s error: %w", p.cfg.Issuer, err)
+	}
+	defer rsp.Body.Close()
+	token := &TokenResponse{}
+	if err := json.NewDecoder(rsp.Body).Decode(token); err != nil {
+		return nil, fmt.Errorf("decode token response of %s error: %w", p.cfg.Issuer, err)
+	}
+	if rsp.StatusCode != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("exchange code from %s error: status %d %s %s", p.cfg.Issuer, rsp.StatusCode, token.Error, token.ErrorDescription)
+	}
+	return token, nil
+}
+
+func containsAny(values []string, expected []string) bool {
+	for _, val := range values {
+		for _, e := range expected {
+			if val == e {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RandomString 生成state、nonce和code verifier
+func RandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Providers 配置的所有外部签发方
+type Providers struct {
+	byName   map[string]*Provider
+	byIssuer map[string]*Provider
+}
+
+func NewProviders(issuers []*config.OIDCIssuer, client *http.Client) *Providers {
+	providers := &Providers{byName: make(map[string]*Provider), byIssuer: make(map[string]*Provider)}
+	for _, issuer := range issuers {
+		provider := NewProvider(issuer, client)
+		providers.byName[issuer.Name] = provider
+		providers.byIssuer[issuer.Issuer] = provider
+	}
+	return providers
+}
+
+func (p *Providers) Get(name string) (*Provider, bool) {
+	provider, ok := p.byName[name]
+	return provider, ok
+}
+
+func (p *Providers) GetByIssuer(issuer string) (*Provider, bool) {
+	provider, ok := p.byIssuer[issuer]
+	return provider, ok
+}
+
+// Issuer 未校验签名的token的签发方，用于选择校验方式
+func Issuer(raw string) string {
+	token, err := ParseToken(raw)
+	if err != nil {
+		return ""
+	}
+	return token.Claims.String("iss")
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"sync"
 
+	rbac "github.com/begonia-org/begonia/api/rbac/v1"
 	"github.com/begonia-org/begonia/gateway"
 	_ "github.com/begonia-org/go-sdk/api/app/v1"
 	_ "github.com/begonia-org/go-sdk/api/endpoint/v1"
@@ -33,6 +34,10 @@ type APIMethodDetails struct {
 	UseJsonResponse bool
 	RequestMethod   string
 	GrpcFullRouter  string
+	// 调用方法需要的权限
+	Permission string
+	// 方法所属端点的标签
+	Tags []string
 }
 type HttpURIRouteToSrvMethod struct {
 	routers    map[string]*APIMethodDetails
@@ -96,6 +101,16 @@ func (r *HttpURIRouteToSrvMethod) getHttpRule(method *descriptorpb.MethodDescrip
 	}
 	return nil
 }
+
+// getPermission 方法的权限，未设置permission选项时使用grpc方法全名
+func (r *HttpURIRouteToSrvMethod) getPermission(fullMethod string, method *descriptorpb.MethodDescriptorProto) string {
+	if options := method.GetOptions(); options != nil {
+		if permission, ok := proto.GetExtension(options, rbac.E_Permission).(string); ok && permission != "" {
+			return permission
+		}
+	}
+	return strings.TrimPrefix(fullMethod, "/")
+}
 func (r *HttpURIRouteToSrvMethod) AddLocalSrv(fullMethod string) {
 	r.localSrv[strings.ToUpper(fullMethod)] = true
 }
@@ -139,7 +154,7 @@ func (h *HttpURIRouteToSrvMethod) DeleteRouterDetails(fullMethod string, method
 	uri, _ := h.getUri(method)
 	h.deleteRoute(uri, fullMethod)
 }
-func (r *HttpURIRouteToSrvMethod) addRouterDetails(serviceName string, useJsonResponse, authRequired bool, methodName *descriptorpb.MethodDescriptorProto) {
+func (r *HttpURIRouteToSrvMethod) addRouterDetails(serviceName string, useJsonResponse, authRequired bool, permission string, methodName *descriptorpb.MethodDescriptorProto) {
 	// 获取并打印 google.api.http 注解
 	if path, method := r.getUri(methodName); path != "" {
 		r.AddRoute(path, &APIMethodDetails{
@@ -149,6 +164,7 @@ func (r *HttpURIRouteToSrvMethod) addRouterDetails(serviceName string, useJsonRe
 			RequestMethod:   method,
 			GrpcFullRouter:  serviceName,
 			UseJsonResponse: useJsonResponse,
+			Permission:      permission,
 		})
 
 	}
@@ -171,7 +187,7 @@ func (r *HttpURIRouteToSrvMethod) LoadAllRouters(pd gateway.ProtobufDescription)
 			// 遍历服务中的所有方法
 			for _, method := range service.GetMethod() {
 				key := fmt.Sprintf("/%s.%s/%s", fd.GetPackage(), service.GetName(), method.GetName())
-				r.addRouterDetails(strings.ToUpper(key), httpResponse, authRequired, method)
+				r.addRouterDetails(strings.ToUpper(key), httpResponse, authRequired, r.getPermission(key, method), method)
 			}
 
 		}
@@ -179,6 +195,23 @@ func (r *HttpURIRouteToSrvMethod) LoadAllRouters(pd gateway.ProtobufDescription)
 
 }
 
+// SetTags 设置描述文件中所有方法所属端点的标签
+func (r *HttpURIRouteToSrvMethod) SetTags(pd gateway.ProtobufDescription, tags []string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	fds := pd.GetFileDescriptorSet()
+	for _, fd := range fds.File {
+		for _, service := range fd.Service {
+			for _, method := range service.GetMethod() {
+				key := fmt.Sprintf("/%s.%s/%s", fd.GetPackage(), service.GetName(), method.GetName())
+				if router := r.grpcRouter[strings.ToUpper(key)]; router != nil {
+					router.Tags = tags
+				}
+			}
+		}
+	}
+}
+
 func (h *HttpURIRouteToSrvMethod) DeleteRouters(pd gateway.ProtobufDescription) {
 	fds := pd.GetFileDescriptorSet()
 	for _, fd := range fds.File {
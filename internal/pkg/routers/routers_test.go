@@ -5,6 +5,8 @@ import (
 	"runtime"
 	"testing"
 
+	ak "github.com/begonia-org/begonia/api/accesskey/v1"
+	rbac "github.com/begonia-org/begonia/api/rbac/v1"
 	"github.com/begonia-org/begonia/gateway"
 	"github.com/begonia-org/begonia/internal/pkg/routers"
 	c "github.com/smartystreets/goconvey/convey"
@@ -49,3 +51,33 @@ func TestDeleteRouters(t *testing.T) {
 		c.So(d, c.ShouldBeNil)
 	})
 }
+func TestRouterPermission(t *testing.T) {
+	c.Convey("TestRouterPermission", t, func() {
+		R := routers.NewHttpURIRouteToSrvMethod()
+		pd, err := gateway.NewDescriptionFromFileDescriptor(rbac.File_rbac_proto, filepath.Join(t.TempDir(), "rbac"))
+		c.So(err, c.ShouldBeNil)
+		R.LoadAllRouters(pd)
+		d := R.GetRouteByGrpcMethod("/begonia.org.rbac.RBACService/PutRole")
+		c.So(d, c.ShouldNotBeNil)
+		c.So(d.AuthRequired, c.ShouldBeTrue)
+		c.So(d.Permission, c.ShouldEqual, "rbac:roles:write")
+		d = R.GetRouteByGrpcMethod("/begonia.org.rbac.RBACService/ListBindings")
+		c.So(d, c.ShouldNotBeNil)
+		c.So(d.Permission, c.ShouldEqual, "rbac:bindings:read")
+	})
+}
+func TestRouterTags(t *testing.T) {
+	c.Convey("TestRouterTags", t, func() {
+		R := routers.NewHttpURIRouteToSrvMethod()
+		pd, err := gateway.NewDescriptionFromFileDescriptor(ak.File_accesskey_proto, filepath.Join(t.TempDir(), "accesskey"))
+		c.So(err, c.ShouldBeNil)
+		R.LoadAllRouters(pd)
+		R.SetTags(pd, []string{"admin"})
+		d := R.GetRouteByGrpcMethod("/begonia.org.accesskey.AccessKeyService/Rotate")
+		c.So(d, c.ShouldNotBeNil)
+		c.So(d.Tags, c.ShouldResemble, []string{"admin"})
+		// 重新加载后使用新的标签
+		R.LoadAllRouters(pd)
+		c.So(R.GetRouteByGrpcMethod("/begonia.org.accesskey.AccessKeyService/Rotate").Tags, c.ShouldBeEmpty)
+	})
+}
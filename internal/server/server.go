@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/begonia-org/begonia/gateway"
 	"github.com/begonia-org/begonia/internal/middleware"
@@ -27,7 +28,9 @@ func NewGatewayConfig(gw string) *gateway.GatewayConfig {
 	_, port, _ := net.SplitHostPort(gw)
 	p, _ := strconv.Atoi(port)
 	return &gateway.GatewayConfig{
-		GrpcProxyAddr: fmt.Sprintf(":%d", p+1),
+		// grpc代理只供http网关转发使用，信任网关写入的身份头，仅监听本机回环地址，
+		// 外部的grpc客户端通过网关端口接入
+		GrpcProxyAddr: fmt.Sprintf("127.0.0.1:%d", p+1),
 		GatewayAddr:   gw,
 	}
 }
@@ -47,7 +50,72 @@ func readDesc(conf *config.Config) (gateway.ProtobufDescription, error) {
 	}
 	return pd, nil
 }
-func NewGateway(cfg *gateway.GatewayConfig, conf *config.Config, services []service.Service, pluginApply *middleware.PluginsApply) *gateway.GatewayServer {
+
+// readServiceDesc 使用服务编译到程序中的描述文件
+func readServiceDesc(conf *config.Config, srv service.DescriptorService) (gateway.ProtobufDescription, error) {
+	pd, err := gateway.NewDescriptionFromFileDescriptor(srv.FileDescriptor(), filepath.Join(conf.GetGatewayDescriptionOut(), srv.Desc().ServiceName))
+	if err != nil {
+		return nil, err
+	}
+	err = pd.SetHttpResponse(common.E_HttpResponse)
+	if err != nil {
+		return nil, err
+	}
+	return pd, nil
+}
+func newHealthCheckOptions(health *config.HealthCheck) *gateway.HealthCheckOptions {
+	opts := gateway.DefaultHealthCheckOptions()
+	if health.Interval > 0 {
+		opts.Interval = time.Duration(health.Interval) * time.Second
+	}
+	if health.Timeout > 0 {
+		opts.Timeout = time.Duration(health.Timeout) * time.Second
+	}
+	if health.UnhealthyThreshold > 0 {
+		opts.UnhealthyThreshold = health.UnhealthyThreshold
+	}
+	if health.ErrorRate > 0 {
+		opts.ErrorRateThreshold = health.ErrorRate
+	}
+	if health.MinRequests > 0 {
+		opts.MinRequests = health.MinRequests
+	}
+	if health.EjectionTime > 0 {
+		opts.EjectionTime = time.Duration(health.EjectionTime) * time.Second
+	}
+	if health.MaxEjectionTime > 0 {
+		opts.MaxEjectionTime = time.Duration(health.MaxEjectionTime) * time.Second
+	}
+	return opts
+}
+
+func newCircuitBreakerOptions(breaker *config.CircuitBreaker) *gateway.CircuitBreakerOptions {
+	opts := gateway.DefaultCircuitBreakerOptions()
+	if breaker.Window > 0 {
+		opts.Window = time.Duration(breaker.Window) * time.Second
+	}
+	if breaker.MinRequests > 0 {
+		opts.MinRequests = breaker.MinRequests
+	}
+	if breaker.ErrorRate > 0 {
+		opts.ErrorRateThreshold = breaker.ErrorRate
+	}
+	if breaker.SlowCallDuration > 0 {
+		opts.SlowCallThreshold = time.Duration(breaker.SlowCallDuration) * time.Millisecond
+	}
+	if breaker.SlowCallRate > 0 {
+		opts.SlowCallRateThreshold = breaker.SlowCallRate
+	}
+	if breaker.OpenTimeout > 0 {
+		opts.OpenTimeout = time.Duration(breaker.OpenTimeout) * time.Second
+	}
+	if breaker.HalfOpenRequests > 0 {
+		opts.HalfOpenRequests = breaker.HalfOpenRequests
+	}
+	return opts
+}
+
+func NewGateway(cfg *gateway.GatewayConfig, conf *config.Config, services []service.Service, jwks *service.JWKSService, pluginApply *middleware.PluginsApply) *gateway.GatewayServer {
 	// 参数选项
 	opts := &gateway.GrpcServerOptions{
 		Middlewares:     make([]gateway.GrpcProxyMiddleware, 0),
@@ -69,32 +137,125 @@ func NewGateway(cfg *gateway.GatewayConfig, conf *config.Config, services []serv
 	// 连接池配置
 	opts.PoolOptions = append(opts.PoolOptions, loadbalance.WithMaxActiveConns(100))
 	opts.PoolOptions = append(opts.PoolOptions, loadbalance.WithPoolSize(128))
-	// 中间件配置
+	// 中间件配置，指标统计和链路追踪位于最外层
+	opts.Options = append(opts.Options, grpc.ChainUnaryInterceptor(gateway.MetricsUnaryInterceptor, gateway.TracingUnaryInterceptor))
+	opts.Options = append(opts.Options, grpc.ChainStreamInterceptor(gateway.MetricsStreamInterceptor, gateway.TracingStreamInterceptor))
 	opts.Options = append(opts.Options, grpc.ChainUnaryInterceptor(pluginApply.UnaryInterceptorChains()...))
 	opts.Options = append(opts.Options, grpc.ChainStreamInterceptor(pluginApply.StreamInterceptorChains()...))
 
+	// 端点健康检查
+	health, err := conf.GetHealthCheck()
+	if err != nil {
+		panic(err)
+	}
+	if health.Enabled {
+		opts.HealthCheck = newHealthCheckOptions(health)
+	}
+	// 端点熔断
+	breaker, err := conf.GetCircuitBreaker()
+	if err != nil {
+		panic(err)
+	}
+	if breaker.Enabled {
+		opts.CircuitBreaker = newCircuitBreakerOptions(breaker)
+	}
 	cors := &gateway.CorsHandler{
 		Cors: conf.GetCorsConfig(),
 	}
 	opts.HttpHandlers = append(opts.HttpHandlers, cors.Handle)
+	opts.HttpHandlers = append(opts.HttpHandlers, gateway.TracingHandler)
+	// 链路追踪
+	tracing, err := conf.GetTracing()
+	if err != nil {
+		panic(err)
+	}
+	if tracing.Enabled {
+		opts.Tracing = &gateway.TracingOptions{
+			Endpoint:    tracing.Endpoint,
+			Insecure:    tracing.Insecure,
+			ServiceName: tracing.ServiceName,
+			SampleRatio: tracing.SampleRatio,
+		}
+	}
+	// 网关监听的tls
+	tlsConfig, err := conf.GetTLS()
+	if err != nil {
+		panic(err)
+	}
+	if tlsConfig.Enabled {
+		clientAuth, err := gateway.ParseClientAuth(tlsConfig.ClientAuth)
+		if err != nil {
+			panic(err)
+		}
+		opts.TLS = &gateway.TLSOptions{
+			CertFile:       tlsConfig.CertFile,
+			KeyFile:        tlsConfig.KeyFile,
+			ClientCAFile:   tlsConfig.ClientCAFile,
+			ClientAuth:     clientAuth,
+			ReloadInterval: time.Duration(tlsConfig.ReloadInterval) * time.Second,
+		}
+	}
 	gw := gateway.New(cfg, opts)
+	gateway.Captures().SetDir(conf.GetCaptureDir())
 
 	pd, err := readDesc(conf)
 	if err != nil {
 		panic(err)
 	}
 	routersList := routers.Get()
+	localMethods := make([]string, 0)
 	for _, srv := range services {
-		err := gw.RegisterLocalService(context.Background(), pd, srv.Desc(), srv)
+		srvPd := pd
+		if ds, ok := srv.(service.DescriptorService); ok {
+			srvPd, err = readServiceDesc(conf, ds)
+			if err != nil {
+				panic(err)
+			}
+			routersList.LoadAllRouters(srvPd)
+			err = gw.RegisterOpenAPI(&gateway.OpenAPIService{ID: srv.Desc().ServiceName, Pd: srvPd, WrapResponse: true})
+			if err != nil {
+				panic(err)
+			}
+		}
+		err := gw.RegisterLocalService(context.Background(), srvPd, srv.Desc(), srv)
 		if err != nil {
 			panic(err)
 		}
 		for _, method := range srv.Desc().Methods {
 			routersList.AddLocalSrv(fmt.Sprintf("/%s/%s", srv.Desc().ServiceName, method.MethodName))
+			localMethods = append(localMethods, fmt.Sprintf("/%s/%s", srv.Desc().ServiceName, method.MethodName))
+		}
+		for _, stream := range srv.Desc().Streams {
+			localMethods = append(localMethods, fmt.Sprintf("/%s/%s", srv.Desc().ServiceName, stream.StreamName))
 		}
 
 	}
 	routersList.LoadAllRouters(pd)
+	// 网关内置服务的响应会被包装为统一的格式
+	err = gw.RegisterOpenAPI(&gateway.OpenAPIService{ID: gateway.OpenAPILocalServices, Pd: pd, WrapResponse: true, Methods: localMethods})
+	if err != nil {
+		panic(err)
+	}
+	err = gw.HandlePath(http.MethodGet, "/openapi.json", gw.OpenAPIHandler())
+	if err != nil {
+		panic(err)
+	}
+	err = gw.HandlePath(http.MethodGet, "/metrics", gateway.MetricsHandler())
+	if err != nil {
+		panic(err)
+	}
+	err = gw.HandlePath(http.MethodGet, "/healthz", gw.HealthzHandler())
+	if err != nil {
+		panic(err)
+	}
+	err = gw.HandlePath(http.MethodGet, "/readyz", gw.ReadyzHandler())
+	if err != nil {
+		panic(err)
+	}
+	err = gw.HandlePath(http.MethodGet, "/.well-known/jwks.json", jwks.Handler)
+	if err != nil {
+		panic(err)
+	}
 
 	return gw
 }
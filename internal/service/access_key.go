@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	api "github.com/begonia-org/begonia/api/accesskey/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/go-sdk/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type AccessKeyService struct {
+	api.UnimplementedAccessKeyServiceServer
+	biz *biz.AppUsecase
+	log logger.Logger
+}
+
+func NewAccessKeyService(biz *biz.AppUsecase, log logger.Logger) api.AccessKeyServiceServer {
+	return &AccessKeyService{biz: biz, log: log}
+}
+
+func (a *AccessKeyService) Create(ctx context.Context, in *api.CreateAccessKeyRequest) (*api.AccessKey, error) {
+	return a.biz.CreateKey(ctx, in, GetIdentity(ctx))
+}
+
+func (a *AccessKeyService) List(ctx context.Context, in *api.ListAccessKeysRequest) (*api.ListAccessKeysResponse, error) {
+	keys, err := a.biz.ListKeys(ctx, in.Appid)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListAccessKeysResponse{Keys: keys}, nil
+}
+
+func (a *AccessKeyService) Update(ctx context.Context, in *api.UpdateAccessKeyRequest) (*api.AccessKey, error) {
+	return a.biz.UpdateKey(ctx, in)
+}
+
+func (a *AccessKeyService) Rotate(ctx context.Context, in *api.RotateAccessKeyRequest) (*api.AccessKey, error) {
+	return a.biz.RotateKey(ctx, in)
+}
+
+func (a *AccessKeyService) Delete(ctx context.Context, in *api.DeleteAccessKeyRequest) (*api.DeleteAccessKeyResponse, error) {
+	if err := a.biz.DelKey(ctx, in.Appid, in.AccessKey); err != nil {
+		return nil, err
+	}
+	return &api.DeleteAccessKeyResponse{}, nil
+}
+
+func (a *AccessKeyService) Desc() *grpc.ServiceDesc {
+	return &api.AccessKeyService_ServiceDesc
+}
+
+func (a *AccessKeyService) FileDescriptor() protoreflect.FileDescriptor {
+	return api.File_accesskey_proto
+}
@@ -0,0 +1,449 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	api "github.com/begonia-org/begonia/api/admin/v1"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/biz/endpoint"
+	"github.com/begonia-org/begonia/internal/pkg"
+	gosdk "github.com/begonia-org/go-sdk"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"github.com/begonia-org/go-sdk/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// marshalConfig 扩展配置以json的形式在管理接口中返回
+func marshalConfig(v interface{}, msg proto.Message) error {
+	b, err := json.Marshal(v)
+	if err == nil {
+		err = protojson.Unmarshal(b, msg)
+	}
+	if err != nil {
+		return gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "marshal_config")
+	}
+	return nil
+}
+
+func toStruct(v interface{}) (*structpb.Struct, error) {
+	s := &structpb.Struct{}
+	if err := marshalConfig(v, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// toStructs 切片中的每个元素转换为json对象，values为nil时返回空列表
+func toStructs(values interface{}) ([]*structpb.Struct, error) {
+	list := &structpb.ListValue{}
+	if values != nil && !reflect.ValueOf(values).IsNil() {
+		if err := marshalConfig(values, list); err != nil {
+			return nil, err
+		}
+	}
+	structs := make([]*structpb.Struct, 0, len(list.Values))
+	for _, value := range list.Values {
+		structs = append(structs, value.GetStructValue())
+	}
+	return structs, nil
+}
+
+// fromStruct 将请求中的json对象解析为扩展配置
+func fromStruct(s *structpb.Struct, v interface{}) error {
+	if s == nil {
+		return gosdk.NewError(fmt.Errorf("%w:config is required", pkg.ErrInvalidAdminConfig), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "unmarshal_config")
+	}
+	b, err := protojson.Marshal(s)
+	if err == nil {
+		err = json.Unmarshal(b, v)
+	}
+	if err != nil {
+		return gosdk.NewError(fmt.Errorf("%w:%s", pkg.ErrInvalidAdminConfig, err.Error()), int32(common.Code_PARAMS_ERROR), codes.InvalidArgument, "unmarshal_config")
+	}
+	return nil
+}
+
+func updatedResponse(updatedAt string, err error) (*api.UpdateEndpointConfigResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	tm, _ := time.Parse(time.RFC3339, updatedAt)
+	return &api.UpdateEndpointConfigResponse{UpdatedAt: timestamppb.New(tm)}, nil
+}
+
+type EndpointAdminService struct {
+	api.UnimplementedEndpointAdminServiceServer
+	biz *endpoint.EndpointUsecase
+	log logger.Logger
+}
+
+func NewEndpointAdminService(biz *endpoint.EndpointUsecase, log logger.Logger) api.EndpointAdminServiceServer {
+	return &EndpointAdminService{biz: biz, log: log}
+}
+
+func (e *EndpointAdminService) config(ctx context.Context, uniqueKey string, get func(ext *endpoint.EndpointExtensions) interface{}) (*api.EndpointConfig, error) {
+	ext, err := e.biz.GetExtensions(ctx, uniqueKey)
+	if err != nil {
+		return nil, err
+	}
+	config, err := toStruct(get(ext))
+	if err != nil {
+		return nil, err
+	}
+	return &api.EndpointConfig{Config: config}, nil
+}
+
+func (e *EndpointAdminService) GetPolicy(ctx context.Context, in *api.EndpointConfigRequest) (*api.EndpointConfig, error) {
+	return e.config(ctx, in.UniqueKey, func(ext *endpoint.EndpointExtensions) interface{} {
+		if ext.Policy == nil {
+			return gateway.EndpointPolicy{}
+		}
+		return ext.Policy
+	})
+}
+
+func (e *EndpointAdminService) PutPolicy(ctx context.Context, in *api.PutEndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	policy := gateway.EndpointPolicy{}
+	if err := fromStruct(in.Config, &policy); err != nil {
+		return nil, err
+	}
+	return updatedResponse(e.biz.PatchPolicy(ctx, in.UniqueKey, policy))
+}
+
+func (e *EndpointAdminService) GetTransform(ctx context.Context, in *api.EndpointConfigRequest) (*api.EndpointConfig, error) {
+	return e.config(ctx, in.UniqueKey, func(ext *endpoint.EndpointExtensions) interface{} {
+		if ext.Transform == nil {
+			return gateway.EndpointTransform{}
+		}
+		return ext.Transform
+	})
+}
+
+func (e *EndpointAdminService) PutTransform(ctx context.Context, in *api.PutEndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	transform := gateway.EndpointTransform{}
+	if err := fromStruct(in.Config, &transform); err != nil {
+		return nil, err
+	}
+	return updatedResponse(e.biz.PatchTransform(ctx, in.UniqueKey, transform))
+}
+
+func (e *EndpointAdminService) GetCache(ctx context.Context, in *api.EndpointConfigRequest) (*api.EndpointConfig, error) {
+	return e.config(ctx, in.UniqueKey, func(ext *endpoint.EndpointExtensions) interface{} {
+		if ext.Cache == nil {
+			return gateway.EndpointCache{}
+		}
+		return ext.Cache
+	})
+}
+
+func (e *EndpointAdminService) PutCache(ctx context.Context, in *api.PutEndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	cache := gateway.EndpointCache{}
+	if err := fromStruct(in.Config, &cache); err != nil {
+		return nil, err
+	}
+	return updatedResponse(e.biz.PatchCache(ctx, in.UniqueKey, cache))
+}
+
+// PurgeCache 清除端点所有方法的响应缓存
+func (e *EndpointAdminService) PurgeCache(ctx context.Context, in *api.EndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	return updatedResponse(e.biz.PurgeCache(ctx, in.UniqueKey))
+}
+
+// ListReleases 获取端点的稳定版本、其他在线版本和分流规则
+func (e *EndpointAdminService) ListReleases(ctx context.Context, in *api.EndpointConfigRequest) (*api.ListReleasesResponse, error) {
+	stable, err := e.biz.Get(ctx, in.UniqueKey)
+	if err != nil {
+		return nil, err
+	}
+	ext, err := e.biz.GetExtensions(ctx, in.UniqueKey)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := toStructs(ext.Releases)
+	if err != nil {
+		return nil, err
+	}
+	rsp := &api.ListReleasesResponse{Stable: stable.Version, Releases: releases}
+	if ext.Traffic != nil {
+		if rsp.Traffic, err = toStruct(ext.Traffic); err != nil {
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+func (e *EndpointAdminService) PutRelease(ctx context.Context, in *api.ReleaseRequest) (*api.UpdateEndpointConfigResponse, error) {
+	release := &endpoint.EndpointRelease{}
+	if err := fromStruct(in.Config, release); err != nil {
+		return nil, err
+	}
+	release.Version = in.Version
+	return updatedResponse(e.biz.PutRelease(ctx, in.UniqueKey, release))
+}
+
+// Rollback 下线版本，流量回到稳定版本
+func (e *EndpointAdminService) Rollback(ctx context.Context, in *api.ReleaseRequest) (*api.UpdateEndpointConfigResponse, error) {
+	return updatedResponse(e.biz.Rollback(ctx, in.UniqueKey, in.Version))
+}
+
+func (e *EndpointAdminService) Promote(ctx context.Context, in *api.ReleaseRequest) (*api.UpdateEndpointConfigResponse, error) {
+	return updatedResponse(e.biz.Promote(ctx, in.UniqueKey, in.Version))
+}
+
+func (e *EndpointAdminService) PutTraffic(ctx context.Context, in *api.PutEndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	split := &gateway.TrafficSplit{}
+	if err := fromStruct(in.Config, split); err != nil {
+		return nil, err
+	}
+	return updatedResponse(e.biz.PatchTraffic(ctx, in.UniqueKey, split))
+}
+
+func (e *EndpointAdminService) GetMirror(ctx context.Context, in *api.EndpointConfigRequest) (*api.EndpointConfig, error) {
+	return e.config(ctx, in.UniqueKey, func(ext *endpoint.EndpointExtensions) interface{} {
+		if ext.Mirror == nil {
+			return &endpoint.EndpointMirror{}
+		}
+		return ext.Mirror
+	})
+}
+
+func (e *EndpointAdminService) PutMirror(ctx context.Context, in *api.PutEndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	mirror := &endpoint.EndpointMirror{}
+	if err := fromStruct(in.Config, mirror); err != nil {
+		return nil, err
+	}
+	return updatedResponse(e.biz.PatchMirror(ctx, in.UniqueKey, mirror))
+}
+
+// DeleteMirror 关闭端点的流量复制
+func (e *EndpointAdminService) DeleteMirror(ctx context.Context, in *api.EndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	return updatedResponse(e.biz.PatchMirror(ctx, in.UniqueKey, nil))
+}
+
+func (e *EndpointAdminService) GetCapture(ctx context.Context, in *api.EndpointConfigRequest) (*api.EndpointConfig, error) {
+	return e.config(ctx, in.UniqueKey, func(ext *endpoint.EndpointExtensions) interface{} {
+		if ext.Capture == nil {
+			return &gateway.CapturePolicy{}
+		}
+		return ext.Capture
+	})
+}
+
+func (e *EndpointAdminService) PutCapture(ctx context.Context, in *api.PutEndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	capture := &gateway.CapturePolicy{}
+	if err := fromStruct(in.Config, capture); err != nil {
+		return nil, err
+	}
+	return updatedResponse(e.biz.PatchCapture(ctx, in.UniqueKey, capture))
+}
+
+// DeleteCapture 关闭端点的录制，已录制的调用保留到被清空
+func (e *EndpointAdminService) DeleteCapture(ctx context.Context, in *api.EndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	return updatedResponse(e.biz.PatchCapture(ctx, in.UniqueKey, nil))
+}
+
+// ListCaptures 当前网关实例录制的端点调用
+func (e *EndpointAdminService) ListCaptures(ctx context.Context, in *api.EndpointConfigRequest) (*api.ListCapturesResponse, error) {
+	if _, err := e.biz.Get(ctx, in.UniqueKey); err != nil {
+		return nil, err
+	}
+	entries, err := toStructs(gateway.Captures().Entries(gateway.EndpointCaptureSource(in.UniqueKey)))
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListCapturesResponse{Entries: entries}, nil
+}
+
+func (e *EndpointAdminService) ClearCaptures(ctx context.Context, in *api.EndpointConfigRequest) (*api.ClearCapturesResponse, error) {
+	gateway.Captures().Clear(gateway.EndpointCaptureSource(in.UniqueKey))
+	return &api.ClearCapturesResponse{}, nil
+}
+
+func (e *EndpointAdminService) GetDiscovery(ctx context.Context, in *api.EndpointConfigRequest) (*api.EndpointConfig, error) {
+	return e.config(ctx, in.UniqueKey, func(ext *endpoint.EndpointExtensions) interface{} {
+		if ext.Discovery == nil {
+			return &gateway.DiscoveryConfig{}
+		}
+		return ext.Discovery
+	})
+}
+
+func (e *EndpointAdminService) PutDiscovery(ctx context.Context, in *api.PutEndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	discovery := &gateway.DiscoveryConfig{}
+	if err := fromStruct(in.Config, discovery); err != nil {
+		return nil, err
+	}
+	return updatedResponse(e.biz.PatchDiscovery(ctx, in.UniqueKey, discovery))
+}
+
+// DeleteDiscovery 关闭端点的动态发现，回到配置中的地址列表
+func (e *EndpointAdminService) DeleteDiscovery(ctx context.Context, in *api.EndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	return updatedResponse(e.biz.PatchDiscovery(ctx, in.UniqueKey, nil))
+}
+
+// GetTLS 获取端点的tls配置，私钥脱敏后返回
+func (e *EndpointAdminService) GetTLS(ctx context.Context, in *api.EndpointConfigRequest) (*api.EndpointConfig, error) {
+	return e.config(ctx, in.UniqueKey, func(ext *endpoint.EndpointExtensions) interface{} {
+		if ext.TLS == nil {
+			return &gateway.UpstreamTLS{}
+		}
+		return ext.TLS.Redacted()
+	})
+}
+
+func (e *EndpointAdminService) PutTLS(ctx context.Context, in *api.PutEndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	upstream := &gateway.UpstreamTLS{}
+	if err := fromStruct(in.Config, upstream); err != nil {
+		return nil, err
+	}
+	return updatedResponse(e.biz.PatchTLS(ctx, in.UniqueKey, upstream))
+}
+
+// DeleteTLS 关闭端点的tls，使用明文连接
+func (e *EndpointAdminService) DeleteTLS(ctx context.Context, in *api.EndpointConfigRequest) (*api.UpdateEndpointConfigResponse, error) {
+	return updatedResponse(e.biz.PatchTLS(ctx, in.UniqueKey, nil))
+}
+
+func (e *EndpointAdminService) addrs(ctx context.Context, uniqueKey string) ([]string, error) {
+	endpoint, err := e.biz.Get(ctx, uniqueKey)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(endpoint.Endpoints))
+	for _, meta := range endpoint.Endpoints {
+		addrs = append(addrs, meta.Addr)
+	}
+	return addrs, nil
+}
+
+func (e *EndpointAdminService) ListCircuitBreakers(ctx context.Context, in *api.EndpointConfigRequest) (*api.ListCircuitBreakersResponse, error) {
+	addrs, err := e.addrs(ctx, in.UniqueKey)
+	if err != nil {
+		return nil, err
+	}
+	states := make([]*gateway.CircuitBreakerState, 0)
+	if gw := gateway.Get(); gw != nil {
+		states = gw.CircuitBreakerStates(addrs...)
+	}
+	structs, err := toStructs(states)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListCircuitBreakersResponse{States: structs}, nil
+}
+
+// ResetCircuitBreakers 手动关闭端点所有地址的熔断器
+func (e *EndpointAdminService) ResetCircuitBreakers(ctx context.Context, in *api.EndpointConfigRequest) (*api.ResetCircuitBreakersResponse, error) {
+	addrs, err := e.addrs(ctx, in.UniqueKey)
+	if err != nil {
+		return nil, err
+	}
+	if gw := gateway.Get(); gw != nil {
+		gw.ResetCircuitBreakers(addrs...)
+	}
+	return &api.ResetCircuitBreakersResponse{}, nil
+}
+
+func (e *EndpointAdminService) AuditResource() (string, []string) {
+	return "endpoint", []string{"unique_key"}
+}
+
+// AuditSnapshot 端点的扩展配置，私钥脱敏后记录
+func (e *EndpointAdminService) AuditSnapshot(ctx context.Context, id string) proto.Message {
+	ext, err := e.biz.GetExtensions(ctx, id)
+	if err != nil {
+		return nil
+	}
+	ext.TLS = ext.TLS.Redacted()
+	snapshot, err := toStruct(ext)
+	if err != nil {
+		return nil
+	}
+	return snapshot
+}
+
+func (e *EndpointAdminService) Desc() *grpc.ServiceDesc {
+	return &api.EndpointAdminService_ServiceDesc
+}
+
+func (e *EndpointAdminService) FileDescriptor() protoreflect.FileDescriptor {
+	return api.File_admin_proto
+}
+
+type AppAdminService struct {
+	api.UnimplementedAppAdminServiceServer
+	biz *biz.AppUsecase
+	log logger.Logger
+}
+
+func NewAppAdminService(biz *biz.AppUsecase, log logger.Logger) api.AppAdminServiceServer {
+	return &AppAdminService{biz: biz, log: log}
+}
+
+func (app *AppAdminService) captureResponse(capture *gateway.AppCapture) (*api.AppCaptureResponse, error) {
+	if capture == nil {
+		capture = &gateway.AppCapture{}
+	}
+	config, err := toStruct(capture)
+	if err != nil {
+		return nil, err
+	}
+	return &api.AppCaptureResponse{Config: config}, nil
+}
+
+func (app *AppAdminService) GetCapture(ctx context.Context, in *api.AppCaptureRequest) (*api.AppCaptureResponse, error) {
+	return app.captureResponse(gateway.Captures().App(in.Appid))
+}
+
+// PutCapture 开启应用的录制，到期后自动关闭
+func (app *AppAdminService) PutCapture(ctx context.Context, in *api.PutAppCaptureRequest) (*api.AppCaptureResponse, error) {
+	capture := &gateway.AppCapture{}
+	if err := fromStruct(in.Config, capture); err != nil {
+		return nil, err
+	}
+	capture, err := app.biz.SetCapture(ctx, in.Appid, capture)
+	if err != nil {
+		return nil, err
+	}
+	return app.captureResponse(capture)
+}
+
+func (app *AppAdminService) DeleteCapture(ctx context.Context, in *api.AppCaptureRequest) (*api.DeleteAppCaptureResponse, error) {
+	if _, err := app.biz.SetCapture(ctx, in.Appid, nil); err != nil {
+		return nil, err
+	}
+	return &api.DeleteAppCaptureResponse{}, nil
+}
+
+// ListCaptures 当前网关实例录制的应用调用
+func (app *AppAdminService) ListCaptures(ctx context.Context, in *api.AppCaptureRequest) (*api.ListCapturesResponse, error) {
+	entries, err := toStructs(gateway.Captures().Entries(gateway.AppCaptureSource(in.Appid)))
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListCapturesResponse{Entries: entries}, nil
+}
+
+func (app *AppAdminService) ClearCaptures(ctx context.Context, in *api.AppCaptureRequest) (*api.ClearCapturesResponse, error) {
+	gateway.Captures().Clear(gateway.AppCaptureSource(in.Appid))
+	return &api.ClearCapturesResponse{}, nil
+}
+
+func (app *AppAdminService) Desc() *grpc.ServiceDesc {
+	return &api.AppAdminService_ServiceDesc
+}
+
+func (app *AppAdminService) FileDescriptor() protoreflect.FileDescriptor {
+	return api.File_admin_proto
+}
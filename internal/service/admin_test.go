@@ -0,0 +1,48 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/begonia-org/begonia/api/admin/v1"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/service"
+	c "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestAdminConfig(t *testing.T) {
+	c.Convey("test admin config", t, func() {
+		srv := service.NewEndpointAdminService(nil, gateway.Log)
+		// 配置在调用biz之前解析
+		_, err := srv.PutPolicy(context.Background(), &api.PutEndpointConfigRequest{UniqueKey: "admin-test"})
+		c.So(err, c.ShouldNotBeNil)
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+
+		config, err := structpb.NewStruct(map[string]interface{}{"*": "timeout"})
+		c.So(err, c.ShouldBeNil)
+		_, err = srv.PutPolicy(context.Background(), &api.PutEndpointConfigRequest{UniqueKey: "admin-test", Config: config})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+		_, err = srv.PutTLS(context.Background(), &api.PutEndpointConfigRequest{UniqueKey: "admin-test"})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+
+		resource, fields := srv.(biz.AuditResource).AuditResource()
+		c.So(resource, c.ShouldEqual, "endpoint")
+		c.So(fields, c.ShouldResemble, []string{"unique_key"})
+
+		apps := service.NewAppAdminService(nil, gateway.Log)
+		_, err = apps.PutCapture(context.Background(), &api.PutAppCaptureRequest{Appid: "admin-test"})
+		c.So(status.Code(err), c.ShouldEqual, codes.InvalidArgument)
+		rsp, err := apps.ListCaptures(context.Background(), &api.AppCaptureRequest{Appid: "admin-test"})
+		c.So(err, c.ShouldBeNil)
+		c.So(rsp.Entries, c.ShouldBeEmpty)
+		capture, err := apps.GetCapture(context.Background(), &api.AppCaptureRequest{Appid: "admin-test"})
+		c.So(err, c.ShouldBeNil)
+		c.So(capture.Config, c.ShouldNotBeNil)
+		_, err = apps.ClearCaptures(context.Background(), &api.AppCaptureRequest{Appid: "admin-test"})
+		c.So(err, c.ShouldBeNil)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	api "github.com/begonia-org/go-sdk/api/app/v1"
 	"github.com/begonia-org/go-sdk/logger"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 type AppService struct {
@@ -71,3 +72,15 @@ func (app *AppService) List(ctx context.Context, in *api.AppsListRequest) (*api.
 	return &api.AppsListResponse{Apps: apps}, nil
 	// return nil, nil
 }
+
+func (app *AppService) AuditResource() (string, []string) {
+	return "app", []string{"appid"}
+}
+
+func (app *AppService) AuditSnapshot(ctx context.Context, id string) proto.Message {
+	apps, err := app.biz.Get(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return apps
+}
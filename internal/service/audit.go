@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	api "github.com/begonia-org/begonia/api/audit/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/go-sdk/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type AuditService struct {
+	api.UnimplementedAuditServiceServer
+	biz *biz.AuditUsecase
+	log logger.Logger
+}
+
+func NewAuditService(biz *biz.AuditUsecase, log logger.Logger) api.AuditServiceServer {
+	return &AuditService{biz: biz, log: log}
+}
+
+func (a *AuditService) List(ctx context.Context, in *api.ListAuditLogsRequest) (*api.ListAuditLogsResponse, error) {
+	logs, err := a.biz.List(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListAuditLogsResponse{Logs: logs}, nil
+}
+
+func (a *AuditService) Desc() *grpc.ServiceDesc {
+	return &api.AuditService_ServiceDesc
+}
+
+func (a *AuditService) FileDescriptor() protoreflect.FileDescriptor {
+	return api.File_audit_proto
+}
@@ -2,25 +2,33 @@ package service
 
 import (
 	"context"
+	"strings"
 
 	"github.com/begonia-org/begonia/internal/biz"
 	"github.com/begonia-org/begonia/internal/pkg/config"
 	"github.com/begonia-org/begonia/internal/pkg/crypto"
+	gosdk "github.com/begonia-org/go-sdk"
 	api "github.com/begonia-org/go-sdk/api/user/v1"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
 	"github.com/begonia-org/go-sdk/logger"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 )
 
 type AuthzService struct {
-	biz    *biz.AuthzUsecase
-	log    logger.Logger
-	config *config.Config
+	biz      *biz.AuthzUsecase
+	sessions *biz.SessionUsecase
+	mfa      *biz.MFAUsecase
+	log      logger.Logger
+	config   *config.Config
 	api.UnimplementedAuthServiceServer
 	authCrypto *crypto.UsersAuth
 }
 
-func NewAuthzService(biz *biz.AuthzUsecase, log logger.Logger, auth *crypto.UsersAuth, config *config.Config) api.AuthServiceServer {
-	return &AuthzService{biz: biz, log: log, authCrypto: auth, config: config}
+func NewAuthzService(biz *biz.AuthzUsecase, sessions *biz.SessionUsecase, mfa *biz.MFAUsecase, log logger.Logger, auth *crypto.UsersAuth, config *config.Config) api.AuthServiceServer {
+	return &AuthzService{biz: biz, sessions: sessions, mfa: mfa, log: log, authCrypto: auth, config: config}
 }
 
 func (u *AuthzService) AuthSeed(ctx context.Context, in *api.AuthLogAPIRequest) (*api.AuthLogAPIResponse, error) {
@@ -36,10 +44,31 @@ func (u *AuthzService) AuthSeed(ctx context.Context, in *api.AuthLogAPIRequest)
 
 }
 
+// Login 登录成功后创建会话，refresh token通过响应头返回，
+// 用户启用了多因素认证时不返回token，通过响应头返回第二步验证的挑战
 func (u *AuthzService) Login(ctx context.Context, in *api.LoginAPIRequest) (*api.LoginAPIResponse, error) {
-	rsp, err := u.biz.Login(ctx, in)
-
-	return rsp, err
+	user, err := u.biz.Authenticate(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	challenge, methods, err := u.mfa.Challenge(ctx, user, in.IsKeepLogin)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != "" {
+		if err := grpc.SetHeader(ctx, metadata.Pairs("x-mfa-challenge", challenge, "x-mfa-methods", strings.Join(methods, ","))); err != nil {
+			return nil, gosdk.NewError(err, int32(common.Code_INTERNAL_ERROR), codes.Internal, "mfa_challenge")
+		}
+		return &api.LoginAPIResponse{}, nil
+	}
+	token, err := u.sessions.Create(ctx, user, in.IsKeepLogin)
+	if err != nil {
+		return nil, err
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs("x-refresh-token", token.RefreshToken, "x-session-id", token.SessionId)); err != nil {
+		u.log.Warnf(ctx, "set refresh token header error:%s", err.Error())
+	}
+	return &api.LoginAPIResponse{User: user, Token: token.AccessToken}, nil
 }
 
 func (u *AuthzService) Logout(ctx context.Context, req *api.LogoutAPIRequest) (*api.LogoutAPIResponse, error) {
@@ -47,6 +76,11 @@ func (u *AuthzService) Logout(ctx context.Context, req *api.LogoutAPIRequest) (*
 	if err != nil {
 		return nil, err
 	}
+	if uid, sid, err := currentSession(ctx); err == nil && sid != "" {
+		if err := u.sessions.Revoke(ctx, uid, sid); err != nil {
+			u.log.Warnf(ctx, "revoke session %s error:%s", sid, err.Error())
+		}
+	}
 	return &api.LogoutAPIResponse{}, nil
 
 }
@@ -54,3 +88,12 @@ func (u *AuthzService) Logout(ctx context.Context, req *api.LogoutAPIRequest) (*
 func (u *AuthzService) Desc() *grpc.ServiceDesc {
 	return &api.AuthService_ServiceDesc
 }
+
+// AuditResource 登录成功后以响应中的用户作为资源和操作者
+func (u *AuthzService) AuditResource() (string, []string) {
+	return "user", []string{"user.uid"}
+}
+
+func (u *AuthzService) AuditSnapshot(ctx context.Context, id string) proto.Message {
+	return nil
+}
@@ -2,13 +2,19 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
+	"github.com/begonia-org/begonia/gateway"
 	"github.com/begonia-org/begonia/internal/biz/endpoint"
 	"github.com/begonia-org/begonia/internal/pkg/config"
+	gosdk "github.com/begonia-org/go-sdk"
 	api "github.com/begonia-org/go-sdk/api/endpoint/v1"
 	"github.com/begonia-org/go-sdk/logger"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -50,6 +56,7 @@ func (e *EndpointsService) List(ctx context.Context, in *api.ListEndpointRequest
 		return nil, err
 
 	}
+	e.sendHealthHeader(ctx, endpoints...)
 	return &api.ListEndpointResponse{Endpoints: endpoints}, nil
 }
 
@@ -66,5 +73,87 @@ func (e *EndpointsService) Get(ctx context.Context, in *api.DetailsEndpointReque
 	if err != nil {
 		return nil, err
 	}
+	e.sendHealthHeader(ctx, endpoint)
 	return &api.DetailsEndpointResponse{Endpoints: endpoint}, nil
 }
+
+// endpointHealth 端点各个后端的健康状态
+func endpointHealth(endpoint *api.Endpoints) []*gateway.EndpointHealth {
+	gw := gateway.Get()
+	if gw == nil || endpoint == nil {
+		return nil
+	}
+	addrs := make([]string, 0, len(endpoint.Endpoints))
+	for _, meta := range endpoint.Endpoints {
+		addrs = append(addrs, meta.Addr)
+	}
+	return gw.EndpointsHealth(addrs...)
+}
+
+// ExtendHttpResponse Get和List的http响应中，每个端点的health字段为各个后端的健康状态
+func (e *EndpointsService) ExtendHttpResponse(ctx context.Context, fullMethod string, rsp interface{}, data *structpb.Struct) error {
+	switch out := rsp.(type) {
+	case *api.DetailsEndpointResponse:
+		return setHealthField(data.GetFields()["endpoints"].GetStructValue(), out.Endpoints)
+	case *api.ListEndpointResponse:
+		values := data.GetFields()["endpoints"].GetListValue().GetValues()
+		for i, endpoint := range out.Endpoints {
+			if i >= len(values) {
+				break
+			}
+			if err := setHealthField(values[i].GetStructValue(), endpoint); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func setHealthField(item *structpb.Struct, endpoint *api.Endpoints) error {
+	if item == nil {
+		return nil
+	}
+	b, err := json.Marshal(endpointHealth(endpoint))
+	if err != nil {
+		return err
+	}
+	health := make([]interface{}, 0)
+	if err := json.Unmarshal(b, &health); err != nil {
+		return err
+	}
+	value, err := structpb.NewList(health)
+	if err != nil {
+		return err
+	}
+	item.Fields["health"] = structpb.NewListValue(value)
+	return nil
+}
+
+// sendHealthHeader 原生grpc客户端无法从sdk消息中获取健康状态，以uid为键通过X-Endpoint-Health响应头返回
+func (e *EndpointsService) sendHealthHeader(ctx context.Context, endpoints ...*api.Endpoints) {
+	if gateway.Get() == nil {
+		return
+	}
+	health := make(map[string][]*gateway.EndpointHealth)
+	for _, endpoint := range endpoints {
+		health[endpoint.Key] = endpointHealth(endpoint)
+	}
+	b, err := json.Marshal(health)
+	if err != nil {
+		e.log.Errorf(ctx, "marshal endpoint health error:%s", err.Error())
+		return
+	}
+	_ = grpc.SendHeader(ctx, metadata.Pairs(gosdk.GetMetadataKey("X-Endpoint-Health"), string(b)))
+}
+
+func (e *EndpointsService) AuditResource() (string, []string) {
+	return "endpoint", []string{"unique_key"}
+}
+
+func (e *EndpointsService) AuditSnapshot(ctx context.Context, id string) proto.Message {
+	endpoint, err := e.biz.Get(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return endpoint
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"google.golang.org/protobuf/proto"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -225,3 +226,13 @@ func (f *FileService) Metadata(ctx context.Context, in *api.FileMetadataRequest)
 func (f *FileService) Desc() *grpc.ServiceDesc {
 	return &api.FileService_ServiceDesc
 }
+
+// AuditResource 文件以key作为资源id，分片上传在初始化之后只有upload_id
+func (f *FileService) AuditResource() (string, []string) {
+	return "file", []string{"key", "upload_id"}
+}
+
+// AuditSnapshot 文件内容不记录，以响应作为操作后的状态
+func (f *FileService) AuditSnapshot(ctx context.Context, id string) proto.Message {
+	return nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/go-sdk/logger"
+)
+
+// JWKSService 发布网关签发jwt的公钥，下游服务可以使用其校验x-token
+type JWKSService struct {
+	biz *biz.JWKSUsecase
+	log logger.Logger
+}
+
+func NewJWKSService(biz *biz.JWKSUsecase, log logger.Logger) *JWKSService {
+	return &JWKSService{biz: biz, log: log}
+}
+
+// Handler 处理/.well-known/jwks.json，使用jwt_secret签发时返回空的密钥集合
+func (j *JWKSService) Handler(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	if !j.biz.Enabled() {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"keys": []interface{}{}})
+		return
+	}
+	set, err := j.biz.JWKS(r.Context())
+	if err != nil {
+		j.log.Errorf(r.Context(), "get jwks error:%s", err.Error())
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	// 缓存时间小于新密钥提前发布的时间，下游在新密钥激活前可以获取到
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(j.biz.CacheMaxAge().Seconds())))
+	writeJSON(w, http.StatusOK, set)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
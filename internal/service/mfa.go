@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+
+	api "github.com/begonia-org/begonia/api/mfa/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/go-sdk/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type MFAService struct {
+	api.UnimplementedMFAServiceServer
+	biz *biz.MFAUsecase
+	log logger.Logger
+}
+
+func NewMFAService(biz *biz.MFAUsecase, log logger.Logger) api.MFAServiceServer {
+	return &MFAService{biz: biz, log: log}
+}
+
+func (m *MFAService) Status(ctx context.Context, in *api.MFAStatusRequest) (*api.MFAStatus, error) {
+	uid, _, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.biz.Status(ctx, uid)
+}
+
+func (m *MFAService) EnrollTOTP(ctx context.Context, in *api.EnrollTOTPRequest) (*api.EnrollTOTPResponse, error) {
+	uid, _, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.biz.EnrollTOTP(ctx, uid)
+}
+
+func (m *MFAService) ActivateTOTP(ctx context.Context, in *api.ActivateTOTPRequest) (*api.BackupCodesResponse, error) {
+	uid, _, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.biz.ActivateTOTP(ctx, uid, in.Code)
+}
+
+func (m *MFAService) DisableTOTP(ctx context.Context, in *api.DisableTOTPRequest) (*api.DisableTOTPResponse, error) {
+	uid, _, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.biz.DisableTOTP(ctx, uid, in.Code); err != nil {
+		return nil, err
+	}
+	return &api.DisableTOTPResponse{}, nil
+}
+
+func (m *MFAService) RegenerateBackupCodes(ctx context.Context, in *api.RegenerateBackupCodesRequest) (*api.BackupCodesResponse, error) {
+	uid, _, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.biz.RegenerateBackupCodes(ctx, uid, in.Code)
+}
+
+func (m *MFAService) BeginWebAuthnRegistration(ctx context.Context, in *api.BeginWebAuthnRegistrationRequest) (*api.WebAuthnOptions, error) {
+	uid, _, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.biz.BeginRegistration(ctx, uid)
+}
+
+func (m *MFAService) FinishWebAuthnRegistration(ctx context.Context, in *api.FinishWebAuthnRegistrationRequest) (*api.WebAuthnCredential, error) {
+	uid, _, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.biz.FinishRegistration(ctx, uid, in)
+}
+
+func (m *MFAService) ListWebAuthnCredentials(ctx context.Context, in *api.ListWebAuthnCredentialsRequest) (*api.ListWebAuthnCredentialsResponse, error) {
+	uid, _, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := m.biz.ListCredentials(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListWebAuthnCredentialsResponse{Credentials: credentials}, nil
+}
+
+func (m *MFAService) DeleteWebAuthnCredential(ctx context.Context, in *api.DeleteWebAuthnCredentialRequest) (*api.DeleteWebAuthnCredentialResponse, error) {
+	uid, _, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.biz.DeleteCredential(ctx, uid, in.Uid); err != nil {
+		return nil, err
+	}
+	return &api.DeleteWebAuthnCredentialResponse{}, nil
+}
+
+func (m *MFAService) Desc() *grpc.ServiceDesc {
+	return &api.MFAService_ServiceDesc
+}
+
+func (m *MFAService) FileDescriptor() protoreflect.FileDescriptor {
+	return api.File_mfa_proto
+}
+
+// MFALoginService 登录第二步验证，使用第一步返回的挑战，不需要登录态
+type MFALoginService struct {
+	api.UnimplementedMFALoginServiceServer
+	biz *biz.MFAUsecase
+	log logger.Logger
+}
+
+func NewMFALoginService(biz *biz.MFAUsecase, log logger.Logger) api.MFALoginServiceServer {
+	return &MFALoginService{biz: biz, log: log}
+}
+
+func (m *MFALoginService) BeginWebAuthn(ctx context.Context, in *api.BeginMFAWebAuthnRequest) (*api.WebAuthnOptions, error) {
+	return m.biz.BeginLogin(ctx, in.ChallengeId)
+}
+
+func (m *MFALoginService) Verify(ctx context.Context, in *api.VerifyMFARequest) (*api.MFALoginResponse, error) {
+	return m.biz.Verify(ctx, in)
+}
+
+func (m *MFALoginService) Desc() *grpc.ServiceDesc {
+	return &api.MFALoginService_ServiceDesc
+}
+
+func (m *MFALoginService) FileDescriptor() protoreflect.FileDescriptor {
+	return api.File_mfa_login_proto
+}
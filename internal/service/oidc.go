@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	api "github.com/begonia-org/begonia/api/oidc/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/go-sdk/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type OIDCService struct {
+	api.UnimplementedOIDCServiceServer
+	biz    *biz.OIDCUsecase
+	log    logger.Logger
+	config *config.Config
+}
+
+func NewOIDCService(biz *biz.OIDCUsecase, log logger.Logger, config *config.Config) api.OIDCServiceServer {
+	return &OIDCService{biz: biz, log: log, config: config}
+}
+
+func (o *OIDCService) Authorize(ctx context.Context, in *api.AuthorizeRequest) (*api.AuthorizeResponse, error) {
+	return o.biz.Authorize(ctx, in.Provider)
+}
+
+func (o *OIDCService) Callback(ctx context.Context, in *api.CallbackRequest) (*api.OIDCLoginResponse, error) {
+	return o.biz.Callback(ctx, in)
+}
+
+func (o *OIDCService) Desc() *grpc.ServiceDesc {
+	return &api.OIDCService_ServiceDesc
+}
+
+func (o *OIDCService) FileDescriptor() protoreflect.FileDescriptor {
+	return api.File_oidc_proto
+}
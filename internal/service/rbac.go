@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	api "github.com/begonia-org/begonia/api/rbac/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/pkg/config"
+	"github.com/begonia-org/go-sdk/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type RBACService struct {
+	api.UnimplementedRBACServiceServer
+	biz    *biz.RBACUsecase
+	log    logger.Logger
+	config *config.Config
+}
+
+func NewRBACService(biz *biz.RBACUsecase, log logger.Logger, config *config.Config) api.RBACServiceServer {
+	return &RBACService{biz: biz, log: log, config: config}
+}
+
+func (r *RBACService) PutRole(ctx context.Context, in *api.RoleRequest) (*api.Role, error) {
+	return r.biz.PutRole(ctx, in)
+}
+
+func (r *RBACService) PatchRole(ctx context.Context, in *api.RoleRequest) (*api.Role, error) {
+	return r.biz.PatchRole(ctx, in)
+}
+
+func (r *RBACService) GetRole(ctx context.Context, in *api.GetRoleRequest) (*api.Role, error) {
+	return r.biz.GetRole(ctx, in.Uid)
+}
+
+func (r *RBACService) DeleteRole(ctx context.Context, in *api.DeleteRoleRequest) (*api.DeleteRoleResponse, error) {
+	if err := r.biz.DelRole(ctx, in.Uid); err != nil {
+		return nil, err
+	}
+	return &api.DeleteRoleResponse{}, nil
+}
+
+func (r *RBACService) ListRoles(ctx context.Context, in *api.ListRolesRequest) (*api.ListRolesResponse, error) {
+	roles, err := r.biz.ListRoles(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListRolesResponse{Roles: roles}, nil
+}
+
+func (r *RBACService) Bind(ctx context.Context, in *api.BindRequest) (*api.RoleBinding, error) {
+	return r.biz.Bind(ctx, in)
+}
+
+func (r *RBACService) Unbind(ctx context.Context, in *api.UnbindRequest) (*api.UnbindResponse, error) {
+	if err := r.biz.Unbind(ctx, in.Uid); err != nil {
+		return nil, err
+	}
+	return &api.UnbindResponse{}, nil
+}
+
+func (r *RBACService) ListBindings(ctx context.Context, in *api.ListBindingsRequest) (*api.ListBindingsResponse, error) {
+	bindings, err := r.biz.ListBindings(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListBindingsResponse{Bindings: bindings}, nil
+}
+
+func (r *RBACService) Desc() *grpc.ServiceDesc {
+	return &api.RBACService_ServiceDesc
+}
+
+func (r *RBACService) FileDescriptor() protoreflect.FileDescriptor {
+	return api.File_rbac_proto
+}
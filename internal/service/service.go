@@ -3,6 +3,13 @@ package service
 import (
 	"context"
 
+	ak "github.com/begonia-org/begonia/api/accesskey/v1"
+	admin "github.com/begonia-org/begonia/api/admin/v1"
+	audit "github.com/begonia-org/begonia/api/audit/v1"
+	mfa "github.com/begonia-org/begonia/api/mfa/v1"
+	oidc "github.com/begonia-org/begonia/api/oidc/v1"
+	rbac "github.com/begonia-org/begonia/api/rbac/v1"
+	session "github.com/begonia-org/begonia/api/session/v1"
 	app "github.com/begonia-org/go-sdk/api/app/v1"
 	ep "github.com/begonia-org/go-sdk/api/endpoint/v1"
 	file "github.com/begonia-org/go-sdk/api/file/v1"
@@ -12,17 +19,35 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type Service interface {
 	Desc() *grpc.ServiceDesc
 }
 
+// DescriptorService 描述文件不在本地api描述文件中的内置服务，使用编译到程序中的描述文件注册
+type DescriptorService interface {
+	Service
+	FileDescriptor() protoreflect.FileDescriptor
+}
+
 var ProviderSet = wire.NewSet(NewAuthzService, NewUserService,
 	NewFileService,
 	NewServices,
 	NewEndpointsService,
 	NewAppService,
+	NewRBACService,
+	NewOIDCService,
+	NewJWKSService,
+	NewSessionService,
+	NewTokenService,
+	NewMFAService,
+	NewMFALoginService,
+	NewAccessKeyService,
+	NewAuditService,
+	NewEndpointAdminService,
+	NewAppAdminService,
 	NewSysService)
 
 type ServiceOptions func(*grpc.Server, *runtime.ServeMux, string) error
@@ -33,10 +58,20 @@ func NewServices(file file.FileServiceServer,
 	app app.AppsServiceServer,
 	sys sys.SystemServiceServer,
 	users user.UserServiceServer,
+	roles rbac.RBACServiceServer,
+	oidc oidc.OIDCServiceServer,
+	sessions session.SessionServiceServer,
+	tokens session.TokenServiceServer,
+	mfa mfa.MFAServiceServer,
+	mfaLogin mfa.MFALoginServiceServer,
+	keys ak.AccessKeyServiceServer,
+	audit audit.AuditServiceServer,
+	endpointAdmin admin.EndpointAdminServiceServer,
+	appAdmin admin.AppAdminServiceServer,
 
 ) []Service {
 	services := make([]Service, 0)
-	services = append(services, file.(Service), authz.(Service), ep.(Service), app.(Service), sys.(Service), users.(Service))
+	services = append(services, file.(Service), authz.(Service), ep.(Service), app.(Service), sys.(Service), users.(Service), roles.(Service), oidc.(Service), sessions.(Service), tokens.(Service), mfa.(Service), mfaLogin.(Service), keys.(Service), audit.(Service), endpointAdmin.(Service), appAdmin.(Service))
 	return services
 }
 
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+
+	api "github.com/begonia-org/begonia/api/session/v1"
+	"github.com/begonia-org/begonia/gateway"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/begonia/internal/pkg"
+	gosdk "github.com/begonia-org/go-sdk"
+	common "github.com/begonia-org/go-sdk/common/api/v1"
+	"github.com/begonia-org/go-sdk/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type SessionService struct {
+	api.UnimplementedSessionServiceServer
+	biz *biz.SessionUsecase
+	log logger.Logger
+}
+
+func NewSessionService(biz *biz.SessionUsecase, log logger.Logger) api.SessionServiceServer {
+	return &SessionService{biz: biz, log: log}
+}
+
+// currentSession 获取jwt鉴权后的用户uid和当前token关联的会话id，
+// x-uid和x-token只由jwt鉴权写入，ak和api-key鉴权的请求没有会话
+func currentSession(ctx context.Context) (string, string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", "", gosdk.NewError(pkg.ErrNoMetadata, int32(common.Code_METADATA_MISSING), codes.InvalidArgument, "metadata_missing")
+	}
+	token := md.Get(gateway.XToken)
+	if len(token) == 0 || token[0] == "" {
+		return "", "", gosdk.NewError(pkg.ErrTokenMissing, int32(common.Code_TOKEN_NOT_FOUND), codes.Unauthenticated, "jwt_required")
+	}
+	uid := md.Get(gateway.XUID)
+	if len(uid) == 0 || uid[0] == "" {
+		return "", "", gosdk.NewError(pkg.ErrTokenMissing, int32(common.Code_TOKEN_NOT_FOUND), codes.Unauthenticated, "uid_missing")
+	}
+	return uid[0], biz.TokenSessionID(token[0]), nil
+}
+
+func (s *SessionService) List(ctx context.Context, in *api.ListSessionsRequest) (*api.ListSessionsResponse, error) {
+	uid, sid, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.biz.List(ctx, uid, sid)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListSessionsResponse{Sessions: sessions}, nil
+}
+
+func (s *SessionService) Revoke(ctx context.Context, in *api.RevokeSessionRequest) (*api.RevokeSessionResponse, error) {
+	uid, _, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.biz.Revoke(ctx, uid, in.Id); err != nil {
+		return nil, err
+	}
+	return &api.RevokeSessionResponse{}, nil
+}
+
+func (s *SessionService) RevokeAll(ctx context.Context, in *api.RevokeAllSessionsRequest) (*api.RevokeAllSessionsResponse, error) {
+	uid, sid, err := currentSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	except := ""
+	if in.KeepCurrent {
+		except = sid
+	}
+	count, err := s.biz.RevokeAll(ctx, uid, except)
+	if err != nil {
+		return nil, err
+	}
+	return &api.RevokeAllSessionsResponse{Count: int32(count)}, nil
+}
+
+// ForceLogout 强制用户下线，已签发的access token通过黑名单同步到所有网关实例
+func (s *SessionService) ForceLogout(ctx context.Context, in *api.ForceLogoutRequest) (*api.ForceLogoutResponse, error) {
+	count, err := s.biz.ForceLogout(ctx, in.Uid)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ForceLogoutResponse{Count: int32(count)}, nil
+}
+
+func (s *SessionService) Desc() *grpc.ServiceDesc {
+	return &api.SessionService_ServiceDesc
+}
+
+func (s *SessionService) FileDescriptor() protoreflect.FileDescriptor {
+	return api.File_session_proto
+}
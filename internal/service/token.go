@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+
+	api "github.com/begonia-org/begonia/api/session/v1"
+	"github.com/begonia-org/begonia/internal/biz"
+	"github.com/begonia-org/go-sdk/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TokenService 使用refresh token换取新的access token，不需要登录态
+type TokenService struct {
+	api.UnimplementedTokenServiceServer
+	biz *biz.SessionUsecase
+	log logger.Logger
+}
+
+func NewTokenService(biz *biz.SessionUsecase, log logger.Logger) api.TokenServiceServer {
+	return &TokenService{biz: biz, log: log}
+}
+
+func (t *TokenService) Refresh(ctx context.Context, in *api.RefreshTokenRequest) (*api.TokenResponse, error) {
+	return t.biz.Refresh(ctx, in.RefreshToken)
+}
+
+func (t *TokenService) Desc() *grpc.ServiceDesc {
+	return &api.TokenService_ServiceDesc
+}
+
+func (t *TokenService) FileDescriptor() protoreflect.FileDescriptor {
+	return api.File_token_proto
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/begonia-org/begonia/internal/biz"
 	"github.com/begonia-org/begonia/internal/pkg/config"
@@ -89,3 +90,15 @@ func (u *UserService) Delete(ctx context.Context, in *api.DeleteUserRequest) (*a
 func (app *UserService) Desc() *grpc.ServiceDesc {
 	return &api.UserService_ServiceDesc
 }
+
+func (u *UserService) AuditResource() (string, []string) {
+	return "user", []string{"uid"}
+}
+
+func (u *UserService) AuditSnapshot(ctx context.Context, id string) proto.Message {
+	user, err := u.biz.Get(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return user
+}
@@ -35,8 +35,14 @@ func NewAuthzSvrForTest(config2 *tiga.Configuration, log logger.Logger) v1.AuthS
 	curd := data.NewCurdImpl(mySQLDao, configConfig)
 	userRepo := data.NewUserRepoImpl(dataData, layeredCache, curd, configConfig)
 	usersAuth := crypto.NewUsersAuth(configConfig)
-	authzUsecase := biz.NewAuthzUsecase(authzRepo, userRepo, log, usersAuth, configConfig)
-	authServiceServer := NewAuthzService(authzUsecase, log, usersAuth, configConfig)
+	jwksRepo := data.NewJWKSRepoImpl(curd, redisDao, configConfig)
+	jwksUsecase := biz.NewJWKSUsecase(jwksRepo, configConfig, log)
+	authzUsecase := biz.NewAuthzUsecase(authzRepo, userRepo, log, usersAuth, configConfig, jwksUsecase)
+	sessionRepo := data.NewSessionRepoImpl(redisDao, configConfig)
+	sessionUsecase := biz.NewSessionUsecase(sessionRepo, userRepo, authzUsecase, configConfig, log)
+	mfaRepo := data.NewMFARepoImpl(curd, redisDao, configConfig)
+	mfaUsecase := biz.NewMFAUsecase(mfaRepo, userRepo, sessionUsecase, configConfig, log)
+	authServiceServer := NewAuthzService(authzUsecase, sessionUsecase, mfaUsecase, log, usersAuth, configConfig)
 	return authServiceServer
 }
 
@@ -46,7 +52,7 @@ func NewAPPSvrForTest(config2 *tiga.Configuration, log logger.Logger) v1_2.AppsS
 	curd := data.NewCurdImpl(mySQLDao, configConfig)
 	redisDao := data.NewRDB(config2)
 	layeredCache := data.NewLayeredCache(redisDao, configConfig, log)
-	appRepo := data.NewAppRepoImpl(curd, layeredCache, configConfig)
+	appRepo := data.NewAppRepoImpl(curd, layeredCache, redisDao, configConfig)
 	appUsecase := biz.NewAppUsecase(appRepo, configConfig)
 	appsServiceServer := NewAppService(appUsecase, log, configConfig)
 	return appsServiceServer
@@ -48,20 +48,26 @@ func New(config2 *tiga.Configuration, log logger.Logger, endpoint2 string) Gatew
 	dataData := data.NewData(mySQLDao, redisDao, etcdDao)
 	curd := data.NewCurdImpl(mySQLDao, configConfig)
 	layeredCache := data.NewLayeredCache(redisDao, configConfig, log)
-	appRepo := data.NewAppRepoImpl(curd, layeredCache, configConfig)
+	appRepo := data.NewAppRepoImpl(curd, layeredCache, redisDao, configConfig)
 	userRepo := data.NewUserRepoImpl(dataData, layeredCache, curd, configConfig)
 	authzRepo := data.NewAuthzRepoImpl(log, layeredCache)
 	dataOperatorRepo := data.NewDataOperatorRepo(dataData, appRepo, userRepo, authzRepo, layeredCache, log)
 	endpointRepo := data.NewEndpointRepoImpl(dataData, configConfig)
 	endpointWatcher := endpoint.NewWatcher(configConfig, endpointRepo)
 	dataOperatorUsecase := biz.NewDataOperatorUsecase(dataOperatorRepo, configConfig, log, endpointWatcher, endpointRepo)
-	daemonDaemon := daemon.NewDaemonImpl(configConfig, dataOperatorUsecase)
+	jwksRepo := data.NewJWKSRepoImpl(curd, redisDao, configConfig)
+	jwksUsecase := biz.NewJWKSUsecase(jwksRepo, configConfig, log)
+	daemonDaemon := daemon.NewDaemonImpl(configConfig, dataOperatorUsecase, jwksUsecase)
 	gatewayConfig := server.NewGatewayConfig(endpoint2)
 	fileUsecase := file.NewFileUsecase(configConfig)
 	fileServiceServer := service.NewFileService(fileUsecase, configConfig)
 	usersAuth := crypto.NewUsersAuth(configConfig)
-	authzUsecase := biz.NewAuthzUsecase(authzRepo, userRepo, log, usersAuth, configConfig)
-	authServiceServer := service.NewAuthzService(authzUsecase, log, usersAuth, configConfig)
+	authzUsecase := biz.NewAuthzUsecase(authzRepo, userRepo, log, usersAuth, configConfig, jwksUsecase)
+	sessionRepo := data.NewSessionRepoImpl(redisDao, configConfig)
+	sessionUsecase := biz.NewSessionUsecase(sessionRepo, userRepo, authzUsecase, configConfig, log)
+	mfaRepo := data.NewMFARepoImpl(curd, redisDao, configConfig)
+	mfaUsecase := biz.NewMFAUsecase(mfaRepo, userRepo, sessionUsecase, configConfig, log)
+	authServiceServer := service.NewAuthzService(authzUsecase, sessionUsecase, mfaUsecase, log, usersAuth, configConfig)
 	endpointUsecase := endpoint.NewEndpointUsecase(endpointRepo, fileUsecase, configConfig)
 	endpointServiceServer := service.NewEndpointsService(endpointUsecase, log, configConfig)
 	appUsecase := biz.NewAppUsecase(appRepo, configConfig)
@@ -69,10 +75,28 @@ func New(config2 *tiga.Configuration, log logger.Logger, endpoint2 string) Gatew
 	systemServiceServer := service.NewSysService()
 	userUsecase := biz.NewUserUsecase(userRepo, configConfig)
 	userServiceServer := service.NewUserService(userUsecase, log, configConfig)
-	v := service.NewServices(fileServiceServer, authServiceServer, endpointServiceServer, appsServiceServer, systemServiceServer, userServiceServer)
+	rbacRepo := data.NewRBACRepoImpl(curd, layeredCache, configConfig)
+	rbacUsecase := biz.NewRBACUsecase(rbacRepo, configConfig)
+	rbacServiceServer := service.NewRBACService(rbacUsecase, log, configConfig)
+	oidcRepo := data.NewOIDCRepoImpl(curd, redisDao, layeredCache, configConfig)
+	oidcUsecase := biz.NewOIDCUsecase(oidcRepo, userRepo, sessionUsecase, configConfig, log)
+	oidcServiceServer := service.NewOIDCService(oidcUsecase, log, configConfig)
+	sessionServiceServer := service.NewSessionService(sessionUsecase, log)
+	tokenServiceServer := service.NewTokenService(sessionUsecase, log)
+	mfaServiceServer := service.NewMFAService(mfaUsecase, log)
+	mfaLoginServiceServer := service.NewMFALoginService(mfaUsecase, log)
+	accessKeyServiceServer := service.NewAccessKeyService(appUsecase, log)
+	auditRepo := data.NewAuditRepoImpl(curd)
+	auditWriter := data.NewAuditWriter(auditRepo, configConfig)
+	auditUsecase := biz.NewAuditUsecase(auditRepo, auditWriter, configConfig, log)
+	auditServiceServer := service.NewAuditService(auditUsecase, log)
+	endpointAdminServiceServer := service.NewEndpointAdminService(endpointUsecase, log)
+	appAdminServiceServer := service.NewAppAdminService(appUsecase, log)
+	v := service.NewServices(fileServiceServer, authServiceServer, endpointServiceServer, appsServiceServer, systemServiceServer, userServiceServer, rbacServiceServer, oidcServiceServer, sessionServiceServer, tokenServiceServer, mfaServiceServer, mfaLoginServiceServer, accessKeyServiceServer, auditServiceServer, endpointAdminServiceServer, appAdminServiceServer)
 	accessKeyAuth := biz.NewAccessKeyAuth(appRepo, configConfig, log)
-	pluginsApply := middleware.New(configConfig, redisDao, authzUsecase, log, accessKeyAuth)
-	gatewayServer := server.NewGateway(gatewayConfig, configConfig, v, pluginsApply)
+	pluginsApply := middleware.New(configConfig, redisDao, authzUsecase, log, accessKeyAuth, rbacUsecase, oidcUsecase, auditUsecase, layeredCache)
+	jwksService := service.NewJWKSService(jwksUsecase, log)
+	gatewayServer := server.NewGateway(gatewayConfig, configConfig, v, jwksService, pluginsApply)
 	gatewayWorker := NewGatewayWorkerImpl(daemonDaemon, gatewayServer)
 	return gatewayWorker
 }
@@ -88,8 +112,14 @@ func NewAuthzSvr(config2 *tiga.Configuration, log logger.Logger) v1.AuthServiceS
 	curd := data.NewCurdImpl(mySQLDao, configConfig)
 	userRepo := data.NewUserRepoImpl(dataData, layeredCache, curd, configConfig)
 	usersAuth := crypto.NewUsersAuth(configConfig)
-	authzUsecase := biz.NewAuthzUsecase(authzRepo, userRepo, log, usersAuth, configConfig)
-	authServiceServer := service.NewAuthzService(authzUsecase, log, usersAuth, configConfig)
+	jwksRepo := data.NewJWKSRepoImpl(curd, redisDao, configConfig)
+	jwksUsecase := biz.NewJWKSUsecase(jwksRepo, configConfig, log)
+	authzUsecase := biz.NewAuthzUsecase(authzRepo, userRepo, log, usersAuth, configConfig, jwksUsecase)
+	sessionRepo := data.NewSessionRepoImpl(redisDao, configConfig)
+	sessionUsecase := biz.NewSessionUsecase(sessionRepo, userRepo, authzUsecase, configConfig, log)
+	mfaRepo := data.NewMFARepoImpl(curd, redisDao, configConfig)
+	mfaUsecase := biz.NewMFAUsecase(mfaRepo, userRepo, sessionUsecase, configConfig, log)
+	authServiceServer := service.NewAuthzService(authzUsecase, sessionUsecase, mfaUsecase, log, usersAuth, configConfig)
 	return authServiceServer
 }
 
@@ -99,7 +129,7 @@ func NewAPPSvr(config2 *tiga.Configuration, log logger.Logger) v1_2.AppsServiceS
 	curd := data.NewCurdImpl(mySQLDao, configConfig)
 	redisDao := data.NewRDB(config2)
 	layeredCache := data.NewLayeredCache(redisDao, configConfig, log)
-	appRepo := data.NewAppRepoImpl(curd, layeredCache, configConfig)
+	appRepo := data.NewAppRepoImpl(curd, layeredCache, redisDao, configConfig)
 	appUsecase := biz.NewAppUsecase(appRepo, configConfig)
 	appsServiceServer := service.NewAppService(appUsecase, log, configConfig)
 	return appsServiceServer
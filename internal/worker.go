@@ -2,15 +2,17 @@ package internal
 
 import (
 	"context"
-	"time"
+	"errors"
 
 	"github.com/begonia-org/begonia/gateway"
 	"github.com/begonia-org/begonia/internal/daemon"
 )
 
 type GatewayWorker interface {
-	// Start the worker
-	Start()
+	// Start the worker, blocks until the gateway server stops
+	Start() error
+	// Shutdown gracefully stops the gateway server and the daemon
+	Shutdown(ctx context.Context) error
 }
 
 type GatewayWorkerImpl struct {
@@ -27,9 +29,21 @@ func NewGatewayWorkerImpl(daemon daemon.Daemon, server *gateway.GatewayServer) G
 	}
 }
 
-func (g *GatewayWorkerImpl) Start() {
-
+func (g *GatewayWorkerImpl) Start() error {
+	// 端点初始加载完成前/readyz返回503
+	g.server.ReadyAfter(g.daemon.Loaded())
 	g.daemon.Start(context.Background())
-	time.Sleep(time.Second * 2)
-	g.server.Start()
+	return g.server.Start()
+}
+
+func (g *GatewayWorkerImpl) Shutdown(ctx context.Context) error {
+	var errs []error
+	// 先停止etcd监听，避免监听事件把端点重新注册到已关闭的负载均衡器
+	if err := g.daemon.Stop(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := g.server.Shutdown(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
 }